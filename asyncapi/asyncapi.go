@@ -0,0 +1,100 @@
+// Package asyncapi provides the types of AsyncAPI 2.x documents, which describe the event
+// channels of an application. Info, Tag, and Schema objects are shared with the spec package,
+// so the same models document both the REST API and its events.
+package asyncapi
+
+import "github.com/kausys/openapi/spec"
+
+// Version is the AsyncAPI Specification version of the generated documents.
+const Version = "2.6.0"
+
+// This is the root object of the AsyncAPI document.
+//
+// See: https://www.asyncapi.com/docs/reference/specification/v2.6.0#A2SObject
+type AsyncAPI struct {
+	// REQUIRED. The version of the AsyncAPI Specification the document uses.
+	AsyncAPI string `json:"asyncapi" yaml:"asyncapi"`
+	// REQUIRED. Provides metadata about the API.
+	Info *spec.Info `json:"info" yaml:"info"`
+	// Default content type to use when encoding/decoding a message's payload.
+	DefaultContentType string `json:"defaultContentType,omitempty" yaml:"defaultContentType,omitempty"`
+	// REQUIRED. The available channels and messages for the API, by channel name.
+	Channels map[string]*Channel `json:"channels" yaml:"channels"`
+	// An element to hold various schemas and messages for the document.
+	Components *Components `json:"components,omitempty" yaml:"components,omitempty"`
+	// A list of tags used by the document with additional metadata.
+	Tags []*spec.Tag `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// Describes the operations available on a single channel.
+//
+// See: https://www.asyncapi.com/docs/reference/specification/v2.6.0#channelItemObject
+type Channel struct {
+	// An optional description of this channel item.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// A definition of the SUBSCRIBE operation, which defines the messages produced by the
+	// application and sent to the channel.
+	Subscribe *Operation `json:"subscribe,omitempty" yaml:"subscribe,omitempty"`
+	// A definition of the PUBLISH operation, which defines the messages consumed by the
+	// application from the channel.
+	Publish *Operation `json:"publish,omitempty" yaml:"publish,omitempty"`
+	// A map of the parameters included in the channel name, by parameter name.
+	Parameters map[string]*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// Describes a publish or a subscribe operation.
+//
+// See: https://www.asyncapi.com/docs/reference/specification/v2.6.0#operationObject
+type Operation struct {
+	// Unique string used to identify the operation.
+	OperationID string `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	// A short summary of what the operation is about.
+	Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	// A verbose explanation of the operation. CommonMark syntax can be used for rich text representation.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// A list of tags for API documentation control.
+	Tags []*spec.Tag `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// A definition of the message that will be published or received by this operation.
+	// Several messages are declared with oneOf.
+	Message *Message `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// Describes a message received on a given channel and operation, or a reference to one.
+//
+// See: https://www.asyncapi.com/docs/reference/specification/v2.6.0#messageObject
+type Message struct {
+	// Reference to a message of the components (#/components/messages/Name).
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	// A machine-friendly name for the message.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// A short summary of what the message is about.
+	Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	// A verbose explanation of the message.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// The content type to use when encoding/decoding the payload, overriding defaultContentType.
+	ContentType string `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	// Definition of the message payload.
+	Payload *spec.Schema `json:"payload,omitempty" yaml:"payload,omitempty"`
+	// Alternative messages of an operation.
+	OneOf []*Message `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+}
+
+// Describes a parameter included in a channel name.
+//
+// See: https://www.asyncapi.com/docs/reference/specification/v2.6.0#parameterObject
+type Parameter struct {
+	// A verbose explanation of the parameter.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Definition of the parameter.
+	Schema *spec.Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// Holds a set of reusable objects for different aspects of the AsyncAPI document.
+//
+// See: https://www.asyncapi.com/docs/reference/specification/v2.6.0#componentsObject
+type Components struct {
+	// An object to hold reusable Schema Objects.
+	Schemas map[string]*spec.Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	// An object to hold reusable Message Objects.
+	Messages map[string]*Message `json:"messages,omitempty" yaml:"messages,omitempty"`
+}
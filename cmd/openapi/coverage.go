@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/kausys/openapi/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	coverageDir          string
+	coveragePattern      string
+	coverageIgnorePaths  []string
+	coverageOnlyPaths    []string
+	coverageBuildTags    []string
+	coverageIncludeTests bool
+	coverageMin          float64
+)
+
+func init() {
+	coverageCmd.Flags().StringVarP(&coverageDir, "dir", "d", ".", "Root directory to scan from")
+	coverageCmd.Flags().StringVarP(&coveragePattern, "pattern", "p", "./...", "Package pattern to scan")
+	coverageCmd.Flags().StringSliceVar(&coverageIgnorePaths, "ignore", nil, "Path patterns to ignore: substrings, globs (internal/*/mocks, *_gen.go), or re:<regexp>")
+	coverageCmd.Flags().StringSliceVar(&coverageOnlyPaths, "only", nil, "Only scan files matching these path patterns (same syntax as --ignore)")
+	coverageCmd.Flags().StringSliceVar(&coverageBuildTags, "build-tags", nil, "Build tags selecting the files to scan, as with go build -tags")
+	coverageCmd.Flags().BoolVar(&coverageIncludeTests, "include-tests", false, "Load _test.go files")
+	coverageCmd.Flags().Float64Var(&coverageMin, "min-coverage", 0, "Fail when less than this percentage of the handlers is documented")
+	rootCmd.AddCommand(coverageCmd)
+}
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "List the HTTP handlers without documentation",
+	Long: `Coverage scans the Go source code for exported HTTP handlers and lists the
+ones without a swagger:route directive, with the percentage of documented
+handlers.
+
+Handlers are recognized by their signature:
+  net/http  func(w http.ResponseWriter, r *http.Request)
+  gin       func(c *gin.Context)
+  echo      func(c echo.Context) error
+  fiber     func(c *fiber.Ctx) error
+
+Functions and methods marked swagger:ignore are left out, e.g. middleware
+with a handler signature. With --min-coverage, the command fails when the
+coverage is below the threshold, to keep it from dropping in CI.
+
+Example:
+  openapi coverage
+  openapi coverage -p ./api/...
+  openapi coverage --min-coverage 90`,
+	Args: cobra.NoArgs,
+	RunE: runCoverage,
+}
+
+func runCoverage(cmd *cobra.Command, args []string) error {
+	if coverageMin < 0 || coverageMin > 100 {
+		return fmt.Errorf("invalid --min-coverage %g, expected a percentage between 0 and 100", coverageMin)
+	}
+
+	configFile, err := generator.ReadConfigFile(coverageDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+	configFile.Register()
+
+	gen := generator.New(
+		generator.WithDir(coverageDir),
+		generator.WithPattern(coveragePattern),
+		generator.WithIgnorePaths(append(configFile.Ignore, coverageIgnorePaths...)...),
+		generator.WithOnlyPaths(append(configFile.Only, coverageOnlyPaths...)...),
+		generator.WithBuildTags(coverageBuildTags...),
+		generator.WithIncludeTests(coverageIncludeTests),
+		generator.WithCache(false),
+	)
+
+	coverage, err := gen.Coverage()
+	if err != nil {
+		return fmt.Errorf("coverage failed: %w", err)
+	}
+
+	for _, handler := range coverage.Undocumented() {
+		fmt.Printf("%s:%d: %s (%s) has no swagger:route\n", handler.SourceFile, handler.Pos.Line, handler.Name, handler.Framework)
+	}
+	fmt.Printf("📊 %d of %d handlers documented (%.1f%%)\n", coverage.Documented, len(coverage.Handlers), coverage.Percent())
+
+	if coverage.Percent() < coverageMin {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("coverage %.1f%% is below the minimum of %g%%", coverage.Percent(), coverageMin)
+	}
+	return nil
+}
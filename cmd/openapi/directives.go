@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	directivesFormat string
+	directivesOutput string
+)
+
+func init() {
+	directivesCmd.Flags().StringVarP(&directivesFormat, "format", "f", "text", "Output format: text or markdown")
+	directivesCmd.Flags().StringVarP(&directivesOutput, "output", "o", "", "Write the documentation to this file instead of stdout")
+	rootCmd.AddCommand(directivesCmd)
+}
+
+var directivesCmd = &cobra.Command{
+	Use:   "directives",
+	Short: "Document the directives read by the scanner",
+	Long: `Directives lists every directive the scanner reads from doc comments with
+its syntax, description, the contexts it applies to, and an example. The
+documentation comes from the directive table of the scanner, so it stays in
+sync with the code.
+
+Example:
+  openapi directives
+  openapi directives --format markdown -o docs/directives.md`,
+	RunE: runDirectives,
+}
+
+func runDirectives(cmd *cobra.Command, args []string) error {
+	var buf bytes.Buffer
+	docs := scanner.Directives()
+
+	switch directivesFormat {
+	case "text":
+		writeDirectivesText(&buf, docs)
+	case "markdown":
+		writeDirectivesMarkdown(&buf, docs)
+	default:
+		return fmt.Errorf("unknown format %q, expected text or markdown", directivesFormat)
+	}
+
+	if directivesOutput == "" {
+		_, err := cmd.OutOrStdout().Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(directivesOutput, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", directivesOutput, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ Directives documented in %s\n", directivesOutput)
+	return nil
+}
+
+// writeDirectivesText writes the directive documentation for the terminal.
+func writeDirectivesText(w io.Writer, docs []scanner.DirectiveDoc) {
+	for i, doc := range docs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, doc.Syntax)
+		if doc.Description != "" {
+			fmt.Fprintf(w, "    %s\n", doc.Description)
+		}
+		fmt.Fprintf(w, "    contexts: %s\n", strings.Join(doc.Contexts, ", "))
+		if doc.Example != "" {
+			fmt.Fprintf(w, "    example:  %s\n", doc.Example)
+		}
+	}
+}
+
+// writeDirectivesMarkdown writes the directive documentation as a markdown page with a
+// table of the directives.
+func writeDirectivesMarkdown(w io.Writer, docs []scanner.DirectiveDoc) {
+	fmt.Fprintln(w, "# Directives")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "<!-- Generated by openapi directives --format markdown. DO NOT EDIT. -->")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Syntax | Description | Contexts | Example |")
+	fmt.Fprintln(w, "|--------|-------------|----------|---------|")
+
+	for _, doc := range docs {
+		example := ""
+		if doc.Example != "" {
+			example = markdownCode(doc.Example)
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n",
+			markdownCode(doc.Syntax), markdownCell(doc.Description), strings.Join(doc.Contexts, ", "), example)
+	}
+}
+
+// markdownCell escapes the pipes and newlines of a markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", "<br>").Replace(s)
+}
+
+// markdownCode returns a markdown table cell as inline code.
+func markdownCode(s string) string {
+	return "`" + markdownCell(s) + "`"
+}
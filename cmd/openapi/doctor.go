@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/kausys/openapi/cache"
+	"github.com/kausys/openapi/generator"
+	"github.com/kausys/openapi/scanner"
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	doctorDir     string
+	doctorPattern string
+)
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorPattern, "pattern", "p", "./...", "Package pattern to scan")
+	doctorCmd.Flags().StringVarP(&doctorDir, "dir", "d", ".", "Root directory to scan from")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the project setup",
+	Long: `Doctor checks the environment the generator depends on and reports
+what it finds, to help diagnose empty or incomplete specs.
+
+It verifies that the go toolchain is available, that the packages matching
+the pattern load, that the config file and cache directory are healthy, and
+prints the number of swagger directives discovered by type.
+
+Example:
+  openapi doctor
+  openapi doctor -p ./api/...`,
+	RunE: runDoctor,
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
+	checks := []struct {
+		name string
+		run  func(io.Writer) error
+	}{
+		{"Go toolchain", checkGoToolchain},
+		{"Packages", checkPackages},
+		{"Config file", checkConfigFile},
+		{"Cache", checkCache},
+		{"Directives", checkDirectives},
+	}
+
+	failed := 0
+	fmt.Fprintln(out, "🩺 OpenAPI Doctor")
+	fmt.Fprintln(out, "────────────────")
+	for _, check := range checks {
+		if err := check.run(out); err != nil {
+			fmt.Fprintf(out, "❌ %s: %v\n", check.name, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("doctor found %d problem(s)", failed)
+	}
+	return nil
+}
+
+// checkGoToolchain verifies that the go command used to load packages is available.
+func checkGoToolchain(out io.Writer) error {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		return errors.New("go command not found in PATH, packages cannot be loaded")
+	}
+
+	version, err := exec.Command(goBin, "env", "GOVERSION").Output()
+	if err != nil {
+		return fmt.Errorf("go env failed: %w", err)
+	}
+
+	fmt.Fprintf(out, "✅ Go toolchain: %s (%s)\n", strings.TrimSpace(string(version)), goBin)
+	return nil
+}
+
+// checkPackages verifies that the packages matching the pattern load without errors.
+func checkPackages(out io.Writer) error {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Dir:  doctorDir,
+	}, doctorPattern)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", doctorPattern, err)
+	}
+	if len(pkgs) == 0 {
+		return fmt.Errorf("pattern %s matches no packages in %s", doctorPattern, doctorDir)
+	}
+
+	files := 0
+	var loadErrors []string
+	for _, pkg := range pkgs {
+		files += len(pkg.GoFiles)
+		for _, pkgErr := range pkg.Errors {
+			loadErrors = append(loadErrors, pkgErr.Error())
+		}
+	}
+
+	module := "no module"
+	if pkgs[0].Module != nil {
+		module = "module " + pkgs[0].Module.Path
+	}
+
+	if len(loadErrors) > 0 {
+		return fmt.Errorf("%d package error(s), only swagger:meta is read from broken packages:\n   %s",
+			len(loadErrors), strings.Join(loadErrors, "\n   "))
+	}
+
+	fmt.Fprintf(out, "✅ Packages: %d package(s), %d file(s) in %s\n", len(pkgs), files, module)
+	return nil
+}
+
+// checkConfigFile verifies that the config file, if any, parses.
+func checkConfigFile(out io.Writer) error {
+	if err := generator.LoadConfigFile(doctorDir); err != nil {
+		return fmt.Errorf("invalid config file: %w", err)
+	}
+
+	fmt.Fprintln(out, "✅ Config file: ok")
+	return nil
+}
+
+// checkCache verifies that the cache index is readable and the cache directory writable.
+func checkCache(out io.Writer) error {
+	mgr := cache.NewManager(doctorDir)
+
+	if _, err := os.Stat(mgr.CachePath()); os.IsNotExist(err) {
+		fmt.Fprintf(out, "✅ Cache: not created yet (%s)\n", mgr.CachePath())
+		return nil
+	}
+
+	if err := mgr.Load(); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("index unreadable, run openapi clean: %w", err)
+	}
+
+	probe, err := os.CreateTemp(mgr.CachePath(), ".doctor-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", mgr.CachePath(), err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	stats := mgr.Stats()
+	fmt.Fprintf(out, "✅ Cache: %d file(s), %d schema(s), %d route(s) in %s\n",
+		stats.FileCount, stats.SchemaCount, stats.RouteCount, mgr.CachePath())
+	return nil
+}
+
+// checkDirectives scans the packages and prints the directives found by type.
+func checkDirectives(out io.Writer) error {
+	s := scanner.New(scanner.WithDir(doctorDir), scanner.WithPattern(doctorPattern))
+	if err := s.Scan(); err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	var models, parameters, headers int
+	for _, structInfo := range s.Structs {
+		switch {
+		case structInfo.IsParameter:
+			parameters++
+		case structInfo.IsHeaders:
+			headers++
+		case structInfo.IsModel:
+			models++
+		}
+	}
+
+	fmt.Fprintln(out, "✅ Directives:")
+	fmt.Fprintf(out, "   swagger:meta       %d\n", len(s.Metas))
+	fmt.Fprintf(out, "   swagger:route      %d\n", len(s.Routes))
+	fmt.Fprintf(out, "   swagger:model      %d\n", models)
+	fmt.Fprintf(out, "   swagger:parameters %d\n", parameters)
+	fmt.Fprintf(out, "   swagger:headers    %d\n", headers)
+	fmt.Fprintf(out, "   swagger:enum       %d\n", len(s.Enums))
+	fmt.Fprintf(out, "   swagger:path       %d\n", len(s.Paths))
+	fmt.Fprintf(out, "   swagger:group      %d\n", len(s.Groups))
+
+	for _, warning := range s.Warnings {
+		fmt.Fprintf(out, "   warning: [%s] %s\n", warning.Code, warning.Message)
+	}
+
+	if len(s.Routes) == 0 {
+		fmt.Fprintln(out, "   💡 No swagger:route found, the spec will have no paths. Routes are declared")
+		fmt.Fprintln(out, "      on functions as // swagger:route METHOD /path [tags] operationID")
+	}
+	if len(s.Metas) == 0 {
+		fmt.Fprintln(out, "   💡 No swagger:meta found, the spec uses a default title and version")
+	}
+	return nil
+}
@@ -1,42 +1,117 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/kausys/openapi/generator"
 	"github.com/spf13/cobra"
 )
 
 var (
-	outputFile   string
+	outputFiles  []string
 	outputFormat string
+	formats      []string
 	pattern      string
 	dir          string
 	noCache      bool
 	flatten      bool
 	validate     bool
 	ignorePaths  []string
+	onlyPaths    []string
+	buildTags    []string
+	includeTests bool
+	workspace    bool
+	modulePats   map[string]string
 	cleanUnused  bool
+	reportUnused bool
 	multiSpec    bool
 	specName     string
 	noDefault    bool
 	enumRefs     bool
+	schemaTitles bool
+	descFallback bool
+	strictObjs   bool
+	profile      string
+	includeTags  []string
+	excludeTags  []string
+	sharedComps  bool
+	versioned    bool
+	strict       bool
+	strictRefs   bool
+	check        bool
+	splitRW      bool
+	fieldPolicy  []string
+	durationFmt  string
+	schemaNaming string
+	pathOrder    string
+	resolveExt   bool
+	overlays     []string
+	postProcess  []string
+	translations []string
+	asyncAPI     string
+	templates    []string
+	backstage    string
+	embedGo      string
+	embedPackage string
+	portableYAML bool
+	problemJSON  bool
+	codeSamples  []string
 )
 
 func init() {
-	generateCmd.Flags().StringVarP(&outputFile, "output", "o", "openapi.yaml", "Output file path")
+	generateCmd.Flags().StringArrayVarP(&outputFiles, "output", "o", []string{"openapi.yaml"}, "Output file path (repeatable; more files are written in the format of their extension)")
 	generateCmd.Flags().StringVarP(&outputFormat, "format", "f", "yaml", "Output format: yaml or json")
+	generateCmd.Flags().StringSliceVar(&formats, "formats", nil, "Write the output in each of these formats (yaml,json), with the extension of the format")
 	generateCmd.Flags().StringVarP(&pattern, "pattern", "p", "./...", "Package pattern to scan")
 	generateCmd.Flags().StringVarP(&dir, "dir", "d", ".", "Root directory to scan from")
 	generateCmd.Flags().BoolVar(&noCache, "no-cache", false, "Disable incremental caching")
 	generateCmd.Flags().BoolVar(&flatten, "flatten", false, "Inline $ref schemas instead of using references")
 	generateCmd.Flags().BoolVar(&validate, "validate", false, "Validate the generated spec")
-	generateCmd.Flags().StringSliceVar(&ignorePaths, "ignore", nil, "Path patterns to ignore")
+	generateCmd.Flags().StringSliceVar(&ignorePaths, "ignore", nil, "Path patterns to ignore: substrings, globs (internal/*/mocks, *_gen.go), or re:<regexp>")
+	generateCmd.Flags().StringSliceVar(&buildTags, "build-tags", nil, "Build tags selecting the files to scan, as with go build -tags")
+	generateCmd.Flags().BoolVar(&includeTests, "include-tests", false, "Load _test.go files so that response examples can reference test variables")
+	generateCmd.Flags().BoolVar(&workspace, "workspace", false, "Scan every module of the go.work file in --dir, with --pattern relative to each module")
+	generateCmd.Flags().StringToStringVar(&modulePats, "module-pattern", nil, "Package pattern of a workspace module, by module directory or path (api=./handlers/...)")
+	generateCmd.Flags().StringSliceVar(&onlyPaths, "only", nil, "Only scan files matching these path patterns (same syntax as --ignore)")
 	generateCmd.Flags().BoolVar(&cleanUnused, "clean-unused", false, "Remove unreferenced schemas")
+	generateCmd.Flags().BoolVar(&reportUnused, "report-unused", false, "Warn about models and enums that no included route references")
 	generateCmd.Flags().BoolVar(&multiSpec, "multi-specs", false, "Generate multiple specs based on spec: directives")
 	generateCmd.Flags().StringVar(&specName, "spec", "", "Generate only a specific spec by name")
+	generateCmd.Flags().BoolVar(&sharedComps, "shared-components", false, "Write schemas shared by several specs to a common components file (with --multi-specs)")
+	generateCmd.Flags().BoolVar(&versioned, "versioned", false, "Generate a spec per API version, from /v1/ path prefixes or version: directives (with --multi-specs or --spec)")
 	generateCmd.Flags().BoolVar(&noDefault, "no-default", false, "Skip generating the default spec for routes without spec: directives")
 	generateCmd.Flags().BoolVar(&enumRefs, "enum-refs", false, "Generate enums as $ref references instead of inline")
+	generateCmd.Flags().BoolVar(&schemaTitles, "schema-titles", false, "Set the title of model and enum schemas to their Go type name")
+	generateCmd.Flags().BoolVar(&descFallback, "description-fallback", false, "Describe fields and parameters without a description with the doc comment of their type")
+	generateCmd.Flags().BoolVar(&strictObjs, "strict-objects", false, "Close the object schemas of models (additionalProperties: false) unless they declare additionalProperties: true")
+	generateCmd.Flags().StringVar(&profile, "profile", "", "Gateway profile from the config file (e.g. kong, cloud-endpoints)")
+	generateCmd.Flags().StringSliceVar(&includeTags, "include-tags", nil, "Only include routes with these tags")
+	generateCmd.Flags().StringSliceVar(&excludeTags, "exclude-tags", nil, "Exclude routes with these tags (\"internal\" strips internal: true routes and models)")
+	generateCmd.Flags().BoolVar(&strict, "strict", false, "Fail when the generation reports warnings, without writing output")
+	generateCmd.Flags().BoolVar(&strictRefs, "strict-refs", false, "Fail when a response or field type cannot be resolved instead of emitting type: string")
+	generateCmd.Flags().BoolVar(&check, "check", false, "Compare the generated spec with the output file without writing it; fail with a diff when it is out of date")
+	generateCmd.Flags().BoolVar(&splitRW, "split-read-write", false, "Derive <Model>Request and <Model>Response schemas from readOnly/writeOnly fields")
+	generateCmd.Flags().StringSliceVar(&fieldPolicy, "field-policy", nil, "Infer required/nullable properties: omitempty-optional, pointer-nullable")
+	generateCmd.Flags().StringVar(&durationFmt, "duration-format", "duration", "Schema of time.Duration fields: duration (string) or int64 (nanoseconds)")
+	generateCmd.Flags().StringVar(&schemaNaming, "schema-naming", "short", "Naming of models of different packages sharing a name: short (collisions fail), package (package prefix), or hash (package path hash)")
+	generateCmd.Flags().StringVar(&pathOrder, "path-order", "alpha", "Order of the paths in the spec: alpha, tag (by operation tag, then path), or source (declaration order)")
+	generateCmd.Flags().BoolVar(&resolveExt, "resolve-external", false, "Load dependency packages declaring referenced types that aren't found in the scanned packages (slower)")
+	generateCmd.Flags().StringSliceVar(&overlays, "overlay", nil, "OpenAPI Overlay files applied, in order, to each generated spec")
+	generateCmd.Flags().StringArrayVar(&postProcess, "post-process", nil, "Command that receives each generated spec as JSON on stdin and prints the resulting spec (repeatable)")
+	generateCmd.Flags().StringSliceVar(&translations, "translations", nil, "Translations files (<locale>.yaml) each writing a localized copy of the spec (openapi.<locale>.yaml)")
+	generateCmd.Flags().BoolVar(&portableYAML, "portable-yaml", false, "Write YAML without anchors or aliases, with the document fields in the order of the specification")
+	generateCmd.Flags().BoolVar(&problemJSON, "problem-details", false, "Document error responses as RFC 7807 problem details (application/problem+json)")
+	generateCmd.Flags().StringSliceVar(&codeSamples, "code-samples", nil, "Generate a code sample per operation in these languages (x-codeSamples): curl")
+	generateCmd.Flags().StringArrayVar(&templates, "template", nil, "Go template rendering each written spec to a file named after it without .tmpl, or to tmpl=file (repeatable)")
+	generateCmd.Flags().StringVar(&backstage, "backstage", "", "Also write a Backstage catalog (catalog-info.yaml) with an API entity per spec, configured by the backstage section of the config file")
+	generateCmd.Flags().StringVar(&embedGo, "embed-go", "", "Also write a Go file declaring the written specs (Specs map, embedded with go:embed) for the swagger handler")
+	generateCmd.Flags().StringVar(&embedPackage, "embed-package", "", "Package of the --embed-go file (default: its directory name)")
+	generateCmd.Flags().StringVar(&asyncAPI, "asyncapi", "", "Also write the AsyncAPI document of the event channels (swagger:channel) to this file")
 	rootCmd.AddCommand(generateCmd)
 }
 
@@ -51,54 +126,159 @@ Supported directives:
   swagger:model      - Schema definitions
   swagger:route      - Operation definitions
   swagger:parameters - Parameter definitions
+  swagger:headers    - Header parameter definitions
+  swagger:path       - Path-level summary, description, and parameters
+  swagger:group      - Path prefix, tags, security, and responses shared by routes
   swagger:enum       - Enum definitions
 
 Example:
   openapi generate
   openapi generate -o api.yaml -p ./api/...
-  openapi generate -o api.json -f json --no-cache`,
+  openapi generate -o api.json -f json --no-cache
+  openapi generate -o openapi.yaml -o openapi.json   # or --formats yaml,json
+  openapi generate --spec admin -o admin.yaml   # see openapi specs list
+  openapi generate --check   # fail in CI when openapi.yaml is out of date`,
 	RunE: runGenerate,
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
-	// Load custom types from config file
-	if err := generator.LoadConfigFile(dir); err != nil {
+	// Versioned mode splits the specs of multi-spec mode, a single spec has no versions
+	if versioned && !multiSpec && specName == "" {
+		return errors.New("--versioned requires --multi-specs or --spec")
+	}
+
+	// Load custom types and gateway profiles from config file
+	configFile, err := generator.ReadConfigFile(dir)
+	if err != nil {
 		return fmt.Errorf("failed to load config file: %w", err)
 	}
+	configFile.Register()
+
+	outputFile, format, additionalOutputs, err := outputTargets()
+	if err != nil {
+		return err
+	}
+	if backstage != "" {
+		configFile.Backstage.Output = backstage
+	}
 
 	gen := generator.New(
 		generator.WithDir(dir),
 		generator.WithPattern(pattern),
-		generator.WithOutput(outputFile, outputFormat),
-		generator.WithCache(!noCache),
+		generator.WithOutput(outputFile, format),
+		generator.WithAdditionalOutputs(additionalOutputs...),
+		generator.WithCache(!noCache && !check),
 		generator.WithFlatten(flatten),
 		generator.WithValidation(validate),
-		generator.WithIgnorePaths(ignorePaths...),
+		generator.WithIgnorePaths(append(configFile.Ignore, ignorePaths...)...),
+		generator.WithOnlyPaths(append(configFile.Only, onlyPaths...)...),
+		generator.WithBuildTags(buildTags...),
+		generator.WithIncludeTests(includeTests),
+		generator.WithWorkspace(workspace),
+		generator.WithModulePatterns(modulePats),
 		generator.WithCleanUnused(cleanUnused),
+		generator.WithReportUnused(reportUnused),
 		generator.WithNoDefault(noDefault),
 		generator.WithEnumRefs(enumRefs),
+		generator.WithSchemaTitles(schemaTitles),
+		generator.WithDescriptionFallback(descFallback),
+		generator.WithStrictObjects(strictObjs),
+		generator.WithProfile(profile),
+		generator.WithSharedComponents(sharedComps),
+		generator.WithVersioned(versioned),
+		generator.WithIncludeTags(includeTags...),
+		generator.WithExcludeTags(excludeTags...),
+		generator.WithStrict(strict),
+		generator.WithStrictRefs(strictRefs),
+		generator.WithCheck(check),
+		generator.WithSplitReadWrite(splitRW),
+		generator.WithFieldPolicies(fieldPolicy...),
+		generator.WithDurationFormat(durationFmt),
+		generator.WithSchemaNaming(schemaNaming),
+		generator.WithPathOrder(pathOrder),
+		generator.WithResolveExternal(resolveExt),
+		generator.WithOverlays(overlays...),
+		generator.WithPostProcessors(postProcess...),
+		generator.WithTranslations(translations...),
+		generator.WithCodeSamples(codeSamples...),
+		generator.WithTemplates(templates...),
+		generator.WithBackstage(configFile.Backstage),
+		generator.WithEmbedOutput(embedGo, embedPackage),
+		generator.WithAsyncAPIOutput(asyncAPI),
+		generator.WithPortableYAML(portableYAML),
+		generator.WithProblemDetails(problemJSON),
 	)
 
-	if multiSpec {
-		_, err := gen.GenerateMulti()
-		if err != nil {
-			return fmt.Errorf("multi-spec generation failed: %w", err)
+	defer printWarnings(cmd, gen)
+
+	switch {
+	case multiSpec:
+		if _, err = gen.GenerateMulti(); err != nil {
+			err = fmt.Errorf("multi-spec generation failed: %w", err)
+		}
+	case specName != "":
+		if _, err = gen.GenerateSpec(specName); err != nil {
+			err = fmt.Errorf("spec generation failed: %w", err)
 		}
-		return nil
+	default:
+		if _, err = gen.Generate(); err != nil {
+			err = fmt.Errorf("generation failed: %w", err)
+		}
+	}
+
+	if stale, ok := errors.AsType[*generator.StaleOutputError](err); ok {
+		cmd.SilenceUsage = true
+		fmt.Fprint(cmd.OutOrStdout(), stale.Diff)
+		return fmt.Errorf("%d file(s) out of date, run openapi generate to update: %s",
+			len(stale.Files), strings.Join(stale.Files, ", "))
 	}
 
-	if specName != "" {
-		_, err := gen.GenerateSpec(specName)
-		if err != nil {
-			return fmt.Errorf("spec generation failed: %w", err)
+	return err
+}
+
+// outputTargets returns the output file and format, and the additional output files, of
+// the --output, --format, and --formats flags. With --formats, the output file is written
+// in each format, with the extension of the format.
+func outputTargets() (string, string, []string, error) {
+	if len(formats) == 0 {
+		return outputFiles[0], outputFormat, outputFiles[1:], nil
+	}
+	if len(outputFiles) > 1 {
+		return "", "", nil, errors.New("--formats requires a single --output")
+	}
+
+	files := make([]string, 0, len(formats))
+	for _, format := range formats {
+		if format != string(generator.FormatYAML) && format != string(generator.FormatJSON) {
+			return "", "", nil, fmt.Errorf("unknown format %q, expected yaml or json", format)
+		}
+		file := outputFiles[0]
+		ext := strings.ToLower(filepath.Ext(file))
+		if ext != "."+format && !(format == string(generator.FormatYAML) && ext == ".yml") {
+			file = strings.TrimSuffix(file, filepath.Ext(file)) + "." + format
 		}
-		return nil
+		files = append(files, file)
 	}
+	return files[0], formats[0], files[1:], nil
+}
 
-	_, err := gen.Generate()
-	if err != nil {
-		return fmt.Errorf("generation failed: %w", err)
+// printWarnings prints the non-fatal problems found during generation,
+// followed by a summary line counting them per code.
+func printWarnings(cmd *cobra.Command, gen *generator.Generator) {
+	warnings := gen.Warnings()
+	if len(warnings) == 0 {
+		return
 	}
 
-	return nil
+	counts := make(map[string]int)
+	for _, warning := range warnings {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: [%s] %s\n", warning.Code, warning.Message)
+		counts[warning.Code]++
+	}
+
+	summary := make([]string, 0, len(counts))
+	for _, code := range slices.Sorted(maps.Keys(counts)) {
+		summary = append(summary, fmt.Sprintf("%s: %d", code, counts[code]))
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "%d warning(s) (%s)\n", len(warnings), strings.Join(summary, ", "))
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kausys/openapi/generator"
+	"github.com/kausys/openapi/spec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphDir          string
+	graphPattern      string
+	graphIgnorePaths  []string
+	graphOnlyPaths    []string
+	graphBuildTags    []string
+	graphIncludeTests bool
+	graphSpec         string
+	graphFormat       string
+	graphOutput       string
+	graphFocus        string
+	graphNoOperations bool
+)
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphDir, "dir", "d", ".", "Root directory to scan from")
+	graphCmd.Flags().StringVarP(&graphPattern, "pattern", "p", "./...", "Package pattern to scan")
+	graphCmd.Flags().StringSliceVar(&graphIgnorePaths, "ignore", nil, "Path patterns to ignore: substrings, globs (internal/*/mocks, *_gen.go), or re:<regexp>")
+	graphCmd.Flags().StringSliceVar(&graphOnlyPaths, "only", nil, "Only scan files matching these path patterns (same syntax as --ignore)")
+	graphCmd.Flags().StringSliceVar(&graphBuildTags, "build-tags", nil, "Build tags selecting the files to scan, as with go build -tags")
+	graphCmd.Flags().BoolVar(&graphIncludeTests, "include-tests", false, "Load _test.go files")
+	graphCmd.Flags().StringVar(&graphSpec, "spec", "", "Graph the spec of this name (see openapi specs list) instead of the full spec")
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", generator.GraphFormatDOT, "Output format: dot (Graphviz) or mermaid")
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "File to write the graph to (default: stdout)")
+	graphCmd.Flags().StringVar(&graphFocus, "focus", "", "Only show the schemas and operations connected to this schema")
+	graphCmd.Flags().BoolVar(&graphNoOperations, "no-operations", false, "Only show the references between schemas")
+	rootCmd.AddCommand(graphCmd)
+}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Render the schema reference graph",
+	Long: `Graph scans the Go source code and renders the reference graph of the
+component schemas: which models reference which, and which operations use which
+schemas through their parameters, request bodies, and responses. Models no
+route references are kept, as nodes without incoming edges.
+
+Example:
+  openapi graph | dot -Tsvg > schemas.svg
+  openapi graph --format mermaid -o schemas.mmd
+  openapi graph --focus User --no-operations
+  openapi graph --spec admin`,
+	Args: cobra.NoArgs,
+	RunE: runGraph,
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	configFile, err := generator.ReadConfigFile(graphDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+	configFile.Register()
+
+	gen := generator.New(
+		generator.WithDir(graphDir),
+		generator.WithPattern(graphPattern),
+		generator.WithOutput("", ""),
+		generator.WithIgnorePaths(append(configFile.Ignore, graphIgnorePaths...)...),
+		generator.WithOnlyPaths(append(configFile.Only, graphOnlyPaths...)...),
+		generator.WithBuildTags(graphBuildTags...),
+		generator.WithIncludeTests(graphIncludeTests),
+		generator.WithCleanUnused(false),
+		generator.WithCache(false),
+	)
+
+	var openAPI *spec.OpenAPI
+	if graphSpec != "" {
+		openAPI, err = gen.GenerateSpecContext(cmd.Context(), graphSpec)
+	} else {
+		openAPI, err = gen.GenerateContext(cmd.Context())
+	}
+	if err != nil {
+		return fmt.Errorf("generation failed: %w", err)
+	}
+
+	graph := generator.NewSchemaGraph(openAPI, !graphNoOperations)
+	if graphFocus != "" {
+		if graph, err = graph.Focus(graphFocus); err != nil {
+			return err
+		}
+	}
+
+	if graphOutput == "" {
+		return graph.Write(cmd.OutOrStdout(), graphFormat)
+	}
+	file, err := os.Create(graphOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create graph file: %w", err)
+	}
+	defer file.Close()
+	return graph.Write(file, graphFormat)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	initDir     string
+	initPackage string
+	initTitle   string
+	initForce   bool
+)
+
+func init() {
+	initCmd.Flags().StringVarP(&initDir, "dir", "d", ".", "Directory to write the starter files to")
+	initCmd.Flags().StringVar(&initPackage, "package", "", "Package name of doc.go (default: detected from the directory)")
+	initCmd.Flags().StringVar(&initTitle, "title", "", "API title (default: derived from the directory name)")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite existing files")
+	rootCmd.AddCommand(initCmd)
+}
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a starter doc.go and config file",
+	Long: `Init writes a doc.go with swagger:meta, an example model, and an example
+route, plus a .openapi.yaml config file, so a project can be generated right away.
+
+Existing files are kept unless --force is set.
+
+Example:
+  openapi init
+  openapi init -d ./api --title "Orders API"
+  openapi generate -d ./api -o openapi.yaml`,
+	RunE: runInit,
+}
+
+// docTemplate is the starter doc.go written by init.
+var docTemplate = template.Must(template.New("doc.go").Parse(`// Package {{.Package}} implements the {{.Title}}.
+//
+// swagger:meta
+// Title: {{.Title}}
+// Version: 0.1.0
+// Description: {{.Title}} generated with openapi init
+// SecuritySchemes:
+//   - name: bearerAuth
+//     type: http
+//     scheme: bearer
+// Security:
+// - bearerAuth
+package {{.Package}}
+
+// Greeting is the message returned by getGreeting.
+//
+// swagger:model Greeting
+type Greeting struct {
+	// The greeting message
+	// required: true
+	// example: Hello, Gopher!
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// GetGreetingParams are the parameters of getGreeting.
+//
+// swagger:parameters getGreeting
+type GetGreetingParams struct {
+	// Name of the person to greet
+	// in: path
+	// required: true
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:route GET /greetings/{name} greetings getGreeting
+// summary: Greet someone by name
+// Responses:
+// - 200: Greeting description:The greeting
+func getGreeting() {}
+`))
+
+// configTemplate is the starter .openapi.yaml written by init.
+const configTemplate = `# Configuration for openapi generate.
+
+# Go types rendered with a fixed schema (time.Time, time.Duration, uuid.UUID,
+# net.IP, url.URL, big.Int, json.Number, sql.Null*, pgtype.*, and others are
+# registered by default).
+custom_types: {}
+#  money.Amount:
+#    type: string
+#    format: decimal
+#    example: "12.50"
+
+# Descriptions of the tags used by swagger:route directives.
+tags:
+  greetings: Example greeting operations
+
+# Gateway profiles selected with openapi generate --profile <name>.
+profiles: {}
+#  kong:
+#    gateway: kong
+#    name: my-service
+
+# Path patterns excluded from scanning, and patterns scanning is restricted to:
+# substrings, globs (internal/*/mocks, *_gen.go), or re:<regexp>. Added to --ignore and --only.
+ignore: []
+only: []
+`
+
+func runInit(cmd *cobra.Command, args []string) error {
+	absDir, err := filepath.Abs(initDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(absDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", initDir, err)
+	}
+
+	pkg := initPackage
+	if pkg == "" {
+		pkg = detectPackageName(absDir)
+	}
+	title := initTitle
+	if title == "" {
+		title = defaultTitle(absDir)
+	}
+
+	var doc bytes.Buffer
+	if err := docTemplate.Execute(&doc, struct{ Package, Title string }{pkg, title}); err != nil {
+		return fmt.Errorf("failed to render doc.go: %w", err)
+	}
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"doc.go", doc.Bytes()},
+		{".openapi.yaml", []byte(configTemplate)},
+	}
+
+	for _, file := range files {
+		path := filepath.Join(initDir, file.name)
+		if _, err := os.Stat(path); err == nil && !initForce {
+			fmt.Fprintf(cmd.OutOrStdout(), "⏭️  %s exists, skipped (use --force to overwrite)\n", path)
+			continue
+		}
+		if err := os.WriteFile(path, file.data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✅ Created %s\n", path)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "\nNext: openapi generate -d %s -o openapi.yaml\n", initDir)
+	return nil
+}
+
+// detectPackageName returns the package of the Go files in dir, falling back to
+// a name derived from the directory.
+func detectPackageName(dir string) string {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), match, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return file.Name.Name
+		}
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, filepath.Base(dir))
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		return "api"
+	}
+	return name
+}
+
+// defaultTitle derives an API title from the directory name (e.g., "user-service" -> "User Service API").
+func defaultTitle(dir string) string {
+	words := strings.FieldsFunc(filepath.Base(dir), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		first, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(first)) + word[size:]
+	}
+	if len(words) == 0 {
+		return "API"
+	}
+	return strings.Join(words, " ") + " API"
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/kausys/openapi/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintDir          string
+	lintPattern      string
+	lintIgnorePaths  []string
+	lintOnlyPaths    []string
+	lintBuildTags    []string
+	lintIncludeTests bool
+	lintWorkspace    bool
+	lintModulePats   map[string]string
+	lintFormat       string
+	lintDescriptions bool
+	lintDescFallback bool
+	lintCheckRoutes  bool
+)
+
+func init() {
+	lintCmd.Flags().StringVarP(&lintDir, "dir", "d", ".", "Root directory to scan from")
+	lintCmd.Flags().StringVarP(&lintPattern, "pattern", "p", "./...", "Package pattern to scan")
+	lintCmd.Flags().StringVarP(&lintFormat, "format", "f", "text", "Output format: text, json (findings with file, line, and column), sarif, or github (workflow annotations)")
+	lintCmd.Flags().StringSliceVar(&lintIgnorePaths, "ignore", nil, "Path patterns to ignore: substrings, globs (internal/*/mocks, *_gen.go), or re:<regexp>")
+	lintCmd.Flags().StringSliceVar(&lintBuildTags, "build-tags", nil, "Build tags selecting the files to scan, as with go build -tags")
+	lintCmd.Flags().BoolVar(&lintIncludeTests, "include-tests", false, "Load _test.go files so that response examples can reference test variables")
+	lintCmd.Flags().BoolVar(&lintWorkspace, "workspace", false, "Scan every module of the go.work file in --dir, with --pattern relative to each module")
+	lintCmd.Flags().StringToStringVar(&lintModulePats, "module-pattern", nil, "Package pattern of a workspace module, by module directory or path (api=./handlers/...)")
+	lintCmd.Flags().StringSliceVar(&lintOnlyPaths, "only", nil, "Only scan files matching these path patterns (same syntax as --ignore)")
+	lintCmd.Flags().BoolVar(&lintDescriptions, "require-descriptions", false, "Report models, enums, and properties without a description")
+	lintCmd.Flags().BoolVar(&lintCheckRoutes, "check-routes", false, "Compare the swagger:route directives with the handlers registered on routers")
+	lintCmd.Flags().BoolVar(&lintDescFallback, "description-fallback", false, "Describe properties without a description with the doc comment of their type")
+	rootCmd.AddCommand(lintCmd)
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check swagger directives for problems",
+	Long: `Lint scans Go source code for swagger directives and reports problems
+without writing a spec.
+
+Rules:
+  sunset-passed          - deprecated operation is past its sunset date
+  unknown-replacement    - deprecated operation points to an unknown replacement
+  undeclared-path-param  - path template variable without a parameter
+  unknown-path-param     - path parameter missing from the path
+  missing-operation-id   - swagger:route without an operation ID (error)
+  invalid-route          - swagger:route with an invalid method or path (error)
+  invalid-path           - swagger:path without an absolute path (error)
+  invalid-headers        - swagger:headers without operation IDs (error)
+  invalid-response       - response line without the list dash (error)
+  invalid-channel        - swagger:channel without a channel, action, or operation ID (error)
+  invalid-envelope       - envelope: without a payload property (error)
+  invalid-example        - example: | block that is empty or not valid YAML, or
+                           example variable calling a function (error)
+  invalid-license        - License: with both an SPDX identifier and a url (error)
+  invalid-code-sample    - codeSample: without a language and a source (error)
+  invalid-ratelimit      - ratelimit: without a limit and a known window (error)
+  invalid-pagination     - pagination: with an unknown style or parameter (error)
+  custom-method          - swagger:route with a method that is not a standard
+                           HTTP method, such as a misspelled GET
+  missing-description    - model, enum, or property without a description
+                           (with --require-descriptions)
+  unregistered-route     - swagger:route that no router registers
+                           (with --check-routes)
+  undocumented-route     - handler registered on a router without a swagger:route
+                           (with --check-routes)
+
+Warnings are reported but do not fail the command; errors do.
+With --format json, the issues are printed as a JSON array of findings
+with their rule, severity, message, operation, file, line, and column.
+--format sarif prints a SARIF 2.1.0 log for code scanning tools, and
+--format github prints GitHub Actions workflow commands that annotate
+the lines of a pull request. Both use paths relative to the current
+directory, the repository root in CI.
+
+Example:
+  openapi lint
+  openapi lint -p ./api/...
+  openapi lint --format json
+  openapi lint --check-routes
+  openapi lint --format sarif > openapi.sarif
+  openapi lint --format github`,
+	RunE: runLint,
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	if !slices.Contains([]string{"text", "json", "sarif", "github"}, lintFormat) {
+		return fmt.Errorf("unknown format %q, expected text, json, sarif, or github", lintFormat)
+	}
+
+	configFile, err := generator.ReadConfigFile(lintDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+	configFile.Register()
+
+	gen := generator.New(
+		generator.WithDir(lintDir),
+		generator.WithPattern(lintPattern),
+		generator.WithIgnorePaths(append(configFile.Ignore, lintIgnorePaths...)...),
+		generator.WithOnlyPaths(append(configFile.Only, lintOnlyPaths...)...),
+		generator.WithBuildTags(lintBuildTags...),
+		generator.WithIncludeTests(lintIncludeTests),
+		generator.WithWorkspace(lintWorkspace),
+		generator.WithModulePatterns(lintModulePats),
+		generator.WithRequireDescriptions(lintDescriptions),
+		generator.WithDescriptionFallback(lintDescFallback),
+		generator.WithCheckRoutes(lintCheckRoutes),
+		generator.WithCache(false),
+	)
+
+	issues, err := gen.Lint()
+	if err != nil {
+		return fmt.Errorf("lint failed: %w", err)
+	}
+
+	switch lintFormat {
+	case "json":
+		if err := printFindings(issues); err != nil {
+			return err
+		}
+	case "sarif":
+		if err := generator.WriteSARIF(os.Stdout, issues, ".", Version); err != nil {
+			return fmt.Errorf("failed to encode SARIF log: %w", err)
+		}
+	case "github":
+		if err := generator.WriteGitHubAnnotations(os.Stdout, issues, "."); err != nil {
+			return err
+		}
+	default:
+		printIssues(issues)
+	}
+
+	errors := 0
+	for _, issue := range issues {
+		if issue.Severity == generator.SeverityError {
+			errors++
+		}
+	}
+
+	if errors > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("found %d error(s)", errors)
+	}
+	return nil
+}
+
+// printIssues prints lint issues for humans, prefixed with their source location.
+func printIssues(issues []generator.LintIssue) {
+	if len(issues) == 0 {
+		fmt.Println("✅ No issues found")
+		return
+	}
+	for _, issue := range issues {
+		if issue.SourceFile != "" {
+			fmt.Printf("%s: %s\n", issue.Location(), issue)
+		} else {
+			fmt.Println(issue)
+		}
+	}
+}
+
+// printFindings prints lint issues as a JSON array, for CI annotations and other tools.
+func printFindings(issues []generator.LintIssue) error {
+	if issues == nil {
+		issues = []generator.LintIssue{}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(issues); err != nil {
+		return fmt.Errorf("failed to encode findings: %w", err)
+	}
+	return nil
+}
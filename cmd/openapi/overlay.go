@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kausys/openapi/overlay"
+	"github.com/kausys/openapi/spec"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	overlayOutput   string
+	overlayPortable bool
+)
+
+func init() {
+	overlayApplyCmd.Flags().StringVarP(&overlayOutput, "output", "o", "", "Output file path (default: stdout)")
+	overlayApplyCmd.Flags().BoolVar(&overlayPortable, "portable-yaml", false, "Write YAML without anchors or aliases, with the document fields in the order of the specification")
+	overlayCmd.AddCommand(overlayApplyCmd)
+	rootCmd.AddCommand(overlayCmd)
+}
+
+var overlayCmd = &cobra.Command{
+	Use:   "overlay",
+	Short: "Work with OpenAPI Overlay documents",
+}
+
+var overlayApplyCmd = &cobra.Command{
+	Use:   "apply <overlay> <spec>",
+	Short: "Apply an OpenAPI Overlay to a spec",
+	Long: `Apply applies the actions of an OpenAPI Overlay document to a spec and
+writes the result. Each action selects nodes with a JSONPath target and either
+merges its update into them or removes them.
+
+The result is written as JSON when the output file (or, without --output,
+the spec) has a .json extension, and as YAML otherwise. With --portable-yaml,
+anchors and aliases of the spec are replaced by copies of the anchored nodes.
+
+Example:
+  openapi overlay apply public.overlay.yaml openapi.yaml -o public.yaml
+  openapi generate --overlay public.overlay.yaml   # apply while generating`,
+	Args: cobra.ExactArgs(2),
+	RunE: runOverlayApply,
+}
+
+func runOverlayApply(cmd *cobra.Command, args []string) error {
+	o, err := overlay.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load overlay: %w", err)
+	}
+
+	data, err := os.ReadFile(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	if err := o.Apply(&doc); err != nil {
+		return fmt.Errorf("failed to apply overlay: %w", err)
+	}
+
+	format := args[1]
+	if overlayOutput != "" {
+		format = overlayOutput
+	}
+
+	var result []byte
+	if strings.EqualFold(filepath.Ext(format), ".json") {
+		result, err = nodeToJSON(&doc)
+	} else {
+		out := &doc
+		if overlayPortable {
+			out = spec.PortableYAML(&doc)
+		}
+		result, err = yaml.Marshal(out)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode spec: %w", err)
+	}
+
+	if overlayOutput == "" {
+		_, err = cmd.OutOrStdout().Write(result)
+		return err
+	}
+	if err := os.WriteFile(overlayOutput, result, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", overlayOutput, err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "✅ Wrote %s\n", overlayOutput)
+	return nil
+}
+
+// nodeToJSON encodes a YAML node as indented JSON, keeping the key order of the document.
+func nodeToJSON(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSONNode(&buf, node); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// writeJSONNode writes the compact JSON encoding of a YAML node.
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSONNode(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSONNode(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSONNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONNode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
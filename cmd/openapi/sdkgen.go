@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kausys/openapi/sdkgen"
 	"github.com/spf13/cobra"
@@ -10,11 +11,17 @@ import (
 var (
 	sdkgenOutputDir string
 	sdkgenProvider  string
+	sdkgenStrict    bool
+	sdkgenHeaders   []string
+	sdkgenCacheDir  string
 )
 
 func init() {
 	sdkgenCmd.Flags().StringVarP(&sdkgenOutputDir, "output", "o", "", "Output directory for generated SDK (required)")
 	sdkgenCmd.Flags().StringVar(&sdkgenProvider, "provider", "", "Override provider name from config")
+	sdkgenCmd.Flags().BoolVar(&sdkgenStrict, "strict", false, "Fail when the spec has unknown fields instead of printing warnings")
+	sdkgenCmd.Flags().StringArrayVarP(&sdkgenHeaders, "header", "H", nil, "Header of the request of a spec URL, as \"Name: value\" (repeatable)")
+	sdkgenCmd.Flags().StringVar(&sdkgenCacheDir, "cache-dir", sdkgen.DefaultSpecCacheDir(), "Directory caching the specs fetched from a URL with their ETag (empty disables the cache)")
 	_ = sdkgenCmd.MarkFlagRequired("output")
 	rootCmd.AddCommand(sdkgenCmd)
 }
@@ -31,17 +38,37 @@ The generated SDK follows the standard 4-layer architecture:
   - models/   Request/response structs and enums
   - services/ Service methods per API tag
 
+The spec.path of the config can be an http(s) URL, to regenerate the SDK
+from the spec a provider publishes. The spec.headers of the config and
+--header are sent with the request, and the spec is cached in --cache-dir
+with its ETag, so an unchanged spec isn't downloaded again and the cached
+one is used when the server can't be reached. YAML and JSON specs are
+told apart by their media type or content.
+
 Example:
   openapi sdkgen pokemon.sdkgen.yaml -o ./pkg/sdk/pokemon
-  openapi sdkgen pokemon.sdkgen.yaml -o ./pkg/sdk/pokemon --provider myProvider`,
+  openapi sdkgen pokemon.sdkgen.yaml -o ./pkg/sdk/pokemon --provider myProvider
+  openapi sdkgen acme.sdkgen.yaml -o ./pkg/sdk/acme -H "Authorization: Bearer $ACME_TOKEN"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSDKGen,
 }
 
 func runSDKGen(cmd *cobra.Command, args []string) error {
+	headers := make(map[string]string, len(sdkgenHeaders))
+	for _, header := range sdkgenHeaders {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid header %q, expected \"Name: value\"", header)
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
 	opts := []sdkgen.Option{
 		sdkgen.WithConfigPath(args[0]),
 		sdkgen.WithOutputDir(sdkgenOutputDir),
+		sdkgen.WithStrict(sdkgenStrict),
+		sdkgen.WithHeaders(headers),
+		sdkgen.WithSpecCacheDir(sdkgenCacheDir),
 	}
 
 	if sdkgenProvider != "" {
@@ -50,7 +77,11 @@ func runSDKGen(cmd *cobra.Command, args []string) error {
 
 	gen := sdkgen.New(opts...)
 
-	if err := gen.Generate(); err != nil {
+	err := gen.Generate()
+	for _, warning := range gen.Warnings() {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", warning)
+	}
+	if err != nil {
 		return fmt.Errorf("SDK generation failed: %w", err)
 	}
 
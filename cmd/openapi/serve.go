@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kausys/openapi/generator"
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/swagger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveDir          string
+	servePattern      string
+	serveIgnorePaths  []string
+	serveOnlyPaths    []string
+	serveBuildTags    []string
+	serveIncludeTests bool
+	serveVersioned    bool
+	serveHost         string
+	servePort         int
+	serveSpecFiles    []string
+	serveWatch        bool
+	serveInterval     time.Duration
+	serveUI           string
+	serveUIZip        string
+)
+
+func init() {
+	serveCmd.Flags().StringVarP(&serveDir, "dir", "d", ".", "Root directory to scan from")
+	serveCmd.Flags().StringVarP(&servePattern, "pattern", "p", "./...", "Package pattern to scan")
+	serveCmd.Flags().StringSliceVar(&serveIgnorePaths, "ignore", nil, "Path patterns to ignore: substrings, globs (internal/*/mocks, *_gen.go), or re:<regexp>")
+	serveCmd.Flags().StringSliceVar(&serveOnlyPaths, "only", nil, "Only scan files matching these path patterns (same syntax as --ignore)")
+	serveCmd.Flags().StringSliceVar(&serveBuildTags, "build-tags", nil, "Build tags selecting the files to scan, as with go build -tags")
+	serveCmd.Flags().BoolVar(&serveIncludeTests, "include-tests", false, "Load _test.go files so that response examples can reference test variables")
+	serveCmd.Flags().BoolVar(&serveVersioned, "versioned", false, "Serve a spec per API version, from /v1/ path prefixes or version: directives")
+	serveCmd.Flags().StringVar(&serveHost, "host", "localhost", "Host to listen on")
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+	serveCmd.Flags().StringSliceVar(&serveSpecFiles, "spec-file", nil, "Serve these spec files instead of generating the specs (named after the file)")
+	serveCmd.Flags().BoolVarP(&serveWatch, "watch", "w", false, "Regenerate the specs when source files change")
+	serveCmd.Flags().DurationVar(&serveInterval, "interval", time.Second, "Interval between two checks for changed files (with --watch)")
+	serveCmd.Flags().StringVar(&serveUI, "ui", "", "Documentation UI: swagger, redoc, or scalar (default swagger when available, else scalar)")
+	serveCmd.Flags().StringVar(&serveUIZip, "ui-zip", "", "Swagger UI zip to serve (from openapi swagger download)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the API documentation locally",
+	Long: `Serve generates the OpenAPI spec(s) and serves them with Swagger UI, Redoc,
+or Scalar, one spec per spec: directive. With --watch, the specs are regenerated
+when Go sources, Markdown descriptions, YAML and JSON files (fragments, examples,
+translations), or the config file change, and a reload of the page shows them.
+
+Swagger UI is served from --ui-zip (see openapi swagger download). Without it,
+Scalar is served, which needs no assets.
+
+Example:
+  openapi serve --port 8080
+  openapi serve --watch -p ./api/...
+  openapi serve --ui redoc --spec-file openapi.yaml`,
+	RunE: runServe,
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	ui, uiZip, err := serveUIAssets()
+	if err != nil {
+		return err
+	}
+
+	var provider swagger.SpecProvider
+	defaultSpec := scanner.DefaultSpec
+	if len(serveSpecFiles) > 0 {
+		provider = swagger.SpecProviderFunc(loadSpecFiles)
+		defaultSpec = specFileName(serveSpecFiles[0])
+	} else {
+		// The config file is read again on every regeneration, so its changes apply
+		provider = swagger.SpecProviderFunc(func(ctx context.Context) (map[string][]byte, error) {
+			configFile, err := generator.ReadConfigFile(serveDir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load config file: %w", err)
+			}
+			// Entries removed from the config file don't stay registered
+			generator.ResetToDefaults()
+			generator.ClearProfiles()
+			generator.ClearTagDescriptions()
+			configFile.Register()
+
+			gen := generator.New(
+				generator.WithDir(serveDir),
+				generator.WithPattern(servePattern),
+				generator.WithOutput("", ""),
+				generator.WithIgnorePaths(append(configFile.Ignore, serveIgnorePaths...)...),
+				generator.WithOnlyPaths(append(configFile.Only, serveOnlyPaths...)...),
+				generator.WithBuildTags(serveBuildTags...),
+				generator.WithIncludeTests(serveIncludeTests),
+				generator.WithVersioned(serveVersioned),
+			)
+			defer printWarnings(cmd, gen)
+			return gen.EncodeSpecs(ctx, generator.FormatYAML)
+		})
+	}
+
+	// The specs are refreshed by the watcher only
+	handler, err := swagger.NewWithProvider(uiZip, provider, swagger.Config{
+		UI:              ui,
+		DefaultSpec:     defaultSpec,
+		RefreshInterval: -1,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := handler.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to load specs: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	handler.Routes(mux)
+	mux.Handle("/{$}", http.RedirectHandler("/swagger/", http.StatusFound))
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(serveHost, strconv.Itoa(servePort)))
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: mux}
+
+	if serveWatch {
+		go watchSources(ctx, cmd, handler)
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Serving %s at http://%s/swagger/\n", ui, listener.Addr())
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serveUIAssets returns the UI to serve and the Swagger UI zip it needs, if any.
+func serveUIAssets() (string, []byte, error) {
+	if serveUIZip != "" {
+		if serveUI != "" && serveUI != swagger.UISwagger {
+			return "", nil, fmt.Errorf("--ui-zip requires the swagger UI, not %s", serveUI)
+		}
+		uiZip, err := os.ReadFile(serveUIZip)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read Swagger UI: %w", err)
+		}
+		return swagger.UISwagger, uiZip, nil
+	}
+
+	switch serveUI {
+	case "":
+		return swagger.UIScalar, nil, nil
+	case swagger.UISwagger:
+		return "", nil, errors.New("--ui swagger requires --ui-zip, download one with openapi swagger download")
+	default:
+		return serveUI, nil, nil
+	}
+}
+
+// loadSpecFiles reads the --spec-file files, by spec name.
+func loadSpecFiles(ctx context.Context) (map[string][]byte, error) {
+	specs := make(map[string][]byte, len(serveSpecFiles))
+	for _, file := range serveSpecFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		specs[specFileName(file)] = data
+	}
+	return specs, nil
+}
+
+// specFileName returns the spec name of a --spec-file: its file name without extension.
+func specFileName(file string) string {
+	return strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+}
+
+// watchSources polls the served sources, the spec files or the generator inputs under
+// --dir, and refreshes the specs of the handler when they change.
+func watchSources(ctx context.Context, cmd *cobra.Command, handler *swagger.Handler) {
+	last, _ := sourcesFingerprint()
+
+	ticker := time.NewTicker(serveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := sourcesFingerprint()
+		if err != nil || current == last {
+			continue
+		}
+		last = current
+
+		if err := handler.Refresh(ctx); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "error: failed to regenerate specs: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s specs regenerated\n", time.Now().Format(time.TimeOnly))
+	}
+}
+
+// watchedExtensions are the extensions of the generator inputs: Go sources, Markdown
+// descriptions, and the YAML and JSON fragments, examples, translations, and config file.
+var watchedExtensions = []string{".go", ".md", ".yaml", ".yml", ".json"}
+
+// sourcesFingerprint returns a hash of the paths, sizes, and modification times of the
+// served sources: the spec files, or the generator inputs under --dir (hidden
+// directories and vendor are skipped, except for the hidden config file).
+func sourcesFingerprint() (uint64, error) {
+	stats := make(map[string]fs.FileInfo)
+	if len(serveSpecFiles) > 0 {
+		for _, file := range serveSpecFiles {
+			info, err := os.Stat(file)
+			if err != nil {
+				return 0, err
+			}
+			stats[file] = info
+		}
+	} else {
+		err := filepath.WalkDir(serveDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if path != serveDir && (strings.HasPrefix(name, ".") || name == "vendor") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !slices.Contains(watchedExtensions, filepath.Ext(path)) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			stats[path] = info
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	hash := fnv.New64a()
+	for _, path := range slices.Sorted(maps.Keys(stats)) {
+		fmt.Fprintf(hash, "%s %d %d\n", path, stats[path].Size(), stats[path].ModTime().UnixNano())
+	}
+	return hash.Sum64(), nil
+}
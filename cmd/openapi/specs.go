@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/kausys/openapi/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	specsDir          string
+	specsPattern      string
+	specsIgnorePaths  []string
+	specsOnlyPaths    []string
+	specsBuildTags    []string
+	specsIncludeTests bool
+	specsVersioned    bool
+	specsNoDefault    bool
+)
+
+func init() {
+	specsListCmd.Flags().StringVarP(&specsDir, "dir", "d", ".", "Root directory to scan from")
+	specsListCmd.Flags().StringVarP(&specsPattern, "pattern", "p", "./...", "Package pattern to scan")
+	specsListCmd.Flags().StringSliceVar(&specsIgnorePaths, "ignore", nil, "Path patterns to ignore: substrings, globs (internal/*/mocks, *_gen.go), or re:<regexp>")
+	specsListCmd.Flags().StringSliceVar(&specsOnlyPaths, "only", nil, "Only scan files matching these path patterns (same syntax as --ignore)")
+	specsListCmd.Flags().StringSliceVar(&specsBuildTags, "build-tags", nil, "Build tags selecting the files to scan, as with go build -tags")
+	specsListCmd.Flags().BoolVar(&specsIncludeTests, "include-tests", false, "Load _test.go files")
+	specsListCmd.Flags().BoolVar(&specsVersioned, "versioned", false, "List a spec per API version, from /v1/ path prefixes or version: directives")
+	specsListCmd.Flags().BoolVar(&specsNoDefault, "no-default", false, "Skip the default spec of routes without spec: directives")
+	specsCmd.AddCommand(specsListCmd)
+	rootCmd.AddCommand(specsCmd)
+}
+
+var specsCmd = &cobra.Command{
+	Use:   "specs",
+	Short: "Inspect the specs declared by spec: directives",
+}
+
+var specsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the spec names that can be generated",
+	Long: `List scans the Go source code and prints, one per line, the names of the
+specs declared by spec: directives. Each name can be generated on its own with
+openapi generate --spec <name>.
+
+Example:
+  openapi specs list
+  openapi specs list --versioned -p ./api/...`,
+	Args: cobra.NoArgs,
+	RunE: runSpecsList,
+}
+
+func runSpecsList(cmd *cobra.Command, args []string) error {
+	configFile, err := generator.ReadConfigFile(specsDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+	configFile.Register()
+
+	gen := generator.New(
+		generator.WithDir(specsDir),
+		generator.WithPattern(specsPattern),
+		generator.WithOutput("", ""),
+		generator.WithIgnorePaths(append(configFile.Ignore, specsIgnorePaths...)...),
+		generator.WithOnlyPaths(append(configFile.Only, specsOnlyPaths...)...),
+		generator.WithBuildTags(specsBuildTags...),
+		generator.WithIncludeTests(specsIncludeTests),
+		generator.WithVersioned(specsVersioned),
+		generator.WithNoDefault(specsNoDefault),
+	)
+
+	names, err := gen.GetSpecNames()
+	if err != nil {
+		return fmt.Errorf("failed to scan specs: %w", err)
+	}
+	for _, name := range names {
+		fmt.Fprintln(cmd.OutOrStdout(), name)
+	}
+	return nil
+}
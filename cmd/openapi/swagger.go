@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/kausys/openapi/swagger"
 	"github.com/spf13/cobra"
@@ -12,6 +14,10 @@ var (
 	swaggerVersion     string
 	swaggerUseDefaults bool
 	swaggerSimple      bool
+	swaggerChecksum    string
+	swaggerCacheDir    string
+	swaggerOffline     bool
+	swaggerProxy       string
 )
 
 func init() {
@@ -22,6 +28,10 @@ func init() {
 	swaggerDownloadCmd.Flags().StringVarP(&swaggerVersion, "version", "v", "", "Specific version to download (default: latest)")
 	swaggerDownloadCmd.Flags().BoolVar(&swaggerUseDefaults, "with-defaults", true, "Include default initializer and CSS customizations")
 	swaggerDownloadCmd.Flags().BoolVar(&swaggerSimple, "simple", false, "Use simple initializer for single-spec mode")
+	swaggerDownloadCmd.Flags().StringVar(&swaggerChecksum, "sha256", "", "Expected SHA-256 checksum of the release archive")
+	swaggerDownloadCmd.Flags().StringVar(&swaggerCacheDir, "cache-dir", swagger.DefaultCacheDir(), "Directory caching the release archives by version (empty disables the cache)")
+	swaggerDownloadCmd.Flags().BoolVar(&swaggerOffline, "offline", false, "Use the cached release archive only, failing when it is missing")
+	swaggerDownloadCmd.Flags().StringVar(&swaggerProxy, "proxy", "", "Proxy URL of the GitHub requests (default from HTTPS_PROXY)")
 
 	rootCmd.AddCommand(swaggerCmd)
 }
@@ -55,7 +65,14 @@ Examples:
   openapi swagger download --with-defaults=false -o ./pkg/openapi
 
   # Download with simple single-spec initializer
-  openapi swagger download --simple -o ./pkg/openapi`,
+  openapi swagger download --simple -o ./pkg/openapi
+
+  # Pin the release archive and never access the network (CI)
+  openapi swagger download -v 5.29.4 --sha256 <checksum> --offline -o ./pkg/openapi
+
+Release archives are cached by version in --cache-dir with their checksum,
+printed on download. Pass it to --sha256 to verify the archive; without it a
+warning reports that the archive is unverified.`,
 	RunE: runSwaggerDownload,
 }
 
@@ -70,6 +87,19 @@ func runSwaggerDownload(cmd *cobra.Command, args []string) error {
 	opts := swagger.DownloadOptions{
 		OutputDir: swaggerOutputDir,
 		Version:   swaggerVersion,
+		Checksum:  swaggerChecksum,
+		CacheDir:  swaggerCacheDir,
+		Offline:   swaggerOffline,
+	}
+
+	if swaggerProxy != "" {
+		proxyURL, err := url.Parse(swaggerProxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = http.ProxyURL(proxyURL)
+		opts.HTTPClient = &http.Client{Transport: transport}
 	}
 
 	if swaggerUseDefaults {
@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/asyncapi"
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateAsyncAPI scans the source files and assembles the AsyncAPI document of the event
+// channels (swagger:channel) and messages (swagger:message). Message payloads are the same
+// models as in the OpenAPI spec. No output file is written.
+func (g *Generator) GenerateAsyncAPI() (*asyncapi.AsyncAPI, error) {
+	return g.GenerateAsyncAPIContext(context.Background())
+}
+
+// GenerateAsyncAPIContext is like GenerateAsyncAPI but stops scanning when ctx is done.
+func (g *Generator) GenerateAsyncAPIContext(ctx context.Context) (*asyncapi.AsyncAPI, error) {
+	run := g.newRun()
+	defer g.finishRun(run)
+
+	if err := run.prepare(ctx); err != nil {
+		return nil, err
+	}
+	return run.assembleAsyncAPI()
+}
+
+// assembleAsyncAPI creates the AsyncAPI document from scanned channels and messages.
+func (g *Generator) assembleAsyncAPI() (*asyncapi.AsyncAPI, error) {
+	// Reset referenced schemas, only the models of the messages are part of the document
+	g.referencedSchemas = make(map[string]bool)
+
+	doc := &asyncapi.AsyncAPI{
+		AsyncAPI:           asyncapi.Version,
+		Info:               &spec.Info{Title: "API", Version: "1.0.0"},
+		DefaultContentType: scanner.ContentTypeJSON,
+		Channels:           make(map[string]*asyncapi.Channel),
+		Components: &asyncapi.Components{
+			Messages: make(map[string]*asyncapi.Message),
+		},
+	}
+	if g.scanner.Meta != nil {
+		doc.Info = g.metaToInfo(g.scanner.Meta)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(g.scanner.Messages)) {
+		doc.Components.Messages[name] = &asyncapi.Message{
+			Name:    name,
+			Payload: g.typeToSchema(g.scanner.Messages[name].Payload),
+		}
+	}
+
+	channels := slices.SortedFunc(maps.Values(g.scanner.Channels), func(a, b *scanner.ChannelInfo) int {
+		return strings.Compare(a.OperationID, b.OperationID)
+	})
+	if err := g.checkChannelCollisions(channels); err != nil {
+		return nil, err
+	}
+	for _, channelInfo := range channels {
+		g.addChannel(doc, channelInfo)
+	}
+
+	// Add the models referenced by the payloads, and the models they reference
+	components := &spec.Components{Schemas: make(map[string]*spec.Schema)}
+	g.buildReferencedSchemas(components, scanner.DefaultSpec)
+	if len(components.Schemas) > 0 {
+		doc.Components.Schemas = components.Schemas
+	}
+
+	return doc, nil
+}
+
+// addChannel adds the operation of a swagger:channel directive to its channel.
+func (g *Generator) addChannel(doc *asyncapi.AsyncAPI, c *scanner.ChannelInfo) {
+	channel, ok := doc.Channels[c.Channel]
+	if !ok {
+		channel = &asyncapi.Channel{}
+		for _, name := range pathTemplateParams(c.Channel) {
+			if channel.Parameters == nil {
+				channel.Parameters = make(map[string]*asyncapi.Parameter)
+			}
+			channel.Parameters[name] = &asyncapi.Parameter{
+				Schema: &spec.Schema{Type: spec.NewSchemaType(scanner.TypeString)},
+			}
+		}
+		doc.Channels[c.Channel] = channel
+	}
+
+	op := &asyncapi.Operation{
+		OperationID: c.OperationID,
+		Summary:     c.Summary,
+		Description: c.Description,
+		Message:     g.channelMessage(c),
+	}
+	for _, tag := range c.Tags {
+		op.Tags = append(op.Tags, &spec.Tag{Name: tag})
+	}
+
+	if c.Action == scanner.ActionPublish {
+		channel.Publish = op
+	} else {
+		channel.Subscribe = op
+	}
+}
+
+// channelMessage returns the message of a channel operation: a reference to a declared
+// message, or a message whose payload is the named model or primitive. Several messages
+// are alternatives (oneOf).
+func (g *Generator) channelMessage(c *scanner.ChannelInfo) *asyncapi.Message {
+	var messages []*asyncapi.Message
+	for _, name := range c.Messages {
+		name = strings.TrimSpace(name)
+		if _, ok := g.scanner.Messages[name]; ok {
+			messages = append(messages, &asyncapi.Message{Ref: "#/components/messages/" + name})
+			continue
+		}
+		if !g.isKnownType(name) {
+			g.warnAt(WarnUnknownMessage, c.Pos, "operation %s: message %s is not a message, model, enum, or primitive, its payload is generated as string",
+				c.OperationID, name)
+		}
+		messages = append(messages, &asyncapi.Message{Payload: g.typeToSchema(name)})
+	}
+
+	switch len(messages) {
+	case 0:
+		return nil
+	case 1:
+		return messages[0]
+	default:
+		return &asyncapi.Message{OneOf: messages}
+	}
+}
+
+// checkChannelCollisions returns an error when two operations declare the same action on
+// the same channel; the second one would silently replace the first.
+func (g *Generator) checkChannelCollisions(channels []*scanner.ChannelInfo) error {
+	declared := make(map[string]*scanner.ChannelInfo, len(channels))
+	for _, c := range channels {
+		key := c.Action + " " + c.Channel
+		if existing, ok := declared[key]; ok {
+			return fmt.Errorf("%s: operation %s declares %s, already declared by operation %s at %s",
+				g.location(c.Pos), c.OperationID, key, existing.OperationID, g.location(existing.Pos))
+		}
+		declared[key] = c
+	}
+	return nil
+}
+
+// writeAsyncAPI assembles the AsyncAPI document and writes it to the configured file,
+// as JSON for a .json file and YAML otherwise.
+func (g *Generator) writeAsyncAPI() error {
+	doc, err := g.assembleAsyncAPI()
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(g.config.AsyncAPIOutput), ".json") {
+		data, err = json.MarshalIndent(doc, "", "  ")
+	} else {
+		data, err = yaml.Marshal(doc)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal AsyncAPI document: %w", err)
+	}
+
+	return g.emitOutput(map[string][]byte{g.config.AsyncAPIOutput: data})
+}
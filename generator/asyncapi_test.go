@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// asyncAPIProject has a REST route and event channels sharing models.
+var asyncAPIProject = map[string]string{
+	"api/users.go": `package api
+
+// swagger:model Address
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// UserCreated is sent when an account is created
+//
+// swagger:message
+type UserCreated struct {
+	ID      string  ` + "`json:\"id\"`" + `
+	Address Address ` + "`json:\"address\"`" + `
+}
+
+// swagger:message SuspendUser
+type SuspendCommand struct {
+	Reason string ` + "`json:\"reason\"`" + `
+}
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: User
+func GetUser() {}
+
+// swagger:channel users.created subscribe users onUserCreated
+// summary: Account created
+// description: Sent once the account is activated.
+// Message: UserCreated
+func PublishUserCreated() {}
+
+// swagger:channel users/{id}/commands publish handleUserCommand
+// Message: SuspendUser|string
+func HandleUserCommand() {}
+`,
+}
+
+// TestGenerateAsyncAPI tests assembling channels, messages, and payload models
+func TestGenerateAsyncAPI(t *testing.T) {
+	tmpDir := createTestProject(t, asyncAPIProject)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	doc, err := g.GenerateAsyncAPI()
+	require.NoError(t, err)
+	assert.Empty(t, warningMessages(g.Warnings()))
+
+	assert.Equal(t, "2.6.0", doc.AsyncAPI)
+	assert.Equal(t, "application/json", doc.DefaultContentType)
+
+	created := doc.Channels["users.created"]
+	require.NotNil(t, created)
+	require.NotNil(t, created.Subscribe)
+	assert.Nil(t, created.Publish)
+	assert.Equal(t, "onUserCreated", created.Subscribe.OperationID)
+	assert.Equal(t, "Account created", created.Subscribe.Summary)
+	assert.Equal(t, "Sent once the account is activated.", created.Subscribe.Description)
+	require.Len(t, created.Subscribe.Tags, 1)
+	assert.Equal(t, "users", created.Subscribe.Tags[0].Name)
+	assert.Equal(t, "#/components/messages/UserCreated", created.Subscribe.Message.Ref)
+
+	commands := doc.Channels["users/{id}/commands"]
+	require.NotNil(t, commands)
+	require.NotNil(t, commands.Publish)
+	require.Contains(t, commands.Parameters, "id")
+	assert.Equal(t, "string", commands.Parameters["id"].Schema.Type.Value())
+	require.Len(t, commands.Publish.Message.OneOf, 2)
+	assert.Equal(t, "#/components/messages/SuspendUser", commands.Publish.Message.OneOf[0].Ref)
+	assert.Equal(t, "string", commands.Publish.Message.OneOf[1].Payload.Type.Value())
+
+	// Messages reference their payload model; only models used by payloads are included
+	require.Contains(t, doc.Components.Messages, "UserCreated")
+	assert.Equal(t, "#/components/schemas/UserCreated", doc.Components.Messages["UserCreated"].Payload.Ref)
+	assert.Equal(t, "#/components/schemas/SuspendCommand", doc.Components.Messages["SuspendUser"].Payload.Ref)
+	assert.ElementsMatch(t, []string{"UserCreated", "Address", "SuspendCommand"}, slices.Collect(maps.Keys(doc.Components.Schemas)))
+}
+
+// TestGenerateAsyncAPIOutput tests writing the AsyncAPI document alongside the OpenAPI spec
+func TestGenerateAsyncAPIOutput(t *testing.T) {
+	tmpDir := createTestProject(t, asyncAPIProject)
+	asyncAPIFile := filepath.Join(tmpDir, "asyncapi.json")
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+		WithOutput(filepath.Join(tmpDir, "openapi.yaml"), "yaml"), WithAsyncAPIOutput(asyncAPIFile))
+	_, err := g.Generate()
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(tmpDir, "openapi.yaml"))
+
+	data, err := os.ReadFile(asyncAPIFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"asyncapi": "2.6.0"`)
+	assert.Contains(t, string(data), `"operationId": "onUserCreated"`)
+}
+
+// TestGenerateAsyncAPIErrors tests malformed channels and channel collisions
+func TestGenerateAsyncAPIErrors(t *testing.T) {
+	t.Run("invalid channel", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{
+			"api/events.go": `package api
+
+// swagger:channel users.created send onUserCreated
+// Message: Unknown
+func PublishUserCreated() {}
+
+// swagger:channel users.deleted subscribe onUserDeleted
+// Message: Unknown
+func PublishUserDeleted() {}
+`,
+		})
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+		doc, err := g.GenerateAsyncAPI()
+		require.NoError(t, err)
+		assert.NotContains(t, doc.Channels, "users.created")
+		assert.Equal(t, []string{
+			`api/events.go:3: swagger:channel users.created send onUserCreated: invalid action "send", expected swagger:channel channel publish|subscribe [tags] operationID; channel skipped`,
+			"api/events.go:7: operation onUserDeleted: message Unknown is not a message, model, enum, or primitive, its payload is generated as string",
+		}, warningMessages(g.Warnings()))
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{
+			"api/events.go": `package api
+
+// swagger:channel users.created subscribe onUserCreated
+// Message: string
+func PublishUserCreated() {}
+
+// swagger:channel users.created subscribe onAccountCreated
+// Message: string
+func PublishAccountCreated() {}
+`,
+		})
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+		_, err := g.GenerateAsyncAPI()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "api/events.go:3: operation onUserCreated declares subscribe users.created, already declared by operation onAccountCreated at api/events.go:7")
+	})
+}
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// BackstageConfig configures the Backstage catalog descriptor written with the specs
+// (see WithBackstage). It is read from the backstage section of the config file.
+type BackstageConfig struct {
+	// Output is the catalog file the API entities are written to (catalog-info.yaml)
+	Output string `yaml:"output"`
+	// Name is the entity name; defaults to the API title. In multi-spec mode, each entity
+	// is named <name>-<spec>.
+	Name string `yaml:"name"`
+	// Owner is the entity owner (a user or group reference), required by Backstage
+	Owner string `yaml:"owner"`
+	// Lifecycle is the lifecycle of the API (default production)
+	Lifecycle string `yaml:"lifecycle"`
+	// System is the system the API belongs to
+	System string `yaml:"system"`
+	// Tags are the tags of the entities
+	Tags []string `yaml:"tags"`
+	// Inline embeds the spec in the entity definition instead of referencing its file
+	Inline bool `yaml:"inline"`
+}
+
+// backstageEntity is a Backstage API entity (backstage.io/v1alpha1).
+type backstageEntity struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   backstageMetadata `yaml:"metadata"`
+	Spec       backstageAPISpec  `yaml:"spec"`
+}
+
+// backstageMetadata is the metadata of a Backstage entity.
+type backstageMetadata struct {
+	Name        string   `yaml:"name"`
+	Title       string   `yaml:"title,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+}
+
+// backstageAPISpec is the spec of a Backstage API entity.
+type backstageAPISpec struct {
+	Type       string `yaml:"type"`
+	Lifecycle  string `yaml:"lifecycle"`
+	Owner      string `yaml:"owner"`
+	System     string `yaml:"system,omitempty"`
+	Definition any    `yaml:"definition"`
+}
+
+// backstageCatalog returns the Backstage catalog of the written specs, an API entity per
+// spec. specFiles maps the spec names to their output files, and files holds the content
+// of the output files.
+func (g *Generator) backstageCatalog(specs map[string]*spec.OpenAPI, specFiles map[string]string, files map[string][]byte, multi bool) ([]byte, error) {
+	config := g.config.Backstage
+	if config.Owner == "" {
+		return nil, errors.New("the Backstage catalog requires an owner (backstage.owner in the config file)")
+	}
+	lifecycle := config.Lifecycle
+	if lifecycle == "" {
+		lifecycle = "production"
+	}
+	catalogDir, err := filepath.Abs(filepath.Dir(config.Output))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	for _, specName := range slices.Sorted(maps.Keys(specFiles)) {
+		openAPI := specs[specName]
+		file := specFiles[specName]
+
+		var title, description string
+		if openAPI.Info != nil {
+			title = openAPI.Info.Title
+			description = openAPI.Info.Summary
+			if description == "" {
+				description, _, _ = strings.Cut(strings.TrimSpace(openAPI.Info.Description), "\n")
+			}
+		}
+
+		name := config.Name
+		if name == "" {
+			name = title
+		}
+		if multi {
+			name += "-" + specName
+			if title != "" {
+				title += " (" + specName + ")"
+			}
+		}
+
+		var definition any = string(files[file])
+		if !config.Inline {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				return nil, err
+			}
+			rel, err := filepath.Rel(catalogDir, abs)
+			if err != nil {
+				return nil, err
+			}
+			definition = map[string]string{"$text": "./" + filepath.ToSlash(rel)}
+		}
+
+		err := enc.Encode(backstageEntity{
+			APIVersion: "backstage.io/v1alpha1",
+			Kind:       "API",
+			Metadata: backstageMetadata{
+				Name:        backstageName(name),
+				Title:       title,
+				Description: description,
+				Tags:        config.Tags,
+			},
+			Spec: backstageAPISpec{
+				Type:       "openapi",
+				Lifecycle:  lifecycle,
+				Owner:      config.Owner,
+				System:     config.System,
+				Definition: definition,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal Backstage entity %s: %w", name, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// backstageName returns a valid Backstage entity name: lower-case letters, digits, and
+// dashes, at most 63 characters.
+func backstageName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	result := strings.TrimRight(b.String(), "-")
+	if len(result) > 63 {
+		result = strings.TrimRight(result[:63], "-")
+	}
+	if result == "" {
+		return "api"
+	}
+	return result
+}
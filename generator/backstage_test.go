@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const backstageTestHandlers = `// swagger:meta
+//
+// Title: Payments API
+// Version: 1.0.0
+// Description: Orders and payments of the shop.
+// Refunds are handled by the billing API.
+package api
+
+// swagger:route GET /orders orders listOrders
+// Responses:
+// - 200:
+func ListOrders() {}
+
+// swagger:route GET /admin/orders admin purgeOrders
+// spec: admin
+// Responses:
+// - 200:
+func PurgeOrders() {}
+`
+
+func TestBackstageCatalog(t *testing.T) {
+	t.Run("referenced definition", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/doc.go": backstageTestHandlers})
+		catalog := filepath.Join(tmpDir, "catalog-info.yaml")
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(filepath.Join(tmpDir, "docs", "openapi.yaml"), "yaml"),
+			WithBackstage(BackstageConfig{Output: catalog, Owner: "team-payments", System: "payments", Tags: []string{"payments"}}))
+		_, err := g.Generate()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(catalog)
+		require.NoError(t, err)
+		assert.Equal(t, `apiVersion: backstage.io/v1alpha1
+kind: API
+metadata:
+  name: payments-api
+  title: Payments API
+  description: Orders and payments of the shop. Refunds are handled by the billing API.
+  tags:
+    - payments
+spec:
+  type: openapi
+  lifecycle: production
+  owner: team-payments
+  system: payments
+  definition:
+    $text: ./docs/openapi.yaml
+`, string(data))
+	})
+
+	t.Run("multi spec inline", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/doc.go": backstageTestHandlers})
+		catalog := filepath.Join(tmpDir, "catalog-info.yaml")
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(filepath.Join(tmpDir, "specs", "openapi.yaml"), "yaml"),
+			WithBackstage(BackstageConfig{Output: catalog, Owner: "team-payments", Name: "Payments", Lifecycle: "experimental", Inline: true}))
+		_, err := g.GenerateMulti()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(catalog)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "name: payments-admin\n  title: Payments API (admin)")
+		assert.Contains(t, string(data), "---\napiVersion: backstage.io/v1alpha1")
+		assert.Contains(t, string(data), "name: payments-default")
+		assert.Contains(t, string(data), "lifecycle: experimental")
+		assert.Contains(t, string(data), "definition: |\n    openapi: 3.1.2")
+	})
+
+	t.Run("owner required", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/doc.go": backstageTestHandlers})
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(filepath.Join(tmpDir, "openapi.yaml"), "yaml"),
+			WithBackstage(BackstageConfig{Output: filepath.Join(tmpDir, "catalog-info.yaml")}))
+		_, err := g.Generate()
+		assert.ErrorContains(t, err, "requires an owner")
+	})
+}
+
+func TestBackstageName(t *testing.T) {
+	assert.Equal(t, "payments-api", backstageName("Payments API"))
+	assert.Equal(t, "orders-v2", backstageName("  Orders (v2) "))
+	assert.Equal(t, "api", backstageName("---"))
+	assert.Len(t, backstageName("a very long title that goes well beyond the sixty three characters allowed"), 63)
+}
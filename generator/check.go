@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ErrStaleOutput is matched by the error returned in check mode when output files are out of date.
+var ErrStaleOutput = errors.New("generated output is out of date")
+
+// StaleOutputError is returned in check mode when the output files differ from the generated specs.
+type StaleOutputError struct {
+	// Files are the output files that are missing or out of date
+	Files []string
+	// Diff is the unified diff from the files on disk to the generated specs
+	Diff string
+}
+
+// Error implements the error interface.
+func (e *StaleOutputError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrStaleOutput, strings.Join(e.Files, ", "))
+}
+
+// Unwrap returns ErrStaleOutput.
+func (e *StaleOutputError) Unwrap() error {
+	return ErrStaleOutput
+}
+
+// emitOutput writes the generated output files, or compares them with the files
+// on disk in check mode.
+func (g *Generator) emitOutput(files map[string][]byte) error {
+	if g.config.Check {
+		return checkOutput(files)
+	}
+
+	for _, filename := range slices.Sorted(maps.Keys(files)) {
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filename, files[filename], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkOutput compares the generated output files with the files on disk and returns
+// a StaleOutputError with a unified diff when any of them is missing or differs.
+func checkOutput(files map[string][]byte) error {
+	var stale []string
+	var diff strings.Builder
+
+	for _, filename := range slices.Sorted(maps.Keys(files)) {
+		current, err := os.ReadFile(filename)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		generated := files[filename]
+		if err == nil && string(current) == string(generated) {
+			continue
+		}
+
+		label := filepath.ToSlash(filename)
+		fromFile := label
+		if err != nil {
+			fromFile = "/dev/null"
+		}
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(current)),
+			B:        difflib.SplitLines(string(generated)),
+			FromFile: fromFile,
+			ToFile:   label,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+
+		stale = append(stale, filename)
+		diff.WriteString(text)
+	}
+
+	if len(stale) > 0 {
+		return &StaleOutputError{Files: stale, Diff: diff.String()}
+	}
+	return nil
+}
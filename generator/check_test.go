@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateCheck tests that check mode compares the output files without writing them
+func TestGenerateCheck(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+`,
+	})
+	outputFile := filepath.Join(tmpDir, "openapi.yaml")
+	newGenerator := func(opts ...Option) *Generator {
+		return New(append([]Option{WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(outputFile, "yaml")}, opts...)...)
+	}
+
+	// Missing output file
+	_, err := newGenerator(WithCheck(true)).Generate()
+	stale, ok := errors.AsType[*StaleOutputError](err)
+	require.True(t, ok, "expected StaleOutputError, got %v", err)
+	assert.ErrorIs(t, err, ErrStaleOutput)
+	assert.Equal(t, []string{outputFile}, stale.Files)
+	assert.Contains(t, stale.Diff, "--- /dev/null")
+	assert.Contains(t, stale.Diff, "+    /users:")
+	assert.NoFileExists(t, outputFile)
+
+	// Up to date
+	_, err = newGenerator().Generate()
+	require.NoError(t, err)
+	_, err = newGenerator(WithCheck(true)).Generate()
+	require.NoError(t, err)
+
+	// Out of date
+	data, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	edited := []byte(strings.Replace(string(data), "listUsers", "getUsers", 1))
+	require.NoError(t, os.WriteFile(outputFile, edited, 0644))
+
+	_, err = newGenerator(WithCheck(true)).Generate()
+	stale, ok = errors.AsType[*StaleOutputError](err)
+	require.True(t, ok, "expected StaleOutputError, got %v", err)
+	assert.Contains(t, stale.Diff, "--- "+filepath.ToSlash(outputFile))
+	assert.Contains(t, stale.Diff, "-            operationId: getUsers")
+	assert.Contains(t, stale.Diff, "+            operationId: listUsers")
+
+	current, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, edited, current)
+}
+
+// TestGenerateMultiCheck tests check mode with one output file per spec
+func TestGenerateMultiCheck(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// spec: public
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route DELETE /users/{id} users deleteUser
+// spec: admin
+// Responses:
+// - 204: description:Deleted
+func DeleteUser() {}
+`,
+	})
+	outputDir := filepath.Join(tmpDir, "specs")
+	newGenerator := func(opts ...Option) *Generator {
+		return New(append([]Option{WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(filepath.Join(outputDir, "openapi.yaml"), "yaml")}, opts...)...)
+	}
+
+	_, err := newGenerator().GenerateMulti()
+	require.NoError(t, err)
+	_, err = newGenerator(WithCheck(true)).GenerateMulti()
+	require.NoError(t, err)
+
+	require.NoError(t, os.Remove(filepath.Join(outputDir, "admin.yaml")))
+
+	_, err = newGenerator(WithCheck(true)).GenerateMulti()
+	stale, ok := errors.AsType[*StaleOutputError](err)
+	require.True(t, ok, "expected StaleOutputError, got %v", err)
+	assert.Equal(t, []string{filepath.Join(outputDir, "admin.yaml")}, stale.Files)
+	assert.NoFileExists(t, filepath.Join(outputDir, "admin.yaml"))
+}
@@ -0,0 +1,284 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/spec"
+)
+
+// Languages of the generated code samples (see WithCodeSamples).
+const (
+	// CodeSampleCurl generates a curl command from the server, parameters, security, and
+	// request body of the operation
+	CodeSampleCurl = "curl"
+)
+
+// CodeSampleLanguages are the languages of the generated code samples.
+var CodeSampleLanguages = []string{CodeSampleCurl}
+
+// codeSampleDepth bounds the nesting of the request bodies of the generated samples.
+const codeSampleDepth = 4
+
+// addCodeSamples adds the configured generated code samples to the x-codeSamples of the
+// operations, after the samples of codeSample: directives. An operation keeps a sample of
+// the same language, and operations with an x-codeSamples extension written by hand are
+// left as is.
+func (g *Generator) addCodeSamples(openAPI *spec.OpenAPI) {
+	if len(g.config.CodeSamples) == 0 || openAPI.Paths == nil {
+		return
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(openAPI.Paths.PathItems)) {
+		item := openAPI.Paths.PathItems[path]
+		for _, op := range pathOperations(path, item) {
+			samples, ok := op.Extensions["x-codeSamples"].([]map[string]any)
+			if !ok && op.Extensions["x-codeSamples"] != nil {
+				continue
+			}
+
+			for _, lang := range g.config.CodeSamples {
+				switch lang {
+				case CodeSampleCurl:
+					if hasCodeSample(samples, "curl", "shell") {
+						continue
+					}
+					samples = append(samples, map[string]any{
+						"lang":   "Shell",
+						"label":  "curl",
+						"source": curlSample(openAPI, item, op),
+					})
+				}
+			}
+
+			if op.Extensions == nil {
+				op.Extensions = make(spec.Extensions)
+			}
+			op.Extensions["x-codeSamples"] = samples
+		}
+	}
+}
+
+// hasCodeSample reports whether samples has a sample with one of the languages or labels.
+func hasCodeSample(samples []map[string]any, langs ...string) bool {
+	return slices.ContainsFunc(samples, func(sample map[string]any) bool {
+		return slices.ContainsFunc(langs, func(lang string) bool {
+			return strings.EqualFold(fmt.Sprint(sample["lang"]), lang) || strings.EqualFold(fmt.Sprint(sample["label"]), lang)
+		})
+	})
+}
+
+// curlSample returns a curl command calling an operation. Parameters without an example
+// are written as {name} placeholders and credentials as environment variables.
+func curlSample(openAPI *spec.OpenAPI, item *spec.PathItem, op TemplateOperation) string {
+	baseURL := "$BASE_URL"
+	if len(openAPI.Servers) > 0 && openAPI.Servers[0].URL != "" {
+		baseURL = strings.TrimSuffix(openAPI.Servers[0].URL, "/")
+	}
+
+	var query, headers []string
+	path := op.Path
+	for _, param := range operationParameters(openAPI, item, op.Operation) {
+		value, ok := parameterExample(param)
+		switch {
+		case param.In == "path" && ok:
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(value))
+		case param.In == "query" && param.Required:
+			if ok {
+				value = url.QueryEscape(value)
+			}
+			query = append(query, url.QueryEscape(param.Name)+"="+value)
+		case param.In == "header" && param.Required:
+			headers = append(headers, param.Name+": "+value)
+		}
+	}
+
+	args := []string{"curl"}
+	if op.Method != "GET" {
+		args = append(args, "-X "+op.Method)
+	}
+
+	security := spec.SecurityRequirements(openAPI.Security)
+	if op.Security != nil {
+		security = op.Security
+	}
+	if len(security) > 0 && openAPI.Components != nil {
+		for _, name := range slices.Sorted(maps.Keys(security[0].Requirements)) {
+			scheme := openAPI.Components.SecuritySchemes[name]
+			if scheme == nil {
+				continue
+			}
+			switch {
+			case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+				args = append(args, `-u "$USERNAME:$PASSWORD"`)
+			case scheme.Type == "http" || scheme.Type == "oauth2" || scheme.Type == "openIdConnect":
+				args = append(args, `-H "Authorization: Bearer $TOKEN"`)
+			case scheme.Type == "apiKey" && scheme.In == "header":
+				args = append(args, fmt.Sprintf(`-H "%s: $API_KEY"`, scheme.Name))
+			case scheme.Type == "apiKey" && scheme.In == "query":
+				query = append(query, url.QueryEscape(scheme.Name)+"=$API_KEY")
+			}
+		}
+	}
+
+	for _, header := range headers {
+		args = append(args, "-H "+shellQuote(header))
+	}
+	args = append(args, requestBodyArgs(openAPI, op.RequestBody)...)
+
+	target := baseURL + path
+	if len(query) > 0 {
+		target += "?" + strings.Join(query, "&")
+	}
+	args = append(args, `"`+target+`"`)
+
+	return strings.Join(args, " \\\n  ")
+}
+
+// operationParameters returns the parameters of an operation and of its path item, with
+// references resolved. Operation parameters override the path item parameters.
+func operationParameters(openAPI *spec.OpenAPI, item *spec.PathItem, op *spec.Operation) []*spec.Parameter {
+	var params []*spec.Parameter
+	seen := make(map[string]bool)
+	for _, param := range slices.Concat(op.Parameters, item.Parameters) {
+		if param != nil && param.Ref != "" && openAPI.Components != nil {
+			param = openAPI.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+		}
+		if param == nil || seen[param.In+" "+param.Name] {
+			continue
+		}
+		seen[param.In+" "+param.Name] = true
+		params = append(params, param)
+	}
+	return params
+}
+
+// parameterExample returns the example of a parameter, or a {name} placeholder and false.
+func parameterExample(param *spec.Parameter) (string, bool) {
+	if param.Example != nil {
+		return fmt.Sprint(param.Example), true
+	}
+	if param.Schema != nil && len(param.Schema.Examples) > 0 {
+		return fmt.Sprint(param.Schema.Examples[0]), true
+	}
+	return "{" + param.Name + "}", false
+}
+
+// requestBodyArgs returns the curl arguments sending the request body: a JSON document
+// built from the example or the schema of the body, form fields for multipart bodies,
+// or the body read from a file.
+func requestBodyArgs(openAPI *spec.OpenAPI, body *spec.RequestBody) []string {
+	if body == nil || len(body.Content) == 0 {
+		return nil
+	}
+
+	mediaTypes := slices.Sorted(maps.Keys(body.Content))
+	mediaType := mediaTypes[0]
+	if slices.Contains(mediaTypes, "application/json") {
+		mediaType = "application/json"
+	}
+	content := body.Content[mediaType]
+
+	switch {
+	case mediaType == "multipart/form-data":
+		schema := resolveSchema(openAPI, content.Schema)
+		if schema == nil {
+			return nil
+		}
+		var args []string
+		for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+			prop := resolveSchema(openAPI, schema.Properties[name])
+			value := fmt.Sprint(schemaSample(openAPI, prop, 0))
+			if prop != nil && (prop.Format == "binary" || prop.ContentMediaType != "" || prop.Type.Contains("array")) {
+				value = "@" + name
+			}
+			args = append(args, "-F "+shellQuote(name+"="+value))
+		}
+		return args
+	case strings.HasSuffix(mediaType, "json"):
+		example := content.Example
+		if example == nil {
+			example = schemaSample(openAPI, content.Schema, 0)
+		}
+		data, err := json.Marshal(example)
+		if err != nil {
+			return nil
+		}
+		return []string{"-H " + shellQuote("Content-Type: "+mediaType), "-d " + shellQuote(string(data))}
+	default:
+		return []string{"-H " + shellQuote("Content-Type: "+mediaType), "--data-binary @body"}
+	}
+}
+
+// resolveSchema returns the component schema of a reference, or the schema itself.
+func resolveSchema(openAPI *spec.OpenAPI, schema *spec.Schema) *spec.Schema {
+	if schema != nil && schema.Ref != "" && openAPI.Components != nil {
+		return openAPI.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// schemaSample returns a sample value of a schema: its example, default, or first enum
+// value, or a value of its type.
+func schemaSample(openAPI *spec.OpenAPI, schema *spec.Schema, depth int) any {
+	schema = resolveSchema(openAPI, schema)
+	if schema == nil || depth > codeSampleDepth {
+		return nil
+	}
+	switch {
+	case len(schema.Examples) > 0:
+		return schema.Examples[0]
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case schema.Const != nil:
+		return schema.Const
+	case len(schema.AllOf) > 0:
+		merged := make(map[string]any)
+		for _, part := range schema.AllOf {
+			if sample, ok := schemaSample(openAPI, part, depth+1).(map[string]any); ok {
+				maps.Copy(merged, sample)
+			}
+		}
+		return merged
+	case len(schema.OneOf) > 0:
+		return schemaSample(openAPI, schema.OneOf[0], depth+1)
+	}
+
+	switch {
+	case schema.Type.Contains("object") || len(schema.Properties) > 0:
+		sample := make(map[string]any, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			if prop.ReadOnly {
+				continue
+			}
+			sample[name] = schemaSample(openAPI, prop, depth+1)
+		}
+		return sample
+	case schema.Type.Contains("array"):
+		if item := schemaSample(openAPI, schema.Items, depth+1); item != nil {
+			return []any{item}
+		}
+		return []any{}
+	case schema.Type.Contains("integer"), schema.Type.Contains("number"):
+		return 0
+	case schema.Type.Contains("boolean"):
+		return true
+	case schema.Format == "date-time":
+		return "2024-01-01T00:00:00Z"
+	case schema.Format == "date":
+		return "2024-01-01"
+	default:
+		return "string"
+	}
+}
+
+// shellQuote returns s single-quoted for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
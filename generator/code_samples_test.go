@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const codeSampleTestAPI = `// swagger:meta
+//
+// Title: Users API
+// Version: 1.0.0
+//
+// Servers:
+// - https://api.example.com/v1 Production
+//
+// SecuritySchemes:
+// - name: bearer
+//   type: http
+//   scheme: bearer
+// Security:
+// - bearer
+package api
+
+// swagger:route POST /teams/{teamID}/users users createUser
+// codeSample: Go file:samples/create_user.go.txt
+// Responses:
+// - 201: User
+func CreateUser() {}
+
+// swagger:route GET /users users listUsers
+// codeSample: Shell curl https://api.example.com/v1/users
+// codeSample: TypeScript
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:parameters createUser
+type CreateUserParams struct {
+	// in: path
+	// required: true
+	// example: 42
+	TeamID int ` + "`json:\"teamID\"`" + `
+
+	// in: query
+	// required: true
+	Notify bool ` + "`json:\"notify\"`" + `
+
+	// in: body
+	Body CreateUserRequest
+}
+
+// swagger:model CreateUserRequest
+type CreateUserRequest struct {
+	// example: Ada
+	Name string ` + "`json:\"name\"`" + `
+	Admin bool ` + "`json:\"admin\"`" + `
+}
+
+// swagger:model User
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`
+
+func TestCodeSamples(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go":                     codeSampleTestAPI,
+		"api/samples/create_user.go.txt": "user, err := client.Users().CreateUser(ctx, 42, req)\n",
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithCodeSamples(CodeSampleCurl))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	create := openAPI.Paths.PathItems["/teams/{teamID}/users"].Post
+	require.NotNil(t, create)
+	assert.Equal(t, []map[string]any{
+		{"lang": "Go", "source": "user, err := client.Users().CreateUser(ctx, 42, req)"},
+		{"lang": "Shell", "label": "curl", "source": `curl \
+  -X POST \
+  -H "Authorization: Bearer $TOKEN" \
+  -H 'Content-Type: application/json' \
+  -d '{"admin":true,"name":"Ada"}' \
+  "https://api.example.com/v1/teams/42/users?notify={notify}"`},
+	}, create.Extensions["x-codeSamples"])
+
+	// The Shell sample of the directive replaces the generated curl sample
+	list := openAPI.Paths.PathItems["/users"].Get
+	require.NotNil(t, list)
+	assert.Equal(t, []map[string]any{
+		{"lang": "Shell", "source": "curl https://api.example.com/v1/users"},
+	}, list.Extensions["x-codeSamples"])
+
+	// A codeSample: without a source is reported
+	var codes []string
+	for _, w := range g.Warnings() {
+		codes = append(codes, w.Code)
+	}
+	assert.Contains(t, codes, WarnInvalidCodeSample)
+}
+
+func TestCodeSamplesUnknownLanguage(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/doc.go": codeSampleTestAPI})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithCodeSamples("cobol"))
+	_, err := g.Generate()
+	assert.ErrorContains(t, err, `unknown code sample language "cobol"`)
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratorConcurrentUse runs generations concurrently on one generator.
+// Run with -race to detect shared mutable state.
+func TestGeneratorConcurrentUse(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/handlers.go": `package api
+
+// swagger:route GET /users users listUsers
+// spec: public
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route GET /admin/users/{id} admin getAdminUser
+// spec: admin
+// Responses:
+// - 200: User
+func GetAdminUser() {}
+`,
+		"api/models.go": `package api
+
+// swagger:model User
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Role Role   ` + "`json:\"role\"`" + `
+}
+
+// swagger:model Role
+type Role struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(true), WithOutput("", ""))
+
+	const workers = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*4)
+
+	for range workers {
+		wg.Go(func() {
+			openAPI, err := g.Generate()
+			if err == nil {
+				assert.Contains(t, openAPI.Components.Schemas, "User")
+			}
+			errs <- err
+		})
+		wg.Go(func() {
+			specs, err := g.GenerateMulti()
+			if err == nil {
+				assert.Len(t, specs, 2)
+			}
+			errs <- err
+		})
+		wg.Go(func() {
+			openAPI, err := g.GenerateSpec("admin")
+			if err == nil {
+				assert.Contains(t, openAPI.Paths.PathItems, "/admin/users/{id}")
+			}
+			errs <- err
+		})
+		wg.Go(func() {
+			_, err := g.Lint()
+			errs <- err
+			_ = g.Warnings()
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
@@ -1,6 +1,21 @@
 // Package generator provides OpenAPI specification generation from Go source code.
 package generator
 
+import (
+	"maps"
+
+	"github.com/kausys/openapi/scanner"
+)
+
+// Format is the encoding of a generated spec.
+type Format string
+
+// Supported output formats.
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
 // Config holds configuration options for the generator.
 type Config struct {
 	// Dir is the root directory of the project
@@ -9,10 +24,26 @@ type Config struct {
 	Pattern string
 	// IgnorePaths contains path patterns to exclude during scanning
 	IgnorePaths []string
+	// OnlyPaths restricts scanning to the files matching one of these path patterns
+	OnlyPaths []string
+	// BuildTags are the build tags used to select the files of the scanned packages
+	BuildTags []string
+	// IncludeTests loads the _test.go files of the scanned packages for example variables
+	IncludeTests bool
+	// Workspace scans the modules of the go.work file in Dir, with Pattern relative to
+	// each module directory
+	Workspace bool
+	// ModulePatterns are the package patterns of workspace modules, by module directory
+	// or module path
+	ModulePatterns map[string]string
 	// OutputFile is the output file path for the generated spec
 	OutputFile string
 	// OutputFormat is the output format: "yaml" or "json"
 	OutputFormat string
+	// AdditionalOutputs are files the spec is also written to in the same run, each in the
+	// format of its extension (.yaml, .yml, or .json). In multi-spec mode, the specs are
+	// also written to the directory of each file with its extension.
+	AdditionalOutputs []string
 	// UseCache enables incremental build caching
 	UseCache bool
 	// Flatten inlines $ref schemas instead of using references
@@ -21,10 +52,86 @@ type Config struct {
 	Validate bool
 	// CleanUnused removes schemas that are declared but not referenced
 	CleanUnused bool
+	// ReportUnused warns about models and enums that no included route references, with their source locations
+	ReportUnused bool
+	// SchemaTitles sets the title of model and enum schemas to the name of their Go type
+	SchemaTitles bool
+	// DescriptionFallback describes the fields and parameters without a description with
+	// the doc comment of their type
+	DescriptionFallback bool
+	// RequireDescriptions makes lint report models, enums, and properties without a description
+	RequireDescriptions bool
+	// CheckRoutes makes lint compare the swagger:route directives with the router registrations
+	CheckRoutes bool
+	// StrictObjects closes the object schemas of models (additionalProperties: false) unless
+	// they opt out with additionalProperties: true
+	StrictObjects bool
 	// NoDefault skips generating the default spec for routes without spec: directives
 	NoDefault bool
 	// EnumRefs generates enums as $ref references to components/schemas instead of inline
 	EnumRefs bool
+	// Profile is the name of the gateway profile used to decorate the spec (see RegisterProfile)
+	Profile string
+	// SharedComponents writes schemas shared by several specs to a common components file in multi-spec mode
+	SharedComponents bool
+	// Versioned splits the specs of multi-spec mode per API version (/v1/ path prefix or version: directive)
+	Versioned bool
+	// IncludeTags keeps only routes with at least one of these tags (internal: true matches "internal")
+	IncludeTags []string
+	// ExcludeTags removes routes with any of these tags (internal: true matches "internal")
+	ExcludeTags []string
+	// Strict fails the generation when any warning is reported
+	Strict bool
+	// StrictRefs fails the generation when a response or field type falls back to a string
+	StrictRefs bool
+	// Check compares the generated specs with the output files instead of writing them
+	Check bool
+	// SplitReadWrite derives <Model>Request and <Model>Response variants from readOnly/writeOnly properties
+	SplitReadWrite bool
+	// FieldPolicies infer required/nullable from omitempty and pointers (FieldPolicyOmitemptyOptional, FieldPolicyPointerNullable)
+	FieldPolicies []string
+	// DurationFormat renders time.Duration as a duration string (DurationString) or integer nanoseconds (DurationInt64)
+	DurationFormat string
+	// SchemaNaming names models of different packages sharing a name (SchemaNamingShort,
+	// SchemaNamingPackage, SchemaNamingHash)
+	SchemaNaming string
+	// PathOrder orders the paths of the written specs (PathOrderAlpha, PathOrderTag,
+	// PathOrderSource)
+	PathOrder string
+	// ResolveExternal loads dependency packages declaring referenced types that aren't
+	// found in the scanned packages
+	ResolveExternal bool
+	// Overlays are OpenAPI Overlay files applied, in order, to each generated spec
+	Overlays []string
+	// PostProcessors are external commands that receive each generated spec on stdin
+	// and print the resulting spec (see RegisterPostProcessor for in-process hooks)
+	PostProcessors []string
+	// Translations are YAML files (<locale>.yaml) whose texts produce a localized copy of
+	// each written spec (openapi.<locale>.yaml)
+	Translations []string
+	// CodeSamples are the languages of the code samples generated for each operation
+	// (x-codeSamples), next to the samples of codeSample: directives
+	CodeSamples []string
+	// Templates are Go text/template files rendering each written spec (TemplateData) to
+	// a file, named after the template without .tmpl or given after = (tmpl=file)
+	Templates []string
+	// Backstage writes a Backstage catalog of API entities, one per written spec, when
+	// its Output is set
+	Backstage BackstageConfig
+	// EmbedOutput is a Go file declaring the written specs by name (Specs), embedded
+	// with //go:embed, to serve them with the swagger handler
+	EmbedOutput string
+	// EmbedPackage is the package of the embed output; defaults to its directory name
+	EmbedPackage string
+	// AsyncAPIOutput is the file the AsyncAPI document of the event channels is written to,
+	// as JSON for a .json file and YAML otherwise
+	AsyncAPIOutput string
+	// PortableYAML writes YAML without anchors, aliases, and merge keys, with the fields of
+	// the document in the order of the specification
+	PortableYAML bool
+	// ProblemDetails documents error responses as RFC 7807 problem details
+	// (application/problem+json), with the canonical Problem schema when untyped
+	ProblemDetails bool
 }
 
 // Option is a function type for configuring the Generator.
@@ -44,13 +151,59 @@ func WithPattern(pattern string) Option {
 	}
 }
 
-// WithIgnorePaths sets the path patterns to ignore.
+// WithIgnorePaths sets the path patterns to ignore. A pattern is a substring of the file
+// or package path, a glob when it contains *, ?, or [ ("internal/*/mocks", "*_gen.go"),
+// or a regular expression with the "re:" prefix ("re:/v[0-9]+/").
 func WithIgnorePaths(paths ...string) Option {
 	return func(c *Config) {
 		c.IgnorePaths = append(c.IgnorePaths, paths...)
 	}
 }
 
+// WithOnlyPaths restricts scanning to the files matching one of the path patterns
+// (same syntax as WithIgnorePaths). Ignore patterns still apply to the matching files.
+func WithOnlyPaths(paths ...string) Option {
+	return func(c *Config) {
+		c.OnlyPaths = append(c.OnlyPaths, paths...)
+	}
+}
+
+// WithBuildTags sets the build tags used to select the files of the scanned packages,
+// as with go build -tags. Files whose //go:build constraints aren't satisfied are not scanned.
+func WithBuildTags(tags ...string) Option {
+	return func(c *Config) {
+		c.BuildTags = append(c.BuildTags, tags...)
+	}
+}
+
+// WithIncludeTests loads the _test.go files of the scanned packages, so that response
+// examples (example:name) can reference variables declared in tests, such as golden
+// fixtures, without adding them to the production build. Directives in test files are ignored.
+func WithIncludeTests(enabled bool) Option {
+	return func(c *Config) {
+		c.IncludeTests = enabled
+	}
+}
+
+// WithWorkspace scans the modules of the go.work file in the directory in one run, with
+// the pattern applied in each module directory, instead of a single module.
+func WithWorkspace(enabled bool) Option {
+	return func(c *Config) {
+		c.Workspace = enabled
+	}
+}
+
+// WithModulePatterns sets the package patterns of workspace modules, by module directory
+// or module path, in place of the pattern (e.g., {"api": "./handlers/..."}).
+func WithModulePatterns(patterns map[string]string) Option {
+	return func(c *Config) {
+		if c.ModulePatterns == nil {
+			c.ModulePatterns = make(map[string]string, len(patterns))
+		}
+		maps.Copy(c.ModulePatterns, patterns)
+	}
+}
+
 // WithOutput sets the output file and format.
 func WithOutput(file, format string) Option {
 	return func(c *Config) {
@@ -59,6 +212,14 @@ func WithOutput(file, format string) Option {
 	}
 }
 
+// WithAdditionalOutputs writes the spec to more files in the same run, each in the
+// format of its extension.
+func WithAdditionalOutputs(files ...string) Option {
+	return func(c *Config) {
+		c.AdditionalOutputs = files
+	}
+}
+
 // WithCache enables or disables caching.
 func WithCache(enabled bool) Option {
 	return func(c *Config) {
@@ -87,6 +248,56 @@ func WithCleanUnused(clean bool) Option {
 	}
 }
 
+// WithReportUnused enables or disables warnings about models and enums that no included
+// route references.
+func WithReportUnused(report bool) Option {
+	return func(c *Config) {
+		c.ReportUnused = report
+	}
+}
+
+// WithSchemaTitles sets the title of model and enum schemas to the name of their Go type,
+// shown by tools in place of bare objects.
+func WithSchemaTitles(titles bool) Option {
+	return func(c *Config) {
+		c.SchemaTitles = titles
+	}
+}
+
+// WithDescriptionFallback describes the fields and parameters without a description with
+// the doc comment of their type, when the type is declared in the scanned packages
+// without a swagger directive (type Email string).
+func WithDescriptionFallback(fallback bool) Option {
+	return func(c *Config) {
+		c.DescriptionFallback = fallback
+	}
+}
+
+// WithRequireDescriptions makes lint report the models, enums, and model properties
+// without a description (missing-description).
+func WithRequireDescriptions(require bool) Option {
+	return func(c *Config) {
+		c.RequireDescriptions = require
+	}
+}
+
+// WithCheckRoutes makes lint compare the swagger:route directives with the handlers
+// registered on routers in the scanned code: routes no router registers
+// (unregistered-route) and registrations without a route (undocumented-route).
+func WithCheckRoutes(check bool) Option {
+	return func(c *Config) {
+		c.CheckRoutes = check
+	}
+}
+
+// WithStrictObjects closes the object schemas of models, so that validators reject
+// undeclared properties. Models opt out with the additionalProperties: true directive.
+func WithStrictObjects(strict bool) Option {
+	return func(c *Config) {
+		c.StrictObjects = strict
+	}
+}
+
 // WithNoDefault skips generating the default spec for routes without spec: directives.
 func WithNoDefault(noDefault bool) Option {
 	return func(c *Config) {
@@ -101,6 +312,241 @@ func WithEnumRefs(enumRefs bool) Option {
 	}
 }
 
+// WithProfile selects a registered gateway profile to decorate the generated spec.
+func WithProfile(name string) Option {
+	return func(c *Config) {
+		c.Profile = name
+	}
+}
+
+// WithSharedComponents writes schemas shared by several specs to a common components
+// file referenced via external $ref, instead of duplicating them in every spec.
+func WithSharedComponents(shared bool) Option {
+	return func(c *Config) {
+		c.SharedComponents = shared
+	}
+}
+
+// WithVersioned splits the specs of multi-spec mode per API version. The version of a
+// route is its version: directive, else the version segment opening its path (/v2/users).
+func WithVersioned(versioned bool) Option {
+	return func(c *Config) {
+		c.Versioned = versioned
+	}
+}
+
+// WithIncludeTags keeps only routes tagged with at least one of the given tags.
+func WithIncludeTags(tags ...string) Option {
+	return func(c *Config) {
+		c.IncludeTags = append(c.IncludeTags, tags...)
+	}
+}
+
+// WithExcludeTags removes routes tagged with any of the given tags.
+// Use scanner.InternalTag to strip internal-only routes and models.
+func WithExcludeTags(tags ...string) Option {
+	return func(c *Config) {
+		c.ExcludeTags = append(c.ExcludeTags, tags...)
+	}
+}
+
+// WithStrict turns generation warnings into an error returned before any output is written.
+func WithStrict(strict bool) Option {
+	return func(c *Config) {
+		c.Strict = strict
+	}
+}
+
+// WithStrictRefs fails the generation when a response or field type cannot be resolved
+// to a model, enum, custom type, or primitive, instead of emitting type: string.
+func WithStrictRefs(strict bool) Option {
+	return func(c *Config) {
+		c.StrictRefs = strict
+	}
+}
+
+// WithCheck compares the generated specs with the existing output files instead of
+// writing them; generation fails with a StaleOutputError when they differ.
+func WithCheck(check bool) Option {
+	return func(c *Config) {
+		c.Check = check
+	}
+}
+
+// WithSplitReadWrite derives <Model>Request and <Model>Response variants of the models
+// with readOnly or writeOnly properties and uses them in request bodies and responses,
+// so server-managed fields are not shown as writable.
+func WithSplitReadWrite(split bool) Option {
+	return func(c *Config) {
+		c.SplitReadWrite = split
+	}
+}
+
+// WithFieldPolicies enables policies inferring the required and nullable state of model
+// properties: FieldPolicyOmitemptyOptional (required unless omitempty) and
+// FieldPolicyPointerNullable (pointers are nullable). The required: and nullable:
+// directives of a field override the policies.
+func WithFieldPolicies(policies ...string) Option {
+	return func(c *Config) {
+		c.FieldPolicies = append(c.FieldPolicies, policies...)
+	}
+}
+
+// Formats of time.Duration fields (see WithDurationFormat).
+const (
+	DurationString = "duration" // type: string, format: duration (default)
+	DurationInt64  = "int64"    // type: integer, format: int64 (nanoseconds, as encoding/json writes them)
+)
+
+// WithDurationFormat sets how time.Duration fields are rendered: DurationString or DurationInt64.
+func WithDurationFormat(format string) Option {
+	return func(c *Config) {
+		c.DurationFormat = format
+	}
+}
+
+// Strategies for models of different packages sharing a name (see WithSchemaNaming).
+const (
+	SchemaNamingShort   = scanner.SchemaNamingShort   // collisions are errors, resolved with swagger:model Name (default)
+	SchemaNamingPackage = scanner.SchemaNamingPackage // colliding models are prefixed with their package name (BillingError)
+	SchemaNamingHash    = scanner.SchemaNamingHash    // colliding models are suffixed with a hash of their package path (Error_1c8f0a3e)
+)
+
+// WithSchemaNaming sets how models of different packages declaring the same type name
+// are named: SchemaNamingShort, SchemaNamingPackage, or SchemaNamingHash. Models named
+// with swagger:model Name keep their name with every strategy.
+func WithSchemaNaming(naming string) Option {
+	return func(c *Config) {
+		c.SchemaNaming = naming
+	}
+}
+
+// Orders of the paths of the written specs (see WithPathOrder).
+const (
+	PathOrderAlpha  = "alpha"  // alphabetical (default)
+	PathOrderTag    = "tag"    // by the first tag of their operations, in the order of the document tags, then alphabetical
+	PathOrderSource = "source" // in the order their routes are declared in the source files
+)
+
+// PathOrders lists the supported path orders.
+var PathOrders = []string{PathOrderAlpha, PathOrderTag, PathOrderSource}
+
+// WithPathOrder sets the order of the paths of the written specs: PathOrderAlpha,
+// PathOrderTag, or PathOrderSource. The operations of a path keep the order of the
+// path item fields.
+func WithPathOrder(order string) Option {
+	return func(c *Config) {
+		c.PathOrder = order
+	}
+}
+
+// WithResolveExternal loads, on demand, the dependency packages (e.g., a shared DTO
+// module) declaring referenced types that aren't found in the scanned packages. Their
+// struct types become models. Disabled by default as loading packages is slow.
+func WithResolveExternal(enabled bool) Option {
+	return func(c *Config) {
+		c.ResolveExternal = enabled
+	}
+}
+
+// WithOverlays applies OpenAPI Overlay files, in order, to each generated spec
+// before it is validated and written.
+func WithOverlays(paths ...string) Option {
+	return func(c *Config) {
+		c.Overlays = append(c.Overlays, paths...)
+	}
+}
+
+// WithTranslations writes a localized copy of each spec next to it for every translations
+// file, named after the locale of the file (translations/fr.yaml writes openapi.fr.yaml).
+// The files translate the info, tag, operation, parameter, response, schema, and property
+// (Schema.property) descriptions; untranslated texts are kept.
+func WithTranslations(paths ...string) Option {
+	return func(c *Config) {
+		c.Translations = append(c.Translations, paths...)
+	}
+}
+
+// WithPostProcessors runs external commands on each generated spec, after overlays
+// and the registered post-processors. Each command is split on spaces (no shell),
+// receives the spec as JSON on stdin, and prints the resulting spec as JSON or YAML;
+// printing nothing keeps the spec unchanged and a non-zero exit fails the generation.
+func WithPostProcessors(commands ...string) Option {
+	return func(c *Config) {
+		c.PostProcessors = append(c.PostProcessors, commands...)
+	}
+}
+
+// WithCodeSamples generates a code sample in each language for every operation, added
+// to the x-codeSamples extension after the samples of codeSample: directives. The only
+// language is curl (CodeSampleCurl), built from the first server, the parameters, the
+// security, and the request body of the operation.
+func WithCodeSamples(langs ...string) Option {
+	return func(c *Config) {
+		c.CodeSamples = append(c.CodeSamples, langs...)
+	}
+}
+
+// WithTemplates renders each written spec through Go text/template files, for outputs
+// such as endpoint inventories. A template receives a TemplateData and is written to the
+// file given after = (endpoints.csv.tmpl=docs/endpoints.csv), or to its name without
+// .tmpl next to the output file. In multi-spec mode, each spec renders a file prefixed
+// with its name (admin.endpoints.csv).
+func WithTemplates(templates ...string) Option {
+	return func(c *Config) {
+		c.Templates = append(c.Templates, templates...)
+	}
+}
+
+// WithBackstage writes a Backstage catalog (catalog-info.yaml) to config.Output with an
+// API entity per written spec, referencing the spec file or with the spec inlined.
+func WithBackstage(config BackstageConfig) Option {
+	return func(c *Config) {
+		c.Backstage = config
+	}
+}
+
+// WithEmbedOutput writes a Go file of the given package (the name of its directory when
+// empty) declaring the written specs by name in a Specs map, ready for the Specs of
+// swagger.Config. The specs under the directory of the file are embedded with //go:embed,
+// the others are declared as string literals. Single specs are named after their file
+// (openapi for openapi.yaml).
+func WithEmbedOutput(file, pkg string) Option {
+	return func(c *Config) {
+		c.EmbedOutput = file
+		c.EmbedPackage = pkg
+	}
+}
+
+// WithAsyncAPIOutput writes the AsyncAPI document of the event channels (swagger:channel)
+// and messages (swagger:message) to a file when the spec is generated, as JSON for a .json
+// file and YAML otherwise. The document is written even without channels.
+func WithAsyncAPIOutput(file string) Option {
+	return func(c *Config) {
+		c.AsyncAPIOutput = file
+	}
+}
+
+// WithPortableYAML writes YAML specs that parsers implementing a subset of YAML read the
+// same way: anchors and aliases are replaced by copies, merge keys (<<) are expanded, and
+// the document fields are ordered as in the specification (info, servers, tags, paths,
+// components). JSON output is not affected.
+func WithPortableYAML(portable bool) Option {
+	return func(c *Config) {
+		c.PortableYAML = portable
+	}
+}
+
+// WithProblemDetails documents the error responses (4xx, 5xx, and default) with the RFC 7807
+// application/problem+json media type. Responses without a type use the canonical Problem
+// schema, extended per route with the problem: directive. A model named Problem replaces
+// the canonical schema.
+func WithProblemDetails(enabled bool) Option {
+	return func(c *Config) {
+		c.ProblemDetails = enabled
+	}
+}
+
 // DefaultConfig returns the default configuration.
 func DefaultConfig() *Config {
 	return &Config{
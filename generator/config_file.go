@@ -9,7 +9,12 @@ import (
 
 // ConfigFile represents the .openapi.yaml configuration file.
 type ConfigFile struct {
-	CustomTypes map[string]TypeConfig `yaml:"custom_types"`
+	CustomTypes map[string]TypeConfig    `yaml:"custom_types"`
+	Profiles    map[string]ProfileConfig `yaml:"profiles"`
+	Tags        map[string]string        `yaml:"tags"`
+	Ignore      []string                 `yaml:"ignore"`    // Path patterns excluded from scanning (see WithIgnorePaths)
+	Only        []string                 `yaml:"only"`      // Path patterns scanning is restricted to (see WithOnlyPaths)
+	Backstage   BackstageConfig          `yaml:"backstage"` // Backstage catalog of the written specs (see WithBackstage)
 }
 
 // TypeConfig represents a custom type configuration in the config file.
@@ -20,9 +25,32 @@ type TypeConfig struct {
 	Default any    `yaml:"default"`
 }
 
-// LoadConfigFile loads custom types from .openapi.yaml in the given directory.
+// ProfileConfig represents a gateway profile in the config file.
+type ProfileConfig struct {
+	Gateway             string         `yaml:"gateway"`
+	Name                string         `yaml:"name"`
+	Plugins             map[string]any `yaml:"plugins"`
+	Host                string         `yaml:"host"`
+	AllowCors           bool           `yaml:"allow_cors"`
+	Backend             map[string]any `yaml:"backend"`
+	Extensions          map[string]any `yaml:"extensions"`
+	OperationExtensions map[string]any `yaml:"operation_extensions"`
+}
+
+// LoadConfigFile loads custom types, gateway profiles, and tag descriptions from .openapi.yaml in the given directory.
 // It searches for .openapi.yaml, .openapi.yml, or openapi.config.yaml.
 func LoadConfigFile(dir string) error {
+	config, err := ReadConfigFile(dir)
+	if err != nil {
+		return err
+	}
+	config.Register()
+	return nil
+}
+
+// ReadConfigFile parses .openapi.yaml in the given directory without registering its
+// contents. It returns an empty ConfigFile when there is no config file.
+func ReadConfigFile(dir string) (*ConfigFile, error) {
 	configNames := []string{
 		".openapi.yaml",
 		".openapi.yml",
@@ -39,20 +67,24 @@ func LoadConfigFile(dir string) error {
 		}
 	}
 
+	var config ConfigFile
 	if configPath == "" {
-		return nil // No config file, not an error
+		return &config, nil // No config file, not an error
 	}
 
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var config ConfigFile
 	if err := yaml.Unmarshal(data, &config); err != nil {
-		return err
+		return nil, err
 	}
+	return &config, nil
+}
 
+// Register registers the custom types, gateway profiles, and tag descriptions of the config file.
+func (config *ConfigFile) Register() {
 	// Register custom types
 	for typeName, typeConfig := range config.CustomTypes {
 		RegisterTypeInfo(typeName, &TypeInfo{
@@ -63,5 +95,22 @@ func LoadConfigFile(dir string) error {
 		})
 	}
 
-	return nil
+	// Register gateway profiles
+	for name, profileConfig := range config.Profiles {
+		RegisterProfile(name, &Profile{
+			Gateway:             profileConfig.Gateway,
+			Name:                profileConfig.Name,
+			Plugins:             profileConfig.Plugins,
+			Host:                profileConfig.Host,
+			AllowCors:           profileConfig.AllowCors,
+			Backend:             profileConfig.Backend,
+			Extensions:          profileConfig.Extensions,
+			OperationExtensions: profileConfig.OperationExtensions,
+		})
+	}
+
+	// Register tag descriptions for tags not declared in swagger:meta
+	for name, description := range config.Tags {
+		RegisterTagDescription(name, description)
+	}
 }
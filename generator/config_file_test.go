@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateConfigFilePaths tests the ignore and only path patterns of the config file
+func TestGenerateConfigFilePaths(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/v1/handlers.go": `package v1
+
+// swagger:route GET /v1/users users listUsersV1
+// Responses:
+// - 204:
+func ListUsers() {}
+`,
+		"api/v2/handlers.go": `package v2
+
+// swagger:route GET /v2/users users listUsersV2
+// Responses:
+// - 204:
+func ListUsers() {}
+`,
+		"api/v2/handlers_gen.go": `package v2
+
+// swagger:route GET /v2/generated users listGenerated
+// Responses:
+// - 204:
+func ListGenerated() {}
+`,
+		".openapi.yaml": `ignore:
+  - "*_gen.go"
+only:
+  - "re:/v2/"
+`,
+	})
+
+	config, err := ReadConfigFile(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*_gen.go"}, config.Ignore)
+	assert.Equal(t, []string{"re:/v2/"}, config.Only)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""),
+		WithIgnorePaths(config.Ignore...), WithOnlyPaths(config.Only...))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	assert.Contains(t, openAPI.Paths.PathItems, "/v2/users")
+	assert.NotContains(t, openAPI.Paths.PathItems, "/v1/users")
+	assert.NotContains(t, openAPI.Paths.PathItems, "/v2/generated")
+}
+
+// TestReadConfigFileMissing tests that a missing config file reads as empty
+func TestReadConfigFileMissing(t *testing.T) {
+	config, err := ReadConfigFile(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, config.Ignore)
+	assert.Empty(t, config.Only)
+}
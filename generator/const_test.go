@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateConst tests single-value const fields and parameters
+func TestGenerateConst(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/payments.go": `package api
+
+// swagger:enum Method
+type Method string
+
+const (
+	MethodCard Method = "card"
+	MethodBank Method = "bank"
+)
+
+// swagger:model Card
+type Card struct {
+	// const: card
+	Type string ` + "`json:\"type\"`" + `
+	// const: 2
+	Version int ` + "`json:\"version\"`" + `
+	// const: card
+	Method Method ` + "`json:\"method\"`" + `
+}
+
+// swagger:parameters listCards
+type ListCardsParams struct {
+	// in: query
+	// const: true
+	Active bool ` + "`json:\"active\"`" + `
+}
+
+// swagger:route GET /cards payments listCards
+// Responses:
+// - 200: Card
+func ListCards() {}
+`,
+	})
+
+	for _, enumRefs := range []bool{false, true} {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithEnumRefs(enumRefs))
+		openAPI, err := g.Generate()
+		require.NoError(t, err)
+
+		card := openAPI.Components.Schemas["Card"]
+		require.NotNil(t, card)
+		assert.Equal(t, "card", card.Properties["type"].Const)
+		assert.Equal(t, "string", card.Properties["type"].Type.Value())
+		assert.Equal(t, int64(2), card.Properties["version"].Const)
+
+		method := card.Properties["method"]
+		assert.Equal(t, "card", method.Const)
+		if enumRefs {
+			require.Len(t, method.AllOf, 1)
+			assert.Equal(t, "#/components/schemas/Method", method.AllOf[0].Ref)
+		} else {
+			assert.Equal(t, []any{"bank", "card"}, method.Enum)
+		}
+
+		param := openAPI.Paths.PathItems["/cards"].Get.Parameters[0]
+		assert.Equal(t, true, param.Schema.Const)
+	}
+}
@@ -1,6 +1,9 @@
 package generator
 
 import (
+	"encoding/json"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -12,23 +15,42 @@ import (
 func (g *Generator) metaToInfo(meta *scanner.MetaInfo) *spec.Info {
 	info := &spec.Info{
 		Title:          meta.Title,
+		Summary:        meta.Summary,
 		Description:    meta.Description,
 		TermsOfService: meta.TermsOfService,
 		Version:        meta.Version,
+		Extensions:     maps.Clone(meta.Extensions),
 	}
 
 	if meta.Contact != nil {
 		info.Contact = &spec.Contact{
-			Name:  meta.Contact.Name,
-			URL:   meta.Contact.URL,
-			Email: meta.Contact.Email,
+			Name:       meta.Contact.Name,
+			URL:        meta.Contact.URL,
+			Email:      meta.Contact.Email,
+			Extensions: maps.Clone(meta.Contact.Extensions),
 		}
 	}
 
+	// An explicit x-logo extension wins
+	if logo := meta.Logo; logo != nil {
+		value := map[string]any{"url": logo.URL}
+		if logo.BackgroundColor != "" {
+			value["backgroundColor"] = logo.BackgroundColor
+		}
+		if logo.AltText != "" {
+			value["altText"] = logo.AltText
+		}
+		if logo.Href != "" {
+			value["href"] = logo.Href
+		}
+		addExtensions(&info.Extensions, map[string]any{"x-logo": value})
+	}
+
 	if meta.License != nil {
 		info.License = &spec.License{
-			Name: meta.License.Name,
-			URL:  meta.License.URL,
+			Name:       meta.License.Name,
+			Identifier: meta.License.Identifier,
+			URL:        meta.License.URL,
 		}
 	}
 
@@ -43,6 +65,7 @@ func (g *Generator) applyMeta(openAPI *spec.OpenAPI, meta, fallbackMeta *scanner
 	if effective == nil {
 		effective = fallbackMeta
 	}
+	g.activeMeta = effective
 
 	if effective == nil {
 		openAPI.Info = &spec.Info{
@@ -58,10 +81,36 @@ func (g *Generator) applyMeta(openAPI *spec.OpenAPI, meta, fallbackMeta *scanner
 		openAPI.Components.SecuritySchemes[name] = g.securitySchemeToSpec(scheme)
 	}
 
+	openAPI.ExternalDocs = externalDocsToSpec(effective.ExternalDocs)
+
+	// Servers, inherited from the fallback meta when not declared
+	servers := effective.Servers
+	if len(servers) == 0 && fallbackMeta != nil {
+		servers = fallbackMeta.Servers
+	}
+	for _, server := range servers {
+		openAPI.Servers = append(openAPI.Servers, &spec.Server{
+			URL:         server.URL,
+			Description: server.Description,
+		})
+	}
+
+	// Global security, inherited from the fallback meta when not declared
+	security := effective.Security
+	if len(security) == 0 && fallbackMeta != nil {
+		security = fallbackMeta.Security
+	}
+	for _, scheme := range security {
+		openAPI.Security = append(openAPI.Security, &spec.SecurityRequirement{
+			Requirements: map[string][]string{scheme: {}},
+		})
+	}
+
 	for _, tag := range effective.Tags {
 		openAPI.Tags = append(openAPI.Tags, &spec.Tag{
-			Name:        tag.Name,
-			Description: tag.Description,
+			Name:         tag.Name,
+			Description:  tag.Description,
+			ExternalDocs: externalDocsToSpec(tag.ExternalDocs),
 		})
 	}
 
@@ -73,6 +122,49 @@ func (g *Generator) applyMeta(openAPI *spec.OpenAPI, meta, fallbackMeta *scanner
 	}
 }
 
+// externalDocsToSpec converts ExternalDocsInfo to spec.ExternalDocs.
+// Returns nil when no URL is set, since url is required by the spec.
+func externalDocsToSpec(docs *scanner.ExternalDocsInfo) *spec.ExternalDocs {
+	if docs == nil || docs.URL == "" {
+		return nil
+	}
+	return &spec.ExternalDocs{
+		Description: docs.Description,
+		URL:         docs.URL,
+	}
+}
+
+// addRouteTags appends tags used by operations but not declared in meta to the
+// top-level tag list, using descriptions registered with RegisterTagDescription.
+func (g *Generator) addRouteTags(openAPI *spec.OpenAPI) {
+	declared := make(map[string]bool, len(openAPI.Tags))
+	for _, tag := range openAPI.Tags {
+		declared[tag.Name] = true
+	}
+
+	var missing []string
+	if openAPI.Paths != nil {
+		for _, pathItem := range openAPI.Paths.PathItems {
+			for _, op := range pathItem.Operations() {
+				for _, name := range op.Tags {
+					if !declared[name] {
+						declared[name] = true
+						missing = append(missing, name)
+					}
+				}
+			}
+		}
+	}
+
+	slices.Sort(missing)
+	for _, name := range missing {
+		openAPI.Tags = append(openAPI.Tags, &spec.Tag{
+			Name:        name,
+			Description: GetTagDescription(name),
+		})
+	}
+}
+
 // shortTypeName returns the unqualified type name.
 // For "dto.Agent" returns "Agent", for "Agent" returns "Agent".
 func shortTypeName(typeName string) string {
@@ -118,8 +210,27 @@ func (g *Generator) isReferenceType(typeName string) bool {
 	return g.scanner.GetEnumForType(typeName) != nil
 }
 
-// structToSchema converts StructInfo to spec.Schema.
+// structToSchema converts StructInfo to spec.Schema, including its vendor extensions.
 func (g *Generator) structToSchema(s *scanner.StructInfo) *spec.Schema {
+	owner := g.convertingModel
+	g.convertingModel = s.Name
+	defer func() { g.convertingModel = owner }()
+
+	schema := g.structTypeToSchema(s)
+	if g.config.SchemaTitles && s.GoType != "" {
+		schema.Title = s.GoType
+	}
+	if s.Example != nil && schema.Ref == "" {
+		schema.Examples = []any{s.Example}
+	}
+	if len(s.Extensions) > 0 {
+		schema.Extensions = maps.Clone(s.Extensions)
+	}
+	return schema
+}
+
+// structTypeToSchema converts the shape of a StructInfo to spec.Schema.
+func (g *Generator) structTypeToSchema(s *scanner.StructInfo) *spec.Schema {
 	// Handle oneOf/anyOf model schemas (pure composition, no type/properties)
 	if s.IsOneOfModel {
 		return g.compositionModelToSchema(s, s.OneOfOptions, s.OneOf)
@@ -137,11 +248,13 @@ func (g *Generator) structToSchema(s *scanner.StructInfo) *spec.Schema {
 			Items:       g.typeToSchema(s.ElementType),
 		}
 	case scanner.KindMap:
-		return &spec.Schema{
+		schema := &spec.Schema{
 			Type:                 spec.NewSchemaType(scanner.TypeObject),
 			Description:          s.Description,
 			AdditionalProperties: g.typeToSchema(s.ElementType),
 		}
+		applyObjectConstraints(schema, s.Validations)
+		return schema
 	case scanner.KindPrimitive:
 		schema := &spec.Schema{
 			Description: s.Description,
@@ -164,10 +277,15 @@ func (g *Generator) structToSchema(s *scanner.StructInfo) *spec.Schema {
 			continue
 		}
 
-		propSchema := g.fieldToSchema(field)
+		if !g.isKnownType(field.Type) {
+			g.warnAt(WarnUnknownFieldType, s.Pos, "model %s: field %s type %s is not a model, enum, or primitive, generated as string",
+				s.Name, propName, field.Type)
+		}
+
+		propSchema := g.propertySchema(field)
 		schema.Properties[propName] = propSchema
 
-		if field.Required || field.ExplicitRequired {
+		if g.isPropertyRequired(field) {
 			required = append(required, propName)
 		}
 	}
@@ -175,6 +293,7 @@ func (g *Generator) structToSchema(s *scanner.StructInfo) *spec.Schema {
 	if len(required) > 0 {
 		schema.Required = required
 	}
+	applyObjectConstraints(schema, s.Validations)
 
 	// Handle legacy composition (mark references)
 	if len(s.AllOf) > 0 {
@@ -202,9 +321,64 @@ func (g *Generator) structToSchema(s *scanner.StructInfo) *spec.Schema {
 		}
 	}
 
+	if g.closedObject(s) {
+		// additionalProperties doesn't see the properties of the allOf schemas
+		if len(schema.AllOf) > 0 {
+			schema.UnevaluatedProperties = spec.BoolSchema(false)
+		} else {
+			schema.AdditionalProperties = spec.BoolSchema(false)
+		}
+	}
+
 	return schema
 }
 
+// closedObject reports whether the object schema of a model rejects undeclared properties:
+// with additionalProperties: false, or with StrictObjects unless the model opts out. The
+// default leaves open the models extended by others through allOf, which a closed schema
+// would reject, including the envelope and problem models combined with the responses.
+func (g *Generator) closedObject(s *scanner.StructInfo) bool {
+	if s.AdditionalProperties != nil {
+		return !*s.AdditionalProperties
+	}
+	if !g.config.StrictObjects {
+		return false
+	}
+	for _, other := range g.scanner.Structs {
+		if slices.Contains(other.AllOf, s.Name) {
+			return false
+		}
+	}
+	return !g.composedInResponses(s.Name)
+}
+
+// composedInResponses reports whether a model is combined (allOf) with other schemas in
+// the responses: an envelope model, the Problem schema, or the problem model of a route.
+func (g *Generator) composedInResponses(name string) bool {
+	is := func(typeName string) bool {
+		modelName, ok := g.resolveModelRef(typeName)
+		return ok && modelName == name
+	}
+
+	if g.config.ProblemDetails && name == ProblemSchemaName {
+		return true
+	}
+	for _, meta := range g.scanner.Metas {
+		if meta.Envelope != nil && meta.Envelope.Model != "" && is(meta.Envelope.Model) {
+			return true
+		}
+	}
+	for _, route := range g.scanner.Routes {
+		if route.Envelope != nil && route.Envelope.Model != "" && is(route.Envelope.Model) {
+			return true
+		}
+		if route.Problem != "" && is(route.Problem) {
+			return true
+		}
+	}
+	return false
+}
+
 // typeToSchema converts a Go type name to a schema.
 func (g *Generator) typeToSchema(typeName string) *spec.Schema {
 	// Check if it resolves to a model
@@ -227,11 +401,26 @@ func (g *Generator) typeToSchema(typeName string) *spec.Schema {
 	return schema
 }
 
+// isKnownType reports whether a Go type name resolves to a model, enum, custom type, or primitive.
+func (g *Generator) isKnownType(typeName string) bool {
+	return g.isReferenceType(typeName) || g.setSchemaType(&spec.Schema{}, typeName)
+}
+
 // setSchemaType sets the type and format for a schema based on Go type.
-func (g *Generator) setSchemaType(schema *spec.Schema, goType string) {
+// Returns false when the type is unknown and the schema falls back to a string.
+func (g *Generator) setSchemaType(schema *spec.Schema, goType string) bool {
+	if goType == "time.Duration" && g.config.DurationFormat == DurationInt64 {
+		schema.Type = spec.NewSchemaType(scanner.TypeInteger)
+		schema.Format = scanner.FormatInt64
+		return true
+	}
+
 	// Check for registered custom types first
 	if typeInfo := GetCustomType(goType); typeInfo != nil {
 		schema.Type = spec.NewSchemaType(typeInfo.Type)
+		if typeInfo.Nullable {
+			schema.Type = schema.Type.WithNull()
+		}
 		schema.Format = typeInfo.Format
 		if typeInfo.Example != nil && len(schema.Examples) == 0 {
 			schema.Examples = []any{typeInfo.Example}
@@ -244,19 +433,19 @@ func (g *Generator) setSchemaType(schema *spec.Schema, goType string) {
 				schema.Format = v
 			}
 		}
-		return
+		return true
 	}
 
 	switch goType {
 	case "string":
 		schema.Type = spec.NewSchemaType(scanner.TypeString)
-	case "int", "int8", "int16", "int32":
+	case "int", "int8", "int16", "int32", "rune":
 		schema.Type = spec.NewSchemaType(scanner.TypeInteger)
 		schema.Format = scanner.FormatInt32
 	case "int64":
 		schema.Type = spec.NewSchemaType(scanner.TypeInteger)
 		schema.Format = scanner.FormatInt64
-	case "uint", "uint8", "uint16", "uint32":
+	case "uint", "uint8", "uint16", "uint32", "byte":
 		schema.Type = spec.NewSchemaType(scanner.TypeInteger)
 		schema.Format = scanner.FormatInt32
 	case "uint64":
@@ -273,16 +462,82 @@ func (g *Generator) setSchemaType(schema *spec.Schema, goType string) {
 	case "any", "interface{}":
 		schema.Type = spec.NewSchemaType(scanner.TypeObject)
 	default:
+		if g.setCollectionSchemaType(schema, goType) {
+			return true
+		}
 		// Check for package-qualified types
 		short := shortTypeName(goType)
 		if short != goType {
 			if _, ok := g.scanner.Structs[short]; ok {
 				schema.Ref = "#/components/schemas/" + short
-				return
+				return true
 			}
 		}
+		// Named primitives (type UserID int64), named collections (type Tags []string), and
+		// aliases resolve to the type they stand for
+		if resolved := g.scanner.ResolveUnderlyingType(goType); resolved != "" && resolved != goType {
+			return g.setSchemaType(schema, resolved)
+		}
+		schema.Type = spec.NewSchemaType(scanner.TypeString)
+		return false
+	}
+	return true
+}
+
+// setCollectionSchemaType sets the schema of a slice, array, or map type ([]T, [N]T,
+// map[K]V), the underlying types of named collections. Byte slices are base64 strings,
+// as encoding/json writes them. Reports false for other types.
+func (g *Generator) setCollectionSchemaType(schema *spec.Schema, goType string) bool {
+	elemSchema := func(elem string) *spec.Schema {
+		return g.typeToSchema(strings.TrimPrefix(elem, "*"))
+	}
+
+	switch {
+	case goType == "[]byte" || goType == "[]uint8":
 		schema.Type = spec.NewSchemaType(scanner.TypeString)
+		schema.Format = scanner.FormatByte
+	case strings.HasPrefix(goType, "[]"):
+		schema.Type = spec.NewSchemaType(scanner.TypeArray)
+		schema.Items = elemSchema(goType[2:])
+	case strings.HasPrefix(goType, "["):
+		length, elem, ok := strings.Cut(goType[1:], "]")
+		n, err := strconv.ParseUint(length, 10, 64)
+		if !ok || err != nil {
+			return false
+		}
+		// encoding/json writes every element of an array
+		schema.Type = spec.NewSchemaType(scanner.TypeArray)
+		schema.Items = elemSchema(elem)
+		schema.MinItems = new(n)
+		schema.MaxItems = new(n)
+	case strings.HasPrefix(goType, "map["):
+		_, elem, ok := cutMapKey(goType[len("map["):])
+		if !ok {
+			return false
+		}
+		schema.Type = spec.NewSchemaType(scanner.TypeObject)
+		schema.AdditionalProperties = elemSchema(elem)
+	default:
+		return false
 	}
+	return true
+}
+
+// cutMapKey splits the rest of a map type after "map[" into its key and element types.
+func cutMapKey(s string) (key, elem string, ok bool) {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				return s[:i], s[i+1:], true
+			}
+			depth--
+		}
+	}
+	return "", "", false
 }
 
 // castToSchemaType converts a string value to the appropriate Go type
@@ -301,6 +556,12 @@ func castToSchemaType(value string, schemaType spec.SchemaType) any {
 		if v, err := strconv.ParseBool(value); err == nil {
 			return v
 		}
+	case scanner.TypeArray, scanner.TypeObject:
+		// Structured examples are JSON (example: | blocks are folded to JSON)
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			return v
+		}
 	}
 	return value
 }
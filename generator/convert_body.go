@@ -48,6 +48,33 @@ func (g *Generator) fieldToRequestBody(f *scanner.FieldInfo, consumes []string)
 	}
 }
 
+// routeRequestBody converts a request body declared with the RequestBody route directive.
+func (g *Generator) routeRequestBody(r *scanner.RouteInfo) *spec.RequestBody {
+	schema := g.typeToSchema(r.RequestBody.Type)
+	if r.RequestBody.IsArray {
+		schema = &spec.Schema{
+			Type:  spec.NewSchemaType(scanner.TypeArray),
+			Items: schema,
+		}
+	}
+
+	contentTypes := r.Consumes
+	if len(contentTypes) == 0 {
+		contentTypes = []string{scanner.ContentTypeJSON}
+	}
+
+	content := make(map[string]*spec.MediaType, len(contentTypes))
+	for _, contentType := range contentTypes {
+		content[contentType] = &spec.MediaType{Schema: schema}
+	}
+
+	return &spec.RequestBody{
+		Description: r.RequestBody.Description,
+		Required:    r.RequestBody.Required,
+		Content:     content,
+	}
+}
+
 // inlineStructToSchema converts an inline StructInfo to spec.Schema.
 func (g *Generator) inlineStructToSchema(s *scanner.StructInfo) *spec.Schema {
 	schema := &spec.Schema{
@@ -64,10 +91,10 @@ func (g *Generator) inlineStructToSchema(s *scanner.StructInfo) *spec.Schema {
 			continue
 		}
 
-		propSchema := g.fieldToSchema(field)
+		propSchema := g.propertySchema(field)
 		schema.Properties[propName] = propSchema
 
-		if field.Required || field.ExplicitRequired {
+		if g.isPropertyRequired(field) {
 			required = append(required, propName)
 		}
 	}
@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"maps"
+
 	"github.com/kausys/openapi/scanner"
 	"github.com/kausys/openapi/spec"
 )
@@ -44,6 +46,57 @@ func (g *Generator) compositionModelToSchema(s *scanner.StructInfo, options []st
 	return schema
 }
 
+// responseToSchema converts a route response type to a schema, applying
+// response-level oneOf options and discriminator overrides.
+func (g *Generator) responseToSchema(resp *scanner.ResponseInfo) *spec.Schema {
+	// Inline oneOf declared as Type1|Type2
+	if len(resp.OneOf) > 0 {
+		schema := &spec.Schema{}
+		for _, typeName := range resp.OneOf {
+			refName := g.resolveSchemaRef(typeName)
+			g.markSchemaAsReferenced(refName)
+			schema.OneOf = append(schema.OneOf, &spec.Schema{
+				Ref: "#/components/schemas/" + refName,
+			})
+		}
+		if resp.Discriminator != nil {
+			schema.Discriminator = g.discriminatorToSpec(resp.Discriminator)
+		}
+		return schema
+	}
+
+	// Discriminator override on a oneOf/anyOf model: inline the composition
+	// with the merged discriminator so the shared model stays untouched.
+	if resp.Discriminator != nil {
+		model, ok := g.scanner.Structs[g.resolveSchemaRef(resp.Type)]
+		if ok && (model.IsOneOfModel || model.IsAnyOfModel) {
+			override := *model
+			override.Discriminator = mergeDiscriminators(model.Discriminator, resp.Discriminator)
+			return g.structToSchema(&override)
+		}
+	}
+
+	return g.typeToSchema(resp.Type)
+}
+
+// mergeDiscriminators overlays a response-level discriminator on a model discriminator.
+func mergeDiscriminators(base, override *scanner.DiscriminatorInfo) *scanner.DiscriminatorInfo {
+	merged := &scanner.DiscriminatorInfo{
+		PropertyName: override.PropertyName,
+		Mapping:      make(map[string]string),
+	}
+
+	if base != nil {
+		if merged.PropertyName == "" {
+			merged.PropertyName = base.PropertyName
+		}
+		maps.Copy(merged.Mapping, base.Mapping)
+	}
+	maps.Copy(merged.Mapping, override.Mapping)
+
+	return merged
+}
+
 // resolveSchemaRef resolves a type name to a schema reference name.
 func (g *Generator) resolveSchemaRef(typeName string) string {
 	// Check if there's a type mapping (Go type name -> model name)
@@ -26,6 +26,10 @@ func (g *Generator) enumToSchema(e *scanner.EnumInfo) *spec.Schema {
 		schema.Examples = []any{e.Example}
 	}
 
+	if g.config.SchemaTitles {
+		schema.Title = e.TypeName
+	}
+
 	return schema
 }
 
@@ -46,6 +50,8 @@ func sortEnumValues(values map[string]any) []any {
 
 // createInlineEnumSchema creates an inline schema for an enum.
 func (g *Generator) createInlineEnumSchema(e *scanner.EnumInfo) *spec.Schema {
+	g.recordInlinedEnum(e.TypeName)
+
 	schema := &spec.Schema{
 		Description: e.Description,
 	}
@@ -67,3 +73,27 @@ func (g *Generator) findEnumInfo(typeName string) *scanner.EnumInfo {
 	// Use scanner's GetEnumForType which handles aliases
 	return g.scanner.GetEnumForType(typeName)
 }
+
+// enumRefSchema returns the $ref to the component of an enum used by a field. Tools ignore
+// the siblings of a $ref, so a description, or a field example or const, wraps the $ref
+// in allOf.
+func (g *Generator) enumRefSchema(ref *spec.Schema, e *scanner.EnumInfo, description string, f *scanner.FieldInfo) *spec.Schema {
+	if description == "" && f.Example == "" && f.Const == "" {
+		return ref
+	}
+
+	enumType := &spec.Schema{}
+	g.setSchemaType(enumType, e.BaseType)
+
+	schema := &spec.Schema{
+		AllOf:       []*spec.Schema{ref},
+		Description: description,
+	}
+	if f.Example != "" {
+		schema.Examples = []any{castToSchemaType(f.Example, enumType.Type)}
+	}
+	if f.Const != "" {
+		schema.Const = castToSchemaType(f.Const, enumType.Type)
+	}
+	return schema
+}
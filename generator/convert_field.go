@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -16,8 +18,23 @@ func (g *Generator) getPropertyName(f *scanner.FieldInfo) string {
 	return f.Name
 }
 
-// fieldToSchema converts FieldInfo to spec.Schema.
+// fieldToSchema converts FieldInfo to spec.Schema, including its readOnly/writeOnly
+// flags and vendor extensions.
 func (g *Generator) fieldToSchema(f *scanner.FieldInfo) *spec.Schema {
+	schema := g.fieldTypeToSchema(f)
+	if schema.Description == "" && schema.Ref == "" {
+		schema.Description = g.typeDescription(f)
+	}
+	schema.ReadOnly = f.Validations["readOnly"] == "true"
+	schema.WriteOnly = f.Validations["writeOnly"] == "true"
+	if len(f.Extensions) > 0 {
+		schema.Extensions = maps.Clone(f.Extensions)
+	}
+	return schema
+}
+
+// fieldTypeToSchema converts the type and validations of a FieldInfo to spec.Schema.
+func (g *Generator) fieldTypeToSchema(f *scanner.FieldInfo) *spec.Schema {
 	// Handle arrays
 	if f.IsArray {
 		schema := &spec.Schema{
@@ -25,6 +42,9 @@ func (g *Generator) fieldToSchema(f *scanner.FieldInfo) *spec.Schema {
 			Description: f.Description,
 			Items:       g.typeToSchema(f.Type),
 		}
+		if f.Example != "" {
+			schema.Examples = []any{castToSchemaType(f.Example, schema.Type)}
+		}
 		if f.Nullable {
 			schema.Type = schema.Type.WithNull()
 		}
@@ -38,6 +58,10 @@ func (g *Generator) fieldToSchema(f *scanner.FieldInfo) *spec.Schema {
 			Description:          f.Description,
 			AdditionalProperties: g.typeToSchema(f.Type),
 		}
+		applyObjectConstraints(schema, f.Validations)
+		if f.Example != "" {
+			schema.Examples = []any{castToSchemaType(f.Example, schema.Type)}
+		}
 		if f.Nullable {
 			schema.Type = schema.Type.WithNull()
 		}
@@ -55,6 +79,14 @@ func (g *Generator) fieldToSchema(f *scanner.FieldInfo) *spec.Schema {
 			if f.Example != "" {
 				schema.Examples = []any{f.Example}
 			}
+			if f.Const != "" {
+				schema.Const = castToSchemaType(f.Const, schema.Type)
+			}
+		} else if enumInfo := g.findEnumInfo(f.Type); enumInfo != nil {
+			schema = g.enumRefSchema(schema, enumInfo, f.Description, f)
+		}
+		if f.Nullable {
+			schema = nullableSchema(schema)
 		}
 		return schema
 	}
@@ -82,9 +114,22 @@ func (g *Generator) fieldToSchema(f *scanner.FieldInfo) *spec.Schema {
 		schema.Default = castToSchemaType(f.Default, schema.Type)
 	}
 
+	if f.Const != "" {
+		schema.Const = castToSchemaType(f.Const, schema.Type)
+	}
+
 	return schema
 }
 
+// typeDescription returns the doc comment of the type of a field, its description
+// fallback with DescriptionFallback. Models and enums are described by their schemas.
+func (g *Generator) typeDescription(f *scanner.FieldInfo) string {
+	if !g.config.DescriptionFallback || g.isReferenceType(f.Type) {
+		return ""
+	}
+	return g.scanner.TypeDescription(f.Type)
+}
+
 // fieldToParameter converts a FieldInfo to spec.Parameter.
 func (g *Generator) fieldToParameter(f *scanner.FieldInfo, path string) *spec.Parameter {
 	paramName := g.getPropertyName(f)
@@ -96,10 +141,10 @@ func (g *Generator) fieldToParameter(f *scanner.FieldInfo, path string) *spec.Pa
 	in := f.In
 	if in == "" {
 		in = "query" // default
-	}
-	// Check if it's a path parameter
-	if strings.Contains(path, "{"+paramName+"}") {
-		in = "path"
+		// Check if it's a path parameter
+		if strings.Contains(path, "{"+paramName+"}") {
+			in = "path"
+		}
 	}
 
 	// Create schema for the parameter
@@ -109,13 +154,17 @@ func (g *Generator) fieldToParameter(f *scanner.FieldInfo, path string) *spec.Pa
 	if enumInfo := g.findEnumInfo(f.Type); enumInfo != nil {
 		if g.config.EnumRefs {
 			g.markSchemaAsReferenced(enumInfo.TypeName)
-			schema = &spec.Schema{Ref: "#/components/schemas/" + enumInfo.TypeName}
+			// The description is set on the parameter
+			schema = g.enumRefSchema(&spec.Schema{Ref: "#/components/schemas/" + enumInfo.TypeName}, enumInfo, "", f)
 		} else {
 			schema = g.createInlineEnumSchema(enumInfo)
 			// Override example if field has its own
 			if f.Example != "" {
 				schema.Examples = []any{castToSchemaType(f.Example, schema.Type)}
 			}
+			if f.Const != "" {
+				schema.Const = castToSchemaType(f.Const, schema.Type)
+			}
 		}
 	} else {
 		schema = &spec.Schema{}
@@ -128,19 +177,73 @@ func (g *Generator) fieldToParameter(f *scanner.FieldInfo, path string) *spec.Pa
 		if f.Default != "" {
 			schema.Default = castToSchemaType(f.Default, schema.Type)
 		}
+		if f.Const != "" {
+			schema.Const = castToSchemaType(f.Const, schema.Type)
+		}
+	}
+
+	// Slice fields are array parameters of their element type; the example and default of
+	// the field are values of the array, as for properties
+	if f.IsArray {
+		items := schema
+		schema = &spec.Schema{
+			Type:  spec.NewSchemaType(scanner.TypeArray),
+			Items: items,
+		}
+		if f.Example != "" {
+			items.Examples = nil
+			schema.Examples = []any{castToSchemaType(f.Example, schema.Type)}
+		}
+		if f.Default != "" {
+			items.Default = nil
+			schema.Default = castToSchemaType(f.Default, schema.Type)
+		}
+	}
+
+	description := f.Description
+	if description == "" {
+		description = g.typeDescription(f)
 	}
 
 	param := &spec.Parameter{
 		Name:        paramName,
 		In:          in,
-		Description: f.Description,
+		Description: description,
 		Required:    f.Required || in == "path", // path parameters are always required
 		Schema:      schema,
 	}
 
+	// A cookie holds a single name=value pair, so arrays and objects are
+	// serialized as comma-separated lists instead of repeated cookies
+	if in == "cookie" && (schema.Type.Contains(scanner.TypeArray) || schema.Type.Contains(scanner.TypeObject)) {
+		param.Style = "form"
+		param.Explode = new(false)
+	}
+
+	// Explicit serialization directives override the location defaults
+	if f.Style != "" {
+		if slices.Contains(parameterStyles[in], f.Style) {
+			param.Style = f.Style
+		} else {
+			g.warn(WarnInvalidParamStyle, "parameter %s (in: %s) of %s: style %s is not allowed, expected one of %s",
+				paramName, in, path, f.Style, strings.Join(parameterStyles[in], ", "))
+		}
+	}
+	if f.Explode != nil {
+		param.Explode = new(*f.Explode)
+	}
+
 	return param
 }
 
+// parameterStyles lists the serialization styles allowed for each parameter location.
+var parameterStyles = map[string][]string{
+	"path":   {"simple", "label", "matrix"},
+	"query":  {"form", "spaceDelimited", "pipeDelimited", "deepObject"},
+	"header": {"simple"},
+	"cookie": {"form"},
+}
+
 // applyValidations applies validation rules to a schema.
 func (g *Generator) applyValidations(schema *spec.Schema, f *scanner.FieldInfo) {
 	if format, ok := f.Validations["format"]; ok {
@@ -179,3 +282,23 @@ func (g *Generator) applyValidations(schema *spec.Schema, f *scanner.FieldInfo)
 		schema.Pattern = pattern
 	}
 }
+
+// applyObjectConstraints applies the object constraints of a model or map field to its
+// object schema: minProperties, maxProperties, and the propertyNames pattern.
+func applyObjectConstraints(schema *spec.Schema, validations map[string]string) {
+	if minProps, ok := validations["minProperties"]; ok {
+		if v, err := strconv.ParseUint(minProps, 10, 64); err == nil {
+			schema.MinProperties = new(v)
+		}
+	}
+
+	if maxProps, ok := validations["maxProperties"]; ok {
+		if v, err := strconv.ParseUint(maxProps, 10, 64); err == nil {
+			schema.MaxProperties = new(v)
+		}
+	}
+
+	if pattern, ok := validations["propertyNames"]; ok {
+		schema.PropertyNames = &spec.Schema{Pattern: pattern}
+	}
+}
@@ -1,36 +1,71 @@
 package generator
 
 import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/kausys/openapi/scanner"
 	"github.com/kausys/openapi/spec"
 )
 
-// routeToOperation converts RouteInfo to spec.Operation.
-func (g *Generator) routeToOperation(r *scanner.RouteInfo) *spec.Operation {
+// routeToOperation converts RouteInfo to spec.Operation. The success responses are wrapped
+// in the envelope of the route, or else in the envelope of the meta. With problem details,
+// error responses are application/problem+json, and the Problem schema when untyped.
+// Rate limited routes get a 429 response with a Retry-After header.
+func (g *Generator) routeToOperation(r *scanner.RouteInfo, envelope *scanner.EnvelopeInfo) *spec.Operation {
 	responses := &spec.Responses{
 		StatusCodes: make(map[string]*spec.Response),
 	}
 
+	produces := g.routeProduces(r)
+	envelope = routeEnvelope(r, envelope)
+
 	// Add responses
 	for _, resp := range r.Responses {
 		response := &spec.Response{
 			Description: resp.Description,
 		}
+		if response.Description == "" {
+			response.Description = defaultResponseDescription(resp.StatusCode)
+		}
 
-		if resp.Type != "" {
-			schema := g.typeToSchema(resp.Type)
+		if len(resp.Contents) > 0 {
+			response.Content = g.responseContentMatrix(r, resp)
+		} else if resp.IsFile {
+			response.Content = g.fileResponseContent(r, resp)
+		} else if resp.Type != "" || len(resp.OneOf) > 0 {
+			if resp.Type != "" && !g.isKnownType(resp.Type) {
+				g.warnAt(WarnUnknownResponseType, r.Pos, "operation %s: response %s type %s is not a model, enum, or primitive, generated as string",
+					r.OperationID, resp.StatusCode, resp.Type)
+			}
+			schema := g.responseToSchema(resp)
 			if resp.IsArray {
 				schema = &spec.Schema{
 					Type:  spec.NewSchemaType(scanner.TypeArray),
 					Items: schema,
 				}
 			}
-			response.Content = map[string]*spec.MediaType{
-				"application/json": {
-					Schema: schema,
-				},
+			if envelope != nil && isSuccessStatus(resp.StatusCode) {
+				schema = g.envelopeSchema(r, schema, envelope)
+			}
+			if g.config.ProblemDetails && isErrorStatus(resp.StatusCode) {
+				response.Content = map[string]*spec.MediaType{ProblemMediaType: {Schema: schema}}
+			} else {
+				response.Content = g.responseContent(r, schema, produces)
+			}
+		} else if g.config.ProblemDetails && isErrorStatus(resp.StatusCode) {
+			response.Content = map[string]*spec.MediaType{ProblemMediaType: {Schema: g.problemSchema(r)}}
+		}
+
+		// Inline the response example in every media type
+		if resp.Example != nil {
+			for _, mediaType := range response.Content {
+				mediaType.Example = resp.Example
 			}
 		}
 
@@ -48,6 +83,31 @@ func (g *Generator) routeToOperation(r *scanner.RouteInfo) *spec.Operation {
 		Tags:        r.Tags,
 		Deprecated:  r.Deprecated,
 		Responses:   responses,
+		Extensions:  maps.Clone(r.Extensions),
+	}
+
+	op.ExternalDocs = externalDocsToSpec(r.ExternalDocs)
+
+	// Add deprecation metadata (sunset date and replacement operation)
+	if r.Deprecated && r.Sunset != "" {
+		addExtensions(&op.Extensions, map[string]any{"x-sunset": r.Sunset})
+	}
+	if r.Deprecated && r.Replacement != "" {
+		addExtensions(&op.Extensions, map[string]any{"x-deprecated-replacement": r.Replacement})
+	}
+	if len(r.CodeSamples) > 0 {
+		samples := make([]map[string]any, len(r.CodeSamples))
+		for i, sample := range r.CodeSamples {
+			samples[i] = map[string]any{"lang": sample.Lang, "source": sample.Source}
+		}
+		addExtensions(&op.Extensions, map[string]any{"x-codeSamples": samples})
+	}
+	if r.RateLimit != nil {
+		addExtensions(&op.Extensions, map[string]any{"x-ratelimit": rateLimitExtension(r.RateLimit)})
+		g.addTooManyRequests(r, responses)
+	}
+	if r.Pagination != nil {
+		addExtensions(&op.Extensions, map[string]any{"x-pagination": paginationExtension(r.Pagination)})
 	}
 
 	// Add parameters and request body from swagger:parameters struct matching operationID
@@ -56,15 +116,27 @@ func (g *Generator) routeToOperation(r *scanner.RouteInfo) *spec.Operation {
 		op.Parameters = params
 	}
 
-	// Only add requestBody for methods that support it (POST, PUT, PATCH)
-	// GET, HEAD, DELETE do not have well-defined semantics for request body
-	method := strings.ToUpper(r.Method)
-	if requestBody != nil && (method == "POST" || method == "PUT" || method == "PATCH") {
-		op.RequestBody = requestBody
+	// Only add requestBody for methods that support it (POST, PUT, PATCH, QUERY).
+	// GET, HEAD, DELETE do not have well-defined semantics for request body,
+	// so routes opt in explicitly with allowBody: true
+	if requestBody != nil {
+		switch strings.ToUpper(r.Method) {
+		case scanner.MethodPost, scanner.MethodPut, scanner.MethodPatch, scanner.MethodQuery:
+			op.RequestBody = requestBody
+		default:
+			if r.AllowBody {
+				op.RequestBody = requestBody
+			} else {
+				g.warnAt(WarnRequestBodyDropped, r.Pos, "operation %s: request body dropped for %s, add allowBody: true to keep it",
+					r.OperationID, strings.ToUpper(r.Method))
+			}
+		}
 	}
 
-	// Add security
-	if len(r.Security) > 0 {
+	// Add security; an empty list removes the global security requirement
+	if r.NoSecurity {
+		op.Security = spec.SecurityRequirements{}
+	} else if len(r.Security) > 0 {
 		for _, scheme := range r.Security {
 			op.Security = append(op.Security, &spec.SecurityRequirement{
 				Requirements: map[string][]string{
@@ -77,13 +149,143 @@ func (g *Generator) routeToOperation(r *scanner.RouteInfo) *spec.Operation {
 	return op
 }
 
+// defaultResponseDescription returns the description of a response declared without one
+// (description is required by OpenAPI): the status text of the code, or of its class.
+func defaultResponseDescription(code string) string {
+	if code == "default" {
+		return "Unexpected error"
+	}
+	if status, err := strconv.Atoi(code); err == nil && http.StatusText(status) != "" {
+		return http.StatusText(status)
+	}
+
+	switch code[0] {
+	case '1':
+		return "Informational"
+	case '2':
+		return "Success"
+	case '3':
+		return "Redirection"
+	case '4':
+		return "Client error"
+	default:
+		return "Server error"
+	}
+}
+
+// routeProduces returns the response media types of a route.
+// Falls back to the meta-level Produces, then to application/json.
+func (g *Generator) routeProduces(r *scanner.RouteInfo) []string {
+	if len(r.Produces) > 0 {
+		return r.Produces
+	}
+	if g.activeMeta != nil && len(g.activeMeta.Produces) > 0 {
+		return g.activeMeta.Produces
+	}
+	return []string{scanner.ContentTypeJSON}
+}
+
+// responseContent builds the response content map for each produced media type.
+// The shared schema is used unless the route overrides it for a media type.
+func (g *Generator) responseContent(r *scanner.RouteInfo, schema *spec.Schema, produces []string) map[string]*spec.MediaType {
+	content := make(map[string]*spec.MediaType, len(produces))
+	for _, contentType := range produces {
+		mediaSchema := schema
+		if typeName, ok := r.ProducesSchemas[contentType]; ok {
+			mediaSchema = g.typeToSchema(strings.TrimPrefix(typeName, "[]"))
+			if strings.HasPrefix(typeName, "[]") {
+				mediaSchema = &spec.Schema{
+					Type:  spec.NewSchemaType(scanner.TypeArray),
+					Items: mediaSchema,
+				}
+			}
+		}
+		content[contentType] = &spec.MediaType{Schema: mediaSchema}
+	}
+	return content
+}
+
+// responseContentMatrix builds the content map of a response declaring a schema per media
+// type (UserJSON (application/json), UserCSV (text/csv)). Entries are not wrapped in
+// envelopes, like the schemas of produces overrides.
+func (g *Generator) responseContentMatrix(r *scanner.RouteInfo, resp *scanner.ResponseInfo) map[string]*spec.MediaType {
+	content := make(map[string]*spec.MediaType, len(resp.Contents))
+	for _, entry := range resp.Contents {
+		var schema *spec.Schema
+		if entry.Type == scanner.FileResponseType {
+			schema = &spec.Schema{
+				Type:             spec.NewSchemaType(scanner.TypeString),
+				ContentMediaType: entry.MediaType,
+			}
+		} else {
+			if !g.isKnownType(entry.Type) {
+				g.warnAt(WarnUnknownResponseType, r.Pos, "operation %s: response %s type %s is not a model, enum, or primitive, generated as string",
+					r.OperationID, resp.StatusCode, entry.Type)
+			}
+			schema = g.typeToSchema(entry.Type)
+		}
+		if entry.IsArray {
+			schema = &spec.Schema{
+				Type:  spec.NewSchemaType(scanner.TypeArray),
+				Items: schema,
+			}
+		}
+		content[entry.MediaType] = &spec.MediaType{Schema: schema}
+	}
+	return content
+}
+
+// fileResponseContent builds the content map for a binary file download response.
+// The media type comes from the response (file:application/pdf), then the
+// non-JSON media types of the route-level Produces, then application/octet-stream.
+func (g *Generator) fileResponseContent(r *scanner.RouteInfo, resp *scanner.ResponseInfo) map[string]*spec.MediaType {
+	var mediaTypes []string
+	if resp.FileMediaType != "" {
+		mediaTypes = []string{resp.FileMediaType}
+	} else {
+		for _, mediaType := range r.Produces {
+			if !isJSONMediaType(mediaType) {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{scanner.ContentTypeBinary}
+	}
+
+	content := make(map[string]*spec.MediaType, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = &spec.MediaType{
+			Schema: &spec.Schema{
+				Type:             spec.NewSchemaType(scanner.TypeString),
+				ContentMediaType: mediaType,
+			},
+		}
+	}
+	return content
+}
+
+// isJSONMediaType reports whether a media type is application/json or a +json
+// structured syntax, such as application/problem+json.
+func isJSONMediaType(mediaType string) bool {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// reservedHeaders are header parameter names ignored by OpenAPI, with the
+// construct that describes them instead.
+var reservedHeaders = map[string]string{
+	"accept":        "the response content types",
+	"content-type":  "the request body content types",
+	"authorization": "a security scheme",
+}
+
 // getOperationParameters finds and converts parameters for an operation.
+// Parameters come from the swagger:parameters struct matching the operationID
+// and from any swagger:headers structs declared for it.
 func (g *Generator) getOperationParameters(r *scanner.RouteInfo) ([]*spec.Parameter, *spec.RequestBody) {
-	// Look for a struct marked as swagger:parameters with matching operationID
-	paramStruct, ok := g.scanner.Structs[r.OperationID]
-	if !ok || !paramStruct.IsParameter {
-		return nil, nil
-	}
+	fields := g.operationFields(r)
 
 	var params []*spec.Parameter
 	var requestBody *spec.RequestBody
@@ -92,13 +294,26 @@ func (g *Generator) getOperationParameters(r *scanner.RouteInfo) ([]*spec.Parame
 		ignoredParams[ignored] = true
 	}
 
-	for _, field := range paramStruct.Fields {
+	// Names declared in the request body, checked against cookie parameters
+	bodyFields := make(map[string]bool)
+	for _, field := range fields {
+		if field.IsRequestBody || field.In == "body" {
+			bodyFields[g.getPropertyName(field)] = true
+		}
+	}
+
+	for _, field := range fields {
 		// Skip ignored parameters
 		paramName := g.getPropertyName(field)
 		if ignoredParams[paramName] {
 			continue
 		}
 
+		if !field.IsInlineStruct && !g.isKnownType(field.Type) {
+			g.warnAt(WarnUnknownFieldType, r.Pos, "operation %s: parameter %s type %s is not a model, enum, or primitive, generated as string",
+				r.OperationID, paramName, field.Type)
+		}
+
 		// Handle request body (in:body)
 		if field.IsRequestBody || field.In == "body" {
 			requestBody = g.fieldToRequestBody(field, r.Consumes)
@@ -106,16 +321,215 @@ func (g *Generator) getOperationParameters(r *scanner.RouteInfo) ([]*spec.Parame
 		}
 
 		param := g.fieldToParameter(field, r.Path)
-		if param != nil {
-			params = append(params, param)
+		if param == nil {
+			continue
+		}
+
+		// OpenAPI ignores Accept, Content-Type, and Authorization header parameters
+		if param.In == "header" {
+			if replacement, ok := reservedHeaders[strings.ToLower(param.Name)]; ok {
+				g.warn(WarnReservedHeader, "operation %s: header parameter %s is ignored, it is described by %s", r.OperationID, param.Name, replacement)
+				continue
+			}
+		}
+
+		if param.In == "cookie" && bodyFields[param.Name] {
+			g.warn(WarnCookieBodyConflict, "operation %s: cookie parameter %s is also declared in the request body", r.OperationID, param.Name)
+			continue
+		}
+
+		// Path parameters must match a template variable
+		if param.In == "path" && !slices.Contains(pathTemplateParams(r.Path), param.Name) {
+			g.warnAt(RuleUnknownPathParam, r.Pos, "operation %s: path parameter %s does not appear in path %s",
+				r.OperationID, param.Name, r.Path)
+			continue
+		}
+
+		params = append(params, param)
+	}
+
+	// Path parameters shared through swagger:path count as declared
+	declared := append(slices.Clone(params), g.pathParameters(r.Path)...)
+	params = append(params, g.missingPathParameters(r, declared)...)
+
+	// Request body declared inline in the route comment
+	if r.RequestBody != nil {
+		if requestBody != nil {
+			g.warnAt(WarnRequestBodyConflict, r.Pos, "operation %s: RequestBody directive ignored, the parameters struct declares a body",
+				r.OperationID)
+		} else {
+			requestBody = g.routeRequestBody(r)
 		}
 	}
 
 	return params, requestBody
 }
 
-// addRoute adds a route to the OpenAPI spec.
-func (g *Generator) addRoute(openAPI *spec.OpenAPI, r *scanner.RouteInfo) {
+// operationFields returns the parameter fields of an operation: the fields of the
+// swagger:parameters struct matching the operationID and of its swagger:headers structs.
+func (g *Generator) operationFields(r *scanner.RouteInfo) []*scanner.FieldInfo {
+	var fields []*scanner.FieldInfo
+
+	// Look for a struct marked as swagger:parameters with matching operationID
+	if paramStruct, ok := g.scanner.Structs[r.OperationID]; ok && paramStruct.IsParameter {
+		fields = append(fields, paramStruct.Fields...)
+	}
+
+	// Header structs: fields without an explicit location are headers
+	for _, headers := range g.scanner.Headers[r.OperationID] {
+		for _, field := range headers.Fields {
+			if field.In == "" {
+				headerField := *field
+				headerField.In = "header"
+				field = &headerField
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// newPathItem creates a path item with the summary, description, and shared
+// parameters declared with swagger:path.
+func (g *Generator) newPathItem(path string) *spec.PathItem {
+	pathItem := &spec.PathItem{}
+	if pathInfo, ok := g.scanner.Paths[path]; ok {
+		pathItem.Summary = pathInfo.Summary
+		pathItem.Description = pathInfo.Description
+		pathItem.Parameters = g.pathParameters(path)
+	}
+	return pathItem
+}
+
+// pathParameters converts the shared parameters declared with swagger:path.
+func (g *Generator) pathParameters(path string) []*spec.Parameter {
+	var params []*spec.Parameter
+	for _, field := range g.pathFields(path) {
+		if param := g.fieldToParameter(field, path); param != nil {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// pathFields returns the shared parameter fields declared with swagger:path.
+func (g *Generator) pathFields(path string) []*scanner.FieldInfo {
+	pathInfo, ok := g.scanner.Paths[path]
+	if !ok || pathInfo.Parameters == nil {
+		return nil
+	}
+	return pathInfo.Parameters.Fields
+}
+
+// missingPathParameters synthesizes required parameters for path template variables
+// not declared in the parameters structs or by swagger:path. Their type comes from the path template
+// (/users/{id:int64}); a regular expression suffix becomes a string pattern.
+func (g *Generator) missingPathParameters(r *scanner.RouteInfo, params []*spec.Parameter) []*spec.Parameter {
+	var missing []*spec.Parameter
+
+	for _, name := range pathTemplateParams(r.Path) {
+		if slices.ContainsFunc(params, func(p *spec.Parameter) bool {
+			return p.In == "path" && p.Name == name
+		}) {
+			continue
+		}
+
+		field := &scanner.FieldInfo{
+			Name:        name,
+			Type:        "string",
+			In:          "path",
+			Required:    true,
+			Validations: make(map[string]string),
+		}
+
+		switch typ := r.PathParamTypes[name]; {
+		case typ == "":
+			g.warnAt(RuleUndeclaredPathParam, r.Pos, "operation %s: path parameter %s is not declared, generated as string",
+				r.OperationID, name)
+		case isGoTypeName(typ):
+			field.Type = typ
+		default:
+			field.Validations["pattern"] = "^" + typ + "$"
+		}
+
+		if param := g.fieldToParameter(field, r.Path); param != nil {
+			missing = append(missing, param)
+		}
+	}
+
+	return missing
+}
+
+// pathTemplateParams returns the names of the template variables in a path, in order.
+func pathTemplateParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "{")[1:] {
+		if name, _, ok := strings.Cut(segment, "}"); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isGoTypeName reports whether s is a (possibly package-qualified) Go type name.
+func isGoTypeName(s string) bool {
+	for i, r := range s {
+		if r != '_' && r != '.' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// checkRouteCollisions rejects routes of a spec declaring the same method and path,
+// which would otherwise replace each other in the path item. Paths differing only by
+// the names of their parameters (/users/{id} and /users/{userId}) are the same path.
+func (g *Generator) checkRouteCollisions(routes []*scanner.RouteInfo, specName string) error {
+	sorted := slices.SortedFunc(slices.Values(routes), func(a, b *scanner.RouteInfo) int {
+		if c := strings.Compare(a.Pos.Filename, b.Pos.Filename); c != 0 {
+			return c
+		}
+		return a.Pos.Line - b.Pos.Line
+	})
+
+	declared := make(map[string]*scanner.RouteInfo, len(sorted))
+	for _, r := range sorted {
+		method := strings.ToUpper(r.Method)
+		key := method + " " + routeTemplate(r.Path)
+		existing, ok := declared[key]
+		if !ok {
+			declared[key] = r
+			continue
+		}
+		inSpec := ""
+		if specName != "" {
+			inSpec = " in spec " + specName
+		}
+		as := ""
+		if existing.Path != r.Path {
+			as = " as " + method + " " + existing.Path
+		}
+		return fmt.Errorf("%s: operation %s declares %s %s%s, already declared%s by operation %s at %s",
+			g.location(r.Pos), r.OperationID, method, r.Path, inSpec, as, existing.OperationID, g.location(existing.Pos))
+	}
+	return nil
+}
+
+// routeTemplate returns a route path with its parameters unnamed (see
+// scanner.NormalizeRoutePath). Unlike the routers, the spec tells /users from /users/,
+// so the trailing slash is kept.
+func routeTemplate(path string) string {
+	template := scanner.NormalizeRoutePath(path)
+	if len(path) > 1 && strings.HasSuffix(path, "/") {
+		template += "/"
+	}
+	return template
+}
+
+// addRoute adds a route to the OpenAPI spec, wrapping its success responses in the envelope
+// of the meta unless the route declares its own.
+func (g *Generator) addRoute(openAPI *spec.OpenAPI, r *scanner.RouteInfo, envelope *scanner.EnvelopeInfo) {
 	if openAPI.Paths == nil {
 		openAPI.Paths = &spec.Paths{
 			PathItems: make(map[string]*spec.PathItem),
@@ -124,28 +538,14 @@ func (g *Generator) addRoute(openAPI *spec.OpenAPI, r *scanner.RouteInfo) {
 
 	pathItem, exists := openAPI.Paths.PathItems[r.Path]
 	if !exists {
-		pathItem = &spec.PathItem{}
+		pathItem = g.newPathItem(r.Path)
 		openAPI.Paths.PathItems[r.Path] = pathItem
 	}
 
-	op := g.routeToOperation(r)
-
-	switch strings.ToUpper(r.Method) {
-	case "GET":
-		pathItem.Get = op
-	case "POST":
-		pathItem.Post = op
-	case "PUT":
-		pathItem.Put = op
-	case "DELETE":
-		pathItem.Delete = op
-	case "PATCH":
-		pathItem.Patch = op
-	case "HEAD":
-		pathItem.Head = op
-	case "OPTIONS":
-		pathItem.Options = op
-	}
+	op := g.routeToOperation(r, envelope)
+
+	// Custom methods (e.g., PURGE, LINK) are additional operations
+	pathItem.SetOperation(strings.ToUpper(r.Method), op)
 }
 
 // securitySchemeToSpec converts SecuritySchemeInfo to spec.SecurityScheme.
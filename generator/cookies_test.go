@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCookieParameters tests cookie parameter generation and body conflicts
+func TestCookieParameters(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/cart.go": `package api
+
+// swagger:model Cart
+type Cart struct {
+	Items []string ` + "`json:\"items\"`" + `
+}
+
+// swagger:parameters updateCart
+type UpdateCartParams struct {
+	// Session identifier
+	// in: cookie
+	// required: true
+	Session string ` + "`json:\"session\"`" + `
+
+	// Preferred currencies
+	// in: cookie
+	Currencies []string ` + "`json:\"currencies\"`" + `
+
+	// in: cookie
+	Items string ` + "`json:\"items\"`" + `
+
+	// in: body
+	Body Cart ` + "`json:\"items\"`" + `
+}
+
+// swagger:route PUT /cart cart updateCart
+// Responses:
+// - 204: description:Updated
+func UpdateCart() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	op := openAPI.Paths.PathItems["/cart"].Put
+	require.NotNil(t, op.RequestBody)
+	require.Len(t, op.Parameters, 2)
+
+	session := op.Parameters[0]
+	assert.Equal(t, &spec.Parameter{
+		Name:        "session",
+		In:          "cookie",
+		Description: "Session identifier",
+		Required:    true,
+		Schema:      session.Schema,
+	}, session)
+	assert.Equal(t, "string", session.Schema.Type.Value())
+
+	currencies := op.Parameters[1]
+	assert.Equal(t, "currencies", currencies.Name)
+	assert.Equal(t, "cookie", currencies.In)
+	assert.False(t, currencies.Required)
+	assert.Equal(t, "form", currencies.Style)
+	require.NotNil(t, currencies.Explode)
+	assert.False(t, *currencies.Explode)
+
+	assert.Equal(t, []string{
+		"operation updateCart: cookie parameter items is also declared in the request body",
+	}, warningMessages(g.Warnings()))
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/scanner"
+)
+
+// Handler is an exported HTTP handler of the scanned code (see Coverage).
+type Handler = scanner.HandlerInfo
+
+// HandlerCoverage is the documentation coverage of the HTTP handlers of the scanned code.
+type HandlerCoverage struct {
+	// Handlers are the handlers found, sorted by source file and line
+	Handlers []*Handler
+	// Documented is the number of handlers with a swagger:route directive
+	Documented int
+}
+
+// Percent returns the percentage of documented handlers, 100 when there are none.
+func (c *HandlerCoverage) Percent() float64 {
+	if len(c.Handlers) == 0 {
+		return 100
+	}
+	return float64(c.Documented) * 100 / float64(len(c.Handlers))
+}
+
+// Undocumented returns the handlers without a swagger:route directive.
+func (c *HandlerCoverage) Undocumented() []*Handler {
+	var handlers []*Handler
+	for _, handler := range c.Handlers {
+		if !handler.Documented {
+			handlers = append(handlers, handler)
+		}
+	}
+	return handlers
+}
+
+// Coverage scans the source files and reports which exported HTTP handlers have a
+// swagger:route directive. Handlers are recognized by their net/http, gin, echo, or fiber
+// signature; functions marked swagger:ignore are left out.
+func (g *Generator) Coverage() (*HandlerCoverage, error) {
+	run := g.newRun()
+	defer g.finishRun(run)
+	run.scanner = newScanner(g.config, scanner.WithHandlers(true))
+
+	return run.coverage(context.Background())
+}
+
+// coverage reports the handler documentation coverage within a run.
+func (g *Generator) coverage(ctx context.Context) (*HandlerCoverage, error) {
+	if err := g.prepare(ctx); err != nil {
+		return nil, err
+	}
+
+	coverage := &HandlerCoverage{Handlers: slices.Clone(g.scanner.Handlers)}
+	slices.SortStableFunc(coverage.Handlers, func(a, b *Handler) int {
+		if c := strings.Compare(a.SourceFile, b.SourceFile); c != 0 {
+			return c
+		}
+		return a.Pos.Line - b.Pos.Line
+	})
+	for _, handler := range coverage.Handlers {
+		if handler.Documented {
+			coverage.Documented++
+		}
+	}
+	return coverage, nil
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverage(t *testing.T) {
+	// Local http, gin, and echo packages stand in for the frameworks, whose types are
+	// matched by package and type name
+	tmpDir := createTestProject(t, map[string]string{
+		"http/http.go": `package http
+
+type ResponseWriter interface{ Write([]byte) (int, error) }
+
+type Request struct{}
+`,
+		"gin/gin.go": `package gin
+
+type Context struct{}
+`,
+		"echo/echo.go": `package echo
+
+type Context interface{ JSON(code int, v any) error }
+`,
+		"api/users.go": `package api
+
+import (
+	"testproject/echo"
+	"testproject/gin"
+	"testproject/http"
+)
+
+type UserHandler struct{}
+
+// GetUser returns a user.
+//
+// swagger:route GET /users/{id} users getUser
+//
+// summary: Get a user
+//
+// Responses:
+// - 200: description: OK
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// DeleteUser deletes a user.
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {}
+
+// ListTeams lists the teams.
+func ListTeams(c *gin.Context) {}
+
+// CreateTeam creates a team.
+func CreateTeam(c echo.Context) error { return nil }
+
+// Auth is a middleware, not an endpoint.
+//
+// swagger:ignore
+func Auth(w http.ResponseWriter, r *http.Request) {}
+
+func unexported(w http.ResponseWriter, r *http.Request) {}
+
+type internalHandler struct{}
+
+func (h internalHandler) Serve(w http.ResponseWriter, r *http.Request) {}
+
+// Helper has another signature.
+func Helper(c *gin.Context) error { return nil }
+`,
+	})
+
+	gen := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	coverage, err := gen.Coverage()
+	require.NoError(t, err)
+
+	var handlers []string
+	for _, handler := range coverage.Handlers {
+		handlers = append(handlers, handler.Name+" "+handler.Framework)
+		assert.Equal(t, "users.go", filepath.Base(handler.SourceFile))
+	}
+	assert.Equal(t, []string{
+		"UserHandler.GetUser net/http",
+		"UserHandler.DeleteUser net/http",
+		"ListTeams gin",
+		"CreateTeam echo",
+	}, handlers)
+
+	assert.Equal(t, 1, coverage.Documented)
+	assert.Equal(t, "getUser", coverage.Handlers[0].OperationID)
+	assert.InDelta(t, 25.0, coverage.Percent(), 0.001)
+
+	undocumented := coverage.Undocumented()
+	require.Len(t, undocumented, 3)
+	assert.Equal(t, "UserHandler.DeleteUser", undocumented[0].Name)
+	assert.Equal(t, "testproject/api", undocumented[0].Package)
+	assert.Equal(t, 22, undocumented[0].Pos.Line)
+}
+
+func TestCoverageWithoutHandlers(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"models/user.go": `package models
+
+// swagger:model
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+`,
+	})
+
+	gen := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	coverage, err := gen.Coverage()
+	require.NoError(t, err)
+
+	assert.Empty(t, coverage.Handlers)
+	assert.InDelta(t, 100.0, coverage.Percent(), 0.001)
+}
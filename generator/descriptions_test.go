@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateDescriptionFiles tests reading meta and route descriptions from markdown files
+func TestGenerateDescriptionFiles(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// Package api is the API.
+//
+// swagger:meta
+// Title: Users
+// Version: 1.0.0
+// Description: file:../docs/api.md
+package api
+`,
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// summary: List users
+// description: file:../docs/users.md
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+`,
+		"docs/api.md":   "# Users API\n\nManages **users**.\n",
+		"docs/users.md": "Lists users.\n\n| Field | Sort |\n|-------|------|\n| name  | yes  |\n",
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Users API\n\nManages **users**.", openAPI.Info.Description)
+	assert.Equal(t, "Lists users.\n\n| Field | Sort |\n|-------|------|\n| name  | yes  |", openAPI.Paths.PathItems["/users"].Get.Description)
+}
+
+// TestGenerateMissingDescriptionFile tests that a missing description file fails with the route location
+func TestGenerateMissingDescriptionFile(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// description: file:./missing.md
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	_, err := g.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api/users.go:3: operation listUsers: failed to read description file")
+}
+
+// descriptionsProject has models, an enum, and fields of documented types without directives.
+var descriptionsProject = map[string]string{
+	"api/users.go": `package api
+
+// Email is an email address.
+type Email string
+
+type (
+	// Nickname is the public name of a user.
+	Nickname string
+)
+
+// swagger:enum Role
+type Role string
+
+const RoleAdmin Role = "admin"
+
+// swagger:model User
+type User struct {
+	Email Email ` + "`json:\"email\"`" + `
+	// The nickname shown to others
+	Nickname Nickname ` + "`json:\"nickname\"`" + `
+	Role     Role     ` + "`json:\"role\"`" + `
+	Age      int      ` + "`json:\"age\"`" + `
+}
+
+// swagger:parameters getUser
+type GetUserParams struct {
+	// in: query
+	Email Email ` + "`json:\"email\"`" + `
+}
+
+// swagger:route GET /users users getUser
+// Responses:
+// - 200: User
+func GetUser() {}
+`,
+}
+
+// TestGenerateSchemaTitles tests setting the title of model and enum schemas to their Go type name
+func TestGenerateSchemaTitles(t *testing.T) {
+	tmpDir := createTestProject(t, descriptionsProject)
+
+	for _, titles := range []bool{false, true} {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithEnumRefs(true), WithSchemaTitles(titles))
+		openAPI, err := g.Generate()
+		require.NoError(t, err)
+
+		if !titles {
+			assert.Empty(t, openAPI.Components.Schemas["User"].Title)
+			continue
+		}
+		assert.Equal(t, "User", openAPI.Components.Schemas["User"].Title)
+		assert.Equal(t, "Role", openAPI.Components.Schemas["Role"].Title)
+	}
+}
+
+// TestGenerateDescriptionFallback tests describing fields and parameters with the doc comment of their type
+func TestGenerateDescriptionFallback(t *testing.T) {
+	tmpDir := createTestProject(t, descriptionsProject)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithDescriptionFallback(true))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	user := openAPI.Components.Schemas["User"]
+	assert.Equal(t, "Email is an email address.", user.Properties["email"].Description)
+	assert.Equal(t, "The nickname shown to others", user.Properties["nickname"].Description)
+	assert.Empty(t, user.Properties["role"].Description)
+	assert.Empty(t, user.Properties["age"].Description)
+
+	param := openAPI.Paths.PathItems["/users"].Get.Parameters[0]
+	assert.Equal(t, "Email is an email address.", param.Description)
+
+	// Without the fallback, fields keep their own descriptions only
+	g = New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err = g.Generate()
+	require.NoError(t, err)
+	assert.Empty(t, openAPI.Components.Schemas["User"].Properties["email"].Description)
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResponseDiscriminator tests discriminator declarations on route responses
+func TestResponseDiscriminator(t *testing.T) {
+	files := map[string]string{
+		"api/pets.go": `package api
+
+// swagger:model Cat
+type Cat struct {
+	PetType string ` + "`json:\"petType\"`" + `
+}
+
+// swagger:model Dog
+type Dog struct {
+	PetType string ` + "`json:\"petType\"`" + `
+}
+
+// swagger:oneOf PetOneOf
+// discriminator: kind
+type PetOneOf struct {
+	// swagger:oneOfOption discriminator=cat
+	Cat
+	// swagger:oneOfOption discriminator=dog
+	Dog
+}
+
+// swagger:route GET /pets/{id} pets getPet
+// Responses:
+// - 200: PetOneOf discriminator:petType mapping:kitty=Cat Pet found
+func GetPet() {}
+
+// swagger:route GET /animals animals listAnimals
+// Responses:
+// - 200: []Cat|Dog discriminator:petType mapping:cat=Cat,dog=Dog
+func ListAnimals() {}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	g := New(
+		WithDir(tmpDir),
+		WithPattern("./..."),
+		WithCache(false),
+		WithOutput(filepath.Join(tmpDir, "openapi.yaml"), "yaml"),
+	)
+
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	t.Run("model with overrides", func(t *testing.T) {
+		resp := openAPI.Paths.PathItems["/pets/{id}"].Get.Responses.StatusCodes["200"]
+		require.NotNil(t, resp)
+		assert.Equal(t, "Pet found", resp.Description)
+
+		schema := resp.Content["application/json"].Schema
+		require.NotNil(t, schema)
+		require.Len(t, schema.OneOf, 2)
+		require.NotNil(t, schema.Discriminator)
+		assert.Equal(t, "petType", schema.Discriminator.PropertyName)
+		assert.Equal(t, map[string]string{
+			"cat":   "#/components/schemas/Cat",
+			"dog":   "#/components/schemas/Dog",
+			"kitty": "#/components/schemas/Cat",
+		}, schema.Discriminator.Mapping)
+	})
+
+	t.Run("inline oneOf", func(t *testing.T) {
+		resp := openAPI.Paths.PathItems["/animals"].Get.Responses.StatusCodes["200"]
+		require.NotNil(t, resp)
+
+		schema := resp.Content["application/json"].Schema
+		require.NotNil(t, schema.Items)
+		require.Len(t, schema.Items.OneOf, 2)
+		assert.Equal(t, "#/components/schemas/Cat", schema.Items.OneOf[0].Ref)
+		assert.Equal(t, "#/components/schemas/Dog", schema.Items.OneOf[1].Ref)
+		require.NotNil(t, schema.Items.Discriminator)
+		assert.Equal(t, "petType", schema.Items.Discriminator.PropertyName)
+	})
+
+	assert.Contains(t, openAPI.Components.Schemas, "Cat")
+	assert.Contains(t, openAPI.Components.Schemas, "Dog")
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// embedFile returns the Go file of the embed output, declaring the written specs by name
+// in a Specs map (for swagger.Config.Specs). specFiles maps the spec names to their output
+// files and files holds the content of the output files.
+//
+// A spec under the directory of the Go file is embedded with //go:embed, others (go:embed
+// can't reach parent directories) are declared as string literals.
+func (g *Generator) embedFile(specFiles map[string]string, files map[string][]byte) ([]byte, error) {
+	goFile, err := filepath.Abs(g.config.EmbedOutput)
+	if err != nil {
+		return nil, err
+	}
+	goDir := filepath.Dir(goFile)
+
+	pkg := g.config.EmbedPackage
+	if pkg == "" {
+		pkg = filepath.Base(goDir)
+	}
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("%q is not a valid package name for %s, set the embed package", pkg, g.config.EmbedOutput)
+	}
+
+	var decls, entries bytes.Buffer
+	embeds := false
+	for i, name := range slices.Sorted(maps.Keys(specFiles)) {
+		file := specFiles[name]
+		varName := "spec" + strconv.Itoa(i)
+
+		rel, err := embedPath(goDir, file)
+		if err != nil {
+			return nil, err
+		}
+		if rel != "" {
+			embeds = true
+			fmt.Fprintf(&decls, "\n//go:embed %s\nvar %s []byte\n", rel, varName)
+			fmt.Fprintf(&entries, "\t%s: %s,\n", strconv.Quote(name), varName)
+		} else {
+			fmt.Fprintf(&entries, "\t%s: []byte(%s),\n", strconv.Quote(name), goStringLiteral(files[file]))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by openapi generate; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n", pkg)
+	if embeds {
+		buf.WriteString("\nimport _ \"embed\"\n")
+	}
+	buf.Write(decls.Bytes())
+	buf.WriteString("\n// Specs are the generated OpenAPI specs by name, for the Specs of swagger.Config.\n")
+	fmt.Fprintf(&buf, "var Specs = map[string][]byte{\n%s}\n", entries.String())
+
+	return format.Source(buf.Bytes())
+}
+
+// embedPath returns the //go:embed pattern of a spec file relative to the directory of
+// the Go file, or "" when go:embed can't reach it.
+func embedPath(goDir, file string) (string, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(goDir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", nil
+	}
+
+	rel = filepath.ToSlash(rel)
+	if strings.ContainsAny(rel, " \t\"`") {
+		return strconv.Quote(rel), nil
+	}
+	return rel, nil
+}
+
+// goStringLiteral returns data as a raw string literal, or as an interpreted one when
+// it contains backquotes or carriage returns.
+func goStringLiteral(data []byte) string {
+	if bytes.ContainsAny(data, "`\r") {
+		return strconv.Quote(string(data))
+	}
+	return "`" + string(data) + "`"
+}
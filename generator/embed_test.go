@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedOutput(t *testing.T) {
+	t.Run("go:embed", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/handlers.go": writeTestHandlers})
+		embedFile := filepath.Join(tmpDir, "docs", "spec.go")
+
+		g := New(WithDir(tmpDir), WithPattern("./api/..."), WithCache(false),
+			WithOutput(filepath.Join(tmpDir, "docs", "openapi.yaml"), "yaml"),
+			WithEmbedOutput(embedFile, ""))
+		_, err := g.Generate()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(embedFile)
+		require.NoError(t, err)
+		file, err := parser.ParseFile(token.NewFileSet(), embedFile, data, parser.ParseComments)
+		require.NoError(t, err)
+
+		assert.Equal(t, "docs", file.Name.Name)
+		assert.Contains(t, string(data), "// Code generated by openapi generate; DO NOT EDIT.")
+		assert.Contains(t, string(data), "import _ \"embed\"")
+		assert.Contains(t, string(data), "//go:embed openapi.yaml\nvar spec0 []byte")
+		assert.Contains(t, string(data), "\"openapi\": spec0,")
+	})
+
+	t.Run("string literal outside the directory", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/handlers.go": writeTestHandlers})
+		embedFile := filepath.Join(tmpDir, "internal", "docs-gen", "specs.go")
+
+		g := New(WithDir(tmpDir), WithPattern("./api/..."), WithCache(false),
+			WithOutput(filepath.Join(tmpDir, "specs", "openapi.yaml"), "yaml"),
+			WithEmbedOutput(embedFile, "docs"))
+		_, err := g.GenerateMulti()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(embedFile)
+		require.NoError(t, err)
+		_, err = parser.ParseFile(token.NewFileSet(), embedFile, data, 0)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(data), "package docs")
+		assert.NotContains(t, string(data), "go:embed")
+		assert.Contains(t, string(data), "\"default\": []byte(`openapi: 3.1.2")
+	})
+
+	t.Run("invalid package name", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/handlers.go": writeTestHandlers})
+
+		g := New(WithDir(tmpDir), WithPattern("./api/..."), WithCache(false),
+			WithOutput(filepath.Join(tmpDir, "openapi.yaml"), "yaml"),
+			WithEmbedOutput(filepath.Join(tmpDir, "docs-gen", "spec.go"), ""))
+		_, err := g.Generate()
+		assert.ErrorContains(t, err, `"docs-gen" is not a valid package name`)
+	})
+}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateEnumRefs tests enum fields emitted as references to the enum components
+func TestGenerateEnumRefs(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/orders.go": `package api
+
+// Priority of an order
+// swagger:enum Priority
+type Priority int
+
+const (
+	PriorityLow  Priority = 1
+	PriorityHigh Priority = 2
+)
+
+// swagger:enum Status
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusClosed Status = "closed"
+)
+
+// swagger:model Order
+type Order struct {
+	Status Status ` + "`json:\"status\"`" + `
+	// Priority of the delivery
+	// example: 2
+	Priority Priority ` + "`json:\"priority\"`" + `
+	// The previous status
+	// nullable: true
+	Previous *Status ` + "`json:\"previous\"`" + `
+}
+
+// swagger:parameters listOrders
+type ListOrdersParams struct {
+	// Status to match
+	// example: open
+	Status Status ` + "`json:\"status\"`" + `
+}
+
+// swagger:route GET /orders orders listOrders
+// Responses:
+// - 200: Order
+func ListOrders() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithEnumRefs(true))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	require.Contains(t, openAPI.Components.Schemas, "Status")
+	require.Contains(t, openAPI.Components.Schemas, "Priority")
+	order := openAPI.Components.Schemas["Order"]
+	require.NotNil(t, order)
+
+	// Without overrides, the field is a plain reference
+	assert.Equal(t, &spec.Schema{Ref: "#/components/schemas/Status"}, order.Properties["status"])
+
+	// Overrides wrap the reference in allOf
+	priority := order.Properties["priority"]
+	assert.Empty(t, priority.Ref)
+	require.Len(t, priority.AllOf, 1)
+	assert.Equal(t, "#/components/schemas/Priority", priority.AllOf[0].Ref)
+	assert.Equal(t, "Priority of the delivery", priority.Description)
+	assert.Equal(t, []any{int64(2)}, priority.Examples)
+
+	// A nullable field keeps the overrides next to the anyOf
+	previous := order.Properties["previous"]
+	assert.Empty(t, previous.AllOf)
+	require.Len(t, previous.AnyOf, 2)
+	assert.Equal(t, "#/components/schemas/Status", previous.AnyOf[0].Ref)
+	assert.Equal(t, "null", previous.AnyOf[1].Type.Value())
+	assert.Equal(t, "The previous status", previous.Description)
+
+	param := openAPI.Paths.PathItems["/orders"].Get.Parameters[0]
+	assert.Equal(t, "Status to match", param.Description)
+	require.Len(t, param.Schema.AllOf, 1)
+	assert.Equal(t, "#/components/schemas/Status", param.Schema.AllOf[0].Ref)
+	assert.Empty(t, param.Schema.Description)
+	assert.Equal(t, []any{"open"}, param.Schema.Examples)
+}
+
+// TestGenerateIotaEnum tests that iota-based integer constants become an integer enum schema
+func TestGenerateIotaEnum(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/tasks.go": `package api
+
+// swagger:enum Priority
+type Priority int
+
+// Level is an alias of Priority.
+type Level = Priority
+
+const (
+	_ Priority = iota
+	PriorityLow
+	PriorityMedium
+	_
+	PriorityCritical
+)
+
+const (
+	LevelBase Level = iota + 10
+	LevelNext
+)
+
+// swagger:model Task
+type Task struct {
+	Priority Priority ` + "`json:\"priority\"`" + `
+}
+
+// swagger:route GET /tasks tasks listTasks
+// Responses:
+// - 200: Task
+func ListTasks() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithEnumRefs(true))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	priority := openAPI.Components.Schemas["Priority"]
+	require.NotNil(t, priority)
+	assert.Equal(t, "integer", priority.Type.Value())
+	assert.Equal(t, []any{int64(10), int64(11), int64(4), int64(1), int64(2)}, priority.Enum)
+}
@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+)
+
+// metaEnvelope returns the envelope of the first meta declaring one, so a spec meta
+// overrides the general meta.
+func metaEnvelope(metas ...*scanner.MetaInfo) *scanner.EnvelopeInfo {
+	for _, meta := range metas {
+		if meta != nil && meta.Envelope != nil {
+			return meta.Envelope
+		}
+	}
+	return nil
+}
+
+// routeEnvelope returns the envelope wrapping the success responses of a route: its own,
+// or the envelope of the meta. Nil means the payloads are not wrapped.
+func routeEnvelope(r *scanner.RouteInfo, fromMeta *scanner.EnvelopeInfo) *scanner.EnvelopeInfo {
+	envelope := r.Envelope
+	if envelope == nil {
+		envelope = fromMeta
+	}
+	if envelope == nil || envelope.Field == "" {
+		return nil
+	}
+	return envelope
+}
+
+// isSuccessStatus reports whether a response status code (200, 2XX) is a success.
+func isSuccessStatus(code string) bool {
+	return strings.HasPrefix(code, "2")
+}
+
+// envelopeSchema wraps a response payload schema in the envelope: an object holding the
+// payload under the envelope field, combined (allOf) with the envelope model if any.
+func (g *Generator) envelopeSchema(r *scanner.RouteInfo, payload *spec.Schema, envelope *scanner.EnvelopeInfo) *spec.Schema {
+	wrapper := &spec.Schema{
+		Type:       spec.NewSchemaType(scanner.TypeObject),
+		Properties: map[string]*spec.Schema{envelope.Field: payload},
+		Required:   []string{envelope.Field},
+	}
+	if envelope.Model == "" {
+		return wrapper
+	}
+
+	if !g.isKnownType(envelope.Model) {
+		g.warnAt(WarnUnknownResponseType, r.Pos, "operation %s: envelope model %s is not a model, the payload is wrapped without it",
+			r.OperationID, envelope.Model)
+		return wrapper
+	}
+	return &spec.Schema{AllOf: []*spec.Schema{g.typeToSchema(envelope.Model), wrapper}}
+}
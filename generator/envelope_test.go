@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationGenerateEnvelope tests wrapping success responses in the envelope of the meta and routes
+func TestIntegrationGenerateEnvelope(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// swagger:meta
+// Title: Users API
+// Version: 1.0.0
+// envelope: data Envelope
+package api
+`,
+		"api/users.go": `package api
+
+// swagger:model Envelope
+type Envelope struct {
+	Meta map[string]string ` + "`json:\"meta\"`" + `
+}
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:model Problem
+type Problem struct {
+	Title string ` + "`json:\"title\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+// - 404: Problem
+func ListUsers() {}
+
+// swagger:route GET /users/me users getUser
+// envelope: result
+// Responses:
+// - 200: User
+func GetUser() {}
+
+// swagger:route GET /health health health
+// envelope: none
+// Responses:
+// - 200: string
+func Health() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+	assert.Empty(t, warningMessages(g.Warnings()))
+
+	schemaOf := func(path, code string) *spec.Schema {
+		response := openAPI.Paths.PathItems[path].Get.Responses.StatusCodes[code]
+		require.NotNil(t, response)
+		return response.Content["application/json"].Schema
+	}
+
+	// Meta envelope, merged with the envelope model
+	list := schemaOf("/users", "200")
+	require.Len(t, list.AllOf, 2)
+	assert.Equal(t, "#/components/schemas/Envelope", list.AllOf[0].Ref)
+	assert.Equal(t, []string{"data"}, list.AllOf[1].Required)
+	data := list.AllOf[1].Properties["data"]
+	require.NotNil(t, data)
+	assert.Equal(t, "array", data.Type.Value())
+	assert.Equal(t, "#/components/schemas/User", data.Items.Ref)
+	assert.Contains(t, openAPI.Components.Schemas, "Envelope")
+
+	// Error responses are not wrapped
+	assert.Equal(t, "#/components/schemas/Problem", schemaOf("/users", "404").Ref)
+
+	// Route envelope without model
+	get := schemaOf("/users/me", "200")
+	assert.Empty(t, get.AllOf)
+	assert.Equal(t, "#/components/schemas/User", get.Properties["result"].Ref)
+
+	// Opted out
+	assert.Equal(t, "string", schemaOf("/health", "200").Type.Value())
+}
+
+// TestGenerateEnvelopeWarnings tests malformed envelopes and unknown envelope models
+func TestGenerateEnvelopeWarnings(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// envelope:
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route GET /users/me users getUser
+// envelope: data Missing
+// Responses:
+// - 200: User
+func GetUser() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	messages := warningMessages(g.Warnings())
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[0], "operation listUsers: envelope:: missing payload property; envelope ignored")
+	assert.Contains(t, messages[1], "operation getUser: envelope model Missing is not a model")
+
+	// The malformed envelope is ignored, the unknown model is left out
+	list := openAPI.Paths.PathItems["/users"].Get.Responses.StatusCodes["200"].Content["application/json"].Schema
+	assert.Equal(t, "array", list.Type.Value())
+	get := openAPI.Paths.PathItems["/users/me"].Get.Responses.StatusCodes["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/User", get.Properties["data"].Ref)
+}
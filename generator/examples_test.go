@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResponseExamples tests response examples loaded from files and Go variables
+func TestResponseExamples(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/testdata/user.json": `{"id": 7, "name": "Jane"}`,
+		"api/users.go": `package api
+
+type Role string
+
+const RoleAdmin Role = "admin"
+
+type Base struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// swagger:model User
+type User struct {
+	Base
+	Name   string ` + "`json:\"name\"`" + `
+	Role   Role   ` + "`json:\"role,omitempty\"`" + `
+	Tags   []string ` + "`json:\"tags\"`" + `
+	Secret string ` + "`json:\"-\"`" + `
+}
+
+var exampleUser = User{
+	Base:   Base{ID: 1},
+	Name:   "John",
+	Role:   RoleAdmin,
+	Tags:   []string{"a", "b"},
+	Secret: "hidden",
+}
+
+// swagger:route GET /users/{id} users getUser
+// Produces:
+// - application/json
+// - application/xml
+// Responses:
+// - 200: User example:./testdata/user.json
+func GetUser() {}
+
+// swagger:route GET /users/me users getMe
+// Responses:
+// - 200: User example:exampleUser description:Current user
+func GetMe() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	t.Run("file example", func(t *testing.T) {
+		content := openAPI.Paths.PathItems["/users/{id}"].Get.Responses.StatusCodes["200"].Content
+		expected := map[string]any{"id": float64(7), "name": "Jane"}
+		assert.Equal(t, expected, content["application/json"].Example)
+		assert.Equal(t, expected, content["application/xml"].Example)
+	})
+
+	t.Run("variable example", func(t *testing.T) {
+		response := openAPI.Paths.PathItems["/users/me"].Get.Responses.StatusCodes["200"]
+		assert.Equal(t, "Current user", response.Description)
+		assert.Equal(t, map[string]any{
+			"id":   int64(1),
+			"name": "John",
+			"role": "admin",
+			"tags": []any{"a", "b"},
+		}, response.Content["application/json"].Example)
+	})
+}
+
+// TestResponseExampleCalls tests that example variables keep conversions to basic types
+// and drop examples calling functions
+func TestResponseExampleCalls(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+type Name string
+
+func lower(s string) string { return s }
+
+// swagger:model User
+type User struct {
+	Name  Name   ` + "`json:\"name\"`" + `
+	Email string ` + "`json:\"email\"`" + `
+}
+
+var convertedUser = User{Name: Name("Jane"), Email: string("jane@example.com")}
+
+var computedUser = User{Name: "Jane", Email: string(lower("JANE@EXAMPLE.COM"))}
+
+// swagger:route GET /users/converted users getConverted
+// Responses:
+// - 200: User example:convertedUser
+func GetConverted() {}
+
+// swagger:route GET /users/computed users getComputed
+// Responses:
+// - 200: User example:computedUser
+func GetComputed() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	converted := openAPI.Paths.PathItems["/users/converted"].Get.Responses.StatusCodes["200"]
+	assert.Equal(t, map[string]any{"name": "Jane", "email": "jane@example.com"}, converted.Content["application/json"].Example)
+
+	computed := openAPI.Paths.PathItems["/users/computed"].Get.Responses.StatusCodes["200"]
+	assert.Nil(t, computed.Content["application/json"].Example)
+
+	warnings := warningMessages(g.Warnings())
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "operation getComputed, response 200: example computedUser")
+	assert.Contains(t, warnings[0], "function calls are not supported: lower")
+}
+
+// TestResponseExampleMissingFile tests that unresolvable examples fail generation
+func TestResponseExampleMissingFile(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: string example:./testdata/missing.json
+func ListUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	_, err := g.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing.json")
+}
+
+// TestGenerateExampleBlocks tests multi-line example: | blocks on fields, models, and responses
+func TestGenerateExampleBlocks(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/orders.go": `package api
+
+// Order is a customer order.
+//
+// swagger:model Order
+//
+// example: |
+//   id: 42
+//   items:
+//     - sku: A-1
+//       quantity: 2
+type Order struct {
+	// Tags of the order.
+	// example: |
+	//   ["gift",
+	//    "express"]
+	Tags []string ` + "`json:\"tags\"`" + `
+	// example: |
+	//   {"color": "red"}
+	Labels map[string]string ` + "`json:\"labels\"`" + `
+	// example: |
+	//
+	Note string ` + "`json:\"note\"`" + `
+	// example: |
+	//   0042
+	Code string ` + "`json:\"code\"`" + `
+	// example: |
+	//   Dear customer,
+	//   your order {"id": 42} shipped.
+	Message *Text ` + "`json:\"message\"`" + `
+}
+
+type Text string
+
+// swagger:route GET /orders orders getOrder
+// Responses:
+// - 200: Order example: |
+//     {"id": 42,
+//      "items": []}
+func GetOrder() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	order := openAPI.Components.Schemas["Order"]
+	require.NotNil(t, order)
+	assert.Equal(t, "Order is a customer order.", order.Description)
+	assert.Equal(t, []any{map[string]any{
+		"id":    float64(42),
+		"items": []any{map[string]any{"sku": "A-1", "quantity": float64(2)}},
+	}}, order.Examples)
+
+	assert.Equal(t, "Tags of the order.", order.Properties["tags"].Description)
+	assert.Equal(t, []any{[]any{"gift", "express"}}, order.Properties["tags"].Examples)
+	assert.Equal(t, []any{map[string]any{"color": "red"}}, order.Properties["labels"].Examples)
+	assert.Empty(t, order.Properties["note"].Examples)
+	// The blocks of string fields are text
+	assert.Equal(t, []any{"0042"}, order.Properties["code"].Examples)
+	assert.Equal(t, []any{"Dear customer,\nyour order {\"id\": 42} shipped."}, order.Properties["message"].Examples)
+
+	response := openAPI.Paths.PathItems["/orders"].Get.Responses.StatusCodes["200"]
+	require.NotNil(t, response)
+	assert.Equal(t, map[string]any{"id": float64(42), "items": []any{}}, response.Content["application/json"].Example)
+
+	require.Len(t, g.Warnings(), 1)
+	assert.Equal(t, WarnInvalidExample, g.Warnings()[0].Code)
+	assert.Equal(t, "api/orders.go:21: invalid example block: the block is empty; example ignored", g.Warnings()[0].Message)
+}
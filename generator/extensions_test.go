@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVendorExtensions tests x-* directives at meta, route, model, and field level
+func TestVendorExtensions(t *testing.T) {
+	files := map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: My API
+// Version: 1.0.0
+// x-audience: external
+package api
+`,
+		"api/users.go": `package api
+
+// swagger:model User
+// A user of the system
+// x-internal: true
+type User struct {
+	// The user ID
+	// x-order: 1
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users/{id} users getUser
+// x-rate-limit: 100
+// Responses:
+// - 200: User
+func GetUser() {}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	g := New(
+		WithDir(tmpDir),
+		WithPattern("./..."),
+		WithCache(false),
+		WithOutput(filepath.Join(tmpDir, "openapi.yaml"), "yaml"),
+	)
+
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	assert.Equal(t, "external", openAPI.Info.Extensions["x-audience"])
+
+	op := openAPI.Paths.PathItems["/users/{id}"].Get
+	require.NotNil(t, op)
+	assert.Equal(t, float64(100), op.Extensions["x-rate-limit"])
+
+	user := openAPI.Components.Schemas["User"]
+	require.NotNil(t, user)
+	assert.Equal(t, "A user of the system", user.Description)
+	assert.Equal(t, true, user.Extensions["x-internal"])
+
+	id := user.Properties["id"]
+	require.NotNil(t, id)
+	assert.Equal(t, "The user ID", id.Description)
+	assert.Equal(t, float64(1), id.Extensions["x-order"])
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+)
+
+// Field policies infer the required and nullable state of model properties from Go types
+// and json tags (see WithFieldPolicies).
+const (
+	// FieldPolicyOmitemptyOptional makes properties without omitempty (or omitzero) required
+	// and properties with it optional.
+	FieldPolicyOmitemptyOptional = "omitempty-optional"
+	// FieldPolicyPointerNullable makes pointer properties nullable.
+	FieldPolicyPointerNullable = "pointer-nullable"
+)
+
+// fieldPolicies lists the supported field policies.
+var fieldPolicies = []string{FieldPolicyOmitemptyOptional, FieldPolicyPointerNullable}
+
+// checkFieldPolicies rejects unknown field policies.
+func (g *Generator) checkFieldPolicies() error {
+	for _, policy := range g.config.FieldPolicies {
+		if !slices.Contains(fieldPolicies, policy) {
+			return fmt.Errorf("unknown field policy %q, expected one of %v", policy, fieldPolicies)
+		}
+	}
+	return nil
+}
+
+// hasFieldPolicy reports whether a field policy is enabled.
+func (g *Generator) hasFieldPolicy(policy string) bool {
+	return slices.Contains(g.config.FieldPolicies, policy)
+}
+
+// isPropertyRequired reports whether a model property is required. The required:
+// directive and the validate:"required" tag win over the omitempty-optional policy.
+func (g *Generator) isPropertyRequired(f *scanner.FieldInfo) bool {
+	switch {
+	case f.ExplicitRequired:
+		return true
+	case f.ExplicitOptional:
+		return false
+	case g.hasFieldPolicy(FieldPolicyOmitemptyOptional):
+		return !f.HasOmitempty
+	}
+	return f.Required
+}
+
+// propertySchema converts a model property, making it nullable when the pointer-nullable
+// policy applies and the field has no nullable: directive.
+func (g *Generator) propertySchema(f *scanner.FieldInfo) *spec.Schema {
+	schema := g.fieldToSchema(f)
+	if f.IsPointer && !f.Nullable && !f.ExplicitNotNull && g.hasFieldPolicy(FieldPolicyPointerNullable) {
+		schema = nullableSchema(schema)
+	}
+	return schema
+}
+
+// nullableSchema allows null for a schema: null is added to its type, or a reference is
+// wrapped in anyOf with a null schema (sibling keywords of $ref can't add a type).
+func nullableSchema(schema *spec.Schema) *spec.Schema {
+	// A reference wrapped in allOf to carry overrides
+	if schema.Ref == "" && len(schema.AllOf) == 1 && schema.AllOf[0].Ref != "" && schema.Type.IsEmpty() {
+		schema.AnyOf = []*spec.Schema{schema.AllOf[0], {Type: spec.NewSchemaType("null")}}
+		schema.AllOf = nil
+		return schema
+	}
+
+	if schema.Ref == "" {
+		if !schema.Type.IsEmpty() {
+			schema.Type = schema.Type.WithNull()
+		}
+		return schema
+	}
+
+	ref := &spec.Schema{Ref: schema.Ref}
+	schema.Ref = ""
+	schema.AnyOf = []*spec.Schema{ref, {Type: spec.NewSchemaType("null")}}
+	return schema
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldPolicyProject has a model mixing pointers, omitempty, and explicit overrides.
+var fieldPolicyProject = map[string]string{
+	"api/users.go": `package api
+
+// swagger:model Address
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// swagger:model User
+type User struct {
+	ID       string   ` + "`json:\"id\"`" + `
+	Nickname string   ` + "`json:\"nickname,omitempty\"`" + `
+	Age      *int     ` + "`json:\"age\"`" + `
+	Bio      *string  ` + "`json:\"bio,omitempty\"`" + `
+	Address  *Address ` + "`json:\"address,omitempty\"`" + `
+	Tags     []*string ` + "`json:\"tags\"`" + `
+	// required: false
+	Internal string ` + "`json:\"internal\"`" + `
+	// required: true
+	// nullable: false
+	Email *string ` + "`json:\"email,omitempty\"`" + `
+}
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: User
+func GetUser() {}
+`,
+}
+
+// TestGenerateFieldPolicies tests inferring required and nullable properties from omitempty and pointers
+func TestGenerateFieldPolicies(t *testing.T) {
+	tests := []struct {
+		name         string
+		policies     []string
+		wantRequired []string
+		wantNullable []string
+	}{
+		{
+			name:         "no policy",
+			wantRequired: []string{"email"},
+		},
+		{
+			name:         "omitempty-optional",
+			policies:     []string{FieldPolicyOmitemptyOptional},
+			wantRequired: []string{"id", "age", "tags", "email"},
+		},
+		{
+			name:         "pointer-nullable",
+			policies:     []string{FieldPolicyPointerNullable},
+			wantRequired: []string{"email"},
+			wantNullable: []string{"age", "bio", "address"},
+		},
+		{
+			name:         "both",
+			policies:     []string{FieldPolicyOmitemptyOptional, FieldPolicyPointerNullable},
+			wantRequired: []string{"id", "age", "tags", "email"},
+			wantNullable: []string{"age", "bio", "address"},
+		},
+	}
+
+	tmpDir := createTestProject(t, fieldPolicyProject)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithFieldPolicies(tt.policies...))
+			openAPI, err := g.Generate()
+			require.NoError(t, err)
+
+			user := openAPI.Components.Schemas["User"]
+			require.NotNil(t, user)
+			assert.ElementsMatch(t, tt.wantRequired, user.Required)
+
+			var nullable []string
+			for name, prop := range user.Properties {
+				if isNullable(prop) {
+					nullable = append(nullable, name)
+				}
+			}
+			assert.ElementsMatch(t, tt.wantNullable, nullable)
+		})
+	}
+}
+
+// TestGenerateFieldPoliciesNullableRef tests that nullable references are wrapped in anyOf
+func TestGenerateFieldPoliciesNullableRef(t *testing.T) {
+	tmpDir := createTestProject(t, fieldPolicyProject)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithFieldPolicies(FieldPolicyPointerNullable))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	address := openAPI.Components.Schemas["User"].Properties["address"]
+	assert.Empty(t, address.Ref)
+	require.Len(t, address.AnyOf, 2)
+	assert.Equal(t, "#/components/schemas/Address", address.AnyOf[0].Ref)
+	assert.Equal(t, "null", address.AnyOf[1].Type.Value())
+
+	// Array elements that are pointers don't make the array nullable
+	assert.Equal(t, []string{"array"}, openAPI.Components.Schemas["User"].Properties["tags"].Type.Values())
+}
+
+// TestGenerateUnknownFieldPolicy tests that unknown policies are rejected
+func TestGenerateUnknownFieldPolicy(t *testing.T) {
+	tmpDir := createTestProject(t, fieldPolicyProject)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithFieldPolicies("pointer-optional"))
+	_, err := g.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field policy "pointer-optional"`)
+}
+
+// isNullable reports whether a property schema allows null.
+func isNullable(schema *spec.Schema) bool {
+	if schema.Type.Contains("null") {
+		return true
+	}
+	for _, s := range schema.AnyOf {
+		if s.Type.Contains("null") {
+			return true
+		}
+	}
+	return false
+}
@@ -32,7 +32,7 @@ func UploadFile() {}
 	}
 
 	tmpDir := createTestProject(t, files)
-	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false))
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
 
 	spec, err := g.Generate()
 
@@ -99,7 +99,7 @@ func UploadMultipleFiles() {}
 	}
 
 	tmpDir := createTestProject(t, files)
-	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false))
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
 
 	spec, err := g.Generate()
 
@@ -160,7 +160,7 @@ func UploadWithMetadata() {}
 	}
 
 	tmpDir := createTestProject(t, files)
-	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false))
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
 
 	spec, err := g.Generate()
 
@@ -217,7 +217,7 @@ func CreateUser() {}
 	}
 
 	tmpDir := createTestProject(t, files)
-	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false))
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
 
 	spec, err := g.Generate()
 
@@ -266,7 +266,7 @@ func UploadFlexible() {}
 	}
 
 	tmpDir := createTestProject(t, files)
-	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false))
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
 
 	spec, err := g.Generate()
 
@@ -325,7 +325,7 @@ func UploadDocument() {}
 	}
 
 	tmpDir := createTestProject(t, files)
-	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false))
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
 
 	spec, err := g.Generate()
 
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"slices"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+)
+
+// routeIncluded reports whether a route passes the include/exclude tag filters.
+// Internal routes match the "internal" tag.
+func (g *Generator) routeIncluded(r *scanner.RouteInfo) bool {
+	tags := r.Tags
+	if r.Internal {
+		tags = append(slices.Clone(tags), scanner.InternalTag)
+	}
+	return g.tagsIncluded(tags)
+}
+
+// modelIncluded reports whether a model is emitted without being referenced.
+// Internal models are filtered like routes tagged "internal".
+func (g *Generator) modelIncluded(s *scanner.StructInfo) bool {
+	return !s.Internal || g.tagsIncluded([]string{scanner.InternalTag})
+}
+
+// tagsIncluded applies the configured tag filters to a set of tags.
+func (g *Generator) tagsIncluded(tags []string) bool {
+	for _, tag := range tags {
+		if slices.Contains(g.config.ExcludeTags, tag) {
+			return false
+		}
+	}
+	if len(g.config.IncludeTags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		if slices.Contains(g.config.IncludeTags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// addReferencedModels adds models skipped by the tag filters that are still
+// referenced by the spec, so no $ref is left dangling.
+func (g *Generator) addReferencedModels(components *spec.Components) {
+	for added := true; added; {
+		added = false
+		for name := range g.referencedSchemas {
+			if _, exists := components.Schemas[name]; exists {
+				continue
+			}
+			if structInfo, ok := g.scanner.Structs[name]; ok && structInfo.IsModel {
+				components.Schemas[name] = g.structToSchema(structInfo)
+				added = true
+			}
+		}
+	}
+}
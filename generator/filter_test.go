@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const filterTestSource = `package api
+
+// swagger:model User
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// swagger:model AuditEntry
+// internal: true
+type AuditEntry struct {
+	Action string ` + "`json:\"action\"`" + `
+}
+
+// swagger:model Shared
+// internal: true
+type Shared struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route GET /users/shared users listShared
+// Responses:
+// - 200: []Shared
+func ListShared() {}
+
+// swagger:route GET /admin/audit admin listAudit
+// internal: true
+// Responses:
+// - 200: []AuditEntry
+func ListAudit() {}
+
+// swagger:route GET /billing billing getBilling
+// Responses:
+// - 200: description:OK
+func GetBilling() {}
+`
+
+// TestTagFilters tests include/exclude tag filters and internal routes and models
+func TestTagFilters(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/api.go": filterTestSource})
+
+	tests := []struct {
+		name            string
+		opts            []Option
+		expectedPaths   []string
+		expectedSchemas []string
+	}{
+		{
+			name:            "no filters",
+			expectedPaths:   []string{"/users", "/users/shared", "/admin/audit", "/billing"},
+			expectedSchemas: []string{"User", "AuditEntry", "Shared"},
+		},
+		{
+			name:            "exclude internal",
+			opts:            []Option{WithExcludeTags(scanner.InternalTag)},
+			expectedPaths:   []string{"/users", "/users/shared", "/billing"},
+			expectedSchemas: []string{"User", "Shared"},
+		},
+		{
+			name:            "include tags",
+			opts:            []Option{WithIncludeTags("users")},
+			expectedPaths:   []string{"/users", "/users/shared"},
+			expectedSchemas: []string{"User", "Shared"},
+		},
+		{
+			name:            "include and exclude",
+			opts:            []Option{WithIncludeTags("users", "admin"), WithExcludeTags("billing", "admin")},
+			expectedPaths:   []string{"/users", "/users/shared"},
+			expectedSchemas: []string{"User", "Shared"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", "")}, tt.opts...)
+			openAPI, err := New(opts...).Generate()
+			require.NoError(t, err)
+
+			var paths []string
+			for path := range openAPI.Paths.PathItems {
+				paths = append(paths, path)
+			}
+			assert.ElementsMatch(t, tt.expectedPaths, paths)
+
+			var schemas []string
+			for name := range openAPI.Components.Schemas {
+				schemas = append(schemas, name)
+			}
+			assert.ElementsMatch(t, tt.expectedSchemas, schemas)
+		})
+	}
+}
+
+// TestGenerateBuildTags tests that build tags select the scanned files
+func TestGenerateBuildTags(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/handlers.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 204:
+func ListUsers() {}
+`,
+		"api/integration.go": `//go:build integration
+
+package api
+
+// swagger:route POST /test/reset testing resetData
+// Responses:
+// - 204:
+func ResetData() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+	assert.NotContains(t, openAPI.Paths.PathItems, "/test/reset")
+
+	g = New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithBuildTags("integration"))
+	openAPI, err = g.Generate()
+	require.NoError(t, err)
+	assert.Contains(t, openAPI.Paths.PathItems, "/test/reset")
+	assert.Contains(t, openAPI.Paths.PathItems, "/users")
+}
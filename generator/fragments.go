@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// fragment is a hand-written OpenAPI document merged into a generated spec (swagger:include).
+type fragment struct {
+	path    string
+	openAPI *spec.OpenAPI
+}
+
+// loadFragments reads the fragment files included by the metas and routes of a spec.
+// Each file is read once per spec, so fragments shared by several routes merge once.
+// The schema references of the fragments are marked, so the models they use are generated.
+func (g *Generator) loadFragments(metas []*scanner.MetaInfo, routes []*scanner.RouteInfo) ([]*fragment, error) {
+	var paths []string
+	for _, meta := range metas {
+		if meta != nil {
+			paths = append(paths, meta.Fragments...)
+		}
+	}
+	for _, route := range routes {
+		paths = append(paths, route.Fragments...)
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	fragments := make([]*fragment, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fragment %s: %w", g.toRelativePath(path), err)
+		}
+
+		// YAML is a superset of JSON, so both fragment formats decode here
+		var openAPI spec.OpenAPI
+		if err := yaml.Unmarshal(data, &openAPI); err != nil {
+			return nil, fmt.Errorf("failed to parse fragment %s: %w", g.toRelativePath(path), err)
+		}
+
+		forEachSpecSchema(&openAPI, func(schema *spec.Schema) {
+			for _, name := range schemaRefNames(schema) {
+				g.markSchemaAsReferenced(name)
+			}
+		})
+		fragments = append(fragments, &fragment{path: g.toRelativePath(path), openAPI: &openAPI})
+	}
+
+	return fragments, nil
+}
+
+// mergeFragments merges the paths, webhooks, components, and tags of the fragments
+// into the spec. Redefining a generated operation or component is an error.
+func mergeFragments(openAPI *spec.OpenAPI, fragments []*fragment) error {
+	for _, f := range fragments {
+		if err := mergeFragment(openAPI, f.openAPI); err != nil {
+			return fmt.Errorf("fragment %s: %w", f.path, err)
+		}
+	}
+	return nil
+}
+
+// mergeFragment merges a single fragment into the spec.
+func mergeFragment(openAPI, fragment *spec.OpenAPI) error {
+	if fragment.Paths != nil {
+		if openAPI.Paths == nil {
+			openAPI.Paths = &spec.Paths{PathItems: make(map[string]*spec.PathItem)}
+		}
+		mergeExtensions(&openAPI.Paths.Extensions, fragment.Paths.Extensions)
+		for _, path := range slices.Sorted(maps.Keys(fragment.Paths.PathItems)) {
+			src := fragment.Paths.PathItems[path]
+			dst, exists := openAPI.Paths.PathItems[path]
+			if !exists {
+				openAPI.Paths.PathItems[path] = src
+				continue
+			}
+			if err := mergePathItem(dst, src); err != nil {
+				return fmt.Errorf("path %s: %w", path, err)
+			}
+		}
+	}
+
+	if err := mergeEntries(&openAPI.Webhooks, fragment.Webhooks, "webhook"); err != nil {
+		return err
+	}
+
+	if src := fragment.Components; src != nil {
+		if openAPI.Components == nil {
+			openAPI.Components = &spec.Components{}
+		}
+		dst := openAPI.Components
+		mergeExtensions(&dst.Extensions, src.Extensions)
+		if err := errors.Join(
+			mergeEntries(&dst.Schemas, src.Schemas, "schema"),
+			mergeEntries(&dst.Responses, src.Responses, "response"),
+			mergeEntries(&dst.Parameters, src.Parameters, "parameter"),
+			mergeEntries(&dst.Examples, src.Examples, "example"),
+			mergeEntries(&dst.RequestBodies, src.RequestBodies, "request body"),
+			mergeEntries(&dst.Headers, src.Headers, "header"),
+			mergeEntries(&dst.SecuritySchemes, src.SecuritySchemes, "security scheme"),
+			mergeEntries(&dst.Links, src.Links, "link"),
+			mergeEntries(&dst.Callbacks, src.Callbacks, "callback"),
+			mergeEntries(&dst.PathItems, src.PathItems, "path item"),
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, tag := range fragment.Tags {
+		if !slices.ContainsFunc(openAPI.Tags, func(t *spec.Tag) bool { return t.Name == tag.Name }) {
+			openAPI.Tags = append(openAPI.Tags, tag)
+		}
+	}
+
+	return nil
+}
+
+// mergePathItem adds the operations of src to a generated path item.
+func mergePathItem(dst, src *spec.PathItem) error {
+	for method, op := range src.Operations() {
+		if dst.Operation(method) != nil {
+			return fmt.Errorf("operation %s is already generated", method)
+		}
+		dst.SetOperation(method, op)
+	}
+
+	if dst.Summary == "" {
+		dst.Summary = src.Summary
+	}
+	if dst.Description == "" {
+		dst.Description = src.Description
+	}
+	dst.Parameters = append(dst.Parameters, src.Parameters...)
+	dst.Servers = append(dst.Servers, src.Servers...)
+	mergeExtensions(&dst.Extensions, src.Extensions)
+
+	return nil
+}
+
+// mergeEntries adds the entries of src to dst, failing on names already defined.
+func mergeEntries[T any](dst *map[string]T, src map[string]T, kind string) error {
+	for _, name := range slices.Sorted(maps.Keys(src)) {
+		if *dst == nil {
+			*dst = make(map[string]T, len(src))
+		}
+		if _, exists := (*dst)[name]; exists {
+			return fmt.Errorf("%s %s is already defined", kind, name)
+		}
+		(*dst)[name] = src[name]
+	}
+	return nil
+}
+
+// mergeExtensions adds the extensions of src to dst, keeping the generated values.
+func mergeExtensions(dst *spec.Extensions, src spec.Extensions) {
+	for name, value := range src {
+		if *dst == nil {
+			*dst = make(spec.Extensions, len(src))
+		}
+		if _, exists := (*dst)[name]; !exists {
+			(*dst)[name] = value
+		}
+	}
+}
+
+// fragmentMetas returns the metas whose fragments apply to a spec: the general meta
+// and, in multi-spec mode, the meta of the spec.
+func (g *Generator) fragmentMetas(meta *scanner.MetaInfo) []*scanner.MetaInfo {
+	if meta == nil || meta == g.scanner.Meta {
+		return []*scanner.MetaInfo{g.scanner.Meta}
+	}
+	return []*scanner.MetaInfo{g.scanner.Meta, meta}
+}
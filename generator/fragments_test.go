@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationGenerateFragments tests merging hand-written fragments included by meta and route comments
+func TestIntegrationGenerateFragments(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// swagger:meta
+// Title: Payments API
+// Version: 1.0.0
+// swagger:include ./fragments/errors.yaml
+package api
+`,
+		"api/payments.go": `package api
+
+// swagger:model Payment
+type Payment struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:model Refund
+type Refund struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /payments payments listPayments
+// swagger:include ./fragments/payments.yaml ./fragments/errors.yaml
+// Responses:
+// - 200: []Payment
+func ListPayments() {}
+`,
+		"api/fragments/errors.yaml": `components:
+  schemas:
+    Problem:
+      type: object
+      properties:
+        title:
+          type: string
+  responses:
+    NotFound:
+      description: Not found
+      content:
+        application/problem+json:
+          schema:
+            $ref: '#/components/schemas/Problem'
+tags:
+  - name: payments
+    description: Payment operations
+`,
+		"api/fragments/payments.yaml": `paths:
+  /payments:
+    x-rate-limit: 100
+    post:
+      operationId: createPayment
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Payment'
+  /payments/{id}/refunds:
+    get:
+      operationId: listRefunds
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Refund'
+`,
+	})
+
+	for _, multi := range []bool{false, true} {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithCleanUnused(true))
+
+		var openAPI *spec.OpenAPI
+		if multi {
+			specs, err := g.GenerateMulti()
+			require.NoError(t, err)
+			openAPI = specs[scanner.DefaultSpec]
+		} else {
+			var err error
+			openAPI, err = g.Generate()
+			require.NoError(t, err)
+		}
+		require.NotNil(t, openAPI)
+
+		payments := openAPI.Paths.PathItems["/payments"]
+		require.NotNil(t, payments)
+		assert.Equal(t, "listPayments", payments.Get.OperationID)
+		require.NotNil(t, payments.Post)
+		assert.Equal(t, "createPayment", payments.Post.OperationID)
+		assert.Equal(t, spec.Extensions{"x-rate-limit": 100}, payments.Extensions, "extensions of merged path items are kept")
+		require.NotNil(t, openAPI.Paths.PathItems["/payments/{id}/refunds"])
+
+		// Models referenced only by fragments are generated
+		assert.Contains(t, openAPI.Components.Schemas, "Refund")
+		assert.Contains(t, openAPI.Components.Schemas, "Payment")
+		assert.Contains(t, openAPI.Components.Schemas, "Problem")
+		assert.Contains(t, openAPI.Components.Responses, "NotFound")
+
+		require.Len(t, openAPI.Tags, 1)
+		assert.Equal(t, "Payment operations", openAPI.Tags[0].Description)
+	}
+}
+
+// TestGenerateFragmentErrors tests missing fragments and fragments redefining generated elements
+func TestGenerateFragmentErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		wantErr  string
+	}{
+		{
+			name:    "missing file",
+			wantErr: "failed to read fragment api/extra.yaml",
+		},
+		{
+			name:     "invalid YAML",
+			fragment: "paths: [",
+			wantErr:  "failed to parse fragment api/extra.yaml",
+		},
+		{
+			name: "operation conflict",
+			fragment: `paths:
+  /users:
+    get:
+      operationId: otherListUsers
+`,
+			wantErr: "fragment api/extra.yaml: path /users: operation GET is already generated",
+		},
+		{
+			name: "schema conflict",
+			fragment: `components:
+  schemas:
+    User:
+      type: string
+`,
+			wantErr: "fragment api/extra.yaml: schema User is already defined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{
+				"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// swagger:include extra.yaml
+// Responses:
+// - 200: []User
+func ListUsers() {}
+`,
+			}
+			if tt.fragment != "" {
+				files["api/extra.yaml"] = tt.fragment
+			}
+			tmpDir := createTestProject(t, files)
+
+			g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+			_, err := g.Generate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
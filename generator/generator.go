@@ -1,10 +1,17 @@
 package generator
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"maps"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kausys/openapi/cache"
 	"github.com/kausys/openapi/scanner"
@@ -13,17 +20,56 @@ import (
 )
 
 // Generator orchestrates the OpenAPI spec generation process.
+//
+// A Generator is safe for concurrent use: every call to Generate, GenerateMulti,
+// GenerateSpec (and their Context variants), WriteSpec, GetSpecNames, and Lint
+// scans and assembles in its own run, and only the configuration and cache are
+// shared. Concurrent calls writing the same output file still race on the
+// filesystem.
 type Generator struct {
 	config  *Config
 	cache   *cache.Manager
 	scanner *scanner.Scanner
 
+	// mu guards the cache and the warnings published by runs; shared by all runs of a generator
+	mu *sync.Mutex
+
 	// referencedSchemas tracks which schemas are actually used in the spec
 	referencedSchemas map[string]bool
 
+	// usedSchemas collects the referenced schemas of every spec assembled by the run
+	usedSchemas map[string]bool
+
+	// inlinedEnums collects the enums inlined by the run, by the model whose schema inlines
+	// them ("" for routes), so that they are used with the model (see enumUsed)
+	inlinedEnums map[string]map[string]bool
+
+	// convertingModel is the model whose schema is being converted, the owner of the enums
+	// it inlines
+	convertingModel string
+
 	// structsByNameAndSpec indexes structs by model name → spec name → *StructInfo.
 	// The empty string key "" represents the general model (no spec: directive).
 	structsByNameAndSpec map[string]map[string]*scanner.StructInfo
+
+	// versionedSpecs maps the specs of versioned mode to the spec and API version they are
+	// generated from (filled by collectSpecNames)
+	versionedSpecs map[string]versionedSpec
+
+	// structsBySpecPattern indexes structs whose spec: directive uses * or !name by model name.
+	structsBySpecPattern map[string][]*scanner.StructInfo
+
+	// activeMeta is the meta applied to the spec being assembled (source of default content types)
+	activeMeta *scanner.MetaInfo
+
+	// warnings collects non-fatal problems found while scanning and assembling specs
+	warnings []Warning
+
+	// now returns the current time (overridable in tests for date-based lint rules)
+	now func() time.Time
+
+	// ir holds the scan results the runs load instead of scanning (see NewFromIR)
+	ir []byte
 }
 
 // New creates a new Generator with the given options.
@@ -33,39 +79,128 @@ func New(opts ...Option) *Generator {
 		opt(cfg)
 	}
 
-	scannerOpts := []scanner.Option{
+	return &Generator{
+		config:            cfg,
+		cache:             cache.NewManager(cfg.Dir),
+		scanner:           newScanner(cfg),
+		mu:                &sync.Mutex{},
+		referencedSchemas: make(map[string]bool),
+		now:               time.Now,
+	}
+}
+
+// NewFromIR creates a Generator running on scan results written by scanner.ExportIR
+// instead of scanning the source files, so a pipeline can scan once and generate, lint,
+// and check several times. The options selecting the files to scan have no effect, and
+// the cache is not used.
+func NewFromIR(r io.Reader, opts ...Option) (*Generator, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read IR: %w", err)
+	}
+	// Report a malformed IR now rather than on every run
+	if err := scanner.New().ImportIR(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	g := New(opts...)
+	g.ir = data
+	return g, nil
+}
+
+// newScanner creates a scanner for the configured directory and patterns, with extra
+// scanner options.
+func newScanner(cfg *Config, opts ...scanner.Option) *scanner.Scanner {
+	return scanner.New(append([]scanner.Option{
 		scanner.WithDir(cfg.Dir),
 		scanner.WithPattern(cfg.Pattern),
 		scanner.WithIgnorePaths(cfg.IgnorePaths...),
-	}
+		scanner.WithOnlyPaths(cfg.OnlyPaths...),
+		scanner.WithBuildTags(cfg.BuildTags...),
+		scanner.WithIncludeTests(cfg.IncludeTests),
+		scanner.WithWorkspace(cfg.Workspace),
+		scanner.WithModulePatterns(cfg.ModulePatterns),
+		scanner.WithSchemaNaming(cfg.SchemaNaming),
+		scanner.WithResolveExternal(cfg.ResolveExternal),
+		scanner.WithKnownTypes(customTypeNames()...),
+		scanner.WithRegistrations(cfg.CheckRoutes),
+	}, opts...)...)
+}
 
+// newRun returns a generator for a single generation call. The run shares the
+// configuration and cache of g but owns its scanner and assembly state.
+func (g *Generator) newRun() *Generator {
 	return &Generator{
-		config:            cfg,
-		cache:             cache.NewManager(cfg.Dir),
-		scanner:           scanner.New(scannerOpts...),
+		config:            g.config,
+		cache:             g.cache,
+		scanner:           newScanner(g.config),
+		mu:                g.mu,
 		referencedSchemas: make(map[string]bool),
+		now:               g.now,
+		ir:                g.ir,
 	}
 }
 
+// finishRun publishes the warnings of a finished run, so Warnings reports the latest call.
+func (g *Generator) finishRun(run *Generator) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.warnings = run.warnings
+}
+
 // prepare initializes cache, scans source files, and caches scanned data.
-func (g *Generator) prepare() error {
-	if g.config.UseCache {
-		if err := g.cache.Init(); err != nil {
-			return fmt.Errorf("failed to initialize cache: %w", err)
+func (g *Generator) prepare(ctx context.Context) error {
+	if g.config.Profile != "" && GetProfile(g.config.Profile) == nil {
+		return fmt.Errorf("unknown profile %q", g.config.Profile)
+	}
+	if err := g.checkFieldPolicies(); err != nil {
+		return err
+	}
+	switch g.config.DurationFormat {
+	case "", DurationString, DurationInt64:
+	default:
+		return fmt.Errorf("unknown duration format %q, expected %s or %s", g.config.DurationFormat, DurationString, DurationInt64)
+	}
+	if g.config.SchemaNaming != "" && !slices.Contains(scanner.SchemaNamings, g.config.SchemaNaming) {
+		return fmt.Errorf("unknown schema naming %q, expected one of %v", g.config.SchemaNaming, scanner.SchemaNamings)
+	}
+	if g.config.PathOrder != "" && !slices.Contains(PathOrders, g.config.PathOrder) {
+		return fmt.Errorf("unknown path order %q, expected one of %v", g.config.PathOrder, PathOrders)
+	}
+	for _, lang := range g.config.CodeSamples {
+		if !slices.Contains(CodeSampleLanguages, lang) {
+			return fmt.Errorf("unknown code sample language %q, expected one of %v", lang, CodeSampleLanguages)
 		}
-		if err := g.cache.Load(); err != nil {
-			return fmt.Errorf("failed to load cache: %w", err)
+	}
+	if _, err := g.outputTargets(); err != nil {
+		return err
+	}
+	g.warnings = nil
+
+	useCache := g.config.UseCache && g.ir == nil
+	if useCache {
+		if err := g.loadCache(); err != nil {
+			return err
 		}
 	}
 
-	if err := g.scanner.Scan(); err != nil {
+	if g.ir != nil {
+		if err := g.scanner.ImportIR(bytes.NewReader(g.ir)); err != nil {
+			return err
+		}
+	} else if err := g.scanner.ScanContext(ctx); err != nil {
 		return fmt.Errorf("failed to scan source files: %w", err)
 	}
 
 	// Build secondary index for multi-spec model lookups
 	g.buildStructIndex()
 
-	if g.config.UseCache {
+	// Report directives skipped by the scanner or referring to nothing
+	g.warnings = append(g.warnings, g.scanner.Warnings...)
+	g.checkScannedData()
+
+	if useCache {
 		if err := g.cacheScannedData(); err != nil {
 			return fmt.Errorf("failed to cache scanned data: %w", err)
 		}
@@ -74,16 +209,59 @@ func (g *Generator) prepare() error {
 	return nil
 }
 
+// loadCache initializes the cache directory and loads the cache index.
+func (g *Generator) loadCache() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.cache.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+	if err := g.cache.Load(); err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+	return nil
+}
+
 // Generate runs the full generation pipeline.
 func (g *Generator) Generate() (*spec.OpenAPI, error) {
-	if err := g.prepare(); err != nil {
-		return nil, err
+	return g.GenerateContext(context.Background())
+}
+
+// GenerateContext is like Generate but stops scanning when ctx is done.
+func (g *Generator) GenerateContext(ctx context.Context) (*spec.OpenAPI, error) {
+	run := g.newRun()
+	defer g.finishRun(run)
+
+	return run.generate(ctx)
+}
+
+// WriteSpec generates the spec and encodes it to w in the given format.
+// The configured output file is not written, so specs can be streamed to
+// HTTP responses or buffers without touching the filesystem.
+func (g *Generator) WriteSpec(ctx context.Context, w io.Writer, format Format) error {
+	run := g.newRun()
+	defer g.finishRun(run)
+
+	openAPI, err := run.build(ctx)
+	if err != nil {
+		return err
 	}
 
-	// Phase 4: Assemble OpenAPI spec
-	openAPI, err := g.assemble()
+	data, err := encodeSpec(openAPI, format, g.config.PortableYAML)
 	if err != nil {
-		return nil, fmt.Errorf("failed to assemble spec: %w", err)
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// generate runs the full generation pipeline within a run.
+func (g *Generator) generate(ctx context.Context) (*spec.OpenAPI, error) {
+	openAPI, err := g.build(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Phase 5: Write output
@@ -92,6 +270,39 @@ func (g *Generator) Generate() (*spec.OpenAPI, error) {
 			return nil, fmt.Errorf("failed to write output: %w", err)
 		}
 	}
+	if g.config.AsyncAPIOutput != "" {
+		if err := g.writeAsyncAPI(); err != nil {
+			return nil, fmt.Errorf("failed to write AsyncAPI document: %w", err)
+		}
+	}
+
+	return openAPI, nil
+}
+
+// build scans the source files and assembles the spec without writing output.
+func (g *Generator) build(ctx context.Context) (*spec.OpenAPI, error) {
+	if err := g.prepare(ctx); err != nil {
+		return nil, err
+	}
+
+	// Phase 4: Assemble OpenAPI spec
+	openAPI, err := g.assemble()
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble spec: %w", err)
+	}
+	g.reportUnusedSchemas(map[string]*spec.OpenAPI{"": openAPI})
+
+	if err := g.applyOverlays(openAPI); err != nil {
+		return nil, err
+	}
+
+	if err := g.postProcess(ctx, "", openAPI); err != nil {
+		return nil, err
+	}
+
+	if err := g.checkStrict(); err != nil {
+		return nil, err
+	}
 
 	return openAPI, nil
 }
@@ -128,6 +339,9 @@ func (g *Generator) cacheScannedData() error {
 		allFiles[f] = true
 	}
 
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	for filePath := range allFiles {
 		schemas := fileSchemas[filePath]
 		routes := fileRoutes[filePath]
@@ -142,7 +356,11 @@ func (g *Generator) cacheScannedData() error {
 
 // toRelativePath converts an absolute path to a path relative to the config directory.
 func (g *Generator) toRelativePath(absPath string) string {
-	relPath, err := filepath.Rel(g.config.Dir, absPath)
+	dir, err := filepath.Abs(g.config.Dir)
+	if err != nil {
+		return absPath
+	}
+	relPath, err := filepath.Rel(dir, absPath)
 	if err != nil {
 		return absPath // fallback to absolute if conversion fails
 	}
@@ -155,7 +373,7 @@ func (g *Generator) assemble() (*spec.OpenAPI, error) {
 	g.referencedSchemas = make(map[string]bool)
 
 	openAPI := &spec.OpenAPI{
-		OpenAPI: "3.1.2",
+		OpenAPI: openAPIVersion,
 		Paths: &spec.Paths{
 			PathItems: make(map[string]*spec.PathItem),
 		},
@@ -170,7 +388,7 @@ func (g *Generator) assemble() (*spec.OpenAPI, error) {
 
 	// Add schemas (all models first)
 	for name, structInfo := range g.scanner.Structs {
-		if structInfo.IsModel {
+		if structInfo.IsModel && g.modelIncluded(structInfo) {
 			openAPI.Components.Schemas[name] = g.structToSchema(structInfo)
 		}
 	}
@@ -181,18 +399,55 @@ func (g *Generator) assemble() (*spec.OpenAPI, error) {
 	}
 
 	// Add paths (this will mark schemas as referenced)
+	var routes []*scanner.RouteInfo
 	for _, routeInfo := range g.scanner.Routes {
-		g.addRoute(openAPI, routeInfo)
+		if g.routeIncluded(routeInfo) {
+			routes = append(routes, routeInfo)
+		}
+	}
+	if err := g.checkRouteCollisions(routes, ""); err != nil {
+		return nil, err
+	}
+	for _, routeInfo := range routes {
+		g.addRoute(openAPI, routeInfo, metaEnvelope(g.scanner.Meta))
+	}
+
+	// Load hand-written fragments (swagger:include), marking the models they reference
+	fragments, err := g.loadFragments(g.fragmentMetas(nil), routes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add filtered-out models still referenced by the included routes
+	g.addReferencedModels(openAPI.Components)
+
+	if err := mergeFragments(openAPI, fragments); err != nil {
+		return nil, err
+	}
+
+	// Declare tags used by routes but missing from meta
+	g.addRouteTags(openAPI)
+
+	// Recursively mark schemas referenced by other referenced schemas
+	if g.config.CleanUnused || g.config.ReportUnused {
+		g.markNestedReferences(openAPI.Components)
+		g.recordUsedSchemas()
 	}
 
 	// Clean unused schemas if enabled
 	if g.config.CleanUnused {
-		// First, recursively mark schemas referenced by other referenced schemas
-		g.markNestedReferences(openAPI.Components)
-		// Then clean unused schemas
 		g.cleanUnusedSchemas(openAPI.Components)
 	}
 
+	// Add the canonical Problem schema of the error responses
+	g.addProblemSchema(openAPI)
+
+	// Derive request/response variants of models with readOnly/writeOnly fields
+	g.splitReadWriteModels(openAPI)
+
+	// Decorate with gateway profile extensions
+	g.applyProfile(openAPI)
+
 	return openAPI, nil
 }
 
@@ -292,26 +547,91 @@ func (g *Generator) cleanUnusedSchemas(components *spec.Components) {
 	}
 }
 
-// writeOutput writes the spec to the output file.
+// writeOutput writes the spec to the output file and the additional outputs.
 func (g *Generator) writeOutput(openAPI *spec.OpenAPI) error {
-	dir := filepath.Dir(g.config.OutputFile)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	targets, err := g.outputTargets()
+	if err != nil {
+		return err
+	}
+	translations, err := g.loadTranslations()
+	if err != nil {
 		return err
 	}
 
-	var data []byte
-	var err error
+	files := make(map[string][]byte, len(targets)*(len(translations)+1))
+	for _, target := range targets {
+		data, err := g.marshalSpecAs(openAPI, target.format)
+		if err != nil {
+			return err
+		}
+		files[target.file] = data
 
-	switch g.config.OutputFormat {
-	case "json":
-		data, err = json.MarshalIndent(openAPI, "", "  ")
-	default:
-		data, err = yaml.Marshal(openAPI)
+		localized, err := g.localizedOutputs(target.file, target.format, openAPI, translations)
+		if err != nil {
+			return err
+		}
+		maps.Copy(files, localized)
 	}
 
-	if err != nil {
-		return err
+	name := strings.TrimSuffix(filepath.Base(g.config.OutputFile), filepath.Ext(g.config.OutputFile))
+	if len(g.config.Templates) > 0 {
+		templates, err := g.loadTemplates()
+		if err != nil {
+			return err
+		}
+		rendered, err := templateOutputs(templates, name, openAPI, false)
+		if err != nil {
+			return err
+		}
+		maps.Copy(files, rendered)
+	}
+
+	if g.config.Backstage.Output != "" {
+		data, err := g.backstageCatalog(map[string]*spec.OpenAPI{name: openAPI}, map[string]string{name: g.config.OutputFile}, files, false)
+		if err != nil {
+			return fmt.Errorf("failed to write Backstage catalog: %w", err)
+		}
+		files[g.config.Backstage.Output] = data
+	}
+
+	if g.config.EmbedOutput != "" {
+		data, err := g.embedFile(map[string]string{name: g.config.OutputFile}, files)
+		if err != nil {
+			return fmt.Errorf("failed to write embed output: %w", err)
+		}
+		files[g.config.EmbedOutput] = data
+	}
+
+	return g.emitOutput(files)
+}
+
+// marshalSpec encodes a spec in the configured output format.
+func (g *Generator) marshalSpec(openAPI *spec.OpenAPI) ([]byte, error) {
+	return g.marshalSpecAs(openAPI, Format(g.config.OutputFormat))
+}
+
+// marshalSpecAs encodes a spec as JSON, or as YAML for any other format.
+func (g *Generator) marshalSpecAs(openAPI *spec.OpenAPI, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return encodeSpec(openAPI, FormatJSON, false)
+	default:
+		return encodeSpec(openAPI, FormatYAML, g.config.PortableYAML)
 	}
+}
 
-	return os.WriteFile(g.config.OutputFile, data, 0644)
+// encodeSpec encodes a spec as YAML or JSON. Portable YAML is written without anchors
+// and with the document fields in the order of the specification.
+func encodeSpec(openAPI *spec.OpenAPI, format Format, portable bool) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(openAPI, "", "  ")
+	case FormatYAML:
+		if portable {
+			return spec.MarshalPortableYAML(openAPI)
+		}
+		return yaml.Marshal(openAPI)
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", format)
+	}
 }
@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kausys/openapi/spec"
+)
+
+// Formats of the schema reference graph (see SchemaGraph.Write).
+const (
+	// GraphFormatDOT writes a Graphviz digraph
+	GraphFormatDOT = "dot"
+	// GraphFormatMermaid writes a Mermaid flowchart
+	GraphFormatMermaid = "mermaid"
+)
+
+// GraphFormats are the formats of the schema reference graph.
+var GraphFormats = []string{GraphFormatDOT, GraphFormatMermaid}
+
+// SchemaGraph is the reference graph of the component schemas of a spec: an edge goes from
+// a schema to each schema it references, and from an operation to each schema its
+// parameters, request body, or responses reference.
+type SchemaGraph struct {
+	// Schemas are the component schema names, sorted
+	Schemas []string
+	// Operations are the operations referencing schemas (METHOD /path), in path order
+	Operations []string
+	// Edges are the references, sorted
+	Edges []GraphEdge
+}
+
+// GraphEdge is a reference of a schema or an operation to a schema.
+type GraphEdge struct {
+	From string
+	To   string
+}
+
+// NewSchemaGraph returns the schema reference graph of a spec, with the operations using
+// the schemas unless operations is false.
+func NewSchemaGraph(openAPI *spec.OpenAPI, operations bool) *SchemaGraph {
+	graph := &SchemaGraph{}
+	edges := make(map[GraphEdge]bool)
+
+	if openAPI.Components != nil {
+		graph.Schemas = slices.Sorted(maps.Keys(openAPI.Components.Schemas))
+		for _, name := range graph.Schemas {
+			for _, ref := range schemaRefNames(openAPI.Components.Schemas[name]) {
+				edges[GraphEdge{From: name, To: ref}] = true
+			}
+		}
+	}
+
+	if operations {
+		for _, op := range templateOperations(openAPI) {
+			refs := operationSchemaRefs(openAPI, openAPI.Paths.PathItems[op.Path], op.Operation)
+			if len(refs) == 0 {
+				continue
+			}
+			node := op.Method + " " + op.Path
+			graph.Operations = append(graph.Operations, node)
+			for _, ref := range refs {
+				edges[GraphEdge{From: node, To: ref}] = true
+			}
+		}
+	}
+
+	graph.Edges = slices.SortedFunc(maps.Keys(edges), func(a, b GraphEdge) int {
+		return strings.Compare(a.From+"\x00"+a.To, b.From+"\x00"+b.To)
+	})
+	return graph
+}
+
+// operationSchemaRefs returns the names of the component schemas referenced by the
+// parameters, request body, and responses of an operation.
+func operationSchemaRefs(openAPI *spec.OpenAPI, item *spec.PathItem, op *spec.Operation) []string {
+	var refs []string
+	visit := func(schema *spec.Schema) {
+		refs = append(refs, schemaRefNames(schema)...)
+	}
+
+	for _, param := range operationParameters(openAPI, item, op) {
+		visitParameter(param, visit)
+	}
+	if op.RequestBody != nil {
+		visitContent(op.RequestBody.Content, visit)
+	}
+	if op.Responses != nil {
+		for _, resp := range slices.Concat(slices.Collect(maps.Values(op.Responses.StatusCodes)), []*spec.Response{op.Responses.Default}) {
+			if resp == nil {
+				continue
+			}
+			visitContent(resp.Content, visit)
+			for _, header := range resp.Headers {
+				visitHeader(header, visit)
+			}
+		}
+	}
+
+	slices.Sort(refs)
+	return slices.Compact(refs)
+}
+
+// Focus returns the part of the graph connected to a schema: the schemas it references,
+// directly or not, and the schemas and operations referencing it.
+func (g *SchemaGraph) Focus(schema string) (*SchemaGraph, error) {
+	if !slices.Contains(g.Schemas, schema) {
+		return nil, fmt.Errorf("unknown schema %q", schema)
+	}
+
+	keep := map[string]bool{schema: true}
+	for _, forward := range []bool{true, false} {
+		queue := []string{schema}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			for _, edge := range g.Edges {
+				from, to := edge.From, edge.To
+				if !forward {
+					from, to = to, from
+				}
+				if from == node && !keep[to] {
+					keep[to] = true
+					queue = append(queue, to)
+				}
+			}
+		}
+	}
+
+	focused := &SchemaGraph{}
+	for _, name := range g.Schemas {
+		if keep[name] {
+			focused.Schemas = append(focused.Schemas, name)
+		}
+	}
+	for _, op := range g.Operations {
+		if keep[op] {
+			focused.Operations = append(focused.Operations, op)
+		}
+	}
+	for _, edge := range g.Edges {
+		if keep[edge.From] && keep[edge.To] {
+			focused.Edges = append(focused.Edges, edge)
+		}
+	}
+	return focused, nil
+}
+
+// Write writes the graph in a format of GraphFormats. Schemas are boxes and operations
+// rounded nodes.
+func (g *SchemaGraph) Write(w io.Writer, format string) error {
+	var b strings.Builder
+	switch format {
+	case GraphFormatDOT:
+		b.WriteString("digraph schemas {\n\trankdir=LR;\n\tnode [shape=box];\n")
+		for _, name := range g.Schemas {
+			fmt.Fprintf(&b, "\t%s;\n", strconv.Quote(name))
+		}
+		for _, op := range g.Operations {
+			fmt.Fprintf(&b, "\t%s [shape=ellipse];\n", strconv.Quote(op))
+		}
+		for _, edge := range g.Edges {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", strconv.Quote(edge.From), strconv.Quote(edge.To))
+		}
+		b.WriteString("}\n")
+	case GraphFormatMermaid:
+		// Mermaid node IDs can't hold the spaces and slashes of operations: nodes are
+		// numbered and labeled instead
+		ids := make(map[string]string, len(g.Schemas)+len(g.Operations))
+		b.WriteString("flowchart LR\n")
+		for i, name := range g.Schemas {
+			ids[name] = "s" + strconv.Itoa(i)
+			fmt.Fprintf(&b, "\t%s[%s]\n", ids[name], mermaidLabel(name))
+		}
+		for i, op := range g.Operations {
+			ids[op] = "o" + strconv.Itoa(i)
+			fmt.Fprintf(&b, "\t%s([%s])\n", ids[op], mermaidLabel(op))
+		}
+		for _, edge := range g.Edges {
+			if ids[edge.From] == "" || ids[edge.To] == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "\t%s --> %s\n", ids[edge.From], ids[edge.To])
+		}
+	default:
+		return fmt.Errorf("unknown graph format %q, expected %s", format, strings.Join(GraphFormats, " or "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidLabel returns a quoted Mermaid node label.
+func mermaidLabel(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "#quot;") + `"`
+}
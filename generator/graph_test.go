@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaGraph(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// swagger:meta
+// Title: Users API
+// Version: 1.0.0
+package api
+`,
+		"api/users.go": `package api
+
+// swagger:model Address
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// swagger:model User
+type User struct {
+	ID      string  ` + "`json:\"id\"`" + `
+	Address Address ` + "`json:\"address\"`" + `
+}
+
+// swagger:model Team
+type Team struct {
+	Members []User ` + "`json:\"members\"`" + `
+}
+
+// swagger:model Error
+type Error struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+// - 500: Error
+func ListUsers() {}
+
+// swagger:route GET /health health health
+// Responses:
+// - 200: description:OK
+func Health() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithCleanUnused(false))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	graph := NewSchemaGraph(openAPI, true)
+	assert.Equal(t, []string{"Address", "Error", "Team", "User"}, graph.Schemas)
+	assert.Equal(t, []string{"GET /users"}, graph.Operations)
+	assert.Equal(t, []GraphEdge{
+		{From: "GET /users", To: "Error"},
+		{From: "GET /users", To: "User"},
+		{From: "Team", To: "User"},
+		{From: "User", To: "Address"},
+	}, graph.Edges)
+
+	var dot bytes.Buffer
+	require.NoError(t, graph.Write(&dot, GraphFormatDOT))
+	assert.Equal(t, `digraph schemas {
+	rankdir=LR;
+	node [shape=box];
+	"Address";
+	"Error";
+	"Team";
+	"User";
+	"GET /users" [shape=ellipse];
+	"GET /users" -> "Error";
+	"GET /users" -> "User";
+	"Team" -> "User";
+	"User" -> "Address";
+}
+`, dot.String())
+
+	// The focus keeps the schemas User references and the schemas referencing it
+	focused, err := NewSchemaGraph(openAPI, false).Focus("User")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Address", "Team", "User"}, focused.Schemas)
+
+	var mermaid bytes.Buffer
+	require.NoError(t, focused.Write(&mermaid, GraphFormatMermaid))
+	assert.Equal(t, `flowchart LR
+	s0["Address"]
+	s1["Team"]
+	s2["User"]
+	s1 --> s2
+	s2 --> s0
+`, mermaid.String())
+
+	_, err = graph.Focus("Missing")
+	assert.ErrorContains(t, err, `unknown schema "Missing"`)
+	assert.ErrorContains(t, graph.Write(&bytes.Buffer{}, "svg"), `unknown graph format "svg"`)
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRouteGroups tests the attributes shared by the routes of a file or package (swagger:group)
+func TestRouteGroups(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// Package api serves the API.
+//
+// swagger:group
+// PathPrefix: /api/
+// Tags: api
+// Responses:
+// - 500: Error description:Internal error
+package api
+
+// swagger:model Error
+type Error struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+`,
+		"api/admin.go": `package api
+
+// AdminRouter serves the admin routes.
+//
+// swagger:group
+// PathPrefix: /admin
+// Tags: admin
+// Security:
+// - bearer
+// Responses:
+// - 401: Error description:Unauthorized
+// - 500: Error description:Admin error
+func AdminRouter() {}
+
+// swagger:route GET /users admin-users listAdminUsers
+// Responses:
+// - 200: description:OK
+// - 401: description:Login required
+func ListAdminUsers() {}
+
+// swagger:route GET /keys admin listKeys
+// Security: none
+// Responses:
+// - 200: description:OK
+func ListKeys() {}
+`,
+		"api/public.go": `package api
+
+// swagger:route GET /health health getHealth
+// Responses:
+// - 200: description:OK
+func GetHealth() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	users := openAPI.Paths.PathItems["/api/admin/users"]
+	require.NotNil(t, users)
+	op := users.Get
+	assert.Equal(t, []string{"api", "admin", "admin-users"}, op.Tags)
+	require.Len(t, op.Security, 1)
+	assert.Equal(t, map[string][]string{"bearer": {}}, op.Security[0].Requirements)
+	assert.Equal(t, "Login required", op.Responses.StatusCodes["401"].Description)
+	assert.Equal(t, "Admin error", op.Responses.StatusCodes["500"].Description)
+
+	keys := openAPI.Paths.PathItems["/api/admin/keys"].Get
+	assert.Equal(t, []string{"api", "admin"}, keys.Tags)
+	require.NotNil(t, keys.Security)
+	assert.Empty(t, keys.Security)
+
+	health := openAPI.Paths.PathItems["/api/health"].Get
+	require.NotNil(t, health)
+	assert.Equal(t, []string{"api", "health"}, health.Tags)
+	assert.Nil(t, health.Security)
+	assert.NotContains(t, health.Responses.StatusCodes, "401")
+	require.Contains(t, health.Responses.StatusCodes, "500")
+	assert.Equal(t, "#/components/schemas/Error", health.Responses.StatusCodes["500"].Content["application/json"].Schema.Ref)
+
+	assert.NotContains(t, openAPI.Paths.PathItems, "/users")
+	assert.Empty(t, g.Warnings())
+}
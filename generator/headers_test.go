@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHeaderParameters tests swagger:headers structs and reserved header exclusion
+func TestHeaderParameters(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// TracingHeaders are sent with every user request.
+//
+// swagger:headers getUser listUsers
+type TracingHeaders struct {
+	// Request correlation ID
+	// required: true
+	RequestID string ` + "`json:\"X-Request-ID\"`" + `
+
+	// Ignored by OpenAPI, declared via security schemes
+	Authorization string ` + "`json:\"Authorization\"`" + `
+
+	// Explicit location wins over the header default
+	// in: query
+	Trace bool ` + "`json:\"trace\"`" + `
+}
+
+// swagger:parameters getUser
+type GetUserParams struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: description:OK
+func GetUser() {}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	findParam := func(params []*spec.Parameter, name string) *spec.Parameter {
+		for _, p := range params {
+			if p.Name == name {
+				return p
+			}
+		}
+		return nil
+	}
+
+	t.Run("merged with parameters struct", func(t *testing.T) {
+		params := openAPI.Paths.PathItems["/users/{id}"].Get.Parameters
+		require.Len(t, params, 3)
+		assert.Equal(t, "path", findParam(params, "id").In)
+
+		requestID := findParam(params, "X-Request-ID")
+		require.NotNil(t, requestID)
+		assert.Equal(t, "header", requestID.In)
+		assert.True(t, requestID.Required)
+		assert.Equal(t, "query", findParam(params, "trace").In)
+	})
+
+	t.Run("header struct only", func(t *testing.T) {
+		params := openAPI.Paths.PathItems["/users"].Get.Parameters
+		require.Len(t, params, 2)
+		assert.Equal(t, "header", findParam(params, "X-Request-ID").In)
+		assert.Nil(t, findParam(params, "Authorization"))
+	})
+
+	t.Run("reserved header warning", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{
+			"operation getUser: header parameter Authorization is ignored, it is described by a security scheme",
+			"operation listUsers: header parameter Authorization is ignored, it is described by a security scheme",
+		}, warningMessages(g.Warnings()))
+	})
+}
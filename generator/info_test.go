@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateInfo tests the summary, logo, and contact extensions of the info object
+func TestGenerateInfo(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: Shop API
+// Summary: Orders and payments of the shop
+// Version: 1.0.0
+//
+// Contact:
+// - name: API Support
+// - email: support@example.com
+// - x-slack: #shop-api
+//
+// License:
+// - name: Apache 2.0
+// - identifier: Apache-2.0
+//
+// Logo:
+// - url: https://example.com/logo.png
+// - backgroundColor: #FFFFFF
+// - altText: Shop logo
+//
+// x-audience: partners
+package api
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	info := openAPI.Info
+	assert.Equal(t, "Orders and payments of the shop", info.Summary)
+	assert.Equal(t, "partners", info.Extensions["x-audience"])
+	assert.Equal(t, map[string]any{
+		"url":             "https://example.com/logo.png",
+		"backgroundColor": "#FFFFFF",
+		"altText":         "Shop logo",
+	}, info.Extensions["x-logo"])
+
+	require.NotNil(t, info.Contact)
+	assert.Equal(t, "support@example.com", info.Contact.Email)
+	assert.Equal(t, "#shop-api", info.Contact.Extensions["x-slack"])
+
+	require.NotNil(t, info.License)
+	assert.Equal(t, "Apache-2.0", info.License.Identifier)
+	assert.Empty(t, info.License.URL)
+	assert.Empty(t, g.Warnings())
+}
+
+// TestGenerateLicenseIdentifierAndURL tests the license identifier excluding the url
+func TestGenerateLicenseIdentifierAndURL(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: Shop API
+// Version: 1.0.0
+// License:
+// - name: MIT
+// - identifier: MIT
+// - url: https://opensource.org/licenses/MIT
+package api
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	assert.Equal(t, "MIT", openAPI.Info.License.Identifier)
+	assert.Empty(t, openAPI.Info.License.URL)
+	require.Len(t, g.Warnings(), 1)
+	assert.Equal(t, WarnInvalidLicense, g.Warnings()[0].Code)
+	assert.Equal(t, "doc.go:5: License: identifier MIT and url https://opensource.org/licenses/MIT are mutually exclusive; url ignored",
+		g.Warnings()[0].Message)
+}
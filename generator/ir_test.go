@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromIR(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// Package api is the users API.
+//
+// swagger:meta
+//
+// Title: Users API
+// Version: 1.0.0
+package api
+`,
+		"api/models.go": `package api
+
+// UserID identifies a user.
+type UserID int64
+
+// swagger:enum
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleUser  Role = "user"
+)
+
+// swagger:model
+type User struct {
+	ID   UserID ` + "`json:\"id\"`" + `
+	Role Role   ` + "`json:\"role\"`" + `
+}
+`,
+		"api/routes.go": `package api
+
+// swagger:route GET /users users listUsers
+//
+// summary: List the users
+//
+// Responses:
+// - 200: User
+func ListUsers() {}
+
+// swagger:route GET /roles
+func ListRoles() {}
+`,
+	})
+
+	options := []Option{WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", "")}
+	scanned, err := New(options...).Generate()
+	require.NoError(t, err)
+
+	s := scanner.New(scanner.WithDir(tmpDir), scanner.WithPattern("./..."))
+	require.NoError(t, s.Scan())
+	var ir bytes.Buffer
+	require.NoError(t, s.ExportIR(&ir))
+
+	// The generator doesn't read the sources again
+	require.NoError(t, os.RemoveAll(tmpDir))
+
+	gen, err := NewFromIR(&ir, options...)
+	require.NoError(t, err)
+	loaded, err := gen.Generate()
+	require.NoError(t, err)
+
+	assert.True(t, loaded.Equal(scanned))
+	assert.Equal(t, "integer", loaded.Components.Schemas["User"].Properties["id"].Type.Value())
+
+	issues, err := gen.Lint()
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, WarnMissingOperationID, issues[0].Rule)
+}
+
+func TestNewFromIRInvalid(t *testing.T) {
+	_, err := NewFromIR(strings.NewReader(`{"version": 99}`))
+	assert.ErrorContains(t, err, "unsupported IR version 99")
+
+	_, err = NewFromIR(strings.NewReader(`{`))
+	assert.ErrorContains(t, err, "failed to decode IR")
+}
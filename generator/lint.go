@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kausys/openapi/scanner"
+)
+
+// Lint rule identifiers.
+const (
+	RuleSunsetPassed        = "sunset-passed"
+	RuleUnknownReplacement  = "unknown-replacement"
+	RuleUndeclaredPathParam = "undeclared-path-param"
+	RuleUnknownPathParam    = "unknown-path-param"
+	RuleMissingDescription  = "missing-description"
+	RuleUnregisteredRoute   = "unregistered-route"
+	RuleUndocumentedRoute   = "undocumented-route"
+)
+
+// Lint severities.
+const (
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// LintIssue is a problem found in the scanned API description. The JSON encoding is
+// the machine-readable findings format of openapi lint --format json.
+type LintIssue struct {
+	Rule        string `json:"rule"`                  // Rule identifier (e.g., "sunset-passed")
+	Severity    string `json:"severity"`              // "warning" or "error"
+	Message     string `json:"message"`               // Human-readable message
+	OperationID string `json:"operationId,omitempty"` // Operation the issue refers to, if any
+	SourceFile  string `json:"file,omitempty"`        // Source file the issue refers to, if any
+	Line        int    `json:"line,omitempty"`        // Line of the directive the issue refers to, if known
+	Column      int    `json:"column,omitempty"`      // Column of the directive the issue refers to, if known
+}
+
+// Location returns the source location of the issue as file:line, or the file alone
+// when the line is unknown.
+func (i LintIssue) Location() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d", i.SourceFile, i.Line)
+	}
+	return i.SourceFile
+}
+
+// String returns the issue formatted as "severity: message [rule]".
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s [%s]", i.Severity, i.Message, i.Rule)
+}
+
+// Lint scans the source files and checks them against the lint rules.
+// Issues are sorted by source file, operation ID, and line.
+func (g *Generator) Lint() ([]LintIssue, error) {
+	run := g.newRun()
+	defer g.finishRun(run)
+
+	return run.lint(context.Background())
+}
+
+// lint checks the source files against the lint rules within a run.
+func (g *Generator) lint(ctx context.Context) ([]LintIssue, error) {
+	if err := g.prepare(ctx); err != nil {
+		return nil, err
+	}
+
+	var issues []LintIssue
+	issues = append(issues, g.lintDirectives()...)
+	issues = append(issues, g.lintDeprecations()...)
+	issues = append(issues, g.lintPathParameters()...)
+	if g.config.RequireDescriptions {
+		issues = append(issues, g.lintDescriptions()...)
+	}
+	if g.config.CheckRoutes {
+		routeIssues, err := g.lintRegistrations()
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, routeIssues...)
+	}
+
+	slices.SortStableFunc(issues, func(a, b LintIssue) int {
+		if c := strings.Compare(a.SourceFile, b.SourceFile); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.OperationID, b.OperationID); c != 0 {
+			return c
+		}
+		return a.Line - b.Line
+	})
+
+	return issues, nil
+}
+
+// directiveErrors are the codes of scanner warnings about malformed directives, which
+// the scan skips and lint reports as errors.
+var directiveErrors = []string{
+	WarnMissingOperationID, WarnInvalidRoute, WarnInvalidPath, WarnInvalidHeaders, WarnInvalidResponse, WarnInvalidChannel,
+	WarnInvalidEnvelope, WarnInvalidExample, WarnInvalidLicense, WarnInvalidCodeSample, WarnInvalidRateLimit,
+	WarnInvalidPagination,
+}
+
+// directiveWarnings are the codes of scanner warnings about directives kept by the scan,
+// which lint reports as warnings.
+var directiveWarnings = []string{WarnCustomMethod}
+
+// lintDirectives reports the malformed directives skipped by the scan, and the suspicious
+// ones it keeps.
+func (g *Generator) lintDirectives() []LintIssue {
+	var issues []LintIssue
+	for _, w := range g.scanner.Warnings {
+		severity := SeverityError
+		switch {
+		case slices.Contains(directiveWarnings, w.Code):
+			severity = SeverityWarning
+		case !slices.Contains(directiveErrors, w.Code):
+			continue
+		}
+		message := strings.TrimPrefix(w.Message, g.location(w.Pos)+": ")
+		issues = append(issues, LintIssue{
+			Rule:       w.Code,
+			Severity:   severity,
+			Message:    message,
+			SourceFile: w.Pos.Filename,
+			Line:       w.Pos.Line,
+			Column:     w.Pos.Column,
+		})
+	}
+	return issues
+}
+
+// lintDeprecations warns about deprecated routes whose sunset date has passed
+// or whose replacement operation does not exist.
+func (g *Generator) lintDeprecations() []LintIssue {
+	var issues []LintIssue
+	today := g.now().Format(time.DateOnly)
+
+	for opID, route := range g.scanner.Routes {
+		if !route.Deprecated {
+			continue
+		}
+
+		// Dates use the YYYY-MM-DD layout, so they compare lexically
+		if route.Sunset != "" && route.Sunset < today {
+			issues = append(issues, LintIssue{
+				Rule:        RuleSunsetPassed,
+				Severity:    SeverityWarning,
+				Message:     fmt.Sprintf("operation %s (%s %s) passed its sunset date %s", opID, route.Method, route.Path, route.Sunset),
+				OperationID: opID,
+				SourceFile:  route.SourceFile,
+				Line:        route.Pos.Line,
+				Column:      route.Pos.Column,
+			})
+		}
+
+		if route.Replacement != "" {
+			if _, ok := g.scanner.Routes[route.Replacement]; !ok {
+				issues = append(issues, LintIssue{
+					Rule:        RuleUnknownReplacement,
+					Severity:    SeverityWarning,
+					Message:     fmt.Sprintf("operation %s is replaced by unknown operation %s", opID, route.Replacement),
+					OperationID: opID,
+					SourceFile:  route.SourceFile,
+					Line:        route.Pos.Line,
+					Column:      route.Pos.Column,
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintPathParameters checks that every path template variable has a declared parameter
+// (or a type in the template) and that in:path parameters appear in the path.
+func (g *Generator) lintPathParameters() []LintIssue {
+	var issues []LintIssue
+
+	for opID, route := range g.scanner.Routes {
+		templateParams := pathTemplateParams(route.Path)
+		declared := make(map[string]bool)
+
+		fields := append(g.operationFields(route), g.pathFields(route.Path)...)
+		for _, field := range fields {
+			name := g.getPropertyName(field)
+			switch {
+			case field.IsRequestBody || (field.In != "" && field.In != "path"):
+				// Only the fields located in the path declare a path parameter
+			case slices.Contains(templateParams, name):
+				declared[name] = true
+			case field.In == "path":
+				issues = append(issues, LintIssue{
+					Rule:        RuleUnknownPathParam,
+					Severity:    SeverityError,
+					Message:     fmt.Sprintf("operation %s declares path parameter %s missing from path %s", opID, name, route.Path),
+					OperationID: opID,
+					SourceFile:  route.SourceFile,
+					Line:        route.Pos.Line,
+					Column:      route.Pos.Column,
+				})
+			}
+		}
+
+		for _, name := range templateParams {
+			if declared[name] || route.PathParamTypes[name] != "" {
+				continue
+			}
+			issues = append(issues, LintIssue{
+				Rule:        RuleUndeclaredPathParam,
+				Severity:    SeverityWarning,
+				Message:     fmt.Sprintf("operation %s has no parameter for {%s} in path %s", opID, name, route.Path),
+				OperationID: opID,
+				SourceFile:  route.SourceFile,
+				Line:        route.Pos.Line,
+				Column:      route.Pos.Column,
+			})
+		}
+	}
+
+	return issues
+}
+
+// lintDescriptions reports the models, enums, and model properties without a description,
+// after the description fallback. Properties referencing a model or enum are described
+// by its schema.
+func (g *Generator) lintDescriptions() []LintIssue {
+	var issues []LintIssue
+	missing := func(pos token.Position, format string, args ...any) {
+		issues = append(issues, LintIssue{
+			Rule:       RuleMissingDescription,
+			Severity:   SeverityWarning,
+			Message:    fmt.Sprintf(format, args...),
+			SourceFile: pos.Filename,
+			Line:       pos.Line,
+			Column:     pos.Column,
+		})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(g.scanner.Structs)) {
+		model := g.scanner.Structs[name]
+		if !model.IsModel {
+			continue
+		}
+		if model.Description == "" {
+			missing(model.Pos, "model %s has no description", name)
+		}
+		for _, field := range model.Fields {
+			propName := g.getPropertyName(field)
+			if propName == "" || propName == "-" || field.Description != "" || g.isReferenceType(field.Type) {
+				continue
+			}
+			if g.typeDescription(field) == "" {
+				missing(model.Pos, "model %s: property %s has no description", name, propName)
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(g.scanner.Enums)) {
+		if enum := g.scanner.Enums[name]; enum.Description == "" {
+			missing(enum.Pos, "enum %s has no description", name)
+		}
+	}
+
+	return issues
+}
+
+// lintRegistrations compares the swagger:route directives with the router registrations of
+// the scanned code, with the path parameters normalized: it reports the routes no router
+// registers, stale after a refactoring, and the registered handlers without a route.
+// A registration without a method matches the routes of its path of every method.
+func (g *Generator) lintRegistrations() ([]LintIssue, error) {
+	if len(g.scanner.Registrations) == 0 {
+		return nil, errors.New("no router registrations found in the scanned packages, scan the packages that build the routers")
+	}
+
+	registered := make(map[string]bool, len(g.scanner.Registrations))
+	for _, reg := range g.scanner.Registrations {
+		registered[reg.Method+" "+scanner.NormalizeRoutePath(reg.Path)] = true
+	}
+	documented := make(map[string]bool, 2*len(g.scanner.Routes))
+	for _, route := range g.scanner.Routes {
+		path := scanner.NormalizeRoutePath(route.Path)
+		documented[strings.ToUpper(route.Method)+" "+path] = true
+		documented[" "+path] = true
+	}
+
+	var issues []LintIssue
+	for _, opID := range slices.Sorted(maps.Keys(g.scanner.Routes)) {
+		route := g.scanner.Routes[opID]
+		path := scanner.NormalizeRoutePath(route.Path)
+		if registered[strings.ToUpper(route.Method)+" "+path] || registered[" "+path] {
+			continue
+		}
+		issues = append(issues, LintIssue{
+			Rule:        RuleUnregisteredRoute,
+			Severity:    SeverityWarning,
+			Message:     fmt.Sprintf("operation %s (%s %s) is not registered on any router", opID, route.Method, route.Path),
+			OperationID: opID,
+			SourceFile:  route.SourceFile,
+			Line:        route.Pos.Line,
+			Column:      route.Pos.Column,
+		})
+	}
+
+	for _, reg := range g.scanner.Registrations {
+		if documented[reg.Method+" "+scanner.NormalizeRoutePath(reg.Path)] {
+			continue
+		}
+
+		method := reg.Method
+		if method == "" {
+			method = "*"
+		}
+		issues = append(issues, LintIssue{
+			Rule:       RuleUndocumentedRoute,
+			Severity:   SeverityWarning,
+			Message:    fmt.Sprintf("%s %s is registered without a swagger:route", method, reg.Path),
+			SourceFile: reg.SourceFile,
+			Line:       reg.Pos.Line,
+			Column:     reg.Pos.Column,
+		})
+	}
+
+	return issues, nil
+}
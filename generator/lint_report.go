@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Identifiers of the SARIF logs written by WriteSARIF.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	lintToolName = "openapi"
+	lintToolURI  = "https://github.com/kausys/openapi"
+)
+
+// sarifLevels maps lint severities to SARIF result levels.
+var sarifLevels = map[string]string{
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIF writes lint issues as a SARIF 2.1.0 log, the format of GitHub code scanning
+// and most static analysis viewers. File paths are written relative to baseDir, the
+// repository root, and toolVersion is reported as the version of the linter.
+func WriteSARIF(w io.Writer, issues []LintIssue, baseDir, toolVersion string) error {
+	var rules []sarifRule
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		index := slices.IndexFunc(rules, func(r sarifRule) bool { return r.ID == issue.Rule })
+		if index < 0 {
+			index = len(rules)
+			rules = append(rules, sarifRule{ID: issue.Rule})
+		}
+
+		result := sarifResult{
+			RuleID:    issue.Rule,
+			RuleIndex: index,
+			Level:     sarifLevels[issue.Severity],
+			Message:   sarifMessage{Text: issue.Message},
+		}
+		if result.Level == "" {
+			result.Level = "note"
+		}
+		if issue.SourceFile != "" {
+			location := sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: reportPath(baseDir, issue.SourceFile)},
+			}
+			if issue.Line > 0 {
+				location.Region = &sarifRegion{StartLine: issue.Line, StartColumn: issue.Column}
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: location}}
+		}
+		results = append(results, result)
+	}
+	if rules == nil {
+		rules = []sarifRule{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           lintToolName,
+				Version:        toolVersion,
+				InformationURI: lintToolURI,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	})
+}
+
+// WriteGitHubAnnotations writes lint issues as GitHub Actions workflow commands
+// (::error file=api/users.go,line=3::message), which annotate the lines of a pull
+// request. File paths are written relative to baseDir, the repository root.
+func WriteGitHubAnnotations(w io.Writer, issues []LintIssue, baseDir string) error {
+	for _, issue := range issues {
+		command := "warning"
+		if issue.Severity == SeverityError {
+			command = "error"
+		}
+
+		var properties []string
+		if issue.SourceFile != "" {
+			properties = append(properties, "file="+escapeAnnotationProperty(reportPath(baseDir, issue.SourceFile)))
+		}
+		if issue.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", issue.Line))
+		}
+		if issue.Column > 0 {
+			properties = append(properties, fmt.Sprintf("col=%d", issue.Column))
+		}
+		properties = append(properties, "title="+escapeAnnotationProperty(issue.Rule))
+
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", command, strings.Join(properties, ","), escapeAnnotationData(issue.Message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportPath returns the path of a source file relative to baseDir, with forward
+// slashes, or the path itself when it is outside baseDir.
+func reportPath(baseDir, file string) string {
+	if baseDir != "" && filepath.IsAbs(file) {
+		if abs, err := filepath.Abs(baseDir); err == nil {
+			if rel, err := filepath.Rel(abs, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+// escapeAnnotationData escapes the message of a workflow command.
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command.
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
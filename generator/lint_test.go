@@ -0,0 +1,366 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deprecationTestSource = `package api
+
+// swagger:route GET /v1/users users getUsers
+// deprecated: 2025-06-01 use getUsersV2
+// Responses:
+// - 200: description:OK
+func GetUsers() {}
+
+// swagger:route GET /v1/orders orders getOrders
+// deprecated: 2030-01-01 use getOrdersV2
+// Responses:
+// - 200: description:OK
+func GetOrders() {}
+
+// swagger:route GET /v2/users users getUsersV2
+// Responses:
+// - 200: description:OK
+func GetUsersV2() {}
+`
+
+// TestDeprecationExtensions tests x-sunset and x-deprecated-replacement emission
+func TestDeprecationExtensions(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/users.go": deprecationTestSource})
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	op := openAPI.Paths.PathItems["/v1/users"].Get
+	assert.True(t, op.Deprecated)
+	assert.Equal(t, "2025-06-01", op.Extensions["x-sunset"])
+	assert.Equal(t, "getUsersV2", op.Extensions["x-deprecated-replacement"])
+
+	assert.Empty(t, openAPI.Paths.PathItems["/v2/users"].Get.Extensions)
+}
+
+// TestLintDeprecations tests the sunset and replacement lint rules
+func TestLintDeprecations(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/users.go": deprecationTestSource})
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false))
+	g.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	issues, err := g.Lint()
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, RuleUnknownReplacement, issues[0].Rule)
+	assert.Equal(t, "getOrders", issues[0].OperationID)
+
+	assert.Equal(t, RuleSunsetPassed, issues[1].Rule)
+	assert.Equal(t, "getUsers", issues[1].OperationID)
+	assert.Equal(t, SeverityWarning, issues[1].Severity)
+	assert.Contains(t, issues[1].Message, "2025-06-01")
+
+	// Issues point at the swagger:route line
+	assert.Equal(t, 9, issues[0].Line)
+	assert.Equal(t, 3, issues[1].Line)
+	assert.Equal(t, 1, issues[1].Column)
+	assert.Equal(t, issues[1].SourceFile+":3", issues[1].Location())
+}
+
+// TestLintPathParameters tests the path parameter coverage lint rules
+func TestLintPathParameters(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/orders.go": `package api
+
+// swagger:parameters getOrder
+type GetOrderParams struct {
+	ID string ` + "`json:\"id\"`" + `
+
+	// in: path
+	Version string ` + "`json:\"version\"`" + `
+
+	// in: query
+	ItemID string ` + "`json:\"itemId\"`" + `
+}
+
+// swagger:route GET /orders/{id}/items/{itemId} orders getOrder
+// Responses:
+// - 200: description:OK
+func GetOrder() {}
+
+// swagger:route GET /users/{id:int64} users getUser
+// Responses:
+// - 200: description:OK
+func GetUser() {}
+`})
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+
+	issues, err := g.Lint()
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, RuleUnknownPathParam, issues[0].Rule)
+	assert.Equal(t, SeverityError, issues[0].Severity)
+	assert.Equal(t, "getOrder", issues[0].OperationID)
+	assert.Contains(t, issues[0].Message, "version")
+	assert.Contains(t, issues[0].SourceFile, "orders.go")
+
+	assert.Equal(t, RuleUndeclaredPathParam, issues[1].Rule)
+	assert.Equal(t, SeverityWarning, issues[1].Severity)
+	assert.Contains(t, issues[1].Message, "{itemId}")
+
+	t.Run("generation warnings", func(t *testing.T) {
+		openAPI, err := g.Generate()
+		require.NoError(t, err)
+
+		// The itemId query parameter doesn't declare the {itemId} path parameter
+		params := openAPI.Paths.PathItems["/orders/{id}/items/{itemId}"].Get.Parameters
+		require.Len(t, params, 3)
+		assert.Equal(t, "id", params[0].Name)
+		assert.Equal(t, "path", params[0].In)
+		assert.Equal(t, "itemId", params[1].Name)
+		assert.Equal(t, "query", params[1].In)
+		assert.Equal(t, "itemId", params[2].Name)
+		assert.Equal(t, "path", params[2].In)
+
+		assert.Equal(t, []string{
+			"api/orders.go:14: operation getOrder: path parameter version does not appear in path /orders/{id}/items/{itemId}",
+			"api/orders.go:14: operation getOrder: path parameter itemId is not declared, generated as string",
+		}, warningMessages(g.Warnings()))
+	})
+}
+
+// TestLintDirectives tests that malformed directives are reported as errors, and custom
+// methods as warnings, at their line
+func TestLintDirectives(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/users.go": `package api
+
+// swagger:route G3T /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// 200: description:OK
+// - 404: description:Not found
+func GetUser() {}
+
+// swagger:path users/{id}
+type UserPath struct{}
+
+// swagger:headers
+type TracingHeaders struct {
+	RequestID string ` + "`json:\"X-Request-ID\"`" + `
+}
+
+// swagger:route GTE /items items listItems
+// Responses:
+// - 200: description:OK
+func ListItems() {}
+`})
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false))
+
+	issues, err := g.Lint()
+	require.NoError(t, err)
+
+	type finding struct {
+		Rule     string
+		Severity string
+		Line     int
+		Message  string
+	}
+	var findings []finding
+	for _, issue := range issues {
+		if issue.Rule == RuleUndeclaredPathParam {
+			continue
+		}
+		assert.Equal(t, filepath.Join(tmpDir, "api", "users.go"), issue.SourceFile)
+		findings = append(findings, finding{issue.Rule, issue.Severity, issue.Line, issue.Message})
+	}
+	assert.Equal(t, []finding{
+		{WarnInvalidRoute, SeverityError, 3, `swagger:route G3T /users users listUsers: invalid HTTP method "G3T", expected swagger:route METHOD /path [tags] operationID; route skipped`},
+		{WarnInvalidResponse, SeverityError, 10, `response "200: description:OK" is missing the list dash, expected "- 200: description:OK"; it and the following responses are ignored`},
+		{WarnInvalidPath, SeverityError, 14, `swagger:path users/{id}: path "users/{id}" does not start with /, expected swagger:path /path; path skipped`},
+		{WarnInvalidHeaders, SeverityError, 17, `swagger:headers: missing operation IDs, expected swagger:headers operationID [operationID...]; headers skipped`},
+		{WarnCustomMethod, SeverityWarning, 22, `swagger:route GTE /items items listItems: GTE is not a standard HTTP method, emitted as an additional operation`},
+	}, findings)
+}
+
+// TestLintDescriptions tests reporting models, enums, and properties without a description
+func TestLintDescriptions(t *testing.T) {
+	tmpDir := createTestProject(t, descriptionsProject)
+
+	messages := func(opts ...Option) []string {
+		g := New(append([]Option{WithDir(tmpDir), WithPattern("./..."), WithCache(false)}, opts...)...)
+		issues, err := g.Lint()
+		require.NoError(t, err)
+
+		var messages []string
+		for _, issue := range issues {
+			if issue.Rule == RuleMissingDescription {
+				assert.Equal(t, SeverityWarning, issue.Severity)
+				assert.Equal(t, filepath.Join(tmpDir, "api", "users.go"), issue.SourceFile)
+				messages = append(messages, issue.Message)
+			}
+		}
+		return messages
+	}
+
+	assert.Empty(t, messages())
+	assert.Equal(t, []string{
+		"enum Role has no description",
+		"model User has no description",
+		"model User: property email has no description",
+		"model User: property age has no description",
+	}, messages(WithRequireDescriptions(true)))
+	assert.Equal(t, []string{
+		"enum Role has no description",
+		"model User has no description",
+		"model User: property age has no description",
+	}, messages(WithRequireDescriptions(true), WithDescriptionFallback(true)))
+}
+
+func TestLintRegistrations(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/router.go": `package api
+
+type HandlerFunc func()
+
+type Router struct{}
+
+func (r *Router) Get(path string, h HandlerFunc)        {}
+func (r *Router) Post(path string, h HandlerFunc)       {}
+func (r *Router) HandleFunc(path string, h HandlerFunc) {}
+func (r *Router) Route(path string, fn func(r *Router)) {}
+
+func Routes(r *Router, h HandlerFunc) {
+	r.Route("/users", func(r *Router) {
+		r.Get("/", h)
+		r.Get("/{userID}", h)
+		r.Post("/{userID}/avatar", h)
+	})
+	r.HandleFunc("/health", h)
+}
+`,
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: description:OK
+func GetUser() {}
+
+// swagger:route DELETE /users/{id} users deleteUser
+// Responses:
+// - 204: description:Deleted
+func DeleteUser() {}
+
+// swagger:route HEAD /health health health
+// Responses:
+// - 200: description:OK
+func Health() {}
+`,
+	})
+
+	lint := func(opts ...Option) []LintIssue {
+		g := New(append([]Option{WithDir(tmpDir), WithPattern("./..."), WithCache(false)}, opts...)...)
+		issues, err := g.Lint()
+		require.NoError(t, err)
+
+		var routeIssues []LintIssue
+		for _, issue := range issues {
+			if issue.Rule == RuleUnregisteredRoute || issue.Rule == RuleUndocumentedRoute {
+				routeIssues = append(routeIssues, issue)
+			}
+		}
+		return routeIssues
+	}
+
+	assert.Empty(t, lint())
+
+	// Path parameters compare equal whatever their names, and HandleFunc serves every method
+	issues := lint(WithCheckRoutes(true))
+	require.Len(t, issues, 2)
+	assert.Equal(t, RuleUndocumentedRoute, issues[0].Rule)
+	assert.Equal(t, "POST /users/{userID}/avatar is registered without a swagger:route", issues[0].Message)
+	assert.Equal(t, filepath.Join(tmpDir, "api", "router.go"), issues[0].SourceFile)
+	assert.Equal(t, 16, issues[0].Line)
+	assert.Equal(t, RuleUnregisteredRoute, issues[1].Rule)
+	assert.Equal(t, "operation deleteUser (DELETE /users/{id}) is not registered on any router", issues[1].Message)
+	assert.Equal(t, "deleteUser", issues[1].OperationID)
+
+	// Without registrations, the check can't tell stale routes from unscanned routers
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithOnlyPaths("users.go"), WithCache(false), WithCheckRoutes(true))
+	_, err := g.Lint()
+	assert.ErrorContains(t, err, "no router registrations found")
+}
+
+// reportTestIssues returns lint issues of files under dir for the report tests
+func reportTestIssues(dir string) []LintIssue {
+	return []LintIssue{
+		{Rule: WarnInvalidRoute, Severity: SeverityError, Message: "swagger:route G3T /users: invalid HTTP method", SourceFile: filepath.Join(dir, "api", "users.go"), Line: 3, Column: 1},
+		{Rule: RuleSunsetPassed, Severity: SeverityWarning, Message: "operation getUsers passed its sunset date 2025-06-01,\n100% done", SourceFile: filepath.Join(dir, "api", "users.go"), Line: 9, Column: 1},
+		{Rule: RuleSunsetPassed, Severity: SeverityWarning, Message: "operation getOrders passed its sunset date 2025-06-01"},
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, reportTestIssues(dir), dir, "1.2.3"))
+
+	var log map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log["version"])
+
+	run := log["runs"].([]any)[0].(map[string]any)
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	assert.Equal(t, "openapi", driver["name"])
+	assert.Equal(t, "1.2.3", driver["version"])
+	assert.Equal(t, []any{
+		map[string]any{"id": WarnInvalidRoute},
+		map[string]any{"id": RuleSunsetPassed},
+	}, driver["rules"])
+
+	results := run["results"].([]any)
+	require.Len(t, results, 3)
+	assert.Equal(t, map[string]any{
+		"ruleId":    WarnInvalidRoute,
+		"ruleIndex": float64(0),
+		"level":     "error",
+		"message":   map[string]any{"text": "swagger:route G3T /users: invalid HTTP method"},
+		"locations": []any{map[string]any{"physicalLocation": map[string]any{
+			"artifactLocation": map[string]any{"uri": "api/users.go"},
+			"region":           map[string]any{"startLine": float64(3), "startColumn": float64(1)},
+		}}},
+	}, results[0])
+	assert.Equal(t, "warning", results[1].(map[string]any)["level"])
+	assert.Equal(t, float64(1), results[1].(map[string]any)["ruleIndex"])
+	assert.NotContains(t, results[2], "locations")
+
+	// No issues is a valid log with an empty run
+	buf.Reset()
+	require.NoError(t, WriteSARIF(&buf, nil, dir, ""))
+	assert.Contains(t, buf.String(), `"results": []`)
+}
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteGitHubAnnotations(&buf, reportTestIssues(dir), dir))
+	assert.Equal(t, `::error file=api/users.go,line=3,col=1,title=invalid-route::swagger:route G3T /users: invalid HTTP method
+::warning file=api/users.go,line=9,col=1,title=sunset-passed::operation getUsers passed its sunset date 2025-06-01,%0A100%25 done
+::warning title=sunset-passed::operation getOrders passed its sunset date 2025-06-01
+`, buf.String())
+}
@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCustomMethods tests TRACE and custom methods emitted as additionalOperations
+func TestCustomMethods(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/cache.go": `package api
+
+// swagger:route TRACE /cache cache traceCache
+// Responses:
+// - 200: description:OK
+func TraceCache() {}
+
+// swagger:route PURGE /cache cache purgeCache
+// x-cache-level: 2
+// Responses:
+// - 204: description:Purged
+func PurgeCache() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	pathItem := openAPI.Paths.PathItems["/cache"]
+	require.NotNil(t, pathItem.Trace)
+	assert.Equal(t, "traceCache", pathItem.Trace.OperationID)
+
+	require.Contains(t, pathItem.AdditionalOperations, "PURGE")
+	purge := pathItem.AdditionalOperations["PURGE"]
+	assert.Equal(t, "purgeCache", purge.OperationID)
+	assert.Equal(t, float64(2), purge.Extensions["x-cache-level"])
+
+	assert.Len(t, maps.Collect(pathItem.Operations()), 2)
+
+	// Custom methods are reported, so a misspelled method doesn't go unnoticed
+	assert.Equal(t, []string{
+		"api/cache.go:8: swagger:route PURGE /cache cache purgeCache: PURGE is not a standard HTTP method, emitted as an additional operation",
+	}, warningMessages(g.Warnings()))
+
+	// additionalOperations were introduced in OpenAPI 3.2
+	assert.Equal(t, "3.2.0", openAPI.OpenAPI)
+}
+
+// TestOpenAPIVersion tests that documents without 3.2 fields declare OpenAPI 3.1
+func TestOpenAPIVersion(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/cache.go": `package api
+
+// swagger:route TRACE /cache cache traceCache
+// Responses:
+// - 200: description:OK
+func TraceCache() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.2", openAPI.OpenAPI)
+}
+
+// TestGenerateRouteCollision tests that two operations with the same method and path are rejected
+func TestGenerateRouteCollision(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// spec: public
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route GET /users admin listAllUsers
+// spec: admin
+// Responses:
+// - 200: description:OK
+func ListAllUsers() {}
+`,
+	})
+
+	// Routes of different specs may share a method and path
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	specs, err := g.GenerateMulti()
+	require.NoError(t, err)
+	assert.NotNil(t, specs["public"].Paths.PathItems["/users"].Get)
+	assert.NotNil(t, specs["admin"].Paths.PathItems["/users"].Get)
+
+	// The same spec can't declare both
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "api", "users.go"), []byte(`package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route GET /users admin listAllUsers
+// Responses:
+// - 200: description:OK
+func ListAllUsers() {}
+`), 0o644))
+
+	_, err = g.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api/users.go:8: operation listAllUsers declares GET /users, already declared by operation listUsers at api/users.go:3")
+
+	// Paths differing by the names of their parameters are the same path
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "api", "users.go"), []byte(`package api
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: description:OK
+func GetUser() {}
+
+// swagger:route GET /users/{userId} admin getAnyUser
+// Responses:
+// - 200: description:OK
+func GetAnyUser() {}
+
+// swagger:route GET /users/{id}/ users getUserSlash
+// Responses:
+// - 200: description:OK
+func GetUserSlash() {}
+`), 0o644))
+
+	_, err = g.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api/users.go:8: operation getAnyUser declares GET /users/{userId}, already declared as GET /users/{id} by operation getUser at api/users.go:3")
+	assert.NotContains(t, err.Error(), "getUserSlash")
+}
@@ -1,28 +1,31 @@
 package generator
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
+	"maps"
 	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/kausys/openapi/scanner"
 	"github.com/kausys/openapi/spec"
-	"gopkg.in/yaml.v3"
 )
 
 // buildStructIndex builds the structsByNameAndSpec index for O(1) lookups.
 // Maps model name → spec name → *StructInfo. Empty string key "" = general model.
+// Models selecting specs with * or !name are indexed separately in structsBySpecPattern.
 func (g *Generator) buildStructIndex() {
 	g.structsByNameAndSpec = make(map[string]map[string]*scanner.StructInfo)
+	g.structsBySpecPattern = make(map[string][]*scanner.StructInfo)
 	for _, structInfo := range g.scanner.Structs {
 		name := structInfo.Name
 		if _, ok := g.structsByNameAndSpec[name]; !ok {
 			g.structsByNameAndSpec[name] = make(map[string]*scanner.StructInfo)
 		}
-		if len(structInfo.Specs) == 0 {
+		if hasSpecPattern(structInfo.Specs) {
+			g.structsBySpecPattern[name] = append(g.structsBySpecPattern[name], structInfo)
+		} else if len(structInfo.Specs) == 0 {
 			g.structsByNameAndSpec[name][""] = structInfo
 		} else {
 			for _, specName := range structInfo.Specs {
@@ -35,14 +38,45 @@ func (g *Generator) buildStructIndex() {
 // GenerateMulti generates multiple OpenAPI specs based on spec: directives.
 // Returns a map of spec name to OpenAPI spec.
 func (g *Generator) GenerateMulti() (map[string]*spec.OpenAPI, error) {
-	if err := g.prepare(); err != nil {
+	return g.GenerateMultiContext(context.Background())
+}
+
+// GenerateMultiContext is like GenerateMulti but stops scanning when ctx is done.
+func (g *Generator) GenerateMultiContext(ctx context.Context) (map[string]*spec.OpenAPI, error) {
+	run := g.newRun()
+	defer g.finishRun(run)
+
+	return run.generateMulti(ctx)
+}
+
+// EncodeSpecs generates the specs like GenerateMulti and encodes them in the given format,
+// by spec name, without writing output files. Without spec: directives, the only spec is
+// the default one.
+func (g *Generator) EncodeSpecs(ctx context.Context, format Format) (map[string][]byte, error) {
+	run := g.newRun()
+	defer g.finishRun(run)
+
+	specs, err := run.buildMulti(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	// Phase 4: Assemble multiple OpenAPI specs
-	specs, err := g.assembleMulti()
+	encoded := make(map[string][]byte, len(specs))
+	for name, openAPI := range specs {
+		data, err := encodeSpec(openAPI, format, g.config.PortableYAML)
+		if err != nil {
+			return nil, fmt.Errorf("spec %s: %w", name, err)
+		}
+		encoded[name] = data
+	}
+	return encoded, nil
+}
+
+// generateMulti generates multiple OpenAPI specs within a run.
+func (g *Generator) generateMulti(ctx context.Context) (map[string]*spec.OpenAPI, error) {
+	specs, err := g.buildMulti(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to assemble specs: %w", err)
+		return nil, err
 	}
 
 	// Phase 5: Write output files
@@ -51,6 +85,40 @@ func (g *Generator) GenerateMulti() (map[string]*spec.OpenAPI, error) {
 			return nil, fmt.Errorf("failed to write output: %w", err)
 		}
 	}
+	if g.config.AsyncAPIOutput != "" {
+		if err := g.writeAsyncAPI(); err != nil {
+			return nil, fmt.Errorf("failed to write AsyncAPI document: %w", err)
+		}
+	}
+
+	return specs, nil
+}
+
+// buildMulti scans the source files and assembles the specs without writing output.
+func (g *Generator) buildMulti(ctx context.Context) (map[string]*spec.OpenAPI, error) {
+	if err := g.prepare(ctx); err != nil {
+		return nil, err
+	}
+
+	// Phase 4: Assemble multiple OpenAPI specs
+	specs, err := g.assembleMulti()
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble specs: %w", err)
+	}
+	g.reportUnusedSchemas(specs)
+
+	for _, name := range slices.Sorted(maps.Keys(specs)) {
+		if err := g.applyOverlays(specs[name]); err != nil {
+			return nil, fmt.Errorf("spec %s: %w", name, err)
+		}
+		if err := g.postProcess(ctx, name, specs[name]); err != nil {
+			return nil, fmt.Errorf("spec %s: %w", name, err)
+		}
+	}
+
+	if err := g.checkStrict(); err != nil {
+		return nil, err
+	}
 
 	return specs, nil
 }
@@ -97,11 +165,30 @@ func (g *Generator) collectSpecNames() map[string]bool {
 			}
 		} else {
 			for _, s := range route.Specs {
-				specNames[s] = true
+				if !isSpecPattern(s) {
+					specNames[s] = true
+				}
 			}
 		}
 	}
 
+	// Specs composed from other specs exist as soon as an included spec does
+	for _, meta := range g.scanner.Metas {
+		if len(meta.Includes) == 0 {
+			continue
+		}
+		for _, name := range meta.Specs {
+			if slices.ContainsFunc(g.specLineage(name), func(included string) bool { return specNames[included] }) {
+				specNames[name] = true
+			}
+		}
+	}
+
+	// Versioned mode generates a spec per API version of each spec
+	if g.config.Versioned {
+		return g.splitVersions(specNames)
+	}
+
 	return specNames
 }
 
@@ -111,7 +198,7 @@ func (g *Generator) assembleForSpec(specName string) (*spec.OpenAPI, error) {
 	g.referencedSchemas = make(map[string]bool)
 
 	openAPI := &spec.OpenAPI{
-		OpenAPI: "3.1.2",
+		OpenAPI: openAPIVersion,
 		Paths: &spec.Paths{
 			PathItems: make(map[string]*spec.PathItem),
 		},
@@ -121,17 +208,41 @@ func (g *Generator) assembleForSpec(specName string) (*spec.OpenAPI, error) {
 		},
 	}
 
-	// Set info, security schemes, and tags from meta (with inheritance from general)
-	meta := g.getMetaForSpec(specName)
+	// A spec of versioned mode is generated from the routes of its spec and version
+	baseSpec, version := g.resolveVersionedSpec(specName)
+
+	// Set info, security schemes, and tags from meta (with inheritance from general).
+	// The meta of a version (spec: v2) takes precedence over the meta of its spec.
+	meta := g.getMetaForSpec(baseSpec)
+	versionMeta := g.specOwnMeta(specName)
+	if version != "" && versionMeta != nil {
+		meta = versionMeta
+	}
 	g.applyMeta(openAPI, meta, g.scanner.Meta)
+	if version != "" && (versionMeta == nil || versionMeta.Version == "") {
+		openAPI.Info.Version = version
+	}
 
 	// Add routes that belong to this spec
 	// This will mark schemas as referenced via markSchemaAsReferenced
+	var routes []*scanner.RouteInfo
 	for _, routeInfo := range g.scanner.Routes {
-		if g.routeBelongsToSpec(routeInfo, specName) {
-			g.addRoute(openAPI, routeInfo)
+		if g.routeBelongsToSpec(routeInfo, baseSpec) && routeInVersion(routeInfo, version) && g.routeIncluded(routeInfo) {
+			routes = append(routes, routeInfo)
 		}
 	}
+	if err := g.checkRouteCollisions(routes, specName); err != nil {
+		return nil, err
+	}
+	for _, routeInfo := range routes {
+		g.addRoute(openAPI, routeInfo, metaEnvelope(meta, g.scanner.Meta))
+	}
+
+	// Load hand-written fragments (swagger:include), marking the models they reference
+	fragments, err := g.loadFragments(g.fragmentMetas(meta), routes)
+	if err != nil {
+		return nil, err
+	}
 
 	// In multi-spec mode, we need to carefully track which schemas are actually used.
 	// The problem is that structToSchema marks references while converting, which
@@ -139,11 +250,71 @@ func (g *Generator) assembleForSpec(specName string) (*spec.OpenAPI, error) {
 	//
 	// Solution: Build schemas iteratively - only convert schemas that are referenced,
 	// then check if those schemas reference more schemas, and repeat.
-	g.buildReferencedSchemas(openAPI.Components, specName)
+	g.buildReferencedSchemas(openAPI.Components, baseSpec)
+	g.recordUsedSchemas()
+
+	if err := mergeFragments(openAPI, fragments); err != nil {
+		return nil, err
+	}
+
+	// Expose routes under the spec's public prefix
+	g.rewritePaths(openAPI, meta)
+
+	// Declare tags used by routes but missing from meta
+	g.addRouteTags(openAPI)
+
+	// Add the canonical Problem schema of the error responses
+	g.addProblemSchema(openAPI)
+
+	// Derive request/response variants of models with readOnly/writeOnly fields
+	g.splitReadWriteModels(openAPI)
+
+	// Decorate with gateway profile extensions
+	g.applyProfile(openAPI)
 
 	return openAPI, nil
 }
 
+// rewritePaths applies the StripPrefix/AddPrefix rules of a meta to the spec paths.
+// Only whole path segments are stripped, so /admin does not match /administrators.
+func (g *Generator) rewritePaths(openAPI *spec.OpenAPI, meta *scanner.MetaInfo) {
+	if meta == nil || (meta.StripPrefix == "" && meta.AddPrefix == "") {
+		return
+	}
+
+	pathItems := make(map[string]*spec.PathItem, len(openAPI.Paths.PathItems))
+	for _, path := range slices.Sorted(maps.Keys(openAPI.Paths.PathItems)) {
+		rewritten := rewritePath(path, meta.StripPrefix, meta.AddPrefix)
+		if _, exists := pathItems[rewritten]; exists {
+			g.warn(WarnPathRewriteConflict, "path %s conflicts with another route after rewriting to %s, skipped", path, rewritten)
+			continue
+		}
+		pathItems[rewritten] = openAPI.Paths.PathItems[path]
+	}
+	openAPI.Paths.PathItems = pathItems
+}
+
+// rewritePath removes strip from the start of path and prepends add to the result.
+func rewritePath(path, strip, add string) string {
+	strip = strings.TrimSuffix(strip, "/")
+	if strip != "" {
+		if rest, ok := strings.CutPrefix(path, strip); ok && (rest == "" || rest[0] == '/') {
+			path = rest
+		}
+	}
+
+	add = strings.TrimSuffix(add, "/")
+	if add != "" && !strings.HasPrefix(add, "/") {
+		add = "/" + add
+	}
+	path = add + path
+
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
 // buildReferencedSchemas iteratively builds only the schemas that are actually referenced.
 // It starts with schemas marked as referenced by routes, then adds schemas referenced by those, and so on.
 func (g *Generator) buildReferencedSchemas(components *spec.Components, specName string) {
@@ -198,14 +369,71 @@ func (g *Generator) getMetaForSpec(specName string) *scanner.MetaInfo {
 	return g.scanner.Meta
 }
 
-// routeBelongsToSpec checks if a route belongs to a specific spec.
+// specLineage returns the spec followed by the specs it includes, transitively.
+// Includes are declared with the includes: directive of the spec's own meta.
+func (g *Generator) specLineage(specName string) []string {
+	lineage := []string{specName}
+	for i := 0; i < len(lineage); i++ {
+		for _, meta := range g.scanner.Metas {
+			if !slices.Contains(meta.Specs, lineage[i]) {
+				continue
+			}
+			for _, included := range meta.Includes {
+				if !slices.Contains(lineage, included) {
+					lineage = append(lineage, included)
+				}
+			}
+		}
+	}
+	return lineage
+}
+
+// routeBelongsToSpec checks if a route belongs to a specific spec or to a spec it includes.
 func (g *Generator) routeBelongsToSpec(route *scanner.RouteInfo, specName string) bool {
+	return slices.ContainsFunc(g.specLineage(specName), func(name string) bool {
+		return routeInSpec(route, name)
+	})
+}
+
+// routeInSpec checks if a route declares a specific spec.
+func routeInSpec(route *scanner.RouteInfo, specName string) bool {
 	// Routes without spec: go to default
 	if len(route.Specs) == 0 {
 		return specName == scanner.DefaultSpec
 	}
 
-	return slices.Contains(route.Specs, specName)
+	return specsMatch(route.Specs, specName)
+}
+
+// isSpecPattern reports whether a spec: value is a wildcard or a negation.
+func isSpecPattern(name string) bool {
+	return name == scanner.SpecWildcard || strings.HasPrefix(name, scanner.SpecNegationPrefix)
+}
+
+// hasSpecPattern reports whether a spec: directive uses a wildcard or a negation.
+func hasSpecPattern(specs []string) bool {
+	return slices.ContainsFunc(specs, isSpecPattern)
+}
+
+// specsMatch reports whether the values of a spec: directive select a spec.
+// Names and * select specs, !name excludes one; a directive with only
+// negations selects every spec not excluded (spec: !internal).
+func specsMatch(specs []string, specName string) bool {
+	matched := true
+	for _, s := range specs {
+		if excluded, ok := strings.CutPrefix(s, scanner.SpecNegationPrefix); ok {
+			if excluded == specName {
+				return false
+			}
+			continue
+		}
+		matched = false
+	}
+	if matched {
+		return true
+	}
+
+	return slices.Contains(specs, specName) || slices.Contains(specs, scanner.SpecWildcard)
 }
 
 // getSchemaForSpec returns the appropriate schema for a spec, with override logic.
@@ -220,9 +448,19 @@ func (g *Generator) getSchemaForSpec(modelName string, specName string) *spec.Sc
 		return nil
 	}
 
-	// Priority: specific > general
-	if specificModel, ok := specMap[specName]; ok {
-		return g.structToSchema(specificModel)
+	// Priority: specific > included specs > wildcard/negation > general
+	lineage := g.specLineage(specName)
+	for _, name := range lineage {
+		if specificModel, ok := specMap[name]; ok {
+			return g.structToSchema(specificModel)
+		}
+	}
+	for _, name := range lineage {
+		for _, patternModel := range g.structsBySpecPattern[modelName] {
+			if specsMatch(patternModel.Specs, name) {
+				return g.structToSchema(patternModel)
+			}
+		}
 	}
 	if generalModel, ok := specMap[""]; ok {
 		return g.structToSchema(generalModel)
@@ -231,73 +469,233 @@ func (g *Generator) getSchemaForSpec(modelName string, specName string) *spec.Sc
 	return nil
 }
 
-// writeMultiOutput writes multiple specs to output files.
+// writeMultiOutput writes multiple specs to output files, in the directory and with the
+// extension of the output file and of each additional output.
 func (g *Generator) writeMultiOutput(specs map[string]*spec.OpenAPI) error {
+	targets, err := g.outputTargets()
+	if err != nil {
+		return err
+	}
+
 	// Determine output directory and extension
-	outputDir := filepath.Dir(g.config.OutputFile)
 	ext := filepath.Ext(g.config.OutputFile)
 	if ext == "" {
 		ext = ".yaml"
 	}
 
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	translations, err := g.loadTranslations()
+	if err != nil {
 		return err
 	}
+	if len(translations) > 0 && g.config.SharedComponents {
+		return fmt.Errorf("translations are not supported with shared components")
+	}
 
-	for specName, openAPI := range specs {
-		filename := filepath.Join(outputDir, specName+ext)
+	files := make(map[string][]byte, (len(specs)+1)*(len(translations)+1)*len(targets))
+	specFiles := make(map[string]string, len(specs))
 
-		var data []byte
-		var err error
+	// The shared components are written in the output format next to the specs of
+	// every output, which all reference them by the same file name
+	var shared []byte
+	if g.config.SharedComponents {
+		if _, exists := specs[SharedComponentsName]; exists {
+			return fmt.Errorf("spec %q conflicts with the shared components file", SharedComponentsName)
+		}
+		var components *spec.OpenAPI
+		specs, components = g.extractSharedComponents(specs, SharedComponentsName+ext)
+		if components != nil {
+			shared, err = g.marshalSpec(components)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shared components: %w", err)
+			}
+		}
+	}
 
-		switch g.config.OutputFormat {
-		case "json":
-			data, err = json.MarshalIndent(openAPI, "", "  ")
-		default:
-			data, err = yaml.Marshal(openAPI)
+	for i, target := range targets {
+		outputDir := filepath.Dir(target.file)
+		targetExt := filepath.Ext(target.file)
+		if i == 0 {
+			targetExt = ext
+		}
+		if shared != nil {
+			files[filepath.Join(outputDir, SharedComponentsName+ext)] = shared
 		}
 
+		for specName, openAPI := range specs {
+			data, err := g.marshalSpecAs(openAPI, target.format)
+			if err != nil {
+				return fmt.Errorf("failed to marshal spec %s: %w", specName, err)
+			}
+			outputFile := filepath.Join(outputDir, specName+targetExt)
+			files[outputFile] = data
+			if i == 0 {
+				specFiles[specName] = outputFile
+			}
+
+			localized, err := g.localizedOutputs(outputFile, target.format, openAPI, translations)
+			if err != nil {
+				return fmt.Errorf("failed to localize spec %s: %w", specName, err)
+			}
+			maps.Copy(files, localized)
+		}
+	}
+
+	if len(g.config.Templates) > 0 {
+		templates, err := g.loadTemplates()
 		if err != nil {
-			return fmt.Errorf("failed to marshal spec %s: %w", specName, err)
+			return err
+		}
+		for specName, openAPI := range specs {
+			rendered, err := templateOutputs(templates, specName, openAPI, true)
+			if err != nil {
+				return fmt.Errorf("spec %s: %w", specName, err)
+			}
+			maps.Copy(files, rendered)
 		}
+	}
 
-		if err := os.WriteFile(filename, data, 0644); err != nil {
-			return fmt.Errorf("failed to write spec %s: %w", specName, err)
+	if g.config.Backstage.Output != "" {
+		data, err := g.backstageCatalog(specs, specFiles, files, true)
+		if err != nil {
+			return fmt.Errorf("failed to write Backstage catalog: %w", err)
 		}
+		files[g.config.Backstage.Output] = data
 	}
 
-	return nil
+	if g.config.EmbedOutput != "" {
+		data, err := g.embedFile(specFiles, files)
+		if err != nil {
+			return fmt.Errorf("failed to write embed output: %w", err)
+		}
+		files[g.config.EmbedOutput] = data
+	}
+
+	return g.emitOutput(files)
 }
 
-// GetSpecNames returns all spec names that would be generated.
+// GetSpecNames returns all spec names that would be generated, sorted.
 func (g *Generator) GetSpecNames() ([]string, error) {
-	// Scan if not already done
-	if len(g.scanner.Routes) == 0 {
-		if err := g.scanner.Scan(); err != nil {
-			return nil, err
-		}
+	run := g.newRun()
+	if err := run.scanner.Scan(); err != nil {
+		return nil, err
 	}
 
+	return slices.Sorted(maps.Keys(run.collectSpecNames())), nil
+}
+
+// UnknownSpecError is returned by GenerateSpec when no route belongs to the spec.
+type UnknownSpecError struct {
+	// Name is the requested spec name
+	Name string
+	// Suggestion is the closest available spec name, if any is close enough
+	Suggestion string
+	// Available are the spec names that can be generated, sorted
+	Available []string
+}
+
+// Error implements the error interface.
+func (e *UnknownSpecError) Error() string {
+	msg := fmt.Sprintf("unknown spec %q", e.Name)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", e.Suggestion)
+	}
+	if len(e.Available) == 0 {
+		return msg + " (no spec is declared)"
+	}
+	return msg + fmt.Sprintf(" (available: %s)", strings.Join(e.Available, ", "))
+}
+
+// checkSpecName returns an UnknownSpecError when specName isn't one of the specs the
+// scanned routes declare. The default spec is always known when no route declares one.
+func (g *Generator) checkSpecName(specName string) error {
 	specNames := g.collectSpecNames()
-	names := make([]string, 0, len(specNames))
-	for name := range specNames {
-		names = append(names, name)
+	if specNames[specName] || (len(specNames) == 0 && specName == scanner.DefaultSpec) {
+		return nil
+	}
+
+	available := slices.Sorted(maps.Keys(specNames))
+	return &UnknownSpecError{
+		Name:       specName,
+		Suggestion: closestName(specName, available),
+		Available:  available,
 	}
+}
+
+// closestName returns the candidate closest to name by edit distance, or "" when none
+// is within a third of the length of name (at least one edit).
+func closestName(name string, candidates []string) string {
+	best, bestDistance := "", max(1, len(name)/3)+1
+	for _, candidate := range candidates {
+		if d := editDistance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
 
-	return names, nil
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
 }
 
 // GenerateSpec generates a single spec by name.
 func (g *Generator) GenerateSpec(specName string) (*spec.OpenAPI, error) {
-	if err := g.prepare(); err != nil {
+	return g.GenerateSpecContext(context.Background(), specName)
+}
+
+// GenerateSpecContext is like GenerateSpec but stops scanning when ctx is done.
+func (g *Generator) GenerateSpecContext(ctx context.Context, specName string) (*spec.OpenAPI, error) {
+	run := g.newRun()
+	defer g.finishRun(run)
+
+	return run.generateSpec(ctx, specName)
+}
+
+// generateSpec generates a single spec by name within a run.
+func (g *Generator) generateSpec(ctx context.Context, specName string) (*spec.OpenAPI, error) {
+	if err := g.prepare(ctx); err != nil {
+		return nil, err
+	}
+
+	specName = strings.ToLower(specName)
+	if err := g.checkSpecName(specName); err != nil {
 		return nil, err
 	}
 
 	// Phase 4: Assemble specific spec
-	openAPI, err := g.assembleForSpec(strings.ToLower(specName))
+	openAPI, err := g.assembleForSpec(specName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to assemble spec: %w", err)
 	}
+	g.reportUnusedSchemas(map[string]*spec.OpenAPI{specName: openAPI})
+
+	if err := g.applyOverlays(openAPI); err != nil {
+		return nil, err
+	}
+
+	if err := g.postProcess(ctx, specName, openAPI); err != nil {
+		return nil, err
+	}
+
+	if err := g.checkStrict(); err != nil {
+		return nil, err
+	}
 
 	// Phase 5: Write output
 	if g.config.OutputFile != "" {
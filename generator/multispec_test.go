@@ -1,8 +1,10 @@
 package generator
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/kausys/openapi/cache"
@@ -30,6 +32,7 @@ func createTestGenerator() *Generator {
 		},
 		cache:             cache.NewManager("."),
 		scanner:           s,
+		mu:                &sync.Mutex{},
 		referencedSchemas: make(map[string]bool),
 	}
 }
@@ -341,6 +344,39 @@ func TestGetSchemaForSpec(t *testing.T) {
 			expectedSchema: true,
 			expectedDesc:   "General user model",
 		},
+		{
+			name:      "negated model overrides general model for other specs",
+			modelName: "User",
+			specName:  "public",
+			structs: map[string]*scanner.StructInfo{
+				"User":          {Name: "User", Description: "General user model", IsModel: true},
+				"User_redacted": {Name: "User", Description: "Redacted user model", IsModel: true, Specs: []string{"!admin"}},
+			},
+			expectedSchema: true,
+			expectedDesc:   "Redacted user model",
+		},
+		{
+			name:      "negated model skipped for excluded spec",
+			modelName: "User",
+			specName:  "admin",
+			structs: map[string]*scanner.StructInfo{
+				"User":          {Name: "User", Description: "General user model", IsModel: true},
+				"User_redacted": {Name: "User", Description: "Redacted user model", IsModel: true, Specs: []string{"!admin"}},
+			},
+			expectedSchema: true,
+			expectedDesc:   "General user model",
+		},
+		{
+			name:      "specific model takes precedence over wildcard model",
+			modelName: "User",
+			specName:  "admin",
+			structs: map[string]*scanner.StructInfo{
+				"User_all":   {Name: "User", Description: "Wildcard user model", IsModel: true, Specs: []string{"*"}},
+				"User_admin": {Name: "User", Description: "Admin user model", IsModel: true, Specs: []string{"admin"}},
+			},
+			expectedSchema: true,
+			expectedDesc:   "Admin user model",
+		},
 	}
 
 	for _, tt := range tests {
@@ -988,6 +1024,7 @@ type HealthResponse struct {
 		WithDir(tmpDir),
 		WithPattern("./..."),
 		WithCache(false),
+		WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"),
 	)
 
 	specs, err := g.GenerateMulti()
@@ -1061,6 +1098,7 @@ func ListUsers() {}
 		WithDir(tmpDir),
 		WithPattern("./..."),
 		WithCache(false),
+		WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"),
 	)
 
 	specs, err := g.GenerateMulti()
@@ -1102,6 +1140,7 @@ func ListPublicUsers() {}
 		WithDir(tmpDir),
 		WithPattern("./..."),
 		WithCache(false),
+		WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"),
 	)
 
 	// Generate only admin spec
@@ -1140,6 +1179,7 @@ func ListPublicUsers() {}
 		WithDir(tmpDir),
 		WithPattern("./..."),
 		WithCache(false),
+		WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"),
 	)
 
 	names, err := g.GetSpecNames()
@@ -1150,6 +1190,45 @@ func ListPublicUsers() {}
 	assert.Contains(t, names, "mobile")
 }
 
+// TestGenerateSpecUnknown tests the error of GenerateSpec for a spec no route declares
+func TestGenerateSpecUnknown(t *testing.T) {
+	files := map[string]string{
+		"api/handlers.go": `package api
+
+// swagger:route GET /admin/users admin listAdminUsers
+// spec: admin
+func ListAdminUsers() {}
+
+// swagger:route GET /users public listPublicUsers
+// spec: public
+func ListPublicUsers() {}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+
+	g := New(
+		WithDir(tmpDir),
+		WithPattern("./..."),
+		WithCache(false),
+		WithOutput("", ""),
+	)
+
+	names, err := g.GetSpecNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "public"}, names)
+
+	_, err = g.GenerateSpec("admn")
+	unknown, ok := errors.AsType[*UnknownSpecError](err)
+	require.True(t, ok, "expected UnknownSpecError, got %v", err)
+	assert.Equal(t, "admin", unknown.Suggestion)
+	assert.Equal(t, []string{"admin", "public"}, unknown.Available)
+	assert.EqualError(t, err, `unknown spec "admn", did you mean "admin"? (available: admin, public)`)
+
+	_, err = g.GenerateSpec("billing")
+	assert.EqualError(t, err, `unknown spec "billing" (available: admin, public)`)
+}
+
 // TestIntegrationGenerateMultiJSON tests JSON output format
 func TestIntegrationGenerateMultiJSON(t *testing.T) {
 	files := map[string]string{
@@ -1429,6 +1508,7 @@ func ListUsers() {}
 		WithDir(tmpDir),
 		WithPattern("./..."),
 		WithCache(false), // Explicitly disable cache
+		WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"),
 	)
 
 	specs, err := g.GenerateMulti()
@@ -1455,6 +1535,7 @@ func ListAdminUsers() {}
 		WithDir(tmpDir),
 		WithPattern("./..."),
 		WithCache(false),
+		WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"),
 	)
 
 	adminSpec, err := g.GenerateSpec("admin")
@@ -1481,6 +1562,7 @@ func ListAdminUsers() {}
 		WithDir(tmpDir),
 		WithPattern("./..."),
 		WithCache(false),
+		WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"),
 	)
 
 	// Use uppercase spec name
@@ -1508,6 +1590,7 @@ type User struct {
 		WithDir(tmpDir),
 		WithPattern("./..."),
 		WithCache(false),
+		WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"),
 	)
 
 	names, err := g.GetSpecNames()
@@ -1942,6 +2025,14 @@ func TestSetSchemaType(t *testing.T) {
 		{"bool", "bool", "boolean", ""},
 		{"time.Time", "time.Time", "string", "date-time"},
 		{"uuid.UUID", "uuid.UUID", "string", "uuid"},
+		{"time.Duration", "time.Duration", "string", "duration"},
+		{"net.IP", "net.IP", "string", "ipv4"},
+		{"netip.Addr", "netip.Addr", "string", "ipv4"},
+		{"url.URL", "url.URL", "string", "uri"},
+		{"mail.Address", "mail.Address", "string", "email"},
+		{"big.Int", "big.Int", "string", ""},
+		{"json.Number", "json.Number", "number", ""},
+		{"json.RawMessage", "json.RawMessage", "", ""},
 	}
 
 	for _, tt := range tests {
@@ -2004,3 +2095,305 @@ func TestSortEnumValues(t *testing.T) {
 	assert.Equal(t, "b", result[1])
 	assert.Equal(t, "c", result[2])
 }
+
+func TestRewritePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		strip    string
+		add      string
+		expected string
+	}{
+		{name: "no rules", path: "/admin/users", expected: "/admin/users"},
+		{name: "strip prefix", path: "/admin/users", strip: "/admin", expected: "/users"},
+		{name: "strip with trailing slash", path: "/admin/users", strip: "/admin/", expected: "/users"},
+		{name: "strip whole path", path: "/admin", strip: "/admin", expected: "/"},
+		{name: "strip only whole segments", path: "/administrators", strip: "/admin", expected: "/administrators"},
+		{name: "strip not matching", path: "/users", strip: "/admin", expected: "/users"},
+		{name: "add prefix", path: "/users", add: "/v1", expected: "/v1/users"},
+		{name: "add prefix without slash", path: "/users", add: "v1/", expected: "/v1/users"},
+		{name: "strip and add", path: "/admin/users/{id}", strip: "/admin", add: "/v2", expected: "/v2/users/{id}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rewritePath(tt.path, tt.strip, tt.add))
+		})
+	}
+}
+
+func TestIntegrationGenerateMultiPathPrefixAndServers(t *testing.T) {
+	files := map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: API
+// Version: 1.0.0
+//
+// Servers:
+// - https://api.example.com
+package api
+`,
+		"admin_doc.go": `// swagger:meta
+//
+// Title: Admin API
+// Version: 1.0.0
+// StripPrefix: /admin
+//
+// Servers:
+// - https://admin.example.com Admin API
+//
+// spec: admin
+package api
+`,
+		"handlers.go": `package api
+
+// swagger:route GET /admin/users admin listAdminUsers
+// spec: admin
+//
+// Responses:
+//   204:
+func ListAdminUsers() {}
+
+// swagger:route GET /users public listPublicUsers
+// spec: public
+//
+// Responses:
+//   204:
+func ListPublicUsers() {}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+
+	specs, err := g.GenerateMulti()
+	require.NoError(t, err)
+
+	admin := specs["admin"]
+	require.NotNil(t, admin)
+	assert.Contains(t, admin.Paths.PathItems, "/users")
+	assert.NotContains(t, admin.Paths.PathItems, "/admin/users")
+	require.Len(t, admin.Servers, 1)
+	assert.Equal(t, "https://admin.example.com", admin.Servers[0].URL)
+	assert.Equal(t, "Admin API", admin.Servers[0].Description)
+
+	// The public spec has no meta of its own and inherits the general servers
+	public := specs["public"]
+	require.NotNil(t, public)
+	assert.Contains(t, public.Paths.PathItems, "/users")
+	require.Len(t, public.Servers, 1)
+	assert.Equal(t, "https://api.example.com", public.Servers[0].URL)
+}
+
+func TestRewritePathsConflict(t *testing.T) {
+	g := createTestGenerator()
+	openAPI := &spec.OpenAPI{
+		Paths: &spec.Paths{
+			PathItems: map[string]*spec.PathItem{
+				"/admin/users": {Get: &spec.Operation{OperationID: "adminUsers"}},
+				"/users":       {Get: &spec.Operation{OperationID: "users"}},
+			},
+		},
+	}
+
+	g.rewritePaths(openAPI, &scanner.MetaInfo{StripPrefix: "/admin"})
+
+	require.Len(t, openAPI.Paths.PathItems, 1)
+	assert.Equal(t, "adminUsers", openAPI.Paths.PathItems["/users"].Get.OperationID)
+	require.Len(t, g.Warnings(), 1)
+	assert.Contains(t, g.Warnings()[0].Message, "/users conflicts")
+	assert.Equal(t, WarnPathRewriteConflict, g.Warnings()[0].Code)
+}
+
+func TestSpecLineage(t *testing.T) {
+	g := createTestGenerator()
+	g.scanner.Metas = []*scanner.MetaInfo{
+		{Specs: []string{"admin"}, Includes: []string{"public", "partner"}},
+		{Specs: []string{"partner"}, Includes: []string{"public", "admin"}},
+		{Specs: []string{"public"}},
+	}
+
+	assert.Equal(t, []string{"admin", "public", "partner"}, g.specLineage("admin"))
+	assert.Equal(t, []string{"partner", "public", "admin"}, g.specLineage("partner"))
+	assert.Equal(t, []string{"public"}, g.specLineage("public"))
+	assert.Equal(t, []string{"unknown"}, g.specLineage("unknown"))
+}
+
+func TestIntegrationGenerateMultiIncludes(t *testing.T) {
+	files := map[string]string{
+		"api/doc.go": `// swagger:meta
+//
+// Title: Admin API
+// Version: 1.0.0
+//
+// spec: admin
+// includes: public
+package api
+`,
+		"api/handlers.go": `package api
+
+// swagger:route GET /admin/users admin listAdminUsers
+// spec: admin
+// Responses:
+// - 200: []User
+func ListAdminUsers() {}
+
+// swagger:route GET /users public listPublicUsers
+// spec: public
+// Responses:
+// - 200: []User
+func ListPublicUsers() {}
+
+// swagger:route GET /health health healthCheck
+// Responses:
+// - 204:
+func HealthCheck() {}
+`,
+		"api/models.go": `package api
+
+// swagger:model User
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// swagger:model User
+// spec: public
+type PublicUser struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+
+	specs, err := g.GenerateMulti()
+	require.NoError(t, err)
+
+	admin := specs["admin"]
+	require.NotNil(t, admin)
+	assert.Contains(t, admin.Paths.PathItems, "/admin/users")
+	assert.Contains(t, admin.Paths.PathItems, "/users")
+	assert.NotContains(t, admin.Paths.PathItems, "/health")
+
+	// Model overrides of included specs apply to the including spec
+	require.Contains(t, admin.Components.Schemas, "User")
+	assert.Contains(t, admin.Components.Schemas["User"].Properties, "name")
+
+	public := specs["public"]
+	require.NotNil(t, public)
+	assert.NotContains(t, public.Paths.PathItems, "/admin/users")
+}
+
+func TestCollectSpecNamesWithIncludes(t *testing.T) {
+	g := createTestGenerator()
+	g.scanner.Routes = map[string]*scanner.RouteInfo{
+		"listUsers": {Method: "GET", Path: "/users", OperationID: "listUsers", Specs: []string{"public"}},
+	}
+	g.scanner.Metas = []*scanner.MetaInfo{
+		{Specs: []string{"admin"}, Includes: []string{"public"}},
+		{Specs: []string{"partner"}, Includes: []string{"billing"}},
+	}
+
+	// admin has no routes of its own but includes public; billing does not exist
+	assert.Equal(t, map[string]bool{"public": true, "admin": true}, g.collectSpecNames())
+}
+
+func TestSpecsMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		specs    []string
+		specName string
+		expected bool
+	}{
+		{name: "exact name", specs: []string{"admin"}, specName: "admin", expected: true},
+		{name: "other name", specs: []string{"admin"}, specName: "public", expected: false},
+		{name: "wildcard", specs: []string{"*"}, specName: "public", expected: true},
+		{name: "wildcard matches default", specs: []string{"*"}, specName: scanner.DefaultSpec, expected: true},
+		{name: "negation matches others", specs: []string{"!internal"}, specName: "public", expected: true},
+		{name: "negation excludes spec", specs: []string{"!internal"}, specName: "internal", expected: false},
+		{name: "multiple negations", specs: []string{"!internal", "!partner"}, specName: "partner", expected: false},
+		{name: "wildcard with negation", specs: []string{"*", "!internal"}, specName: "internal", expected: false},
+		{name: "name with negation", specs: []string{"admin", "!internal"}, specName: "public", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, specsMatch(tt.specs, tt.specName))
+		})
+	}
+}
+
+func TestCollectSpecNamesIgnoresPatterns(t *testing.T) {
+	g := createTestGenerator()
+	g.scanner.Routes = map[string]*scanner.RouteInfo{
+		"listUsers":  {Method: "GET", Path: "/users", OperationID: "listUsers", Specs: []string{"public"}},
+		"health":     {Method: "GET", Path: "/health", OperationID: "health", Specs: []string{"*"}},
+		"getMetrics": {Method: "GET", Path: "/metrics", OperationID: "getMetrics", Specs: []string{"!public"}},
+	}
+
+	assert.Equal(t, map[string]bool{"public": true}, g.collectSpecNames())
+}
+
+func TestIntegrationGenerateMultiSpecPatterns(t *testing.T) {
+	files := map[string]string{
+		"api/handlers.go": `package api
+
+// swagger:route GET /admin/users admin listAdminUsers
+// spec: admin
+// Responses:
+// - 200: []User
+func ListAdminUsers() {}
+
+// swagger:route GET /users public listPublicUsers
+// spec: public
+// Responses:
+// - 200: []User
+func ListPublicUsers() {}
+
+// swagger:route GET /internal/jobs internal listJobs
+// spec: internal
+// Responses:
+// - 204:
+func ListJobs() {}
+
+// swagger:route GET /health health healthCheck
+// spec: *
+// Responses:
+// - 204:
+func HealthCheck() {}
+
+// swagger:route GET /status status getStatus
+// spec: !internal
+// Responses:
+// - 204:
+func GetStatus() {}
+`,
+		"api/models.go": `package api
+
+// swagger:model User
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+
+	specs, err := g.GenerateMulti()
+	require.NoError(t, err)
+	require.Len(t, specs, 3)
+
+	for _, name := range []string{"admin", "public", "internal"} {
+		assert.Contains(t, specs[name].Paths.PathItems, "/health", "spec: * adds the route to %s", name)
+	}
+
+	assert.Contains(t, specs["admin"].Paths.PathItems, "/status")
+	assert.Contains(t, specs["public"].Paths.PathItems, "/status")
+	assert.NotContains(t, specs["internal"].Paths.PathItems, "/status")
+	assert.NotContains(t, specs["public"].Paths.PathItems, "/admin/users")
+}
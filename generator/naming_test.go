@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateSchemaNamingPackage tests that models of different packages sharing a name get package prefixes
+func TestGenerateSchemaNamingPackage(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"billing/models.go": `package billing
+
+// swagger:model
+type Error struct {
+	Code int ` + "`json:\"code\"`" + `
+}
+
+// swagger:model
+type Invoice struct {
+	Err Error ` + "`json:\"err\"`" + `
+}
+
+// swagger:route GET /invoices billing listInvoices
+// Responses:
+// - 200: []Invoice
+// - 400: billing.Error
+func ListInvoices() {}
+`,
+		"auth/models.go": `package auth
+
+// swagger:model
+type Error struct {
+	Reason string ` + "`json:\"reason\"`" + `
+}
+
+// swagger:route POST /login auth login
+// Responses:
+// - 204:
+// - 401: auth.Error
+func Login() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithSchemaNaming(SchemaNamingPackage))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	schemas := openAPI.Components.Schemas
+	assert.NotContains(t, schemas, "Error")
+	require.Contains(t, schemas, "BillingError")
+	require.Contains(t, schemas, "AuthError")
+	assert.Contains(t, schemas["BillingError"].Properties, "code")
+	assert.Contains(t, schemas["AuthError"].Properties, "reason")
+	assert.Equal(t, "#/components/schemas/BillingError", schemas["Invoice"].Properties["err"].Ref)
+
+	invoices := openAPI.Paths.PathItems["/invoices"].Get.Responses.StatusCodes["400"]
+	assert.Equal(t, "#/components/schemas/BillingError", invoices.Content["application/json"].Schema.Ref)
+	login := openAPI.Paths.PathItems["/login"].Post.Responses.StatusCodes["401"]
+	assert.Equal(t, "#/components/schemas/AuthError", login.Content["application/json"].Schema.Ref)
+}
+
+// TestGenerateSchemaNamingRouteTypes tests that ambiguous short type names of routes resolve to the model of the route's package
+func TestGenerateSchemaNamingRouteTypes(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"billing/models.go": `package billing
+
+// swagger:model
+type Error struct {
+	Code int ` + "`json:\"code\"`" + `
+}
+`,
+		"auth/models.go": `package auth
+
+// swagger:model
+type Error struct {
+	Reason string ` + "`json:\"reason\"`" + `
+}
+
+// swagger:model
+type Credentials struct {
+	Username string ` + "`json:\"username\"`" + `
+}
+
+// swagger:route POST /login auth login
+// RequestBody: Credentials
+// Responses:
+// - 204:
+// - 400: Error
+func Login() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithSchemaNaming(SchemaNamingPackage))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	login := openAPI.Paths.PathItems["/login"].Post
+	assert.Equal(t, "#/components/schemas/AuthError", login.Responses.StatusCodes["400"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/Credentials", login.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Empty(t, warningMessages(g.Warnings()))
+}
+
+// TestGenerateUnknownSchemaNaming tests that unknown schema naming strategies are rejected
+func TestGenerateUnknownSchemaNaming(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/server.go": `package api
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithSchemaNaming("prefix"))
+	_, err := g.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown schema naming "prefix"`)
+}
@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// objectsProject has models closed, opened, and extended through allOf.
+var objectsProject = map[string]string{
+	"api/payments.go": `package api
+
+// swagger:model Payment
+// additionalProperties: false
+type Payment struct {
+	Amount int ` + "`json:\"amount\"`" + `
+}
+
+// swagger:model Metadata
+// additionalProperties: true
+type Metadata struct {
+	Source string ` + "`json:\"source\"`" + `
+}
+
+// swagger:model Base
+type Base struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:model Card
+// allOf: Base
+type Card struct {
+	Number string ` + "`json:\"number\"`" + `
+}
+
+// swagger:model Receipt
+type Receipt struct {
+	Payment  Payment  ` + "`json:\"payment\"`" + `
+	Metadata Metadata ` + "`json:\"metadata\"`" + `
+	Card     Card     ` + "`json:\"card\"`" + `
+}
+
+// swagger:route GET /receipts payments getReceipt
+// Responses:
+// - 200: Receipt
+func GetReceipt() {}
+`,
+}
+
+// TestGenerateStrictObjects tests closing object schemas with additionalProperties: false
+func TestGenerateStrictObjects(t *testing.T) {
+	closed := spec.BoolSchema(false)
+
+	tests := []struct {
+		name       string
+		strict     bool
+		wantClosed []string
+		wantUneval []string
+	}{
+		{
+			name:       "directives only",
+			wantClosed: []string{"Payment"},
+		},
+		{
+			name:       "strict objects",
+			strict:     true,
+			wantClosed: []string{"Payment", "Receipt"},
+			wantUneval: []string{"Card"},
+		},
+	}
+
+	tmpDir := createTestProject(t, objectsProject)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithStrictObjects(tt.strict))
+			openAPI, err := g.Generate()
+			require.NoError(t, err)
+
+			var gotClosed, gotUneval []string
+			for name, schema := range openAPI.Components.Schemas {
+				if closed.Equal(schema.AdditionalProperties) {
+					gotClosed = append(gotClosed, name)
+				}
+				if closed.Equal(schema.UnevaluatedProperties) {
+					gotUneval = append(gotUneval, name)
+				}
+			}
+			assert.ElementsMatch(t, tt.wantClosed, gotClosed)
+			assert.ElementsMatch(t, tt.wantUneval, gotUneval)
+		})
+	}
+}
+
+// TestGenerateStrictObjectsComposition tests that strict objects leave open the envelope and problem models combined with responses
+func TestGenerateStrictObjectsComposition(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// swagger:meta
+// Title: Users API
+// Version: 1.0.0
+// envelope: data Envelope
+package api
+`,
+		"api/users.go": `package api
+
+// swagger:model Envelope
+type Envelope struct {
+	Meta map[string]string ` + "`json:\"meta\"`" + `
+}
+
+// swagger:model Problem
+type Problem struct {
+	Title string ` + "`json:\"title\"`" + `
+}
+
+// swagger:model ValidationProblem
+type ValidationProblem struct {
+	Fields []string ` + "`json:\"fields\"`" + `
+}
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:route POST /users users createUser
+// problem: ValidationProblem
+// Responses:
+// - 201: User
+// - 400: description:Invalid user
+func CreateUser() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""),
+		WithStrictObjects(true), WithProblemDetails(true))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	schemas := openAPI.Components.Schemas
+	for _, name := range []string{"Envelope", "Problem", "ValidationProblem"} {
+		require.Contains(t, schemas, name)
+		assert.Nil(t, schemas[name].AdditionalProperties, name)
+		assert.Nil(t, schemas[name].UnevaluatedProperties, name)
+	}
+	assert.True(t, spec.BoolSchema(false).Equal(schemas["User"].AdditionalProperties))
+
+	created := openAPI.Paths.PathItems["/users"].Post.Responses.StatusCodes["201"]
+	require.Len(t, created.Content["application/json"].Schema.AllOf, 2)
+}
+
+// TestGenerateObjectConstraints tests minProperties, maxProperties, and propertyNames on models and map fields
+func TestGenerateObjectConstraints(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/settings.go": `package api
+
+// Labels of a resource
+// swagger:model Labels
+// maxProperties: 64
+// propertyNames: ^[a-z][a-z0-9_]*$
+type Labels map[string]string
+
+// Settings of an account
+// swagger:model Settings
+// minProperties: 1
+// maxProperties: 2
+type Settings struct {
+	Theme    string ` + "`json:\"theme,omitempty\"`" + `
+	Language string ` + "`json:\"language,omitempty\"`" + `
+	Labels   Labels ` + "`json:\"labels\"`" + `
+	// Feature flags
+	// minProperties: 1
+	// propertyNames: ^[A-Z]+$
+	Flags map[string]bool ` + "`json:\"flags\"`" + `
+}
+
+// swagger:route GET /settings settings getSettings
+// Responses:
+// - 200: Settings
+func GetSettings() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	settings := openAPI.Components.Schemas["Settings"]
+	require.NotNil(t, settings)
+	assert.Equal(t, "Settings of an account", settings.Description)
+	assert.Equal(t, new(uint64(1)), settings.MinProperties)
+	assert.Equal(t, new(uint64(2)), settings.MaxProperties)
+	assert.Nil(t, settings.PropertyNames)
+
+	labels := openAPI.Components.Schemas["Labels"]
+	require.NotNil(t, labels)
+	assert.Equal(t, "Labels of a resource", labels.Description)
+	assert.Nil(t, labels.MinProperties)
+	assert.Equal(t, new(uint64(64)), labels.MaxProperties)
+	assert.Equal(t, &spec.Schema{Pattern: "^[a-z][a-z0-9_]*$"}, labels.PropertyNames)
+
+	flags := settings.Properties["flags"]
+	assert.Equal(t, "Feature flags", flags.Description)
+	assert.Equal(t, new(uint64(1)), flags.MinProperties)
+	assert.Equal(t, &spec.Schema{Pattern: "^[A-Z]+$"}, flags.PropertyNames)
+}
@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// outputTarget is a file the spec is written to, with its format.
+type outputTarget struct {
+	file   string
+	format Format
+}
+
+// outputTargets returns the output file in the configured format, followed by the
+// additional outputs in the format of their extension.
+func (g *Generator) outputTargets() ([]outputTarget, error) {
+	targets := []outputTarget{{file: g.config.OutputFile, format: Format(g.config.OutputFormat)}}
+	for _, file := range g.config.AdditionalOutputs {
+		format, err := formatForFile(file)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, outputTarget{file: file, format: format})
+	}
+	return targets, nil
+}
+
+// formatForFile returns the format of an output file from its extension.
+func formatForFile(file string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("cannot infer the format of output %q, expected a .yaml, .yml, or .json extension", file)
+	}
+}
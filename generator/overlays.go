@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/kausys/openapi/overlay"
+	"github.com/kausys/openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// applyOverlays applies the configured overlay files to a generated spec.
+// The spec is round-tripped through a yaml.Node, which the overlay actions edit.
+func (g *Generator) applyOverlays(openAPI *spec.OpenAPI) error {
+	if len(g.config.Overlays) == 0 {
+		return nil
+	}
+
+	var node yaml.Node
+	if err := node.Encode(openAPI); err != nil {
+		return fmt.Errorf("failed to encode spec for overlays: %w", err)
+	}
+
+	for _, path := range g.config.Overlays {
+		o, err := overlay.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to load overlay: %w", err)
+		}
+		if err := o.Apply(&node); err != nil {
+			return fmt.Errorf("overlay %s: %w", path, err)
+		}
+	}
+
+	var result spec.OpenAPI
+	if err := node.Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode spec after overlays: %w", err)
+	}
+	*openAPI = result
+	return nil
+}
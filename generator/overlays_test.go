@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationGenerateOverlays tests applying overlay files to generated specs
+func TestIntegrationGenerateOverlays(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route DELETE /users/{id} admin deleteUser
+// Responses:
+// - 204: description:Deleted
+func DeleteUser() {}
+`,
+		"public.overlay.yaml": `overlay: 1.0.0
+info:
+  title: Public API
+  version: 1.0.0
+actions:
+  - target: $.paths.*[?@.tags[0] == 'admin']
+    remove: true
+  - target: $.info
+    update:
+      description: Public API
+      x-audience: public
+`,
+		"schemas.overlay.yaml": `overlay: 1.0.0
+info:
+  title: Schema examples
+  version: 1.0.0
+actions:
+  - target: $.components.schemas.User.properties.id
+    update:
+      examples: [usr_123]
+  - target: $.paths['/users'].get.responses['200']
+    update:
+      x-cache-ttl: 60
+`,
+	})
+
+	overlays := []string{
+		filepath.Join(tmpDir, "public.overlay.yaml"),
+		filepath.Join(tmpDir, "schemas.overlay.yaml"),
+	}
+
+	for _, multi := range []bool{false, true} {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithOverlays(overlays...))
+
+		var openAPI *spec.OpenAPI
+		if multi {
+			specs, err := g.GenerateMulti()
+			require.NoError(t, err)
+			require.Len(t, specs, 1)
+			for _, s := range specs {
+				openAPI = s
+			}
+		} else {
+			var err error
+			openAPI, err = g.Generate()
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, "Public API", openAPI.Info.Description)
+		assert.Equal(t, "public", openAPI.Info.Extensions["x-audience"])
+
+		require.NotNil(t, openAPI.Paths.PathItems["/users"])
+		assert.Equal(t, "listUsers", openAPI.Paths.PathItems["/users"].Get.OperationID)
+		assert.Nil(t, openAPI.Paths.PathItems["/users/{id}"].Delete)
+		assert.Equal(t, spec.Extensions{"x-cache-ttl": 60}, openAPI.Paths.PathItems["/users"].Get.Responses.StatusCodes["200"].Extensions,
+			"extensions of all objects survive the round trip")
+
+		id := openAPI.Components.Schemas["User"].Properties["id"]
+		require.NotNil(t, id)
+		assert.Equal(t, []any{"usr_123"}, id.Examples)
+	}
+}
+
+// TestGenerateOverlayErrors tests missing and invalid overlay files
+func TestGenerateOverlayErrors(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 204: description:Empty
+func ListUsers() {}
+`,
+	})
+
+	invalid := filepath.Join(tmpDir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("overlay: 1.0.0\nactions:\n  - target: $.info.title\n    update:\n      x: y\n"), 0644))
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"missing file", filepath.Join(tmpDir, "missing.yaml"), "failed to load overlay"},
+		{"scalar target", invalid, "action 1 ($.info.title)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithOverlays(tt.path))
+			_, err := g.Generate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParameterStyle tests style and explode directives on parameters
+func TestParameterStyle(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/search.go": `package api
+
+// swagger:parameters search
+type SearchParams struct {
+	// Tags to match
+	// style: pipeDelimited
+	// explode: false
+	Tags []string ` + "`json:\"tags\"`" + `
+
+	// Repeated ids
+	// explode: true
+	IDs []int ` + "`json:\"ids\"`" + `
+
+	// Path segments
+	// style: label
+	Path string ` + "`json:\"path\"`" + `
+
+	// Not a header style
+	// in: header
+	// style: form
+	Region string ` + "`json:\"X-Region\"`" + `
+
+	// Cookie list kept exploded
+	// in: cookie
+	// explode: true
+	Flags []string ` + "`json:\"flags\"`" + `
+}
+
+// swagger:route GET /search/{path} search search
+// Responses:
+// - 200: description:OK
+func Search() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	params := make(map[string]*spec.Parameter)
+	for _, p := range openAPI.Paths.PathItems["/search/{path}"].Get.Parameters {
+		params[p.Name] = p
+	}
+	require.Len(t, params, 5)
+
+	tests := []struct {
+		name    string
+		style   string
+		explode *bool
+	}{
+		{name: "tags", style: "pipeDelimited", explode: new(false)},
+		{name: "ids", explode: new(true)},
+		{name: "path", style: "label"},
+		{name: "X-Region"},
+		{name: "flags", style: "form", explode: new(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Contains(t, params, tt.name)
+			assert.Equal(t, tt.style, params[tt.name].Style)
+			assert.Equal(t, tt.explode, params[tt.name].Explode)
+		})
+	}
+
+	assert.Equal(t, "array", params["tags"].Schema.Type.Value())
+	assert.Equal(t, []string{
+		"parameter X-Region (in: header) of /search/{path}: style form is not allowed, expected one of simple",
+	}, warningMessages(g.Warnings()))
+}
@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSharedParameterStructs tests swagger:parameters structs applied to several operations
+// and composed of embedded structs
+func TestSharedParameterStructs(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/params.go": `package api
+
+// Pagination is embedded by the parameters of the list operations.
+type Pagination struct {
+	// Page number
+	// in: query
+	Page int ` + "`json:\"page\"`" + `
+	// Page size
+	// in: query
+	Limit int ` + "`json:\"limit\"`" + `
+}
+
+// Filters is embedded by the parameters of several operations.
+type Filters struct {
+	Pagination
+
+	// Status to match
+	// in: query
+	Status string ` + "`json:\"status\"`" + `
+	// in: query
+	Limit string ` + "`json:\"limit\"`" + `
+}
+
+// swagger:parameters listUsers listOrders exportUsers
+type ListParams struct {
+	// Search text
+	// in: query
+	Query string ` + "`json:\"q\"`" + `
+
+	Filters
+
+	// Sort order
+	// in: query
+	Sort string ` + "`json:\"sort\"`" + `
+}
+
+// swagger:parameters getUser
+type GetUserParams struct {
+	// Fields to include
+	// in: query
+	Fields string ` + "`json:\"fields\"`" + `
+
+	ListParams
+}
+`,
+		"api/routes.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route GET /orders orders listOrders
+// Responses:
+// - 200: description:OK
+func ListOrders() {}
+
+// swagger:route GET /users/me users getUser
+// Responses:
+// - 200: description:OK
+func GetUser() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	paramNames := func(params []*spec.Parameter) []string {
+		names := make([]string, len(params))
+		for i, p := range params {
+			names[i] = p.In + ":" + p.Name
+		}
+		return names
+	}
+
+	// Embedded fields take the place of the embedded struct, and as in Go the fields of
+	// a struct shadow the embedded fields of the same name
+	listParams := []string{"query:q", "query:page", "query:status", "query:limit", "query:sort"}
+	assert.Equal(t, listParams, paramNames(openAPI.Paths.PathItems["/users"].Get.Parameters))
+	assert.Equal(t, listParams, paramNames(openAPI.Paths.PathItems["/orders"].Get.Parameters))
+	assert.Equal(t, append([]string{"query:fields"}, listParams...), paramNames(openAPI.Paths.PathItems["/users/me"].Get.Parameters))
+
+	limit := openAPI.Paths.PathItems["/users"].Get.Parameters[3]
+	assert.Empty(t, limit.Description)
+	assert.Equal(t, "string", limit.Schema.Type.Value())
+
+	assert.Equal(t, []string{"api/params.go:24: swagger:parameters exportUsers does not match any operation"},
+		warningMessages(g.Warnings()))
+}
+
+// TestArrayParameters tests that slice fields are array parameters of their element type
+func TestArrayParameters(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:enum Role
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+)
+
+// swagger:parameters listUsers
+type ListUsersParams struct {
+	// in: query
+	// example: [1, 2]
+	IDs []int64 ` + "`json:\"ids\"`" + `
+	// in: query
+	Roles []Role ` + "`json:\"roles\"`" + `
+	// in: header
+	// default: ["en"]
+	Languages []string ` + "`json:\"Accept-Language\"`" + `
+	// in: query
+	Limit int ` + "`json:\"limit\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	params := make(map[string]*spec.Parameter)
+	for _, param := range openAPI.Paths.PathItems["/users"].Get.Parameters {
+		params[param.Name] = param
+	}
+
+	ids := params["ids"].Schema
+	assert.Equal(t, spec.NewSchemaType("array"), ids.Type)
+	assert.Equal(t, spec.NewSchemaType("integer"), ids.Items.Type)
+	assert.Equal(t, []any{[]any{float64(1), float64(2)}}, ids.Examples)
+	assert.Nil(t, ids.Items.Examples)
+
+	roles := params["roles"].Schema
+	assert.Equal(t, spec.NewSchemaType("array"), roles.Type)
+	assert.Equal(t, []any{"admin", "member"}, roles.Items.Enum)
+
+	languages := params["Accept-Language"].Schema
+	assert.Equal(t, spec.NewSchemaType("array"), languages.Type)
+	assert.Equal(t, []any{"en"}, languages.Default)
+	assert.Nil(t, languages.Items.Default)
+
+	assert.Equal(t, spec.NewSchemaType("integer"), params["limit"].Schema.Type)
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/spec"
+)
+
+// orderPaths sets the order of the paths of a spec from the PathOrder of the
+// configuration. Paths are serialized in alphabetical order by default.
+func (g *Generator) orderPaths(openAPI *spec.OpenAPI) {
+	if openAPI.Paths == nil || len(openAPI.Paths.PathItems) == 0 {
+		return
+	}
+
+	items := openAPI.Paths.PathItems
+	paths := slices.Sorted(maps.Keys(items))
+	switch g.config.PathOrder {
+	case PathOrderTag:
+		rank := tagRanks(openAPI.Tags)
+		slices.SortStableFunc(paths, func(a, b string) int {
+			return compareTags(pathTag(items[a]), pathTag(items[b]), rank)
+		})
+	case PathOrderSource:
+		slices.SortStableFunc(paths, func(a, b string) int {
+			return g.comparePathSources(items[a], items[b])
+		})
+	default:
+		openAPI.Paths.Order = nil
+		return
+	}
+	openAPI.Paths.Order = paths
+}
+
+// tagRanks returns the positions of the tags declared by the document.
+func tagRanks(tags []*spec.Tag) map[string]int {
+	rank := make(map[string]int, len(tags))
+	for i, tag := range tags {
+		if _, ok := rank[tag.Name]; !ok {
+			rank[tag.Name] = i
+		}
+	}
+	return rank
+}
+
+// pathTag returns the first tag of the operations of a path item, or "" when untagged.
+func pathTag(item *spec.PathItem) string {
+	for _, op := range item.Operations() {
+		if len(op.Tags) > 0 {
+			return op.Tags[0]
+		}
+	}
+	return ""
+}
+
+// compareTags orders the tags declared by the document first, in their order, then the
+// other tags alphabetically, then the untagged paths.
+func compareTags(a, b string, rank map[string]int) int {
+	if a == b {
+		return 0
+	}
+	if a == "" || b == "" {
+		return strings.Compare(b, a)
+	}
+	rankA, declaredA := rank[a]
+	rankB, declaredB := rank[b]
+	switch {
+	case declaredA && declaredB:
+		return cmp.Compare(rankA, rankB)
+	case declaredA:
+		return -1
+	case declaredB:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// comparePathSources orders path items by the first declaration of their routes, by
+// source file then line. Paths without scanned routes (fragments, overlays) come last.
+func (g *Generator) comparePathSources(a, b *spec.PathItem) int {
+	fileA, lineA, okA := g.pathSource(a)
+	fileB, lineB, okB := g.pathSource(b)
+	switch {
+	case okA && okB:
+		return cmp.Or(strings.Compare(fileA, fileB), cmp.Compare(lineA, lineB))
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+	return 0
+}
+
+// pathSource returns the position of the first declared route of a path item.
+func (g *Generator) pathSource(item *spec.PathItem) (file string, line int, ok bool) {
+	for _, op := range item.Operations() {
+		route, found := g.scanner.Routes[op.OperationID]
+		if !found {
+			continue
+		}
+		pos := route.Pos
+		if !ok || pos.Filename < file || pos.Filename == file && pos.Line < line {
+			file, line, ok = pos.Filename, pos.Line, true
+		}
+	}
+	return file, line, ok
+}
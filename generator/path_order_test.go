@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPathOrder tests the order of the paths of the written specs
+func TestPathOrder(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: Shop API
+// Version: 1.0.0
+// Tags:
+// - name: orders
+// - name: users
+package api
+`,
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route GET /health getHealth
+// Responses:
+// - 200: description:OK
+func GetHealth() {}
+
+// swagger:route GET /admin/stats admin getStats
+// Responses:
+// - 200: description:OK
+func GetStats() {}
+
+// swagger:route POST /orders orders createOrder
+// Responses:
+// - 201: description:Created
+func CreateOrder() {}
+`,
+	})
+
+	tests := []struct {
+		order    string
+		expected []string
+	}{
+		{order: "", expected: []string{"/admin/stats", "/health", "/orders", "/users"}},
+		{order: PathOrderAlpha, expected: []string{"/admin/stats", "/health", "/orders", "/users"}},
+		{order: PathOrderTag, expected: []string{"/orders", "/users", "/admin/stats", "/health"}},
+		{order: PathOrderSource, expected: []string{"/users", "/health", "/admin/stats", "/orders"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithPathOrder(tt.order))
+			openAPI, err := g.Generate()
+			require.NoError(t, err)
+
+			data, err := json.Marshal(openAPI.Paths)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pathPositions(string(data), tt.expected))
+		})
+	}
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithPathOrder("random"))
+	_, err := g.Generate()
+	assert.ErrorContains(t, err, `unknown path order "random"`)
+}
+
+// pathPositions returns the paths sorted by their position in the encoded Paths object.
+func pathPositions(data string, paths []string) []string {
+	return slices.SortedFunc(slices.Values(paths), func(a, b string) int {
+		return strings.Index(data, `"`+a+`":`) - strings.Index(data, `"`+b+`":`)
+	})
+}
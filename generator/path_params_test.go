@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMissingPathParameters tests synthesized path parameters from the path template
+func TestMissingPathParameters(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/orders.go": `package api
+
+// swagger:enum Status
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusClosed Status = "closed"
+)
+
+// swagger:parameters getOrderItem
+type GetOrderItemParams struct {
+	// The order ID
+	OrderID string ` + "`json:\"orderId\"`" + `
+}
+
+// swagger:route GET /orders/{orderId}/items/{itemId} orders getOrderItem
+// Responses:
+// - 200: description:OK
+func GetOrderItem() {}
+
+// swagger:route GET /users/{id:int64}/orders/{status:Status}/{code:[A-Z]{3}} orders listUserOrders
+// Responses:
+// - 200: description:OK
+func ListUserOrders() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	t.Run("untyped variable missing from struct", func(t *testing.T) {
+		params := openAPI.Paths.PathItems["/orders/{orderId}/items/{itemId}"].Get.Parameters
+		require.Len(t, params, 2)
+		assert.Equal(t, "orderId", params[0].Name)
+		assert.Equal(t, "The order ID", params[0].Description)
+
+		assert.Equal(t, "itemId", params[1].Name)
+		assert.Equal(t, "path", params[1].In)
+		assert.True(t, params[1].Required)
+		assert.Equal(t, "string", params[1].Schema.Type.Value())
+	})
+
+	t.Run("typed template", func(t *testing.T) {
+		pathItem := openAPI.Paths.PathItems["/users/{id}/orders/{status}/{code}"]
+		require.NotNil(t, pathItem, "type suffixes are stripped from the path")
+
+		params := make(map[string]*spec.Parameter)
+		for _, p := range pathItem.Get.Parameters {
+			params[p.Name] = p
+			assert.True(t, p.Required)
+		}
+		require.Len(t, params, 3)
+
+		assert.Equal(t, "integer", params["id"].Schema.Type.Value())
+		assert.Equal(t, "int64", params["id"].Schema.Format)
+		assert.ElementsMatch(t, []any{"open", "closed"}, params["status"].Schema.Enum)
+		assert.Equal(t, "string", params["code"].Schema.Type.Value())
+		assert.Equal(t, "^[A-Z]{3}$", params["code"].Schema.Pattern)
+	})
+
+	assert.Equal(t, []string{
+		"api/orders.go:17: operation getOrderItem: path parameter itemId is not declared, generated as string",
+	}, warningMessages(g.Warnings()))
+}
+
+// TestSharedPathParameters tests path-level summary, description, and parameters from swagger:path
+func TestSharedPathParameters(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// A single user.
+//
+// swagger:path /users/{id}
+// summary: User resource
+type UserPath struct {
+	// The user ID
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: description:OK
+func GetUser() {}
+
+// swagger:route DELETE /users/{id} users deleteUser
+// Responses:
+// - 204: description:Deleted
+func DeleteUser() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	pathItem := openAPI.Paths.PathItems["/users/{id}"]
+	require.NotNil(t, pathItem)
+	assert.Equal(t, "User resource", pathItem.Summary)
+	assert.Equal(t, "A single user.", pathItem.Description)
+
+	require.Len(t, pathItem.Parameters, 1)
+	param := pathItem.Parameters[0]
+	assert.Equal(t, "id", param.Name)
+	assert.Equal(t, "path", param.In)
+	assert.True(t, param.Required)
+	assert.Equal(t, "The user ID", param.Description)
+	assert.Equal(t, "integer", param.Schema.Type.Value())
+
+	assert.Empty(t, pathItem.Get.Parameters)
+	assert.Empty(t, pathItem.Delete.Parameters)
+	assert.Empty(t, g.Warnings())
+
+	issues, err := g.Lint()
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
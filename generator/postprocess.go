@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/kausys/openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// PostProcessor mutates or checks a generated spec before it is written.
+// Returning an error fails the generation.
+type PostProcessor func(*spec.OpenAPI) error
+
+var (
+	postProcessors   []PostProcessor
+	postProcessorsMu sync.RWMutex
+)
+
+// RegisterPostProcessor registers a function applied to every generated spec, after
+// overlays and before the output is written. Post-processors run in registration order.
+func RegisterPostProcessor(fn PostProcessor) {
+	postProcessorsMu.Lock()
+	defer postProcessorsMu.Unlock()
+
+	postProcessors = append(postProcessors, fn)
+}
+
+// ClearPostProcessors removes all registered post-processors.
+// Useful for testing.
+func ClearPostProcessors() {
+	postProcessorsMu.Lock()
+	defer postProcessorsMu.Unlock()
+
+	postProcessors = nil
+}
+
+// getPostProcessors returns a snapshot of the registered post-processors.
+func getPostProcessors() []PostProcessor {
+	postProcessorsMu.RLock()
+	defer postProcessorsMu.RUnlock()
+
+	return append([]PostProcessor(nil), postProcessors...)
+}
+
+// postProcess adds the generated code samples, then runs the registered post-processors
+// and the configured external post-processor commands on a generated spec.
+func (g *Generator) postProcess(ctx context.Context, specName string, openAPI *spec.OpenAPI) error {
+	g.addCodeSamples(openAPI)
+
+	for i, fn := range getPostProcessors() {
+		if err := fn(openAPI); err != nil {
+			return fmt.Errorf("post-processor %d: %w", i+1, err)
+		}
+	}
+
+	for _, command := range g.config.PostProcessors {
+		if err := runPostProcessCommand(ctx, command, specName, openAPI); err != nil {
+			return fmt.Errorf("post-processor %q: %w", command, err)
+		}
+	}
+
+	// Commands replace the spec, so the paths are ordered last
+	g.orderPaths(openAPI)
+	setOpenAPIVersion(openAPI)
+	return nil
+}
+
+// runPostProcessCommand runs an external post-processor. The command receives the spec
+// as JSON on stdin and the spec name in OPENAPI_SPEC, and prints the resulting spec
+// (JSON or YAML) on stdout. A command that prints nothing leaves the spec unchanged.
+func runPostProcessCommand(ctx context.Context, command, specName string, openAPI *spec.OpenAPI) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return errors.New("empty command")
+	}
+
+	input, err := json.Marshal(openAPI)
+	if err != nil {
+		return fmt.Errorf("failed to encode spec: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "OPENAPI_SPEC="+specName)
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil
+	}
+
+	// YAML is a superset of JSON, so both output formats decode here
+	var result spec.OpenAPI
+	if err := yaml.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return fmt.Errorf("failed to parse output: %w", err)
+	}
+	*openAPI = result
+	return nil
+}
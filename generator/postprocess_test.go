@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postProcessProject is a project with one route per spec.
+var postProcessProject = map[string]string{
+	"api/doc.go": `// swagger:meta
+// Title: Users API
+// Version: 1.0.0
+package api
+`,
+	"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 204: description:Empty
+func ListUsers() {}
+
+// swagger:route DELETE /users/{id} admin deleteUser
+// spec: admin
+// Responses:
+// - 204: description:Deleted
+func DeleteUser() {}
+`,
+}
+
+// TestGenerateRegisteredPostProcessor tests that registered post-processors mutate every generated spec
+func TestGenerateRegisteredPostProcessor(t *testing.T) {
+	tmpDir := createTestProject(t, postProcessProject)
+
+	t.Cleanup(ClearPostProcessors)
+	RegisterPostProcessor(func(openAPI *spec.OpenAPI) error {
+		for _, pathItem := range openAPI.Paths.PathItems {
+			for _, op := range pathItem.Operations() {
+				op.Responses.Default = &spec.Response{Description: "Unexpected error"}
+			}
+		}
+		return nil
+	})
+	RegisterPostProcessor(func(openAPI *spec.OpenAPI) error {
+		openAPI.Info.Title += " (processed)"
+		return nil
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+	assert.Equal(t, "Users API (processed)", openAPI.Info.Title)
+	assert.Equal(t, "Unexpected error", openAPI.Paths.PathItems["/users"].Get.Responses.Default.Description)
+
+	specs, err := g.GenerateMulti()
+	require.NoError(t, err)
+	require.Contains(t, specs, "admin")
+	for name, s := range specs {
+		assert.Equal(t, "Users API (processed)", s.Info.Title, name)
+		for _, pathItem := range s.Paths.PathItems {
+			for _, op := range pathItem.Operations() {
+				require.NotNil(t, op.Responses.Default, name)
+			}
+		}
+	}
+}
+
+// TestGenerateRegisteredPostProcessorError tests that a failing post-processor fails the generation
+func TestGenerateRegisteredPostProcessorError(t *testing.T) {
+	tmpDir := createTestProject(t, postProcessProject)
+	output := filepath.Join(tmpDir, "openapi.yaml")
+
+	t.Cleanup(ClearPostProcessors)
+	errMissingContact := errors.New("info.contact is required")
+	RegisterPostProcessor(func(*spec.OpenAPI) error { return errMissingContact })
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(output, "yaml"))
+	_, err := g.Generate()
+	require.ErrorIs(t, err, errMissingContact)
+	assert.Contains(t, err.Error(), "post-processor 1")
+	assert.NoFileExists(t, output)
+}
+
+// TestGenerateExternalPostProcessors tests post-processor commands receiving the spec on stdin
+func TestGenerateExternalPostProcessors(t *testing.T) {
+	tmpDir := createTestProject(t, postProcessProject)
+
+	script := func(name, body string) string {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(body), 0755))
+		return "sh " + path
+	}
+	rename := script("rename.sh", `sed "s/\"title\":\"Users API\"/\"title\":\"Users API $OPENAPI_SPEC\"/"`+"\n")
+	check := script("check.sh", "cat > /dev/null\n")
+	fail := script("fail.sh", "cat > /dev/null\necho 'operation deleteUser has no 404 response' >&2\nexit 1\n")
+
+	t.Run("output replaces the spec", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""),
+			WithPostProcessors(rename, check))
+
+		specs, err := g.GenerateMulti()
+		require.NoError(t, err)
+		assert.Equal(t, "Users API admin", specs["admin"].Info.Title)
+		require.NotNil(t, specs["admin"].Paths.PathItems["/users/{id}"].Delete)
+
+		openAPI, err := g.GenerateSpec("admin")
+		require.NoError(t, err)
+		assert.Equal(t, "Users API admin", openAPI.Info.Title)
+	})
+
+	t.Run("failing command", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""),
+			WithPostProcessors(fail))
+
+		_, err := g.Generate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fail.sh")
+		assert.Contains(t, err.Error(), "operation deleteUser has no 404 response")
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""),
+			WithPostProcessors(script("garbage.sh", "cat > /dev/null\necho 'paths: ['\n")))
+
+		_, err := g.Generate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse output")
+	})
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+)
+
+const (
+	// ProblemSchemaName is the component name of the RFC 7807 problem details schema.
+	ProblemSchemaName = "Problem"
+	// ProblemMediaType is the media type of RFC 7807 problem details.
+	ProblemMediaType = "application/problem+json"
+)
+
+// isErrorStatus reports whether a response status code (404, 5XX, default) is an error.
+func isErrorStatus(code string) bool {
+	return code == "default" || strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5")
+}
+
+// problemSchema returns the schema of the untyped error responses of a route: the Problem
+// schema, combined (allOf) with the problem model of the route if any.
+func (g *Generator) problemSchema(r *scanner.RouteInfo) *spec.Schema {
+	problem := &spec.Schema{Ref: schemaRefPrefix + ProblemSchemaName}
+	if g.isKnownType(ProblemSchemaName) {
+		problem = g.typeToSchema(ProblemSchemaName)
+	}
+	if r.Problem == "" {
+		return problem
+	}
+
+	if !g.isKnownType(r.Problem) {
+		g.warnAt(WarnUnknownResponseType, r.Pos, "operation %s: problem model %s is not a model, error responses use the Problem schema",
+			r.OperationID, r.Problem)
+		return problem
+	}
+	return &spec.Schema{AllOf: []*spec.Schema{problem, g.typeToSchema(r.Problem)}}
+}
+
+// canonicalProblemSchema returns the Problem Details object of RFC 7807. Problem types may
+// add fields, so additional properties are allowed.
+func canonicalProblemSchema() *spec.Schema {
+	bound := func(v float64) *float64 { return &v }
+	return &spec.Schema{
+		Type:        spec.NewSchemaType(scanner.TypeObject),
+		Description: "Problem details of an error response (RFC 7807).",
+		Properties: map[string]*spec.Schema{
+			"type": {
+				Type:        spec.NewSchemaType(scanner.TypeString),
+				Format:      "uri-reference",
+				Default:     "about:blank",
+				Description: "A URI reference that identifies the problem type.",
+			},
+			"title": {
+				Type:        spec.NewSchemaType(scanner.TypeString),
+				Description: "A short, human-readable summary of the problem type.",
+			},
+			"status": {
+				Type:        spec.NewSchemaType(scanner.TypeInteger),
+				Format:      "int32",
+				Minimum:     bound(100),
+				Maximum:     bound(599),
+				Description: "The HTTP status code of the response.",
+			},
+			"detail": {
+				Type:        spec.NewSchemaType(scanner.TypeString),
+				Description: "A human-readable explanation specific to this occurrence of the problem.",
+			},
+			"instance": {
+				Type:        spec.NewSchemaType(scanner.TypeString),
+				Format:      "uri-reference",
+				Description: "A URI reference that identifies the specific occurrence of the problem.",
+			},
+		},
+	}
+}
+
+// addProblemSchema adds the canonical Problem schema to the components when error responses
+// reference it and no model named Problem is documented.
+func (g *Generator) addProblemSchema(openAPI *spec.OpenAPI) {
+	if !g.config.ProblemDetails || openAPI.Components == nil {
+		return
+	}
+	if _, ok := openAPI.Components.Schemas[ProblemSchemaName]; ok {
+		return
+	}
+
+	referenced := false
+	forEachSpecSchema(openAPI, func(schema *spec.Schema) {
+		if slices.Contains(schemaRefNames(schema), ProblemSchemaName) {
+			referenced = true
+		}
+	})
+	if !referenced {
+		return
+	}
+
+	if openAPI.Components.Schemas == nil {
+		openAPI.Components.Schemas = make(map[string]*spec.Schema)
+	}
+	openAPI.Components.Schemas[ProblemSchemaName] = canonicalProblemSchema()
+}
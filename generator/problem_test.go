@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationGenerateProblemDetails tests documenting error responses as RFC 7807 problem details
+func TestIntegrationGenerateProblemDetails(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:model ValidationProblem
+type ValidationProblem struct {
+	Errors []string ` + "`json:\"errors\"`" + `
+}
+
+// swagger:model NotFound
+type NotFound struct {
+	Resource string ` + "`json:\"resource\"`" + `
+}
+
+// swagger:route GET /users/me users getUser
+// Responses:
+// - 200: User
+// - 404: NotFound
+// - 500: description:Internal error
+func GetUser() {}
+
+// swagger:route POST /users users createUser
+// problem: ValidationProblem
+// Responses:
+// - 201: User
+// - 400: description:Invalid user
+// - default: description:Unexpected error
+func CreateUser() {}
+`,
+	})
+
+	generate := func(enabled bool) *spec.OpenAPI {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithProblemDetails(enabled))
+		openAPI, err := g.Generate()
+		require.NoError(t, err)
+		assert.Empty(t, warningMessages(g.Warnings()))
+		return openAPI
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		openAPI := generate(true)
+		get := openAPI.Paths.PathItems["/users/me"].Get.Responses
+		post := openAPI.Paths.PathItems["/users"].Post.Responses
+
+		// Success responses keep the produced media types
+		assert.Contains(t, get.StatusCodes["200"].Content, "application/json")
+
+		// Typed error responses keep their type
+		notFound := get.StatusCodes["404"].Content
+		assert.NotContains(t, notFound, "application/json")
+		require.Contains(t, notFound, ProblemMediaType)
+		assert.Equal(t, "#/components/schemas/NotFound", notFound[ProblemMediaType].Schema.Ref)
+
+		// Untyped error responses use the Problem schema
+		internal := get.StatusCodes["500"].Content
+		require.Contains(t, internal, ProblemMediaType)
+		assert.Equal(t, "#/components/schemas/Problem", internal[ProblemMediaType].Schema.Ref)
+
+		// The route problem model extends it
+		for _, response := range []*spec.Response{post.StatusCodes["400"], post.Default} {
+			require.NotNil(t, response)
+			schema := response.Content[ProblemMediaType].Schema
+			require.Len(t, schema.AllOf, 2)
+			assert.Equal(t, "#/components/schemas/Problem", schema.AllOf[0].Ref)
+			assert.Equal(t, "#/components/schemas/ValidationProblem", schema.AllOf[1].Ref)
+		}
+
+		problem := openAPI.Components.Schemas[ProblemSchemaName]
+		require.NotNil(t, problem)
+		assert.ElementsMatch(t, []string{"type", "title", "status", "detail", "instance"}, slices.Collect(maps.Keys(problem.Properties)))
+		assert.Equal(t, "about:blank", problem.Properties["type"].Default)
+		assert.Contains(t, openAPI.Components.Schemas, "ValidationProblem")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		openAPI := generate(false)
+		get := openAPI.Paths.PathItems["/users/me"].Get.Responses
+
+		assert.Contains(t, get.StatusCodes["404"].Content, "application/json")
+		assert.Empty(t, get.StatusCodes["500"].Content)
+		assert.NotContains(t, openAPI.Components.Schemas, ProblemSchemaName)
+	})
+}
+
+// TestIntegrationGenerateProblemDetailsModel tests that a Problem model replaces the canonical schema
+func TestIntegrationGenerateProblemDetailsModel(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model Problem
+type Problem struct {
+	Code  string ` + "`json:\"code\"`" + `
+	Title string ` + "`json:\"title\"`" + `
+}
+
+// swagger:route DELETE /users/me users deleteUser
+// problem: Missing
+// Responses:
+// - 204: description:Deleted
+// - 409: description:Conflict
+func DeleteUser() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithProblemDetails(true))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+	assert.Contains(t, warningMessages(g.Warnings()), "api/users.go:9: operation deleteUser: problem model Missing is not a model, error responses use the Problem schema")
+
+	conflict := openAPI.Paths.PathItems["/users/me"].Delete.Responses.StatusCodes["409"]
+	assert.Equal(t, "#/components/schemas/Problem", conflict.Content[ProblemMediaType].Schema.Ref)
+
+	problem := openAPI.Components.Schemas[ProblemSchemaName]
+	require.NotNil(t, problem)
+	assert.Contains(t, problem.Properties, "code")
+	assert.NotContains(t, problem.Properties, "instance")
+}
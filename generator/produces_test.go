@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResponseProduces tests route-level and meta-level response media types
+func TestResponseProduces(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: My API
+// Version: 1.0.0
+// Produces:
+// - application/json
+// - application/xml
+package api
+`,
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route GET /users/export users exportUsers
+// Produces:
+// - application/json
+// - text/csv: string
+// Responses:
+// - 200: []User
+func ExportUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	t.Run("meta defaults", func(t *testing.T) {
+		content := openAPI.Paths.PathItems["/users"].Get.Responses.StatusCodes["200"].Content
+		require.Len(t, content, 2)
+		assert.Equal(t, "#/components/schemas/User", content["application/json"].Schema.Items.Ref)
+		assert.Equal(t, "#/components/schemas/User", content["application/xml"].Schema.Items.Ref)
+	})
+
+	t.Run("route override with per-type schema", func(t *testing.T) {
+		content := openAPI.Paths.PathItems["/users/export"].Get.Responses.StatusCodes["200"].Content
+		require.Len(t, content, 2)
+		assert.Equal(t, "array", content["application/json"].Schema.Type.Value())
+		assert.Equal(t, "string", content["text/csv"].Schema.Type.Value())
+	})
+}
+
+// TestFileDownloadResponse tests binary file download responses
+func TestFileDownloadResponse(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/reports.go": `package api
+
+// swagger:route GET /reports/{id}/pdf reports downloadReport
+// Responses:
+// - 200: file:application/pdf description:PDF export
+// - 404: description:Not found
+func DownloadReport() {}
+
+// swagger:route GET /reports/{id}/raw reports downloadRaw
+// Responses:
+// - 200: file
+func DownloadRaw() {}
+
+// swagger:route GET /reports/{id}/image reports downloadImage
+// Produces:
+// - image/png
+// - image/jpeg
+// Responses:
+// - 200: file
+func DownloadImage() {}
+
+// swagger:route GET /reports/{id}/archive reports downloadArchive
+// Produces:
+// - application/json
+// Responses:
+// - 200: file
+func DownloadArchive() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	pdf := openAPI.Paths.PathItems["/reports/{id}/pdf"].Get.Responses.StatusCodes["200"]
+	assert.Equal(t, "PDF export", pdf.Description)
+	require.Contains(t, pdf.Content, "application/pdf")
+	assert.Equal(t, "string", pdf.Content["application/pdf"].Schema.Type.Value())
+	assert.Equal(t, "application/pdf", pdf.Content["application/pdf"].Schema.ContentMediaType)
+
+	raw := openAPI.Paths.PathItems["/reports/{id}/raw"].Get.Responses.StatusCodes["200"]
+	require.Contains(t, raw.Content, "application/octet-stream")
+	assert.NotContains(t, raw.Content, "application/json")
+
+	image := openAPI.Paths.PathItems["/reports/{id}/image"].Get.Responses.StatusCodes["200"]
+	require.Len(t, image.Content, 2)
+	assert.Equal(t, "image/jpeg", image.Content["image/jpeg"].Schema.ContentMediaType)
+
+	archive := openAPI.Paths.PathItems["/reports/{id}/archive"].Get.Responses.StatusCodes["200"]
+	require.Len(t, archive.Content, 1)
+	assert.Contains(t, archive.Content, "application/octet-stream")
+}
+
+// TestResponseContentMatrix tests responses declaring a schema per media type
+func TestResponseContentMatrix(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// swagger:model UserRow
+type UserRow struct {
+	Line string ` + "`json:\"line\"`" + `
+}
+
+// swagger:route GET /users/export users exportUsers
+// Responses:
+// - 200: []User (application/json), []UserRow (application/xml), file (text/csv) description:Exported users
+// - 404: description:Not found
+func ExportUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	export := openAPI.Paths.PathItems["/users/export"].Get.Responses.StatusCodes["200"]
+	assert.Equal(t, "Exported users", export.Description)
+	require.Len(t, export.Content, 3)
+
+	json := export.Content["application/json"].Schema
+	assert.Equal(t, "array", json.Type.Value())
+	assert.Equal(t, "#/components/schemas/User", json.Items.Ref)
+
+	xml := export.Content["application/xml"].Schema
+	assert.Equal(t, "#/components/schemas/UserRow", xml.Items.Ref)
+	assert.Contains(t, openAPI.Components.Schemas, "UserRow")
+
+	csv := export.Content["text/csv"].Schema
+	assert.Equal(t, "string", csv.Type.Value())
+	assert.Equal(t, "text/csv", csv.ContentMediaType)
+	assert.Empty(t, g.Warnings())
+}
@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/kausys/openapi/spec"
+)
+
+// Supported gateway profile kinds.
+const (
+	GatewayKong           = "kong"
+	GatewayCloudEndpoints = "cloud-endpoints"
+)
+
+// Profile decorates a generated spec with the vendor extensions required by an API gateway.
+type Profile struct {
+	Gateway             string         // Gateway kind: "kong" or "cloud-endpoints"
+	Name                string         // Kong: service name (x-kong-name)
+	Plugins             map[string]any // Kong: plugins applied globally (x-kong-plugin-<name>)
+	Host                string         // Cloud Endpoints: service host (servers[].x-google-endpoint)
+	AllowCors           bool           // Cloud Endpoints: allow CORS requests on the endpoint
+	Backend             map[string]any // Cloud Endpoints: backend configuration (x-google-backend)
+	Extensions          map[string]any // Extra root-level extensions
+	OperationExtensions map[string]any // Extra extensions added to every operation
+}
+
+var (
+	profiles   = make(map[string]*Profile)
+	profilesMu sync.RWMutex
+)
+
+// RegisterProfile registers a gateway profile by name.
+func RegisterProfile(name string, profile *Profile) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+
+	profiles[name] = profile
+}
+
+// GetProfile returns the registered profile with the given name.
+// Returns nil if the profile is not registered.
+func GetProfile(name string) *Profile {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+
+	return profiles[name]
+}
+
+// ClearProfiles removes all registered profiles.
+// Useful for testing.
+func ClearProfiles() {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+
+	profiles = make(map[string]*Profile)
+}
+
+// applyProfile decorates the spec with the extensions of the configured profile.
+func (g *Generator) applyProfile(openAPI *spec.OpenAPI) {
+	if g.config.Profile == "" {
+		return
+	}
+
+	profile := GetProfile(g.config.Profile)
+	if profile == nil {
+		return
+	}
+
+	switch profile.Gateway {
+	case GatewayKong:
+		applyKongProfile(openAPI, profile)
+	case GatewayCloudEndpoints:
+		applyCloudEndpointsProfile(openAPI, profile)
+	}
+
+	addExtensions(&openAPI.Extensions, profile.Extensions)
+
+	if len(profile.OperationExtensions) > 0 && openAPI.Paths != nil {
+		for _, pathItem := range openAPI.Paths.PathItems {
+			for _, op := range pathItem.Operations() {
+				addExtensions(&op.Extensions, profile.OperationExtensions)
+			}
+		}
+	}
+}
+
+// applyKongProfile adds the extensions understood by Kong's OpenAPI converters.
+func applyKongProfile(openAPI *spec.OpenAPI, profile *Profile) {
+	if profile.Name != "" {
+		addExtensions(&openAPI.Extensions, map[string]any{"x-kong-name": profile.Name})
+	}
+
+	for name, plugin := range profile.Plugins {
+		if plugin == nil {
+			plugin = map[string]any{}
+		}
+		addExtensions(&openAPI.Extensions, map[string]any{"x-kong-plugin-" + name: plugin})
+	}
+}
+
+// applyCloudEndpointsProfile adds the extensions required by Google Cloud Endpoints (ESPv2).
+func applyCloudEndpointsProfile(openAPI *spec.OpenAPI, profile *Profile) {
+	if profile.Host != "" {
+		endpoint := map[string]any{}
+		if profile.AllowCors {
+			endpoint["allowCors"] = true
+		}
+
+		url := profile.Host
+		if !strings.Contains(url, "://") {
+			url = "https://" + url
+		}
+
+		openAPI.Servers = append(openAPI.Servers, &spec.Server{
+			URL:        url,
+			Extensions: spec.Extensions{"x-google-endpoint": endpoint},
+		})
+	}
+
+	if len(profile.Backend) > 0 {
+		addExtensions(&openAPI.Extensions, map[string]any{"x-google-backend": profile.Backend})
+	}
+}
+
+// addExtensions merges extensions into target without overriding existing values,
+// so directives declared in source code take precedence over profile defaults.
+func addExtensions(target *spec.Extensions, extensions map[string]any) {
+	if len(extensions) == 0 {
+		return
+	}
+	if *target == nil {
+		*target = make(spec.Extensions, len(extensions))
+	}
+	for name, value := range extensions {
+		if _, exists := (*target)[name]; !exists && spec.IsExtension(name) {
+			(*target)[name] = value
+		}
+	}
+}
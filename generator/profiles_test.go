@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profilesTestSource = `package api
+
+// swagger:route GET /users users listUsers
+// x-kong-plugin-cache: {"enabled": false}
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+`
+
+// TestKongProfile tests Kong extensions loaded from the config file
+func TestKongProfile(t *testing.T) {
+	t.Cleanup(ClearProfiles)
+
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": profilesTestSource,
+		".openapi.yaml": `profiles:
+  kong:
+    gateway: kong
+    name: users-service
+    plugins:
+      rate-limiting:
+        config:
+          minute: 60
+    operation_extensions:
+      x-kong-plugin-cache:
+        enabled: true
+`,
+	})
+	require.NoError(t, LoadConfigFile(tmpDir))
+
+	g := New(
+		WithDir(tmpDir),
+		WithPattern("./..."),
+		WithCache(false),
+		WithProfile("kong"),
+		WithOutput(filepath.Join(tmpDir, "openapi.json"), "json"),
+	)
+
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	assert.Equal(t, "users-service", openAPI.Extensions["x-kong-name"])
+	assert.Equal(t, map[string]any{"config": map[string]any{"minute": 60}}, openAPI.Extensions["x-kong-plugin-rate-limiting"])
+
+	// Directives in source take precedence over profile defaults
+	op := openAPI.Paths.PathItems["/users"].Get
+	assert.Equal(t, map[string]any{"enabled": false}, op.Extensions["x-kong-plugin-cache"])
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "openapi.json"))
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "users-service", decoded["x-kong-name"])
+}
+
+// TestCloudEndpointsProfile tests Google Cloud Endpoints extensions
+func TestCloudEndpointsProfile(t *testing.T) {
+	t.Cleanup(ClearProfiles)
+
+	RegisterProfile("gcp", &Profile{
+		Gateway:   GatewayCloudEndpoints,
+		Host:      "users.endpoints.my-project.cloud.goog",
+		AllowCors: true,
+		Backend:   map[string]any{"address": "https://users.run.app"},
+	})
+
+	tmpDir := createTestProject(t, map[string]string{"api/users.go": profilesTestSource})
+	g := New(
+		WithDir(tmpDir),
+		WithPattern("./..."),
+		WithCache(false),
+		WithProfile("gcp"),
+		WithOutput("", ""),
+	)
+
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	require.Len(t, openAPI.Servers, 1)
+	assert.Equal(t, "https://users.endpoints.my-project.cloud.goog", openAPI.Servers[0].URL)
+	assert.Equal(t, map[string]any{"allowCors": true}, openAPI.Servers[0].Extensions["x-google-endpoint"])
+	assert.Equal(t, map[string]any{"address": "https://users.run.app"}, openAPI.Extensions["x-google-backend"])
+}
+
+// TestUnknownProfile tests that selecting an unregistered profile fails
+func TestUnknownProfile(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/users.go": profilesTestSource})
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithProfile("missing"), WithOutput("", ""))
+
+	_, err := g.Generate()
+	assert.ErrorContains(t, err, `unknown profile "missing"`)
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"net/http"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+)
+
+// RetryAfterHeader is the header of the 429 responses of rate limited routes.
+const RetryAfterHeader = "Retry-After"
+
+// rateLimitExtension returns the x-ratelimit extension of a rate limited route.
+func rateLimitExtension(rateLimit *scanner.RateLimitInfo) map[string]any {
+	extension := map[string]any{
+		"limit":  rateLimit.Limit,
+		"window": rateLimit.Window,
+	}
+	if rateLimit.Scope != "" {
+		extension["scope"] = rateLimit.Scope
+	}
+	return extension
+}
+
+// paginationExtension returns the x-pagination extension of a paginated route: its style
+// and the names of its query parameters (cursorParam, limitParam, ...).
+func paginationExtension(pagination *scanner.PaginationInfo) map[string]any {
+	extension := map[string]any{"style": pagination.Style}
+	for role, param := range pagination.Params {
+		extension[role+"Param"] = param
+	}
+	return extension
+}
+
+// addTooManyRequests documents the 429 response of a rate limited route with a Retry-After
+// header, adding the response when the route doesn't declare it. With problem details, an
+// added response is the Problem schema of the route.
+func (g *Generator) addTooManyRequests(r *scanner.RouteInfo, responses *spec.Responses) {
+	response, ok := responses.StatusCodes["429"]
+	if !ok {
+		response = &spec.Response{Description: defaultResponseDescription("429")}
+		if g.config.ProblemDetails {
+			response.Content = map[string]*spec.MediaType{ProblemMediaType: {Schema: g.problemSchema(r)}}
+		}
+		responses.StatusCodes["429"] = response
+	}
+
+	for name := range response.Headers {
+		if http.CanonicalHeaderKey(name) == RetryAfterHeader {
+			return
+		}
+	}
+	if response.Headers == nil {
+		response.Headers = make(map[string]*spec.Header)
+	}
+	response.Headers[RetryAfterHeader] = &spec.Header{
+		Description: "Seconds to wait before retrying the request",
+		Schema:      &spec.Schema{Type: spec.NewSchemaType(scanner.TypeInteger)},
+	}
+}
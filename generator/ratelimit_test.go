@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationGenerateRateLimitAndPagination tests the x-ratelimit and x-pagination
+// extensions and the 429 responses of rate limited routes
+func TestIntegrationGenerateRateLimitAndPagination(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// swagger:meta
+// Title: Users API
+// Version: 1.0.0
+package api
+`,
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// ratelimit: 100/minute user
+// pagination: cursor cursor=after
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route POST /users users createUser
+// ratelimit: 10/s
+// Responses:
+// - 201: User
+// - 429: description:Slow down
+func CreateUser() {}
+
+// swagger:route GET /users/export users exportUsers
+// ratelimit: 1/week
+// pagination: keyset
+// Responses:
+// - 200: []User
+func ExportUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithProblemDetails(true))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	list := openAPI.Paths.PathItems["/users"].Get
+	require.NotNil(t, list)
+	assert.Equal(t, map[string]any{"limit": 100, "window": "minute", "scope": "user"}, list.Extensions["x-ratelimit"])
+	assert.Equal(t, map[string]any{"style": "cursor", "cursorParam": "after", "limitParam": "limit"}, list.Extensions["x-pagination"])
+
+	tooMany := list.Responses.StatusCodes["429"]
+	require.NotNil(t, tooMany)
+	assert.Equal(t, "Too Many Requests", tooMany.Description)
+	require.Contains(t, tooMany.Headers, RetryAfterHeader)
+	assert.Equal(t, spec.NewSchemaType("integer"), tooMany.Headers[RetryAfterHeader].Schema.Type)
+	require.Contains(t, tooMany.Content, ProblemMediaType)
+	assert.Equal(t, schemaRefPrefix+ProblemSchemaName, tooMany.Content[ProblemMediaType].Schema.Ref)
+
+	// A declared 429 response keeps its description and gets the Retry-After header
+	create := openAPI.Paths.PathItems["/users"].Post
+	require.NotNil(t, create)
+	assert.Equal(t, map[string]any{"limit": 10, "window": "second"}, create.Extensions["x-ratelimit"])
+	assert.Equal(t, "Slow down", create.Responses.StatusCodes["429"].Description)
+	assert.Contains(t, create.Responses.StatusCodes["429"].Headers, RetryAfterHeader)
+
+	// Invalid directives are reported and ignored
+	export := openAPI.Paths.PathItems["/users/export"].Get
+	require.NotNil(t, export)
+	assert.NotContains(t, export.Extensions, "x-ratelimit")
+	assert.NotContains(t, export.Extensions, "x-pagination")
+	assert.NotContains(t, export.Responses.StatusCodes, "429")
+
+	var codes []string
+	for _, w := range g.Warnings() {
+		codes = append(codes, w.Code)
+	}
+	assert.Contains(t, codes, WarnInvalidRateLimit)
+	assert.Contains(t, codes, WarnInvalidPagination)
+}
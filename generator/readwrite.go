@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/spec"
+)
+
+// Suffixes of the model variants derived with WithSplitReadWrite.
+const (
+	RequestModelSuffix  = "Request"
+	ResponseModelSuffix = "Response"
+)
+
+// splitReadWriteModels derives <Model>Request and <Model>Response variants of the models
+// with readOnly or writeOnly properties: request variants drop the readOnly properties and
+// response variants drop the writeOnly ones. Models referencing a split model are split too,
+// so the variants reference each other. Request bodies and responses are pointed at the
+// variants, and variants nothing references are removed. The original models are kept.
+func (g *Generator) splitReadWriteModels(openAPI *spec.OpenAPI) {
+	if !g.config.SplitReadWrite || openAPI.Components == nil {
+		return
+	}
+	schemas := openAPI.Components.Schemas
+
+	// Models with a conflicting variant name are not split, nor are the models split
+	// only because they reference one, so the propagation restarts without them
+	excluded := make(map[string]bool)
+	split := splitModelNames(schemas, excluded)
+	for conflict := true; conflict; {
+		conflict = false
+		for _, name := range slices.Sorted(maps.Keys(split)) {
+			for _, suffix := range []string{RequestModelSuffix, ResponseModelSuffix} {
+				if _, exists := schemas[name+suffix]; exists {
+					g.warn(WarnSplitModelConflict, "model %s: %s already exists, request and response variants not derived", name, name+suffix)
+					excluded[name] = true
+					conflict = true
+					break
+				}
+			}
+		}
+		if conflict {
+			split = splitModelNames(schemas, excluded)
+		}
+	}
+	if len(split) == 0 {
+		return
+	}
+
+	variants := make(map[*spec.Schema]bool, 2*len(split))
+	for _, name := range slices.Sorted(maps.Keys(split)) {
+		request := schemaVariant(schemas[name], split, RequestModelSuffix, func(s *spec.Schema) bool { return s.ReadOnly })
+		response := schemaVariant(schemas[name], split, ResponseModelSuffix, func(s *spec.Schema) bool { return s.WriteOnly })
+		schemas[name+RequestModelSuffix] = request
+		schemas[name+ResponseModelSuffix] = response
+		variants[request] = true
+		variants[response] = true
+	}
+
+	requestVisit := func(schema *spec.Schema) { renameSchemaRefs(schema, split, RequestModelSuffix) }
+	responseVisit := func(schema *spec.Schema) { renameSchemaRefs(schema, split, ResponseModelSuffix) }
+	for _, body := range openAPI.Components.RequestBodies {
+		if body != nil {
+			visitContent(body.Content, requestVisit)
+		}
+	}
+	for _, resp := range openAPI.Components.Responses {
+		if resp != nil {
+			visitContent(resp.Content, responseVisit)
+		}
+	}
+	if openAPI.Paths != nil {
+		for _, pathItem := range openAPI.Paths.PathItems {
+			for _, op := range pathItem.Operations() {
+				if op.RequestBody != nil {
+					visitContent(op.RequestBody.Content, requestVisit)
+				}
+				if op.Responses == nil {
+					continue
+				}
+				for _, resp := range op.Responses.StatusCodes {
+					if resp != nil {
+						visitContent(resp.Content, responseVisit)
+					}
+				}
+				if op.Responses.Default != nil {
+					visitContent(op.Responses.Default.Content, responseVisit)
+				}
+			}
+		}
+	}
+
+	// Remove the variants that are not referenced outside of other variants
+	used := make(map[string]bool)
+	var queue []string
+	forEachSpecSchema(openAPI, func(schema *spec.Schema) {
+		if !variants[schema] {
+			queue = append(queue, schemaRefNames(schema)...)
+		}
+	})
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		queue = append(queue, schemaRefNames(schemas[name])...)
+	}
+	for name := range split {
+		for _, variant := range []string{name + RequestModelSuffix, name + ResponseModelSuffix} {
+			if !used[variant] {
+				delete(schemas, variant)
+			}
+		}
+	}
+}
+
+// hasAccessModifiers reports whether a schema has readOnly or writeOnly sub-schemas.
+func hasAccessModifiers(schema *spec.Schema) bool {
+	found := false
+	walkSchema(schema, func(s *spec.Schema) {
+		if s != schema && (s.ReadOnly || s.WriteOnly) {
+			found = true
+		}
+	})
+	return found
+}
+
+// splitModelNames returns the models with readOnly or writeOnly properties and the models
+// referencing them, directly or through other models, leaving out the excluded models.
+func splitModelNames(schemas map[string]*spec.Schema, excluded map[string]bool) map[string]bool {
+	split := make(map[string]bool)
+	for name, schema := range schemas {
+		if !excluded[name] && hasAccessModifiers(schema) {
+			split[name] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for name, schema := range schemas {
+			if !split[name] && !excluded[name] && slices.ContainsFunc(schemaRefNames(schema), func(ref string) bool { return split[ref] }) {
+				split[name] = true
+				changed = true
+			}
+		}
+	}
+	return split
+}
+
+// schemaVariant returns a copy of a schema without the properties matching drop,
+// referencing the variants of the split models.
+func schemaVariant(schema *spec.Schema, split map[string]bool, suffix string, drop func(*spec.Schema) bool) *spec.Schema {
+	variant := schema.Clone()
+	walkSchema(variant, func(s *spec.Schema) {
+		for name, prop := range s.Properties {
+			if prop != nil && drop(prop) {
+				delete(s.Properties, name)
+				s.Required = slices.DeleteFunc(s.Required, func(required string) bool { return required == name })
+			}
+		}
+	})
+	renameSchemaRefs(variant, split, suffix)
+	return variant
+}
+
+// renameSchemaRefs points the references to split models at their variants.
+func renameSchemaRefs(schema *spec.Schema, split map[string]bool, suffix string) {
+	rename := func(ref string) string {
+		if name, ok := strings.CutPrefix(ref, schemaRefPrefix); ok && split[name] {
+			return ref + suffix
+		}
+		return ref
+	}
+
+	walkSchema(schema, func(s *spec.Schema) {
+		s.Ref = rename(s.Ref)
+		if s.Discriminator != nil {
+			for value, ref := range s.Discriminator.Mapping {
+				s.Discriminator.Mapping[value] = rename(ref)
+			}
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readWriteProject has models with readOnly and writeOnly fields used in requests and responses.
+var readWriteProject = map[string]string{
+	"api/users.go": `package api
+
+// swagger:model Address
+type Address struct {
+	// readOnly: true
+	Verified bool ` + "`json:\"verified\"`" + `
+	City string ` + "`json:\"city\"`" + `
+}
+
+// swagger:model Team
+type Team struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:model User
+type User struct {
+	// readOnly: true
+	// required: true
+	ID string ` + "`json:\"id\"`" + `
+	// required: true
+	Name string ` + "`json:\"name\"`" + `
+	// writeOnly: true
+	Password string ` + "`json:\"password\"`" + `
+	Address Address ` + "`json:\"address\"`" + `
+	Team Team ` + "`json:\"team\"`" + `
+}
+
+// swagger:model AuditEntry
+type AuditEntry struct {
+	// readOnly: true
+	At string ` + "`json:\"at\"`" + `
+}
+
+// swagger:route POST /users users createUser
+// RequestBody: User required
+// Responses:
+// - 201: User
+func CreateUser() {}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route GET /audit audit listAudit
+// Responses:
+// - 200: []AuditEntry
+func ListAudit() {}
+`,
+}
+
+// TestGenerateSplitReadWrite tests deriving request and response variants from readOnly/writeOnly fields
+func TestGenerateSplitReadWrite(t *testing.T) {
+	tmpDir := createTestProject(t, readWriteProject)
+
+	for _, multi := range []bool{false, true} {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithSplitReadWrite(true))
+
+		var openAPI *spec.OpenAPI
+		if multi {
+			specs, err := g.GenerateMulti()
+			require.NoError(t, err)
+			require.Len(t, specs, 1)
+			for _, s := range specs {
+				openAPI = s
+			}
+		} else {
+			var err error
+			openAPI, err = g.Generate()
+			require.NoError(t, err)
+		}
+		schemas := openAPI.Components.Schemas
+
+		// The original models are kept
+		require.Contains(t, schemas, "User")
+		assert.Contains(t, schemas["User"].Properties, "id")
+		assert.Contains(t, schemas, "Address")
+
+		request := schemas["UserRequest"]
+		require.NotNil(t, request)
+		assert.NotContains(t, request.Properties, "id")
+		assert.Contains(t, request.Properties, "password")
+		assert.Equal(t, []string{"name"}, request.Required)
+		assert.Equal(t, "#/components/schemas/AddressRequest", request.Properties["address"].Ref)
+		assert.Equal(t, "#/components/schemas/Team", request.Properties["team"].Ref)
+
+		response := schemas["UserResponse"]
+		require.NotNil(t, response)
+		assert.Contains(t, response.Properties, "id")
+		assert.NotContains(t, response.Properties, "password")
+		assert.Equal(t, "#/components/schemas/AddressResponse", response.Properties["address"].Ref)
+
+		require.Contains(t, schemas, "AddressRequest")
+		assert.NotContains(t, schemas["AddressRequest"].Properties, "verified")
+		assert.Contains(t, schemas["AddressResponse"].Properties, "verified")
+		assert.NotContains(t, schemas, "TeamRequest")
+
+		users := openAPI.Paths.PathItems["/users"]
+		assert.Equal(t, "#/components/schemas/UserRequest", users.Post.RequestBody.Content["application/json"].Schema.Ref)
+		assert.Equal(t, "#/components/schemas/UserResponse", users.Post.Responses.StatusCodes["201"].Content["application/json"].Schema.Ref)
+		assert.Equal(t, "#/components/schemas/UserResponse", users.Get.Responses.StatusCodes["200"].Content["application/json"].Schema.Items.Ref)
+
+		// Variants nothing references are removed
+		assert.Contains(t, schemas, "AuditEntryResponse")
+		assert.NotContains(t, schemas, "AuditEntryRequest")
+	}
+}
+
+// TestGenerateSplitReadWriteConflict tests that models whose variant names are taken are not split,
+// nor the models referencing them
+func TestGenerateSplitReadWriteConflict(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	// readOnly: true
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:model UserResponse
+type UserResponse struct {
+	Users []User ` + "`json:\"users\"`" + `
+}
+
+// swagger:route POST /users users createUser
+// RequestBody: User
+// Responses:
+// - 200: UserResponse
+func CreateUser() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithSplitReadWrite(true))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	assert.NotContains(t, openAPI.Components.Schemas, "UserRequest")
+	assert.Equal(t, "#/components/schemas/User", openAPI.Paths.PathItems["/users"].Post.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Contains(t, warningMessages(g.Warnings()), "model User: UserResponse already exists, request and response variants not derived")
+
+	// UserResponse was only split because it references User
+	assert.NotContains(t, openAPI.Components.Schemas, "UserResponseRequest")
+	assert.NotContains(t, openAPI.Components.Schemas, "UserResponseResponse")
+	assert.Equal(t, "#/components/schemas/UserResponse", openAPI.Paths.PathItems["/users"].Post.Responses.StatusCodes["200"].Content["application/json"].Schema.Ref)
+}
+
+// TestGenerateSplitReadWriteDisabled tests that no variants are derived by default
+func TestGenerateSplitReadWriteDisabled(t *testing.T) {
+	tmpDir := createTestProject(t, readWriteProject)
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	assert.NotContains(t, openAPI.Components.Schemas, "UserRequest")
+	assert.NotContains(t, openAPI.Components.Schemas, "UserResponse")
+	assert.Equal(t, "#/components/schemas/User", openAPI.Paths.PathItems["/users"].Post.RequestBody.Content["application/json"].Schema.Ref)
+}
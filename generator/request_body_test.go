@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRouteRequestBody tests request bodies declared with the RequestBody route directive
+func TestRouteRequestBody(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model CreateUserRequest
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:route POST /users users createUser
+// RequestBody: CreateUserRequest required The user to create
+// Responses:
+// - 201: description:Created
+func CreateUser() {}
+
+// swagger:route PUT /users users replaceUsers
+// Consumes:
+// - application/json
+// - application/xml
+// RequestBody: []CreateUserRequest
+// Responses:
+// - 204: description:Replaced
+func ReplaceUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	t.Run("single model", func(t *testing.T) {
+		op := openAPI.Paths.PathItems["/users"].Post
+		require.NotNil(t, op.RequestBody)
+		assert.True(t, op.RequestBody.Required)
+		assert.Equal(t, "The user to create", op.RequestBody.Description)
+		assert.Equal(t, "#/components/schemas/CreateUserRequest", op.RequestBody.Content["application/json"].Schema.Ref)
+		assert.Empty(t, op.Parameters)
+		assert.Empty(t, op.Description)
+	})
+
+	t.Run("array with consumes", func(t *testing.T) {
+		body := openAPI.Paths.PathItems["/users"].Put.RequestBody
+		require.NotNil(t, body)
+		assert.False(t, body.Required)
+		require.Len(t, body.Content, 2)
+		schema := body.Content["application/xml"].Schema
+		assert.Equal(t, "array", schema.Type.Value())
+		assert.Equal(t, "#/components/schemas/CreateUserRequest", schema.Items.Ref)
+	})
+}
+
+// TestRequestBodyMethods tests the allowBody opt-in and QUERY operations
+func TestRequestBodyMethods(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/search.go": `package api
+
+// swagger:model Filter
+type Filter struct {
+	Term string ` + "`json:\"term\"`" + `
+}
+
+// swagger:route GET /search search searchGet
+// RequestBody: Filter
+// Responses:
+// - 200: description:OK
+func SearchGet() {}
+
+// swagger:route DELETE /search search searchDelete
+// allowBody: true
+// RequestBody: Filter
+// Responses:
+// - 204: description:Deleted
+func SearchDelete() {}
+
+// swagger:route QUERY /search search searchQuery
+// RequestBody: Filter required
+// Responses:
+// - 200: description:OK
+func SearchQuery() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	pathItem := openAPI.Paths.PathItems["/search"]
+	require.NotNil(t, pathItem.Get)
+	assert.Nil(t, pathItem.Get.RequestBody)
+
+	require.NotNil(t, pathItem.Delete)
+	assert.NotNil(t, pathItem.Delete.RequestBody)
+
+	require.NotNil(t, pathItem.Query)
+	assert.Equal(t, "searchQuery", pathItem.Query.OperationID)
+	require.NotNil(t, pathItem.Query.RequestBody)
+	assert.True(t, pathItem.Query.RequestBody.Required)
+
+	// query operations were introduced in OpenAPI 3.2
+	assert.Equal(t, "3.2.0", openAPI.OpenAPI)
+	data, err := encodeSpec(openAPI, FormatYAML, false)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "openapi: 3.2.0\n")
+
+	assert.Equal(t, []string{
+		"api/search.go:8: operation searchGet: request body dropped for GET, add allowBody: true to keep it",
+	}, warningMessages(g.Warnings()))
+}
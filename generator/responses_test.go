@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationGenerateResponseRanges tests status code ranges and default response descriptions
+func TestIntegrationGenerateResponseRanges(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:model Problem
+type Problem struct {
+	Title string ` + "`json:\"title\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+// - 2XX: description:Other successes
+// - 4xx: Problem
+// - 5XX: Problem description:Server failure
+// - 299: description:
+// - default: Problem
+func ListUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	responses := openAPI.Paths.PathItems["/users"].Get.Responses
+	require.Len(t, responses.StatusCodes, 5)
+
+	assert.Equal(t, "OK", responses.StatusCodes["200"].Description)
+	assert.Equal(t, "Other successes", responses.StatusCodes["2XX"].Description)
+	assert.Equal(t, "Client error", responses.StatusCodes["4XX"].Description)
+	assert.Equal(t, "Server failure", responses.StatusCodes["5XX"].Description)
+	assert.Equal(t, "Success", responses.StatusCodes["299"].Description)
+	require.NotNil(t, responses.Default)
+	assert.Equal(t, "Unexpected error", responses.Default.Description)
+
+	schema := responses.StatusCodes["4XX"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/Problem", schema.Ref)
+}
+
+// TestGenerateInvalidResponseStatusCode tests that a mistyped status code fails the scan
+func TestGenerateInvalidResponseStatusCode(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 20O: description:OK
+func ListUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	_, err := g.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api/users.go:5: operation listUsers: invalid response status code \"20O\"")
+}
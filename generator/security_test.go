@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// TestGlobalSecurity tests meta-level security and the route-level opt-out
+func TestGlobalSecurity(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: Secure API
+// Version: 1.0.0
+// SecuritySchemes:
+// - bearer:
+//   type: http
+//   scheme: bearer
+// - apiKey:
+//   type: apiKey
+//   in: header
+//   name: X-API-Key
+// Security:
+// - bearer
+package api
+`,
+		"api/health.go": `package api
+
+// swagger:route GET /health health getHealth
+// Security: none
+// Responses:
+// - 200: description:OK
+func GetHealth() {}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route GET /keys keys listKeys
+// Security:
+// - apiKey
+// Responses:
+// - 200: description:OK
+func ListKeys() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	require.Len(t, openAPI.Security, 1)
+	assert.Equal(t, map[string][]string{"bearer": {}}, openAPI.Security[0].Requirements)
+
+	health := openAPI.Paths.PathItems["/health"].Get
+	require.NotNil(t, health.Security)
+	assert.Empty(t, health.Security)
+
+	assert.Nil(t, openAPI.Paths.PathItems["/users"].Get.Security)
+	require.Len(t, openAPI.Paths.PathItems["/keys"].Get.Security, 1)
+
+	t.Run("empty security is serialized", func(t *testing.T) {
+		data, err := json.Marshal(health)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"security":[]`)
+
+		data, err = yaml.Marshal(health)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "security: []")
+
+		data, err = yaml.Marshal(openAPI.Paths.PathItems["/users"].Get)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "security")
+	})
+}
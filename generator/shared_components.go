@@ -0,0 +1,289 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/spec"
+)
+
+// SharedComponentsName is the base name of the shared components file written in multi-spec mode.
+const SharedComponentsName = "components"
+
+// schemaRefPrefix is the prefix of local references to component schemas.
+const schemaRefPrefix = "#/components/schemas/"
+
+// extractSharedComponents moves the schemas that are identical in at least two specs
+// into a shared components document and returns copies of the specs whose references
+// point to it (e.g., components.yaml#/components/schemas/User). The given specs are
+// left unchanged. Returns the specs as is and a nil document when no schema is shared.
+func (g *Generator) extractSharedComponents(specs map[string]*spec.OpenAPI, file string) (map[string]*spec.OpenAPI, *spec.OpenAPI) {
+	shared := sharedSchemaNames(specs)
+	if len(shared) == 0 {
+		return specs, nil
+	}
+
+	rewritten := make(map[string]*spec.OpenAPI, len(specs))
+	components := &spec.Components{Schemas: make(map[string]*spec.Schema, len(shared))}
+	for _, specName := range slices.Sorted(maps.Keys(specs)) {
+		openAPI := specs[specName].Clone()
+		rewritten[specName] = openAPI
+		if openAPI.Components == nil {
+			continue
+		}
+		for name, schema := range openAPI.Components.Schemas {
+			if !shared[name] {
+				continue
+			}
+			if _, exists := components.Schemas[name]; !exists {
+				components.Schemas[name] = schema
+			}
+			delete(openAPI.Components.Schemas, name)
+		}
+		rewriteSpecSchemaRefs(openAPI, shared, file)
+	}
+
+	info := &spec.Info{Title: "Shared Components", Version: "1.0.0"}
+	if g.scanner.Meta != nil && g.scanner.Meta.Version != "" {
+		info.Version = g.scanner.Meta.Version
+	}
+
+	return rewritten, &spec.OpenAPI{
+		OpenAPI:    openAPIVersion,
+		Info:       info,
+		Components: components,
+	}
+}
+
+// sharedSchemaNames returns the schemas declared by at least two specs with identical content.
+// Schemas referencing a schema that is not shared stay in each spec, since the
+// components document could not resolve the reference.
+func sharedSchemaNames(specs map[string]*spec.OpenAPI) map[string]bool {
+	count := make(map[string]int)
+	schemas := make(map[string]*spec.Schema)
+	conflicting := make(map[string]bool)
+
+	for _, openAPI := range specs {
+		if openAPI.Components == nil {
+			continue
+		}
+		for name, schema := range openAPI.Components.Schemas {
+			if previous, ok := schemas[name]; ok && !previous.Equal(schema) {
+				conflicting[name] = true
+			}
+			schemas[name] = schema
+			count[name]++
+		}
+	}
+
+	shared := make(map[string]bool)
+	for name, n := range count {
+		if n > 1 && !conflicting[name] {
+			shared[name] = true
+		}
+	}
+
+	// Drop schemas depending on non-shared schemas until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for name := range shared {
+			for _, ref := range schemaRefNames(schemas[name]) {
+				if !shared[ref] {
+					delete(shared, name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	return shared
+}
+
+// schemaRefNames returns the names of all component schemas referenced by a schema,
+// including references nested in sub-schemas and discriminator mappings.
+func schemaRefNames(schema *spec.Schema) []string {
+	var names []string
+	walkSchema(schema, func(s *spec.Schema) {
+		if name, ok := strings.CutPrefix(s.Ref, schemaRefPrefix); ok {
+			names = append(names, name)
+		}
+		if s.Discriminator != nil {
+			for _, ref := range s.Discriminator.Mapping {
+				if name, ok := strings.CutPrefix(ref, schemaRefPrefix); ok {
+					names = append(names, name)
+				}
+			}
+		}
+	})
+	return names
+}
+
+// rewriteSpecSchemaRefs points the references to shared schemas at the components file.
+func rewriteSpecSchemaRefs(openAPI *spec.OpenAPI, shared map[string]bool, file string) {
+	rewrite := func(ref string) string {
+		if name, ok := strings.CutPrefix(ref, schemaRefPrefix); ok && shared[name] {
+			return file + ref
+		}
+		return ref
+	}
+
+	forEachSpecSchema(openAPI, func(schema *spec.Schema) {
+		walkSchema(schema, func(s *spec.Schema) {
+			s.Ref = rewrite(s.Ref)
+			if s.Discriminator != nil {
+				for key, ref := range s.Discriminator.Mapping {
+					s.Discriminator.Mapping[key] = rewrite(ref)
+				}
+			}
+		})
+	})
+}
+
+// forEachSpecSchema calls visit for the top-level schemas of the components, parameters,
+// request bodies, responses, and headers of a spec, including those of its webhooks and
+// callbacks. Sub-schemas are reached with walkSchema.
+func forEachSpecSchema(openAPI *spec.OpenAPI, visit func(*spec.Schema)) {
+	if components := openAPI.Components; components != nil {
+		for _, schema := range components.Schemas {
+			visit(schema)
+		}
+		for _, param := range components.Parameters {
+			visitParameter(param, visit)
+		}
+		for _, body := range components.RequestBodies {
+			visitRequestBody(body, visit)
+		}
+		for _, resp := range components.Responses {
+			visitResponse(resp, visit)
+		}
+		for _, header := range components.Headers {
+			visitHeader(header, visit)
+		}
+		for _, callback := range components.Callbacks {
+			visitCallback(callback, visit)
+		}
+		for _, pathItem := range components.PathItems {
+			visitPathItem(pathItem, visit)
+		}
+	}
+	for _, pathItem := range openAPI.Webhooks {
+		visitPathItem(pathItem, visit)
+	}
+	if openAPI.Paths == nil {
+		return
+	}
+	for _, pathItem := range openAPI.Paths.PathItems {
+		visitPathItem(pathItem, visit)
+	}
+}
+
+// visitPathItem calls visit for the schemas of the parameters, request bodies, responses,
+// and callbacks of a path item's operations.
+func visitPathItem(pathItem *spec.PathItem, visit func(*spec.Schema)) {
+	if pathItem == nil {
+		return
+	}
+	for _, param := range pathItem.Parameters {
+		visitParameter(param, visit)
+	}
+	for _, op := range pathItem.Operations() {
+		for _, param := range op.Parameters {
+			visitParameter(param, visit)
+		}
+		visitRequestBody(op.RequestBody, visit)
+		if op.Responses != nil {
+			for _, resp := range op.Responses.StatusCodes {
+				visitResponse(resp, visit)
+			}
+			visitResponse(op.Responses.Default, visit)
+		}
+		for _, callback := range op.Callbacks {
+			visitCallback(callback, visit)
+		}
+	}
+}
+
+// visitCallback calls visit for the schemas of the path items of a callback.
+func visitCallback(callback *spec.Callback, visit func(*spec.Schema)) {
+	if callback == nil {
+		return
+	}
+	for _, pathItem := range callback.PathItems {
+		visitPathItem(pathItem, visit)
+	}
+}
+
+// visitRequestBody calls visit for the schemas of a request body.
+func visitRequestBody(body *spec.RequestBody, visit func(*spec.Schema)) {
+	if body != nil {
+		visitContent(body.Content, visit)
+	}
+}
+
+// visitResponse calls visit for the schemas of a response and its headers.
+func visitResponse(resp *spec.Response, visit func(*spec.Schema)) {
+	if resp == nil {
+		return
+	}
+	visitContent(resp.Content, visit)
+	for _, header := range resp.Headers {
+		visitHeader(header, visit)
+	}
+}
+
+// visitHeader calls visit for the schemas of a header.
+func visitHeader(header *spec.Header, visit func(*spec.Schema)) {
+	if header == nil {
+		return
+	}
+	visit(header.Schema)
+	visitContent(header.Content, visit)
+}
+
+// visitParameter calls visit for the schemas of a parameter.
+func visitParameter(param *spec.Parameter, visit func(*spec.Schema)) {
+	if param == nil {
+		return
+	}
+	visit(param.Schema)
+	visitContent(param.Content, visit)
+}
+
+// visitContent calls visit for the schema of every media type.
+func visitContent(content map[string]*spec.MediaType, visit func(*spec.Schema)) {
+	for _, mediaType := range content {
+		if mediaType != nil {
+			visit(mediaType.Schema)
+		}
+	}
+}
+
+// walkSchema calls fn for a schema and all of its sub-schemas.
+func walkSchema(schema *spec.Schema, fn func(*spec.Schema)) {
+	if schema == nil {
+		return
+	}
+	fn(schema)
+
+	for _, s := range []*spec.Schema{
+		schema.Not, schema.If, schema.Then, schema.Else, schema.Items, schema.Contains,
+		schema.AdditionalProperties, schema.PropertyNames, schema.UnevaluatedProperties, schema.UnevaluatedItems,
+		schema.ContentSchema,
+	} {
+		walkSchema(s, fn)
+	}
+	for _, list := range [][]*spec.Schema{schema.AllOf, schema.OneOf, schema.AnyOf, schema.PrefixItems} {
+		for _, s := range list {
+			walkSchema(s, fn)
+		}
+	}
+	for _, m := range []map[string]*spec.Schema{
+		schema.Properties, schema.PatternProperties, schema.Defs, schema.DependentSchemas,
+	} {
+		for _, s := range m {
+			walkSchema(s, fn)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSharedSchemaNames(t *testing.T) {
+	specs := map[string]*spec.OpenAPI{
+		"admin": {Components: &spec.Components{Schemas: map[string]*spec.Schema{
+			"User":    {Type: spec.NewSchemaType("object"), Properties: map[string]*spec.Schema{"role": {Ref: "#/components/schemas/Role"}}},
+			"Role":    {Type: spec.NewSchemaType("string")},
+			"Account": {Type: spec.NewSchemaType("object"), Properties: map[string]*spec.Schema{"owner": {Ref: "#/components/schemas/Owner"}}},
+			"Owner":   {Type: spec.NewSchemaType("object"), Description: "admin owner"},
+			"Audit":   {Type: spec.NewSchemaType("object")},
+		}}},
+		"public": {Components: &spec.Components{Schemas: map[string]*spec.Schema{
+			"User":    {Type: spec.NewSchemaType("object"), Properties: map[string]*spec.Schema{"role": {Ref: "#/components/schemas/Role"}}},
+			"Role":    {Type: spec.NewSchemaType("string")},
+			"Account": {Type: spec.NewSchemaType("object"), Properties: map[string]*spec.Schema{"owner": {Ref: "#/components/schemas/Owner"}}},
+			"Owner":   {Type: spec.NewSchemaType("object"), Description: "public owner"},
+		}}},
+	}
+
+	shared := sharedSchemaNames(specs)
+
+	// Owner differs between specs, so Account (which references it) is not shared either.
+	// Audit only appears in one spec.
+	assert.Equal(t, map[string]bool{"User": true, "Role": true}, shared)
+}
+
+func TestRewriteSpecSchemaRefs(t *testing.T) {
+	userRef := func() *spec.Schema { return &spec.Schema{Ref: "#/components/schemas/User"} }
+	userContent := func() map[string]*spec.MediaType {
+		return map[string]*spec.MediaType{"application/json": {Schema: userRef()}}
+	}
+	event := func() *spec.PathItem {
+		return &spec.PathItem{Post: &spec.Operation{RequestBody: &spec.RequestBody{Content: userContent()}}}
+	}
+
+	openAPI := &spec.OpenAPI{
+		Paths: &spec.Paths{PathItems: map[string]*spec.PathItem{
+			"/users": {Post: &spec.Operation{
+				Callbacks: map[string]*spec.Callback{
+					"onCreated": {PathItems: map[string]*spec.PathItem{"{$request.body#/callback}": event()}},
+				},
+			}},
+		}},
+		Webhooks: map[string]*spec.PathItem{"userCreated": event()},
+		Components: &spec.Components{
+			Responses:     map[string]*spec.Response{"UserResponse": {Content: userContent()}},
+			Parameters:    map[string]*spec.Parameter{"UserFilter": {Schema: userRef()}},
+			RequestBodies: map[string]*spec.RequestBody{"UserBody": {Content: userContent()}},
+		},
+	}
+
+	rewriteSpecSchemaRefs(openAPI, map[string]bool{"User": true}, "components.yaml")
+
+	const want = "components.yaml#/components/schemas/User"
+	callback := openAPI.Paths.PathItems["/users"].Post.Callbacks["onCreated"].PathItems["{$request.body#/callback}"]
+	assert.Equal(t, want, callback.Post.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, want, openAPI.Webhooks["userCreated"].Post.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, want, openAPI.Components.Responses["UserResponse"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, want, openAPI.Components.Parameters["UserFilter"].Schema.Ref)
+	assert.Equal(t, want, openAPI.Components.RequestBodies["UserBody"].Content["application/json"].Schema.Ref)
+}
+
+func TestIntegrationGenerateMultiSharedComponents(t *testing.T) {
+	files := map[string]string{
+		"api/handlers.go": `package api
+
+// swagger:route GET /admin/users admin listAdminUsers
+// Responses:
+// - 200: []User
+// spec: admin
+func ListAdminUsers() {}
+
+// swagger:route GET /users public listPublicUsers
+// Responses:
+// - 200: []User
+// - 400: Error
+// spec: public
+func ListPublicUsers() {}
+`,
+		"api/models.go": `package api
+
+// swagger:model User
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Role Role   ` + "`json:\"role\"`" + `
+}
+
+// swagger:model Role
+type Role struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:model Error
+type Error struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	outputDir := filepath.Join(tmpDir, "specs")
+
+	g := New(
+		WithDir(tmpDir),
+		WithPattern("./..."),
+		WithCache(false),
+		WithOutput(filepath.Join(outputDir, "openapi.yaml"), "yaml"),
+		WithSharedComponents(true),
+	)
+
+	specs, err := g.GenerateMulti()
+	require.NoError(t, err)
+
+	// Shared components file holds the schemas used by both specs
+	data, err := os.ReadFile(filepath.Join(outputDir, "components.yaml"))
+	require.NoError(t, err)
+	var shared spec.OpenAPI
+	require.NoError(t, yaml.Unmarshal(data, &shared))
+	require.NotNil(t, shared.Components)
+	assert.Contains(t, shared.Components.Schemas, "User")
+	assert.Contains(t, shared.Components.Schemas, "Role")
+	assert.NotContains(t, shared.Components.Schemas, "Error")
+	assert.Equal(t, "#/components/schemas/Role", shared.Components.Schemas["User"].Properties["role"].Ref)
+
+	// Per-spec files reference it instead of duplicating the schemas
+	readSpec := func(name string) *spec.OpenAPI {
+		data, err := os.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err)
+		var openAPI spec.OpenAPI
+		require.NoError(t, yaml.Unmarshal(data, &openAPI))
+		return &openAPI
+	}
+
+	admin := readSpec("admin.yaml")
+	assert.Empty(t, admin.Components.Schemas)
+	content := admin.Paths.PathItems["/admin/users"].Get.Responses.StatusCodes["200"].Content["application/json"]
+	assert.Equal(t, "components.yaml#/components/schemas/User", content.Schema.Items.Ref)
+
+	public := readSpec("public.yaml")
+	assert.Contains(t, public.Components.Schemas, "Error")
+	errContent := public.Paths.PathItems["/users"].Get.Responses.StatusCodes["400"].Content["application/json"]
+	assert.Equal(t, "#/components/schemas/Error", errContent.Schema.Ref)
+
+	// The returned specs stay self-contained
+	require.NotNil(t, specs["admin"])
+	assert.Contains(t, specs["admin"].Components.Schemas, "User")
+	content = specs["admin"].Paths.PathItems["/admin/users"].Get.Responses.StatusCodes["200"].Content["application/json"]
+	assert.Equal(t, "#/components/schemas/User", content.Schema.Items.Ref)
+}
+
+func TestWriteMultiOutputSharedComponentsNameConflict(t *testing.T) {
+	g := createTestGenerator()
+	g.config.OutputFile = filepath.Join(t.TempDir(), "openapi.yaml")
+	g.config.SharedComponents = true
+
+	err := g.writeMultiOutput(map[string]*spec.OpenAPI{
+		SharedComponentsName: {OpenAPI: "3.1.2"},
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with the shared components file")
+}
@@ -0,0 +1,35 @@
+package generator
+
+import "sync"
+
+var (
+	tagDescriptions   = make(map[string]string)
+	tagDescriptionsMu sync.RWMutex
+)
+
+// RegisterTagDescription registers the description used for a tag that is
+// referenced by routes but not declared in swagger:meta.
+func RegisterTagDescription(name, description string) {
+	tagDescriptionsMu.Lock()
+	defer tagDescriptionsMu.Unlock()
+
+	tagDescriptions[name] = description
+}
+
+// GetTagDescription returns the registered description for a tag.
+// Returns an empty string if the tag is not registered.
+func GetTagDescription(name string) string {
+	tagDescriptionsMu.RLock()
+	defer tagDescriptionsMu.RUnlock()
+
+	return tagDescriptions[name]
+}
+
+// ClearTagDescriptions removes all registered tag descriptions.
+// Useful for testing.
+func ClearTagDescriptions() {
+	tagDescriptionsMu.Lock()
+	defer tagDescriptionsMu.Unlock()
+
+	tagDescriptions = make(map[string]string)
+}
@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRouteExternalDocsAndTags tests operation external docs and auto-declared route tags
+func TestRouteExternalDocsAndTags(t *testing.T) {
+	t.Cleanup(ClearTagDescriptions)
+
+	tmpDir := createTestProject(t, map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: My API
+// Version: 1.0.0
+// Tags:
+// - name: users
+package api
+`,
+		"api/handlers.go": `package api
+
+// swagger:route GET /users users listUsers
+// ExternalDocs: https://example.com/users Users guide
+// Responses:
+// - 200: description:OK
+func ListUsers() {}
+
+// swagger:route GET /orders orders billing listOrders
+// Responses:
+// - 200: description:OK
+func ListOrders() {}
+`,
+		".openapi.yaml": `tags:
+  orders: Order management
+`,
+	})
+	require.NoError(t, LoadConfigFile(tmpDir))
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	op := openAPI.Paths.PathItems["/users"].Get
+	require.NotNil(t, op.ExternalDocs)
+	assert.Equal(t, "https://example.com/users", op.ExternalDocs.URL)
+	assert.Equal(t, "Users guide", op.ExternalDocs.Description)
+
+	require.Len(t, openAPI.Tags, 3)
+	assert.Equal(t, "users", openAPI.Tags[0].Name)
+	assert.Equal(t, "billing", openAPI.Tags[1].Name)
+	assert.Empty(t, openAPI.Tags[1].Description)
+	assert.Equal(t, "orders", openAPI.Tags[2].Name)
+	assert.Equal(t, "Order management", openAPI.Tags[2].Description)
+}
@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/kausys/openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateData is the data of the output templates (see WithTemplates): the assembled
+// spec, its name, and its operations.
+type TemplateData struct {
+	*spec.OpenAPI
+	// Name is the spec name, or the output file name without extension for a single spec
+	Name string
+	// Operations are the operations of the spec, in the order of the paths
+	Operations []TemplateOperation
+}
+
+// TemplateOperation is an operation of the spec with its path and method.
+type TemplateOperation struct {
+	*spec.Operation
+	// Path is the path of the operation
+	Path string
+	// Method is the upper-case HTTP method
+	Method string
+}
+
+// templateFuncs are the functions available to the output templates, next to the
+// text/template builtins.
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"join":  func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"yaml": func(v any) (string, error) {
+		data, err := yaml.Marshal(v)
+		return string(data), err
+	},
+	"csv": func(fields ...string) (string, error) {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write(fields); err != nil {
+			return "", err
+		}
+		w.Flush()
+		return strings.TrimSuffix(buf.String(), "\n"), w.Error()
+	},
+}
+
+// outputTemplate is an output template with the file it renders to.
+type outputTemplate struct {
+	tmpl   *template.Template
+	output string
+}
+
+// loadTemplates parses the configured output templates. A template is written to the
+// file given after = (templates/endpoints.csv.tmpl=endpoints.csv), or to its name without
+// .tmpl next to the output file.
+func (g *Generator) loadTemplates() ([]outputTemplate, error) {
+	templates := make([]outputTemplate, 0, len(g.config.Templates))
+	for _, entry := range g.config.Templates {
+		file, output, ok := strings.Cut(entry, "=")
+		if !ok {
+			output = filepath.Join(filepath.Dir(g.config.OutputFile), strings.TrimSuffix(filepath.Base(file), ".tmpl"))
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		tmpl, err := template.New(filepath.Base(file)).Funcs(templateFuncs).Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template: %w", err)
+		}
+		templates = append(templates, outputTemplate{tmpl: tmpl, output: output})
+	}
+	return templates, nil
+}
+
+// templateOutputs renders the output templates for a spec. In multi-spec mode, the
+// files are prefixed with the spec name (admin.endpoints.csv).
+func templateOutputs(templates []outputTemplate, name string, openAPI *spec.OpenAPI, multi bool) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(templates))
+	data := &TemplateData{OpenAPI: openAPI, Name: name, Operations: templateOperations(openAPI)}
+	for _, t := range templates {
+		var buf bytes.Buffer
+		if err := t.tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render template: %w", err)
+		}
+
+		output := t.output
+		if multi {
+			output = filepath.Join(filepath.Dir(output), name+"."+filepath.Base(output))
+		}
+		files[output] = buf.Bytes()
+	}
+	return files, nil
+}
+
+// templateOperations returns the operations of a spec, in the order of its paths
+// (alphabetical unless ordered) and of the methods of a path item.
+func templateOperations(openAPI *spec.OpenAPI) []TemplateOperation {
+	if openAPI.Paths == nil {
+		return nil
+	}
+
+	paths := openAPI.Paths.Order
+	if len(paths) == 0 {
+		paths = slices.Sorted(maps.Keys(openAPI.Paths.PathItems))
+	}
+
+	var ops []TemplateOperation
+	for _, path := range paths {
+		ops = append(ops, pathOperations(path, openAPI.Paths.PathItems[path])...)
+	}
+	return ops
+}
+
+// pathOperations returns the operations of a path item with their path and method, in
+// the order of the methods of the path item.
+func pathOperations(path string, item *spec.PathItem) []TemplateOperation {
+	if item == nil {
+		return nil
+	}
+
+	var ops []TemplateOperation
+	for method, op := range item.Operations() {
+		ops = append(ops, TemplateOperation{Operation: op, Path: path, Method: strings.ToUpper(method)})
+	}
+	return ops
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const templateTestHandlers = `package api
+
+// swagger:route GET /users users listUsers
+// summary: List users, paginated
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route POST /users users createUser
+// summary: Create a user
+// Responses:
+// - 201: User
+func CreateUser() {}
+
+// swagger:route DELETE /admin/users admin purgeUsers
+// summary: Purge users
+// spec: admin
+// Responses:
+// - 204:
+func PurgeUsers() {}
+
+// swagger:model User
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`
+
+const endpointsTemplate = `method,path,operation,summary
+{{range .Operations}}{{csv .Method .Path .OperationID .Summary}}
+{{end}}`
+
+func TestTemplates(t *testing.T) {
+	t.Run("single spec", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{
+			"api/handlers.go":         templateTestHandlers,
+			"tmpl/endpoints.csv.tmpl": endpointsTemplate,
+			"tmpl/title.tmpl":         `{{.Name}}: {{.Info.Title}} ({{len .Components.Schemas}} schemas) {{upper (join "," (index .Operations 0).Tags)}}`,
+		})
+		outputFile := filepath.Join(tmpDir, "docs", "openapi.yaml")
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(outputFile, "yaml"),
+			WithTemplates(
+				filepath.Join(tmpDir, "tmpl", "endpoints.csv.tmpl"),
+				filepath.Join(tmpDir, "tmpl", "title.tmpl")+"="+filepath.Join(tmpDir, "title.txt"),
+			))
+		_, err := g.Generate()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(tmpDir, "docs", "endpoints.csv"))
+		require.NoError(t, err)
+		assert.Equal(t, `method,path,operation,summary
+DELETE,/admin/users,purgeUsers,Purge users
+GET,/users,listUsers,"List users, paginated"
+POST,/users,createUser,Create a user
+`, string(data))
+
+		data, err = os.ReadFile(filepath.Join(tmpDir, "title.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "openapi: API (1 schemas) ADMIN", string(data))
+	})
+
+	t.Run("multi spec", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{
+			"api/handlers.go":         templateTestHandlers,
+			"tmpl/endpoints.csv.tmpl": endpointsTemplate,
+		})
+		outputDir := filepath.Join(tmpDir, "specs")
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(filepath.Join(outputDir, "openapi.yaml"), "yaml"),
+			WithTemplates(filepath.Join(tmpDir, "tmpl", "endpoints.csv.tmpl")))
+		_, err := g.GenerateMulti()
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(outputDir, "admin.endpoints.csv"))
+		require.NoError(t, err)
+		assert.Equal(t, "method,path,operation,summary\nDELETE,/admin/users,purgeUsers,Purge users\n", string(data))
+		assert.FileExists(t, filepath.Join(outputDir, "default.endpoints.csv"))
+	})
+
+	t.Run("template error", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{
+			"api/handlers.go": templateTestHandlers,
+			"broken.tmpl":     `{{.Missing}`,
+		})
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(filepath.Join(tmpDir, "openapi.yaml"), "yaml"),
+			WithTemplates(filepath.Join(tmpDir, "broken.tmpl")))
+		_, err := g.Generate()
+		assert.ErrorContains(t, err, "failed to parse template")
+	})
+}
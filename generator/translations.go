@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// translation holds the localized texts of a translations file (see WithTranslations).
+// Texts missing from the file keep the text of the generated spec.
+type translation struct {
+	locale     string
+	Info       infoTranslation                 `yaml:"info"`
+	Tags       map[string]string               `yaml:"tags"`       // Tag name -> description
+	Operations map[string]operationTranslation `yaml:"operations"` // Operation ID -> texts
+	Schemas    map[string]string               `yaml:"schemas"`    // Schema or Schema.property -> description
+}
+
+// infoTranslation holds the localized title and description of the spec.
+type infoTranslation struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+}
+
+// operationTranslation holds the localized texts of an operation.
+type operationTranslation struct {
+	Summary     string            `yaml:"summary"`
+	Description string            `yaml:"description"`
+	Parameters  map[string]string `yaml:"parameters"` // Parameter name -> description
+	Responses   map[string]string `yaml:"responses"`  // Status code -> description
+}
+
+// loadTranslations reads the configured translations files. The locale of a file is its
+// name without extension (translations/fr.yaml is fr).
+func (g *Generator) loadTranslations() ([]*translation, error) {
+	var translations []*translation
+	for _, path := range g.config.Translations {
+		locale := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if slices.ContainsFunc(translations, func(t *translation) bool { return t.locale == locale }) {
+			return nil, fmt.Errorf("translations file %s: locale %s is already translated", path, locale)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read translations file: %w", err)
+		}
+		t := &translation{locale: locale}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(t); err != nil {
+			return nil, fmt.Errorf("failed to parse translations file %s: %w", path, err)
+		}
+		translations = append(translations, t)
+	}
+	return translations, nil
+}
+
+// localizedOutputs encodes a localized copy of a spec for each translations file, keyed by
+// the output file name with the locale before the extension (openapi.fr.yaml).
+func (g *Generator) localizedOutputs(outputFile string, format Format, openAPI *spec.OpenAPI, translations []*translation) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(translations))
+	ext := filepath.Ext(outputFile)
+	for _, t := range translations {
+		localized := openAPI.Clone()
+		t.apply(localized)
+
+		data, err := g.marshalSpecAs(localized, format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s spec: %w", t.locale, err)
+		}
+		files[strings.TrimSuffix(outputFile, ext)+"."+t.locale+ext] = data
+	}
+	return files, nil
+}
+
+// apply replaces the texts of a spec with their translations.
+func (t *translation) apply(openAPI *spec.OpenAPI) {
+	if openAPI.Info != nil {
+		openAPI.Info.Title = translated(openAPI.Info.Title, t.Info.Title)
+		openAPI.Info.Description = translated(openAPI.Info.Description, t.Info.Description)
+	}
+
+	for _, tag := range openAPI.Tags {
+		tag.Description = translated(tag.Description, t.Tags[tag.Name])
+	}
+
+	if openAPI.Paths != nil {
+		for _, path := range slices.Sorted(maps.Keys(openAPI.Paths.PathItems)) {
+			for _, op := range openAPI.Paths.PathItems[path].Operations() {
+				if ot, ok := t.Operations[op.OperationID]; ok {
+					ot.apply(op)
+				}
+			}
+		}
+	}
+
+	if openAPI.Components != nil {
+		for name, schema := range openAPI.Components.Schemas {
+			schema.Description = translated(schema.Description, t.Schemas[name])
+			for propName, prop := range schema.Properties {
+				prop.Description = translated(prop.Description, t.Schemas[name+"."+propName])
+			}
+		}
+	}
+}
+
+// apply replaces the texts of an operation with their translations.
+func (ot operationTranslation) apply(op *spec.Operation) {
+	op.Summary = translated(op.Summary, ot.Summary)
+	op.Description = translated(op.Description, ot.Description)
+	for _, param := range op.Parameters {
+		param.Description = translated(param.Description, ot.Parameters[param.Name])
+	}
+	if op.Responses == nil {
+		return
+	}
+	if op.Responses.Default != nil {
+		op.Responses.Default.Description = translated(op.Responses.Default.Description, ot.Responses["default"])
+	}
+	for code, resp := range op.Responses.StatusCodes {
+		resp.Description = translated(resp.Description, ot.Responses[code])
+	}
+}
+
+// translated returns the translation of a text, or the text when it isn't translated.
+func translated(text, translation string) string {
+	if translation == "" {
+		return text
+	}
+	return translation
+}
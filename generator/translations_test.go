@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// translationsProject has a documented route and model, and a French translations file.
+var translationsProject = map[string]string{
+	"api/doc.go": `// Package api is the API.
+//
+// swagger:meta
+// Title: Users API
+// Version: 1.0.0
+// Description: Manages accounts.
+package api
+`,
+	"api/users.go": `package api
+
+// User is an account
+//
+// swagger:model User
+type User struct {
+	// The email address
+	Email string ` + "`json:\"email\"`" + `
+	// The display name
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:parameters listUsers
+type ListUsersParams struct {
+	// Maximum number of results
+	// in: query
+	Limit int ` + "`json:\"limit\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// summary: List users
+// description: Returns the active users.
+// Responses:
+// - 200: []User List of users
+func ListUsers() {}
+`,
+	"translations/fr.yaml": `info:
+  title: API des utilisateurs
+tags:
+  users: Utilisateurs
+operations:
+  listUsers:
+    summary: Lister les utilisateurs
+    parameters:
+      limit: Nombre maximum de résultats
+    responses:
+      "200": Liste des utilisateurs
+schemas:
+  User: Un compte
+  User.email: Adresse e-mail
+`,
+}
+
+// TestIntegrationGenerateTranslations tests writing a localized spec for a translations file
+func TestIntegrationGenerateTranslations(t *testing.T) {
+	tmpDir := createTestProject(t, translationsProject)
+	outputFile := filepath.Join(tmpDir, "openapi.yaml")
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(outputFile, "yaml"),
+		WithTranslations(filepath.Join(tmpDir, "translations", "fr.yaml")))
+	_, err := g.Generate()
+	require.NoError(t, err)
+
+	readSpec := func(path string) *spec.OpenAPI {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var openAPI spec.OpenAPI
+		require.NoError(t, yaml.Unmarshal(data, &openAPI))
+		return &openAPI
+	}
+
+	// The default spec keeps the texts of the comments
+	openAPI := readSpec(outputFile)
+	assert.Equal(t, "Users API", openAPI.Info.Title)
+	assert.Equal(t, "List users", openAPI.Paths.PathItems["/users"].Get.Summary)
+
+	fr := readSpec(filepath.Join(tmpDir, "openapi.fr.yaml"))
+	assert.Equal(t, "API des utilisateurs", fr.Info.Title)
+	assert.Equal(t, "Manages accounts.", fr.Info.Description, "untranslated texts are kept")
+
+	op := fr.Paths.PathItems["/users"].Get
+	require.NotNil(t, op)
+	assert.Equal(t, "Lister les utilisateurs", op.Summary)
+	assert.Equal(t, "Returns the active users.", op.Description)
+	require.Len(t, op.Parameters, 1)
+	assert.Equal(t, "Nombre maximum de résultats", op.Parameters[0].Description)
+	assert.Equal(t, "Liste des utilisateurs", op.Responses.StatusCodes["200"].Description)
+
+	user := fr.Components.Schemas["User"]
+	require.NotNil(t, user)
+	assert.Equal(t, "Un compte", user.Description)
+	assert.Equal(t, "Adresse e-mail", user.Properties["email"].Description)
+	assert.Equal(t, "The display name", user.Properties["name"].Description)
+}
+
+// TestGenerateTranslationsErrors tests that invalid translations files are rejected
+func TestGenerateTranslationsErrors(t *testing.T) {
+	files := map[string]string{
+		"translations/de.yaml": `operations:
+  listUsers:
+    sumary: Benutzer auflisten
+`,
+		"other/fr.yaml": `info:
+  title: API
+`,
+	}
+	for name, content := range translationsProject {
+		files[name] = content
+	}
+	tmpDir := createTestProject(t, files)
+
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr string
+	}{
+		{
+			name:    "unknown key",
+			paths:   []string{"translations/de.yaml"},
+			wantErr: "field sumary not found",
+		},
+		{
+			name:    "duplicate locale",
+			paths:   []string{"translations/fr.yaml", "other/fr.yaml"},
+			wantErr: "locale fr is already translated",
+		},
+		{
+			name:    "missing file",
+			paths:   []string{"translations/es.yaml"},
+			wantErr: "failed to read translations file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			for _, path := range tt.paths {
+				paths = append(paths, filepath.Join(tmpDir, path))
+			}
+
+			g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+				WithOutput(filepath.Join(t.TempDir(), "openapi.yaml"), "yaml"), WithTranslations(paths...))
+			_, err := g.Generate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package generator
 
 import (
+	"maps"
 	"reflect"
+	"slices"
 	"sync"
 )
 
@@ -12,6 +14,7 @@ type TypeInfo struct {
 	Example     any               // Example value
 	Default     any               // Default value
 	Validations map[string]string // Additional validations (pattern, minLength, etc.)
+	Nullable    bool              // Whether the type can be null (sql.NullString, pgtype.Text, etc.)
 }
 
 // TypeHandler is a function that configures TypeInfo for a custom type.
@@ -99,6 +102,14 @@ func GetCustomType(typeName string) *TypeInfo {
 	return customTypes[typeName]
 }
 
+// customTypeNames returns the names of the registered custom types.
+func customTypeNames() []string {
+	customTypesMu.RLock()
+	defer customTypesMu.RUnlock()
+
+	return slices.Collect(maps.Keys(customTypes))
+}
+
 // ClearCustomTypes removes all registered custom types.
 // Useful for testing.
 func ClearCustomTypes() {
@@ -128,6 +139,83 @@ func registerDefaults() {
 		info.Type = "string"
 		info.Format = "date-time"
 	})
+
+	// time.Duration (rendered as integer nanoseconds with WithDurationFormat(DurationInt64))
+	RegisterType("time.Duration", func(info *TypeInfo) {
+		info.Type = "string"
+		info.Format = "duration"
+	})
+
+	// net.IP and netip.Addr
+	for _, typeName := range []string{"net.IP", "netip.Addr"} {
+		RegisterType(typeName, func(info *TypeInfo) {
+			info.Type = "string"
+			info.Format = "ipv4"
+		})
+	}
+
+	// url.URL
+	RegisterType("url.URL", func(info *TypeInfo) {
+		info.Type = "string"
+		info.Format = "uri"
+	})
+
+	// mail.Address
+	RegisterType("mail.Address", func(info *TypeInfo) {
+		info.Type = "string"
+		info.Format = "email"
+	})
+
+	// big.Float and big.Rat marshal as strings; big.Int marshals as a number but is
+	// documented as a string since its values exceed what JSON clients parse exactly
+	for _, typeName := range []string{"big.Int", "big.Float", "big.Rat"} {
+		RegisterType(typeName, func(info *TypeInfo) {
+			info.Type = "string"
+		})
+	}
+
+	// json.Number
+	RegisterType("json.Number", func(info *TypeInfo) {
+		info.Type = "number"
+	})
+
+	// json.RawMessage holds any JSON value, so its schema has no type
+	RegisterType("json.RawMessage", func(info *TypeInfo) {})
+
+	// database/sql and pgx nullable types
+	for typeName, base := range nullableTypes {
+		RegisterType(typeName, func(info *TypeInfo) {
+			info.Type = base.Type
+			info.Format = base.Format
+			info.Nullable = true
+		})
+	}
+}
+
+// nullableTypes maps the database/sql and pgx (pgtype) nullable types to the
+// schema of the value they hold.
+var nullableTypes = map[string]TypeInfo{
+	"sql.NullString":  {Type: "string"},
+	"sql.NullInt64":   {Type: "integer", Format: "int64"},
+	"sql.NullInt32":   {Type: "integer", Format: "int32"},
+	"sql.NullInt16":   {Type: "integer", Format: "int32"},
+	"sql.NullByte":    {Type: "integer", Format: "int32"},
+	"sql.NullFloat64": {Type: "number", Format: "double"},
+	"sql.NullBool":    {Type: "boolean"},
+	"sql.NullTime":    {Type: "string", Format: "date-time"},
+
+	"pgtype.Text":        {Type: "string"},
+	"pgtype.Int2":        {Type: "integer", Format: "int32"},
+	"pgtype.Int4":        {Type: "integer", Format: "int32"},
+	"pgtype.Int8":        {Type: "integer", Format: "int64"},
+	"pgtype.Float4":      {Type: "number", Format: "float"},
+	"pgtype.Float8":      {Type: "number", Format: "double"},
+	"pgtype.Numeric":     {Type: "number"},
+	"pgtype.Bool":        {Type: "boolean"},
+	"pgtype.Date":        {Type: "string", Format: "date"},
+	"pgtype.Timestamp":   {Type: "string", Format: "date-time"},
+	"pgtype.Timestamptz": {Type: "string", Format: "date-time"},
+	"pgtype.UUID":        {Type: "string", Format: "uuid"},
 }
 
 func init() {
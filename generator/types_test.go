@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDurationFormat tests the time.Duration schema for each duration format
+func TestDurationFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		wantType   string
+		wantFormat string
+	}{
+		{"default", "", "string", "duration"},
+		{"duration", DurationString, "string", "duration"},
+		{"int64", DurationInt64, "integer", "int64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := createTestGenerator()
+			g.config.DurationFormat = tt.format
+
+			schema := g.fieldToSchema(&scanner.FieldInfo{Name: "Timeout", Type: "time.Duration"})
+			assert.Equal(t, tt.wantType, schema.Type.Value())
+			assert.Equal(t, tt.wantFormat, schema.Format)
+		})
+	}
+}
+
+// TestGenerateUnknownDurationFormat tests that unknown duration formats are rejected
+func TestGenerateUnknownDurationFormat(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/server.go": `package api
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithDurationFormat("seconds"))
+	_, err := g.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown duration format "seconds"`)
+}
+
+// TestStdlibTypesAreKnown tests that the standard library types are registered by default
+func TestStdlibTypesAreKnown(t *testing.T) {
+	g := createTestGenerator()
+
+	for _, typeName := range []string{
+		"time.Duration", "net.IP", "netip.Addr", "url.URL", "mail.Address",
+		"big.Int", "big.Float", "big.Rat", "json.Number", "json.RawMessage",
+	} {
+		assert.True(t, g.isKnownType(typeName), typeName)
+	}
+}
+
+// TestNullableTypes tests that database/sql and pgx nullable types map to nullable primitives
+func TestNullableTypes(t *testing.T) {
+	tests := []struct {
+		goType     string
+		wantType   []string
+		wantFormat string
+	}{
+		{"sql.NullString", []string{"string", "null"}, ""},
+		{"sql.NullInt64", []string{"integer", "null"}, "int64"},
+		{"sql.NullInt32", []string{"integer", "null"}, "int32"},
+		{"sql.NullFloat64", []string{"number", "null"}, "double"},
+		{"sql.NullBool", []string{"boolean", "null"}, ""},
+		{"sql.NullTime", []string{"string", "null"}, "date-time"},
+		{"pgtype.Text", []string{"string", "null"}, ""},
+		{"pgtype.Int8", []string{"integer", "null"}, "int64"},
+		{"pgtype.Numeric", []string{"number", "null"}, ""},
+		{"pgtype.Date", []string{"string", "null"}, "date"},
+		{"pgtype.Timestamptz", []string{"string", "null"}, "date-time"},
+		{"pgtype.UUID", []string{"string", "null"}, "uuid"},
+	}
+
+	g := createTestGenerator()
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			schema := g.fieldToSchema(&scanner.FieldInfo{Name: "Value", Type: tt.goType})
+			assert.Equal(t, tt.wantType, schema.Type.Values())
+			assert.Equal(t, tt.wantFormat, schema.Format)
+		})
+	}
+
+	// Explicit nullable: true doesn't add null twice
+	schema := g.fieldToSchema(&scanner.FieldInfo{Name: "Value", Type: "sql.NullString", Nullable: true})
+	assert.Equal(t, []string{"string", "null"}, schema.Type.Values())
+
+	// Array items are nullable, not the array
+	schema = g.fieldToSchema(&scanner.FieldInfo{Name: "Values", Type: "sql.NullInt64", IsArray: true})
+	assert.Equal(t, []string{"array"}, schema.Type.Values())
+	assert.Equal(t, []string{"integer", "null"}, schema.Items.Type.Values())
+}
+
+// TestGenerateNamedPrimitives tests that named primitive types resolve to their underlying type
+func TestGenerateNamedPrimitives(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+type UserID int64
+type Score float64
+type Age = int32
+
+// swagger:model User
+type User struct {
+	ID     UserID   ` + "`json:\"id\"`" + `
+	Scores []Score  ` + "`json:\"scores\"`" + `
+	Age    *Age     ` + "`json:\"age\"`" + `
+}
+
+// swagger:parameters getUser
+type GetUserParams struct {
+	// in: path
+	ID UserID ` + "`json:\"id\"`" + `
+}
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: User
+func GetUser() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+
+	user := openAPI.Components.Schemas["User"]
+	require.NotNil(t, user)
+	assert.Equal(t, "integer", user.Properties["id"].Type.Value())
+	assert.Equal(t, "int64", user.Properties["id"].Format)
+	assert.Equal(t, "number", user.Properties["scores"].Items.Type.Value())
+	assert.Equal(t, "int32", user.Properties["age"].Format)
+
+	param := openAPI.Paths.PathItems["/users/{id}"].Get.Parameters[0]
+	assert.Equal(t, "integer", param.Schema.Type.Value())
+	assert.Empty(t, warningMessages(g.Warnings()))
+}
+
+// TestGenerateNamedCollections tests that named slice, array, and map types resolve to array and object schemas
+func TestGenerateNamedCollections(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+type UserID int64
+type Tags []string
+type Labels map[string]string
+type Members []*User
+type Digest [4]uint8
+type Token []byte
+
+// swagger:model User
+type User struct {
+	ID      UserID  ` + "`json:\"id\"`" + `
+	Tags    Tags    ` + "`json:\"tags\"`" + `
+	Labels  Labels  ` + "`json:\"labels\"`" + `
+	Friends Members ` + "`json:\"friends\"`" + `
+	Digest  Digest  ` + "`json:\"digest\"`" + `
+	Token   Token   ` + "`json:\"token\"`" + `
+}
+
+// swagger:parameters listUsers
+type ListUsersParams struct {
+	// in: query
+	Tags Tags ` + "`json:\"tags\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+func ListUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+	assert.Empty(t, warningMessages(g.Warnings()))
+
+	user := openAPI.Components.Schemas["User"]
+	require.NotNil(t, user)
+
+	tags := user.Properties["tags"]
+	assert.Equal(t, "array", tags.Type.Value())
+	assert.Equal(t, "string", tags.Items.Type.Value())
+
+	labels := user.Properties["labels"]
+	assert.Equal(t, "object", labels.Type.Value())
+	assert.Equal(t, "string", labels.AdditionalProperties.Type.Value())
+
+	friends := user.Properties["friends"]
+	assert.Equal(t, "array", friends.Type.Value())
+	assert.Equal(t, "#/components/schemas/User", friends.Items.Ref)
+
+	digest := user.Properties["digest"]
+	assert.Equal(t, "array", digest.Type.Value())
+	assert.Equal(t, "integer", digest.Items.Type.Value())
+	assert.Equal(t, new(uint64(4)), digest.MinItems)
+	assert.Equal(t, new(uint64(4)), digest.MaxItems)
+
+	token := user.Properties["token"]
+	assert.Equal(t, "string", token.Type.Value())
+	assert.Equal(t, "byte", token.Format)
+
+	param := openAPI.Paths.PathItems["/users"].Get.Parameters[0]
+	assert.Equal(t, "array", param.Schema.Type.Value())
+	assert.Equal(t, "string", param.Schema.Items.Type.Value())
+}
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/kausys/openapi/spec"
+)
+
+// recordUsedSchemas adds the schemas referenced by the spec being assembled to the
+// schemas used by the run, reported by reportUnusedSchemas.
+func (g *Generator) recordUsedSchemas() {
+	if g.usedSchemas == nil {
+		g.usedSchemas = make(map[string]bool)
+	}
+	maps.Copy(g.usedSchemas, g.referencedSchemas)
+}
+
+// recordInlinedEnum records an enum inlined in the schema being converted.
+func (g *Generator) recordInlinedEnum(name string) {
+	if g.inlinedEnums == nil {
+		g.inlinedEnums = make(map[string]map[string]bool)
+	}
+	if g.inlinedEnums[g.convertingModel] == nil {
+		g.inlinedEnums[g.convertingModel] = make(map[string]bool)
+	}
+	g.inlinedEnums[g.convertingModel][name] = true
+}
+
+// enumUsed reports whether an enum is referenced, or inlined by a route or a used model.
+func (g *Generator) enumUsed(name string) bool {
+	if g.usedSchemas[name] || g.inlinedEnums[""][name] {
+		return true
+	}
+	for model, enums := range g.inlinedEnums {
+		if model != "" && enums[name] && g.usedSchemas[model] {
+			return true
+		}
+	}
+	return false
+}
+
+// reportUnusedSchemas warns about the declared models and enums that no included route
+// references, directly or through another schema, so that dead annotations can be
+// deleted. The warning tells whether the schema was removed from the assembled specs.
+// Models and enums of other specs than the assembled ones, by name, are not reported;
+// the unnamed spec of Generate holds every model.
+func (g *Generator) reportUnusedSchemas(specs map[string]*spec.OpenAPI) {
+	if !g.config.ReportUnused {
+		return
+	}
+
+	outcome := func(name string) string {
+		for _, openAPI := range specs {
+			if openAPI.Components != nil && openAPI.Components.Schemas[name] != nil {
+				return "kept in the spec"
+			}
+		}
+		return "removed from the spec"
+	}
+	inSpecs := func(modelSpecs []string) bool {
+		if len(modelSpecs) == 0 {
+			return true
+		}
+		for specName := range specs {
+			baseSpec, _ := g.resolveVersionedSpec(specName)
+			if specName == "" || specsMatch(modelSpecs, baseSpec) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(g.scanner.Structs)) {
+		info := g.scanner.Structs[name]
+		if !info.IsModel || !g.modelIncluded(info) || !inSpecs(info.Specs) || g.usedSchemas[name] {
+			continue
+		}
+		g.warnAt(WarnUnusedSchema, info.Pos, "model %s is not referenced by any included route, %s", name, outcome(name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(g.scanner.Enums)) {
+		if g.enumUsed(name) || !g.enumInSpecs(name, inSpecs) {
+			continue
+		}
+		g.warnAt(WarnUnusedSchema, g.scanner.Enums[name].Pos, "enum %s is not referenced by any included route, %s", name, outcome(name))
+	}
+}
+
+// enumInSpecs reports whether an enum belongs to the assembled specs, selected by inSpecs
+// from the specs of a model: it does unless all the models using it belong to other specs.
+func (g *Generator) enumInSpecs(name string, inSpecs func(modelSpecs []string) bool) bool {
+	usesEnum := func(f *scanner.FieldInfo) bool {
+		enumInfo := g.scanner.GetEnumForType(f.Type)
+		return enumInfo != nil && enumInfo.TypeName == name
+	}
+
+	used := false
+	for _, info := range g.scanner.Structs {
+		if !info.IsModel || !slices.ContainsFunc(info.Fields, usesEnum) {
+			continue
+		}
+		if inSpecs(info.Specs) {
+			return true
+		}
+		used = true
+	}
+	return !used
+}
@@ -0,0 +1,43 @@
+package generator
+
+import "github.com/kausys/openapi/spec"
+
+// Versions of the generated documents. Documents using the fields introduced in
+// OpenAPI 3.2 declare openAPIVersion32, which OpenAPI 3.1 tools would reject.
+const (
+	openAPIVersion   = "3.1.2"
+	openAPIVersion32 = "3.2.0"
+)
+
+// setOpenAPIVersion declares OpenAPI 3.2 in a 3.1 document using 3.2 fields.
+func setOpenAPIVersion(openAPI *spec.OpenAPI) {
+	if openAPI.OpenAPI == openAPIVersion && usesOpenAPI32(openAPI) {
+		openAPI.OpenAPI = openAPIVersion32
+	}
+}
+
+// usesOpenAPI32 reports whether the path items of a document, webhooks and components
+// included, have a query operation or additionalOperations.
+func usesOpenAPI32(openAPI *spec.OpenAPI) bool {
+	var pathItems []*spec.PathItem
+	if openAPI.Paths != nil {
+		for _, pathItem := range openAPI.Paths.PathItems {
+			pathItems = append(pathItems, pathItem)
+		}
+	}
+	for _, pathItem := range openAPI.Webhooks {
+		pathItems = append(pathItems, pathItem)
+	}
+	if openAPI.Components != nil {
+		for _, pathItem := range openAPI.Components.PathItems {
+			pathItems = append(pathItems, pathItem)
+		}
+	}
+
+	for _, pathItem := range pathItems {
+		if pathItem != nil && (pathItem.Query != nil || len(pathItem.AdditionalOperations) > 0) {
+			return true
+		}
+	}
+	return false
+}
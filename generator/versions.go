@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/kausys/openapi/scanner"
+)
+
+// versionPathPattern matches the version segment opening a versioned path (/v2/users).
+var versionPathPattern = regexp.MustCompile(`^/(v[0-9]+)(?:/|$)`)
+
+// versionedSpec is the spec and API version a spec of versioned mode is generated from.
+type versionedSpec struct {
+	spec    string
+	version string
+}
+
+// routeVersion returns the API version of a route: its version: directive, else the
+// version segment opening its path. Unversioned routes return "".
+func routeVersion(route *scanner.RouteInfo) string {
+	if route.Version != "" {
+		return route.Version
+	}
+	if match := versionPathPattern.FindStringSubmatch(route.Path); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// routeInVersion reports whether a route belongs to an API version. Unversioned routes
+// (/health) belong to every version.
+func routeInVersion(route *scanner.RouteInfo, version string) bool {
+	routeVer := routeVersion(route)
+	return version == "" || routeVer == "" || routeVer == version
+}
+
+// versionedSpecName returns the name of the spec of an API version: the version for the
+// default spec (v2), else the spec name followed by the version (public-v2).
+func versionedSpecName(specName, version string) string {
+	if specName == scanner.DefaultSpec {
+		return version
+	}
+	return specName + "-" + version
+}
+
+// splitVersions replaces each spec with versioned routes by one spec per version,
+// recording the spec and version each one is generated from. Specs without versioned
+// routes are kept as is.
+func (g *Generator) splitVersions(specNames map[string]bool) map[string]bool {
+	g.versionedSpecs = make(map[string]versionedSpec)
+
+	result := make(map[string]bool, len(specNames))
+	for _, specName := range slices.Sorted(maps.Keys(specNames)) {
+		versions := make(map[string]bool)
+		for _, route := range g.scanner.Routes {
+			if version := routeVersion(route); version != "" && g.routeBelongsToSpec(route, specName) {
+				versions[version] = true
+			}
+		}
+		if len(versions) == 0 {
+			result[specName] = true
+			continue
+		}
+		for version := range versions {
+			name := versionedSpecName(specName, version)
+			result[name] = true
+			g.versionedSpecs[name] = versionedSpec{spec: specName, version: version}
+		}
+	}
+	return result
+}
+
+// resolveVersionedSpec returns the spec and API version a spec of versioned mode is
+// generated from, or the spec itself without version.
+func (g *Generator) resolveVersionedSpec(specName string) (string, string) {
+	if !g.config.Versioned {
+		return specName, ""
+	}
+	if g.versionedSpecs == nil {
+		g.collectSpecNames()
+	}
+	if v, ok := g.versionedSpecs[specName]; ok {
+		return v.spec, v.version
+	}
+	return specName, ""
+}
+
+// specOwnMeta returns the meta declared for exactly this spec (spec: v2), or nil.
+func (g *Generator) specOwnMeta(specName string) *scanner.MetaInfo {
+	for _, meta := range g.scanner.Metas {
+		if slices.Contains(meta.Specs, specName) {
+			return meta
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/kausys/openapi/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteVersion(t *testing.T) {
+	tests := []struct {
+		name  string
+		route *scanner.RouteInfo
+		want  string
+	}{
+		{"path prefix", &scanner.RouteInfo{Path: "/v1/users"}, "v1"},
+		{"path prefix only", &scanner.RouteInfo{Path: "/v12"}, "v12"},
+		{"directive", &scanner.RouteInfo{Path: "/users", Version: "v2"}, "v2"},
+		{"directive over path", &scanner.RouteInfo{Path: "/v1/users", Version: "v2"}, "v2"},
+		{"unversioned", &scanner.RouteInfo{Path: "/health"}, ""},
+		{"not a version segment", &scanner.RouteInfo{Path: "/videos"}, ""},
+		{"nested segment", &scanner.RouteInfo{Path: "/api/v1/users"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, routeVersion(tt.route))
+		})
+	}
+}
+
+// TestGenerateMultiVersioned tests that versioned mode generates a spec per API version
+func TestGenerateMultiVersioned(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/meta.go": `// Package api Users API.
+//
+// swagger:meta
+// Title: Users API
+// Version: 1.0.0
+package api
+
+// swagger:meta
+// spec: v2
+// Title: Users API
+// Version: 2.3.0
+type v2Meta struct{}
+`,
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:model UserV2
+type UserV2 struct {
+	ID   string ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:route GET /v1/users/me users getMeV1
+// Responses:
+// - 200: User
+func GetMeV1() {}
+
+// swagger:route GET /v2/users/me users getMeV2
+// Responses:
+// - 200: UserV2
+func GetMeV2() {}
+
+// swagger:route GET /me/settings users getSettings
+// version: v2
+// Responses:
+// - 200: UserV2
+func GetSettings() {}
+
+// swagger:route GET /health health getHealth
+// Responses:
+// - 200: description:OK
+func GetHealth() {}
+
+// swagger:route GET /v1/admin/users admin listAdminUsers
+// spec: admin
+// Responses:
+// - 200: []User
+func ListAdminUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithVersioned(true))
+	specs, err := g.GenerateMulti()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"v1", "v2", "admin-v1"}, slices.Collect(maps.Keys(specs)))
+
+	v1 := specs["v1"]
+	assert.Equal(t, "v1", v1.Info.Version)
+	assert.ElementsMatch(t, []string{"/v1/users/me", "/health"}, slices.Collect(maps.Keys(v1.Paths.PathItems)))
+	assert.Contains(t, v1.Components.Schemas, "User")
+	assert.NotContains(t, v1.Components.Schemas, "UserV2")
+
+	v2 := specs["v2"]
+	assert.Equal(t, "2.3.0", v2.Info.Version)
+	assert.ElementsMatch(t, []string{"/v2/users/me", "/me/settings", "/health"}, slices.Collect(maps.Keys(v2.Paths.PathItems)))
+	assert.NotContains(t, v2.Components.Schemas, "User")
+
+	assert.Equal(t, "v1", specs["admin-v1"].Info.Version)
+	assert.ElementsMatch(t, []string{"/v1/admin/users"}, slices.Collect(maps.Keys(specs["admin-v1"].Paths.PathItems)))
+
+	names, err := g.GetSpecNames()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"v1", "v2", "admin-v1"}, names)
+
+	openAPI, err := g.GenerateSpec("v2")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"/v2/users/me", "/me/settings", "/health"}, slices.Collect(maps.Keys(openAPI.Paths.PathItems)))
+
+	// Without versioned mode, the versions share the default spec
+	g = New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	specs, err = g.GenerateMulti()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{scanner.DefaultSpec, "admin"}, slices.Collect(maps.Keys(specs)))
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/scanner"
+)
+
+// Warning is a non-fatal problem found during generation.
+type Warning = scanner.Warning
+
+// Codes of the warnings reported by the generator. Path parameter warnings
+// use the matching lint rules (RuleUndeclaredPathParam, RuleUnknownPathParam).
+const (
+	WarnMissingOperationID  = scanner.WarnMissingOperationID
+	WarnInvalidRoute        = scanner.WarnInvalidRoute
+	WarnInvalidPath         = scanner.WarnInvalidPath
+	WarnInvalidHeaders      = scanner.WarnInvalidHeaders
+	WarnInvalidResponse     = scanner.WarnInvalidResponse
+	WarnInvalidChannel      = scanner.WarnInvalidChannel
+	WarnInvalidEnvelope     = scanner.WarnInvalidEnvelope
+	WarnInvalidExample      = scanner.WarnInvalidExample
+	WarnInvalidLicense      = scanner.WarnInvalidLicense
+	WarnInvalidCodeSample   = scanner.WarnInvalidCodeSample
+	WarnInvalidRateLimit    = scanner.WarnInvalidRateLimit
+	WarnInvalidPagination   = scanner.WarnInvalidPagination
+	WarnCustomMethod        = scanner.WarnCustomMethod
+	WarnUnknownResponseType = "unknown-response-type"   // response type is not a model, enum, or primitive
+	WarnUnknownFieldType    = "unknown-field-type"      // model or parameter field type is not a model, enum, or primitive
+	WarnEmptyEnum           = "empty-enum"              // swagger:enum without values
+	WarnUnknownOperation    = "unknown-operation"       // swagger:parameters or swagger:headers for a missing operation
+	WarnReservedHeader      = "reserved-header"         // header parameter described elsewhere by OpenAPI
+	WarnCookieBodyConflict  = "cookie-body-conflict"    // cookie parameter also declared in the request body
+	WarnRequestBodyConflict = "request-body-conflict"   // RequestBody directive and a body in the parameters struct
+	WarnRequestBodyDropped  = "request-body-dropped"    // request body on a method without allowBody
+	WarnInvalidParamStyle   = "invalid-parameter-style" // style not allowed for the parameter location
+	WarnPathRewriteConflict = "path-rewrite-conflict"   // two paths equal after StripPrefix/AddPrefix
+	WarnSplitModelConflict  = "split-model-conflict"    // request/response variant name already used by a model
+	WarnUnknownMessage      = "unknown-message"         // channel message is not a message, model, enum, or primitive
+	WarnUnusedSchema        = "unused-schema"           // model or enum referenced by no included route (ReportUnused)
+)
+
+// Warnings returns the non-fatal problems found during the last generation.
+func (g *Generator) Warnings() []Warning {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return slices.Clone(g.warnings)
+}
+
+// warn records a warning, ignoring duplicates from specs assembled more than once.
+func (g *Generator) warn(code, format string, args ...any) {
+	g.addWarning(Warning{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// warnAt records a warning about a directive, prefixing the message with its file and line.
+func (g *Generator) warnAt(code string, pos token.Position, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if pos.IsValid() {
+		message = g.location(pos) + ": " + message
+	}
+	g.addWarning(Warning{Code: code, Message: message, Pos: pos})
+}
+
+// location formats a source position as file:line, relative to the project directory.
+func (g *Generator) location(pos token.Position) string {
+	return fmt.Sprintf("%s:%d", g.toRelativePath(pos.Filename), pos.Line)
+}
+
+// addWarning records a warning unless it was already reported.
+func (g *Generator) addWarning(warning Warning) {
+	if !slices.Contains(g.warnings, warning) {
+		g.warnings = append(g.warnings, warning)
+	}
+}
+
+// ErrWarnings is returned in strict mode when the generation reports warnings.
+var ErrWarnings = errors.New("generation reported warnings")
+
+// ErrUnresolvedRefs is returned with StrictRefs when a type falls back to a string.
+var ErrUnresolvedRefs = errors.New("unresolved type references")
+
+// checkStrict fails a run that reported warnings in strict mode, or unresolved
+// types with StrictRefs.
+func (g *Generator) checkStrict() error {
+	if g.config.StrictRefs {
+		var unresolved []string
+		for _, w := range g.warnings {
+			if w.Code == WarnUnknownResponseType || w.Code == WarnUnknownFieldType {
+				unresolved = append(unresolved, w.Message)
+			}
+		}
+		if len(unresolved) > 0 {
+			return fmt.Errorf("%w:\n  %s", ErrUnresolvedRefs, strings.Join(unresolved, "\n  "))
+		}
+	}
+
+	if g.config.Strict && len(g.warnings) > 0 {
+		return fmt.Errorf("%w: %d warning(s)", ErrWarnings, len(g.warnings))
+	}
+	return nil
+}
+
+// checkScannedData warns about scanned directives that cannot contribute to the spec.
+func (g *Generator) checkScannedData() {
+	for _, name := range slices.Sorted(maps.Keys(g.scanner.Enums)) {
+		if enumInfo := g.scanner.Enums[name]; len(enumInfo.Values) == 0 {
+			g.warnAt(WarnEmptyEnum, enumInfo.Pos, "enum %s has no values", name)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(g.scanner.Structs)) {
+		structInfo := g.scanner.Structs[name]
+		if structInfo.IsParameter && g.scanner.Routes[name] == nil {
+			g.warnAt(WarnUnknownOperation, structInfo.Pos, "swagger:parameters %s does not match any operation", name)
+		}
+	}
+
+	for _, opID := range slices.Sorted(maps.Keys(g.scanner.Headers)) {
+		if g.scanner.Routes[opID] != nil {
+			continue
+		}
+		for _, headers := range g.scanner.Headers[opID] {
+			g.warnAt(WarnUnknownOperation, headers.Pos, "swagger:headers %s does not match any operation", opID)
+		}
+	}
+}
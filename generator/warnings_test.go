@@ -0,0 +1,282 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// warningMessages returns the messages of the given warnings.
+func warningMessages(warnings []Warning) []string {
+	messages := make([]string, len(warnings))
+	for i, w := range warnings {
+		messages[i] = w.Message
+	}
+	return messages
+}
+
+// TestGenerateWarnings tests the warnings collected for directives that cannot contribute to the spec
+func TestGenerateWarnings(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:enum Role
+type Role string
+
+// swagger:parameters deleteUser
+type DeleteUserParams struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// swagger:headers getUser archiveUser
+type TracingHeaders struct {
+	RequestID string ` + "`json:\"X-Request-ID\"`" + `
+}
+
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: User
+// - 404: Problem
+func GetUser() {}
+
+// swagger:route GET /users
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:route GET users/export users exportUsers
+// Responses:
+// - 200: []User
+func ExportUsers() {}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+	require.NotNil(t, openAPI.Paths.PathItems["/users/{id}"])
+
+	codes := make(map[string][]string)
+	for _, w := range g.Warnings() {
+		codes[w.Code] = append(codes[w.Code], w.Message)
+	}
+
+	assert.Equal(t, []string{`api/users.go:27: swagger:route GET /users: missing operation ID, expected swagger:route METHOD /path [tags] operationID; route skipped`}, codes[WarnMissingOperationID])
+	assert.Equal(t, []string{`api/users.go:32: swagger:route GET users/export users exportUsers: path "users/export" does not start with /, expected swagger:route METHOD /path [tags] operationID; route skipped`}, codes[WarnInvalidRoute])
+	assert.Equal(t, []string{"api/users.go:8: enum Role has no values"}, codes[WarnEmptyEnum])
+	assert.Equal(t, []string{
+		"api/users.go:11: swagger:parameters deleteUser does not match any operation",
+		"api/users.go:16: swagger:headers archiveUser does not match any operation",
+	}, codes[WarnUnknownOperation])
+	assert.Equal(t, []string{
+		"api/users.go:21: operation getUser: response 404 type Problem is not a model, enum, or primitive, generated as string",
+	}, codes[WarnUnknownResponseType])
+}
+
+// TestGenerateStrict tests that strict mode fails on warnings without writing output
+func TestGenerateStrict(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []Missing
+func ListUsers() {}
+`,
+	})
+	outputFile := filepath.Join(tmpDir, "openapi.yaml")
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(outputFile, "yaml"), WithStrict(true))
+	_, err := g.Generate()
+	require.ErrorIs(t, err, ErrWarnings)
+	assert.NoFileExists(t, outputFile)
+	require.Len(t, g.Warnings(), 1)
+	assert.Equal(t, WarnUnknownResponseType, g.Warnings()[0].Code)
+
+	_, err = g.GenerateMulti()
+	require.ErrorIs(t, err, ErrWarnings)
+
+	g = New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(outputFile, "yaml"))
+	_, err = g.Generate()
+	require.NoError(t, err)
+	assert.FileExists(t, outputFile)
+}
+
+// TestGenerateStrictRefs tests that unresolved response and field types fail with StrictRefs
+func TestGenerateStrictRefs(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID    string ` + "`json:\"id\"`" + `
+	Tags  []Label ` + "`json:\"tags\"`" + `
+	Owner *User ` + "`json:\"owner\"`" + `
+	Data  interface{} ` + "`json:\"data\"`" + `
+	Feed  chan string ` + "`json:\"feed\"`" + `
+}
+
+// swagger:parameters listUsers
+type ListUsersParams struct {
+	// in: query
+	Filter Criteria ` + "`json:\"filter\"`" + `
+}
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+// - 400: Problem
+func ListUsers() {}
+
+// Label is not annotated as a model
+type Label struct {
+	Name string
+}
+
+// Criteria is not annotated as a model
+type Criteria struct {
+	Query string
+}
+`,
+	})
+
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithStrictRefs(true))
+	_, err := g.Generate()
+	require.ErrorIs(t, err, ErrUnresolvedRefs)
+	assert.Contains(t, err.Error(), "api/users.go:3: model User: field tags type Label is not a model, enum, or primitive, generated as string")
+	assert.Contains(t, err.Error(), "api/users.go:3: model User: field feed type chan string is not a model, enum, or primitive, generated as string")
+	assert.Contains(t, err.Error(), "api/users.go:18: operation listUsers: parameter filter type Criteria is not a model, enum, or primitive, generated as string")
+	assert.Contains(t, err.Error(), "api/users.go:18: operation listUsers: response 400 type Problem is not a model, enum, or primitive, generated as string")
+	assert.NotContains(t, err.Error(), "owner")
+	assert.NotContains(t, err.Error(), "data")
+
+	// Without StrictRefs the unresolved types are only warnings
+	g = New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+	openAPI, err := g.Generate()
+	require.NoError(t, err)
+	assert.Equal(t, "string", openAPI.Components.Schemas["User"].Properties["tags"].Items.Type.Value())
+	assert.Equal(t, "object", openAPI.Components.Schemas["User"].Properties["data"].Type.Value())
+	assert.Len(t, g.Warnings(), 4)
+}
+
+// TestReportUnusedSchemas tests the warnings about models and enums no included route references
+func TestReportUnusedSchemas(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:model User
+type User struct {
+	ID      string  ` + "`json:\"id\"`" + `
+	Address Address ` + "`json:\"address\"`" + `
+}
+
+// swagger:model Address
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// swagger:model LegacyUser
+type LegacyUser struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:enum Status
+type Status string
+
+const (
+	StatusActive Status = "active"
+)
+
+// swagger:route GET /users/me users getMe
+// Responses:
+// - 200: User
+func GetMe() {}
+
+// swagger:model AdminUser
+// spec: admin
+type AdminUser struct {
+	Role Role ` + "`json:\"role\"`" + `
+}
+
+// swagger:enum Role
+type Role string
+
+const (
+	RoleOwner Role = "owner"
+)
+
+// swagger:route GET /admin/users admin listAdmins
+// spec: admin
+// Responses:
+// - 200: AdminUser
+func ListAdmins() {}
+`,
+	})
+
+	unused := func(g *Generator) []string {
+		var messages []string
+		for _, w := range g.Warnings() {
+			if w.Code == WarnUnusedSchema {
+				messages = append(messages, w.Message)
+			}
+		}
+		return messages
+	}
+
+	t.Run("removed", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithReportUnused(true))
+		openAPI, err := g.Generate()
+		require.NoError(t, err)
+		assert.NotContains(t, openAPI.Components.Schemas, "LegacyUser")
+		assert.Equal(t, []string{
+			"api/users.go:14: model LegacyUser is not referenced by any included route, removed from the spec",
+			"api/users.go:19: enum Status is not referenced by any included route, removed from the spec",
+		}, unused(g))
+	})
+
+	t.Run("kept", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithCleanUnused(false), WithReportUnused(true))
+		openAPI, err := g.Generate()
+		require.NoError(t, err)
+		assert.Contains(t, openAPI.Components.Schemas, "LegacyUser")
+		assert.Equal(t, []string{
+			"api/users.go:14: model LegacyUser is not referenced by any included route, kept in the spec",
+			"api/users.go:19: enum Status is not referenced by any included route, kept in the spec",
+		}, unused(g))
+	})
+
+	t.Run("multi-spec", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithReportUnused(true))
+		_, err := g.GenerateMulti()
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"api/users.go:14: model LegacyUser is not referenced by any included route, removed from the spec",
+			"api/users.go:19: enum Status is not referenced by any included route, removed from the spec",
+		}, unused(g))
+	})
+
+	t.Run("single spec", func(t *testing.T) {
+		// The model and enum of the admin spec are not reported with the default spec
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithReportUnused(true))
+		_, err := g.GenerateSpec("default")
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"api/users.go:14: model LegacyUser is not referenced by any included route, removed from the spec",
+			"api/users.go:19: enum Status is not referenced by any included route, removed from the spec",
+		}, unused(g))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+		_, err := g.Generate()
+		require.NoError(t, err)
+		assert.Empty(t, unused(g))
+	})
+}
@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kausys/openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const writeTestHandlers = `package api
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: []User
+func ListUsers() {}
+
+// swagger:model User
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`
+
+func TestWriteSpec(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/handlers.go": writeTestHandlers})
+	outputFile := filepath.Join(tmpDir, "openapi.yaml")
+
+	t.Run("json", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(outputFile, "yaml"))
+
+		var buf bytes.Buffer
+		require.NoError(t, g.WriteSpec(context.Background(), &buf, FormatJSON))
+
+		var openAPI spec.OpenAPI
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &openAPI))
+		assert.Contains(t, openAPI.Paths.PathItems, "/users")
+		assert.Contains(t, openAPI.Components.Schemas, "User")
+
+		// The configured output file is not written
+		assert.NoFileExists(t, outputFile)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+
+		var buf bytes.Buffer
+		require.NoError(t, g.WriteSpec(context.Background(), &buf, FormatYAML))
+
+		var openAPI spec.OpenAPI
+		require.NoError(t, yaml.Unmarshal(buf.Bytes(), &openAPI))
+		assert.Contains(t, openAPI.Paths.PathItems, "/users")
+	})
+
+	t.Run("portable yaml", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""), WithPortableYAML(true))
+
+		var buf bytes.Buffer
+		require.NoError(t, g.WriteSpec(context.Background(), &buf, FormatYAML))
+
+		var doc yaml.Node
+		require.NoError(t, yaml.Unmarshal(buf.Bytes(), &doc))
+		var fields []string
+		for i, node := range doc.Content[0].Content {
+			if i%2 == 0 {
+				fields = append(fields, node.Value)
+			}
+		}
+		assert.Equal(t, []string{"openapi", "info", "tags", "paths", "components"}, fields)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput("", ""))
+
+		var buf bytes.Buffer
+		err := g.WriteSpec(context.Background(), &buf, Format("toml"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unsupported output format "toml"`)
+		assert.Zero(t, buf.Len())
+	})
+}
+
+func TestEncodeSpecs(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/handlers.go": writeTestHandlers + `
+// swagger:route GET /admin/users admin listAdminUsers
+// spec: admin
+// Responses:
+// - 200: []User
+func ListAdminUsers() {}
+`,
+	})
+	outputFile := filepath.Join(tmpDir, "openapi.yaml")
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(outputFile, "yaml"))
+
+	specs, err := g.EncodeSpecs(context.Background(), FormatJSON)
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+
+	var admin spec.OpenAPI
+	require.NoError(t, json.Unmarshal(specs["admin"], &admin))
+	assert.Contains(t, admin.Paths.PathItems, "/admin/users")
+	assert.NotContains(t, admin.Paths.PathItems, "/users")
+
+	var def spec.OpenAPI
+	require.NoError(t, json.Unmarshal(specs["default"], &def))
+	assert.Contains(t, def.Paths.PathItems, "/users")
+
+	// No output file is written
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.NotEqual(t, ".yaml", filepath.Ext(entry.Name()))
+	}
+}
+
+func TestGenerateContextCanceled(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/handlers.go": writeTestHandlers})
+	g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false), WithOutput(filepath.Join(tmpDir, "openapi.yaml"), "yaml"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := g.GenerateContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = g.GenerateMultiContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = g.GenerateSpecContext(ctx, "default")
+	require.ErrorIs(t, err, context.Canceled)
+
+	var buf bytes.Buffer
+	require.ErrorIs(t, g.WriteSpec(ctx, &buf, FormatJSON), context.Canceled)
+
+	_, statErr := os.Stat(filepath.Join(tmpDir, "openapi.yaml"))
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestAdditionalOutputs(t *testing.T) {
+	t.Run("single spec", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/handlers.go": writeTestHandlers})
+		yamlFile := filepath.Join(tmpDir, "openapi.yaml")
+		jsonFile := filepath.Join(tmpDir, "gateway", "openapi.json")
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(yamlFile, "yaml"), WithAdditionalOutputs(jsonFile))
+		_, err := g.Generate()
+		require.NoError(t, err)
+
+		yamlData, err := os.ReadFile(yamlFile)
+		require.NoError(t, err)
+		var fromYAML spec.OpenAPI
+		require.NoError(t, yaml.Unmarshal(yamlData, &fromYAML))
+
+		jsonData, err := os.ReadFile(jsonFile)
+		require.NoError(t, err)
+		assert.True(t, json.Valid(jsonData))
+		var fromJSON spec.OpenAPI
+		require.NoError(t, json.Unmarshal(jsonData, &fromJSON))
+
+		assert.Contains(t, fromJSON.Paths.PathItems, "/users")
+		assert.Equal(t, fromYAML.Components.Schemas["User"].Properties["id"].Type, fromJSON.Components.Schemas["User"].Properties["id"].Type)
+	})
+
+	t.Run("multi spec", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/handlers.go": writeTestHandlers})
+		outputDir := filepath.Join(tmpDir, "specs")
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(filepath.Join(outputDir, "openapi.yaml"), "yaml"),
+			WithAdditionalOutputs(filepath.Join(outputDir, "openapi.json")))
+		_, err := g.GenerateMulti()
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(outputDir, "default.yaml"))
+		data, err := os.ReadFile(filepath.Join(outputDir, "default.json"))
+		require.NoError(t, err)
+		assert.True(t, json.Valid(data))
+	})
+
+	t.Run("unknown extension", func(t *testing.T) {
+		tmpDir := createTestProject(t, map[string]string{"api/handlers.go": writeTestHandlers})
+
+		g := New(WithDir(tmpDir), WithPattern("./..."), WithCache(false),
+			WithOutput(filepath.Join(tmpDir, "openapi.yaml"), "yaml"),
+			WithAdditionalOutputs(filepath.Join(tmpDir, "openapi.txt")))
+		_, err := g.Generate()
+		assert.ErrorContains(t, err, "cannot infer the format")
+	})
+}
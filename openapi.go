@@ -20,6 +20,8 @@
 //   - swagger:route - Operation definitions
 //   - swagger:parameters - Parameter definitions
 //   - swagger:enum - Enum definitions
+//   - swagger:channel - Event operations of the AsyncAPI document
+//   - swagger:message - Event message payloads
 //
 // # Caching
 //
@@ -32,9 +34,26 @@
 //	spec, err := openapi.Generate(
 //		openapi.WithCache(false),
 //	)
+//
+// # Streaming Output
+//
+// WriteSpec encodes the spec to an io.Writer instead of a file, e.g. to serve
+// it from an HTTP handler:
+//
+//	err := openapi.WriteSpec(r.Context(), w, openapi.FormatJSON, openapi.WithCache(false))
+//
+// # Concurrency
+//
+// A generator.Generator is safe for concurrent use, so a server can keep one
+// generator and call Generate or GenerateSpec from several goroutines. Each call
+// scans and assembles independently; only the configuration and cache are shared.
 package openapi
 
 import (
+	"context"
+	"io"
+
+	"github.com/kausys/openapi/asyncapi"
 	"github.com/kausys/openapi/generator"
 	"github.com/kausys/openapi/spec"
 )
@@ -42,12 +61,64 @@ import (
 // Option is a function type for configuring the generator.
 type Option = generator.Option
 
+// Format is the encoding of a generated spec.
+type Format = generator.Format
+
+// Warning is a non-fatal problem found during generation (see generator.Generator.Warnings).
+type Warning = generator.Warning
+
+// ErrWarnings is returned by strict generations that reported warnings.
+var ErrWarnings = generator.ErrWarnings
+
+// ErrUnresolvedRefs is returned by generations with WithStrictRefs when a type cannot be resolved.
+var ErrUnresolvedRefs = generator.ErrUnresolvedRefs
+
+// StaleOutputError is returned by generations with WithCheck when the output files are out of date.
+type StaleOutputError = generator.StaleOutputError
+
+// ErrStaleOutput is matched by StaleOutputError.
+var ErrStaleOutput = generator.ErrStaleOutput
+
+// BackstageConfig configures the Backstage catalog written with WithBackstage.
+type BackstageConfig = generator.BackstageConfig
+
+// TemplateData is the data of the output templates (see WithTemplates).
+type TemplateData = generator.TemplateData
+
+// TemplateOperation is an operation of TemplateData with its path and method.
+type TemplateOperation = generator.TemplateOperation
+
+// Supported output formats.
+const (
+	FormatYAML = generator.FormatYAML
+	FormatJSON = generator.FormatJSON
+)
+
 // Generate creates an OpenAPI specification from Go source code.
 // It scans the specified packages for swagger directives and generates
 // a complete OpenAPI 3.1 specification.
 func Generate(opts ...Option) (*spec.OpenAPI, error) {
+	return GenerateContext(context.Background(), opts...)
+}
+
+// GenerateContext is like Generate but stops scanning when ctx is done.
+func GenerateContext(ctx context.Context, opts ...Option) (*spec.OpenAPI, error) {
+	gen := generator.New(opts...)
+	return gen.GenerateContext(ctx)
+}
+
+// GenerateAsyncAPI creates an AsyncAPI document of the event channels and messages
+// declared with swagger:channel and swagger:message.
+func GenerateAsyncAPI(ctx context.Context, opts ...Option) (*asyncapi.AsyncAPI, error) {
 	gen := generator.New(opts...)
-	return gen.Generate()
+	return gen.GenerateAsyncAPIContext(ctx)
+}
+
+// WriteSpec generates an OpenAPI specification and encodes it to w,
+// without writing the configured output file.
+func WriteSpec(ctx context.Context, w io.Writer, format Format, opts ...Option) error {
+	gen := generator.New(opts...)
+	return gen.WriteSpec(ctx, w, format)
 }
 
 // WithDir sets the root directory to scan from.
@@ -59,9 +130,28 @@ var WithPattern = generator.WithPattern
 // WithIgnorePaths sets path patterns to exclude during scanning.
 var WithIgnorePaths = generator.WithIgnorePaths
 
+// WithOnlyPaths restricts scanning to the files matching one of the path patterns.
+var WithOnlyPaths = generator.WithOnlyPaths
+
+// WithBuildTags sets the build tags used to select the files to scan.
+var WithBuildTags = generator.WithBuildTags
+
+// WithIncludeTests loads _test.go files so that response examples can reference test variables.
+var WithIncludeTests = generator.WithIncludeTests
+
+// WithWorkspace scans the modules of the go.work file in the directory in one run.
+var WithWorkspace = generator.WithWorkspace
+
+// WithModulePatterns sets the package patterns of workspace modules, by module directory or path.
+var WithModulePatterns = generator.WithModulePatterns
+
 // WithOutput sets the output file path and format ("yaml" or "json").
 var WithOutput = generator.WithOutput
 
+// WithAdditionalOutputs writes the spec to more files in the same run, each in the format
+// of its extension (.yaml, .yml, or .json).
+var WithAdditionalOutputs = generator.WithAdditionalOutputs
+
 // WithCache enables or disables incremental caching.
 var WithCache = generator.WithCache
 
@@ -74,5 +164,96 @@ var WithValidation = generator.WithValidation
 // WithCleanUnused enables or disables removal of unreferenced schemas.
 var WithCleanUnused = generator.WithCleanUnused
 
+// WithReportUnused enables or disables warnings about models and enums no included route references.
+var WithReportUnused = generator.WithReportUnused
+
 // WithEnumRefs enables generating enums as $ref references instead of inline.
 var WithEnumRefs = generator.WithEnumRefs
+
+// WithSchemaTitles sets the title of model and enum schemas to their Go type name.
+var WithSchemaTitles = generator.WithSchemaTitles
+
+// WithDescriptionFallback describes fields and parameters with the doc comment of their type.
+var WithDescriptionFallback = generator.WithDescriptionFallback
+
+// WithRequireDescriptions makes lint report models, enums, and properties without a description.
+var WithRequireDescriptions = generator.WithRequireDescriptions
+
+// WithCheckRoutes makes lint compare the swagger:route directives with the router registrations.
+var WithCheckRoutes = generator.WithCheckRoutes
+
+// WithStrictObjects closes the object schemas of models (additionalProperties: false).
+var WithStrictObjects = generator.WithStrictObjects
+
+// WithProfile selects a registered gateway profile (e.g. Kong, Cloud Endpoints).
+var WithProfile = generator.WithProfile
+
+// WithSharedComponents writes schemas shared by several specs to a common components file.
+var WithSharedComponents = generator.WithSharedComponents
+
+// WithVersioned splits the specs of multi-spec mode per API version.
+var WithVersioned = generator.WithVersioned
+
+// WithIncludeTags keeps only routes tagged with at least one of the given tags.
+var WithIncludeTags = generator.WithIncludeTags
+
+// WithExcludeTags removes routes tagged with any of the given tags ("internal" strips internal routes and models).
+var WithExcludeTags = generator.WithExcludeTags
+
+// WithStrict fails the generation when any warning is reported.
+var WithStrict = generator.WithStrict
+
+// WithStrictRefs fails the generation when a response or field type cannot be resolved.
+var WithStrictRefs = generator.WithStrictRefs
+
+// WithCheck compares the generated specs with the output files instead of writing them.
+var WithCheck = generator.WithCheck
+
+// WithSplitReadWrite derives <Model>Request and <Model>Response variants from readOnly/writeOnly fields.
+var WithSplitReadWrite = generator.WithSplitReadWrite
+
+// WithFieldPolicies infers required/nullable properties from omitempty and pointers.
+var WithFieldPolicies = generator.WithFieldPolicies
+
+// WithDurationFormat renders time.Duration as a duration string or integer nanoseconds.
+var WithDurationFormat = generator.WithDurationFormat
+
+// WithSchemaNaming sets how models of different packages sharing a name are named.
+var WithSchemaNaming = generator.WithSchemaNaming
+
+// WithPathOrder sets the order of the paths of the written specs: alpha, tag, or source.
+var WithPathOrder = generator.WithPathOrder
+
+// WithResolveExternal loads dependency packages declaring referenced types on demand.
+var WithResolveExternal = generator.WithResolveExternal
+
+// WithOverlays applies OpenAPI Overlay files, in order, to each generated spec.
+var WithOverlays = generator.WithOverlays
+
+// WithPostProcessors runs external commands that receive each generated spec on stdin and print the result.
+var WithPostProcessors = generator.WithPostProcessors
+
+// WithTranslations writes a localized copy of each spec for every translations file (<locale>.yaml).
+var WithTranslations = generator.WithTranslations
+
+// WithCodeSamples generates a code sample per operation in each language (x-codeSamples).
+var WithCodeSamples = generator.WithCodeSamples
+
+// WithTemplates renders each written spec (TemplateData) through Go text/template files.
+var WithTemplates = generator.WithTemplates
+
+// WithBackstage writes a Backstage catalog with an API entity per written spec.
+var WithBackstage = generator.WithBackstage
+
+// WithEmbedOutput writes a Go file declaring the written specs by name in a Specs map,
+// embedded with //go:embed, for the Specs of swagger.Config.
+var WithEmbedOutput = generator.WithEmbedOutput
+
+// WithAsyncAPIOutput writes the AsyncAPI document of the event channels to a file (JSON for .json, YAML otherwise).
+var WithAsyncAPIOutput = generator.WithAsyncAPIOutput
+
+// WithPortableYAML writes YAML without anchors or aliases, with the document fields in the order of the specification.
+var WithPortableYAML = generator.WithPortableYAML
+
+// WithProblemDetails documents error responses as RFC 7807 problem details (application/problem+json).
+var WithProblemDetails = generator.WithProblemDetails
@@ -0,0 +1,570 @@
+package overlay
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// path is a compiled JSONPath expression (RFC 9535 subset).
+//
+// Supported syntax: the root $, child names (.name, ['name']), wildcards (.*, [*]),
+// array indexes ([0], [-1]), unions (['a','b']), descendants (..name, ..*), and filters
+// ([?@.name == 'value'], also written [?(...)]) with ==, !=, <, <=, >, >=, &&, ||, !,
+// and existence tests ([?@.deprecated]).
+type path struct {
+	segments []segment
+}
+
+// segment selects children of the current nodes, or of all their descendants.
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// selector selects children of a node by name, index, wildcard, or filter.
+type selector struct {
+	wildcard bool
+	name     *string
+	index    *int
+	filter   expr
+}
+
+// match is a node selected by a path, with its position in its parent.
+type match struct {
+	node   *yaml.Node
+	parent *yaml.Node // nil for the root
+	index  int        // index of node in parent.Content
+}
+
+// parsePath compiles a JSONPath expression.
+func parsePath(expression string) (*path, error) {
+	p := &pathParser{input: expression}
+	compiled, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", expression, err)
+	}
+	return compiled, nil
+}
+
+// selectNodes returns the nodes of root selected by the path, without duplicates.
+func (p *path) selectNodes(root *yaml.Node) []match {
+	current := []match{{node: root}}
+
+	for _, seg := range p.segments {
+		var candidates []match
+		if seg.descendant {
+			for _, m := range current {
+				candidates = append(candidates, descendants(m)...)
+			}
+		} else {
+			candidates = current
+		}
+
+		var next []match
+		for _, m := range candidates {
+			for _, sel := range seg.selectors {
+				next = append(next, sel.apply(m.node)...)
+			}
+		}
+		current = next
+	}
+
+	seen := make(map[*yaml.Node]bool, len(current))
+	result := current[:0]
+	for _, m := range current {
+		if !seen[m.node] {
+			seen[m.node] = true
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// apply returns the children of node selected by the selector.
+func (s selector) apply(node *yaml.Node) []match {
+	var matches []match
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			child := node.Content[i]
+			switch {
+			case s.wildcard,
+				s.name != nil && node.Content[i-1].Value == *s.name,
+				s.filter != nil && s.filter.test(child):
+				matches = append(matches, match{node: child, parent: node, index: i})
+			}
+		}
+	case yaml.SequenceNode:
+		if s.index != nil {
+			i := *s.index
+			if i < 0 {
+				i += len(node.Content)
+			}
+			if i >= 0 && i < len(node.Content) {
+				matches = append(matches, match{node: node.Content[i], parent: node, index: i})
+			}
+			break
+		}
+		for i, child := range node.Content {
+			if s.wildcard || s.filter != nil && s.filter.test(child) {
+				matches = append(matches, match{node: child, parent: node, index: i})
+			}
+		}
+	}
+
+	return matches
+}
+
+// descendants returns m and all the nodes nested in it, in document order.
+func descendants(m match) []match {
+	result := []match{m}
+	switch m.node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(m.node.Content); i += 2 {
+			result = append(result, descendants(match{node: m.node.Content[i], parent: m.node, index: i})...)
+		}
+	case yaml.SequenceNode:
+		for i, child := range m.node.Content {
+			result = append(result, descendants(match{node: child, parent: m.node, index: i})...)
+		}
+	}
+	return result
+}
+
+// expr is a filter expression evaluated against the current node (@).
+type expr interface {
+	test(node *yaml.Node) bool
+}
+
+// orExpr is true when any operand is true.
+type orExpr []expr
+
+func (e orExpr) test(node *yaml.Node) bool {
+	for _, operand := range e {
+		if operand.test(node) {
+			return true
+		}
+	}
+	return false
+}
+
+// andExpr is true when all operands are true.
+type andExpr []expr
+
+func (e andExpr) test(node *yaml.Node) bool {
+	for _, operand := range e {
+		if !operand.test(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// notExpr negates its operand.
+type notExpr struct{ operand expr }
+
+func (e notExpr) test(node *yaml.Node) bool {
+	return !e.operand.test(node)
+}
+
+// existsExpr is true when the relative path selects a node.
+type existsExpr struct{ path *path }
+
+func (e existsExpr) test(node *yaml.Node) bool {
+	return len(e.path.selectNodes(node)) > 0
+}
+
+// operand is a relative path (@...) or a literal value in a comparison.
+type operand struct {
+	path    *path
+	literal any
+}
+
+// value returns the value of the operand for the current node.
+func (o operand) value(node *yaml.Node) (any, bool) {
+	if o.path == nil {
+		return o.literal, true
+	}
+	matches := o.path.selectNodes(node)
+	if len(matches) != 1 || matches[0].node.Kind != yaml.ScalarNode {
+		return nil, false
+	}
+	var v any
+	if err := matches[0].node.Decode(&v); err != nil {
+		return nil, false
+	}
+	return normalize(v), true
+}
+
+// compareExpr compares two operands.
+type compareExpr struct {
+	left, right operand
+	op          string
+}
+
+func (e compareExpr) test(node *yaml.Node) bool {
+	left, leftOK := e.left.value(node)
+	right, rightOK := e.right.value(node)
+	if !leftOK || !rightOK {
+		// A missing value only equals another missing value
+		switch e.op {
+		case "==":
+			return !leftOK && !rightOK
+		case "!=":
+			return leftOK != rightOK
+		}
+		return false
+	}
+
+	switch e.op {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	}
+
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		return ok && compareOrdered(l, r, e.op)
+	case string:
+		r, ok := right.(string)
+		return ok && compareOrdered(l, r, e.op)
+	}
+	return false
+}
+
+// compareOrdered applies an ordering operator.
+func compareOrdered[T float64 | string](l, r T, op string) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+// normalize converts decoded numbers to float64 so they compare with literals.
+func normalize(v any) any {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	}
+	return v
+}
+
+// pathParser parses JSONPath expressions.
+type pathParser struct {
+	input string
+	pos   int
+}
+
+// parse parses an absolute path ($...).
+func (p *pathParser) parse() (*path, error) {
+	p.skipSpaces()
+	if !p.consume("$") {
+		return nil, fmt.Errorf("must start with $")
+	}
+	compiled, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.input[p.pos:], p.pos)
+	}
+	return compiled, nil
+}
+
+// parseSegments parses the segments following $ or @.
+func (p *pathParser) parseSegments() (*path, error) {
+	compiled := &path{}
+	for p.pos < len(p.input) {
+		var seg segment
+		switch {
+		case p.consume(".."):
+			seg.descendant = true
+			if p.peek() == '[' {
+				p.pos++
+				sels, err := p.parseBracket()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = sels
+			} else {
+				sel, err := p.parseDotSelector()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = []selector{sel}
+			}
+		case p.consume("."):
+			sel, err := p.parseDotSelector()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = []selector{sel}
+		case p.consume("["):
+			sels, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = sels
+		default:
+			return compiled, nil
+		}
+		compiled.segments = append(compiled.segments, seg)
+	}
+	return compiled, nil
+}
+
+// parseDotSelector parses the name or wildcard following a dot.
+func (p *pathParser) parseDotSelector() (selector, error) {
+	if p.consume("*") {
+		return selector{wildcard: true}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.input) && isNameChar(p.input[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return selector{}, fmt.Errorf("expected a name at offset %d", start)
+	}
+	name := p.input[start:p.pos]
+	return selector{name: &name}, nil
+}
+
+// parseBracket parses the comma-separated selectors of a bracket, after the [.
+func (p *pathParser) parseBracket() ([]selector, error) {
+	var sels []selector
+	for {
+		p.skipSpaces()
+		var sel selector
+		switch c := p.peek(); {
+		case c == '*':
+			p.pos++
+			sel.wildcard = true
+		case c == '\'' || c == '"':
+			name, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			sel.name = &name
+		case c == '?':
+			p.pos++
+			filter, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			sel.filter = filter
+		case c == '-' || c >= '0' && c <= '9':
+			start := p.pos
+			p.pos++
+			for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+				p.pos++
+			}
+			index, err := strconv.Atoi(p.input[start:p.pos])
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q", p.input[start:p.pos])
+			}
+			sel.index = &index
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", string(c), p.pos)
+		}
+		sels = append(sels, sel)
+
+		p.skipSpaces()
+		if p.consume("]") {
+			return sels, nil
+		}
+		if !p.consume(",") {
+			return nil, fmt.Errorf("expected , or ] at offset %d", p.pos)
+		}
+	}
+}
+
+// parseOr parses a filter expression: and-expressions joined by ||.
+func (p *pathParser) parseOr() (expr, error) {
+	var operands orExpr
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		p.skipSpaces()
+		if !p.consume("||") {
+			break
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+// parseAnd parses unary expressions joined by &&.
+func (p *pathParser) parseAnd() (expr, error) {
+	var operands andExpr
+	for {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		p.skipSpaces()
+		if !p.consume("&&") {
+			break
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+// parseUnary parses a negation, a parenthesized expression, a comparison, or an existence test.
+func (p *pathParser) parseUnary() (expr, error) {
+	p.skipSpaces()
+	if p.peek() == '!' && !strings.HasPrefix(p.input[p.pos:], "!=") {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{operand}, nil
+	}
+	if p.consume("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, fmt.Errorf("expected ) at offset %d", p.pos)
+		}
+		return inner, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return compareExpr{left: left, right: right, op: op}, nil
+		}
+	}
+
+	if left.path == nil {
+		return nil, fmt.Errorf("expected a comparison at offset %d", p.pos)
+	}
+	return existsExpr{left.path}, nil
+}
+
+// parseOperand parses a relative path or a literal.
+func (p *pathParser) parseOperand() (operand, error) {
+	p.skipSpaces()
+	switch c := p.peek(); {
+	case c == '@':
+		p.pos++
+		relative, err := p.parseSegments()
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{path: relative}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		return operand{literal: s}, err
+	}
+
+	for _, keyword := range []struct {
+		text  string
+		value any
+	}{{"true", true}, {"false", false}, {"null", nil}} {
+		if p.consume(keyword.text) {
+			return operand{literal: keyword.value}, nil
+		}
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && strings.ContainsRune("+-.0123456789eE", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	number, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		return operand{}, fmt.Errorf("expected a value at offset %d", start)
+	}
+	return operand{literal: number}, nil
+}
+
+// parseString parses a single- or double-quoted string with backslash escapes.
+func (p *pathParser) parseString() (string, error) {
+	quote := p.input[p.pos]
+	p.pos++
+
+	var sb strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return sb.String(), nil
+		case c == '\\' && p.pos < len(p.input):
+			sb.WriteByte(p.input[p.pos])
+			p.pos++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// consume advances past token when the input continues with it.
+func (p *pathParser) consume(token string) bool {
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+// peek returns the next byte, or 0 at the end of the input.
+func (p *pathParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// skipSpaces advances past blanks.
+func (p *pathParser) skipSpaces() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// isNameChar reports whether c can appear in a dot-notation name.
+func isNameChar(c byte) bool {
+	return c == '_' || c == '-' || c == '$' ||
+		c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
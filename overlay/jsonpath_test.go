@@ -0,0 +1,100 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testSpec = `openapi: 3.1.0
+info:
+  title: Pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+    post:
+      operationId: createPet
+      tags: [pets, admin]
+      deprecated: true
+  /admin:
+    delete:
+      operationId: purge
+      tags: [admin]
+servers:
+  - url: https://a.example.com
+  - url: https://b.example.com
+components:
+  schemas:
+    Pet:
+      properties:
+        age:
+          type: integer
+          minimum: 0
+`
+
+func TestSelectNodes(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"child", "$.info.title", []string{"Pets"}},
+		{"bracket name", "$.paths['/pets'].get.operationId", []string{"listPets"}},
+		{"double quoted", `$.paths["/admin"].delete.operationId`, []string{"purge"}},
+		{"wildcard", "$.paths['/pets'].*.operationId", []string{"listPets", "createPet"}},
+		{"index", "$.servers[1].url", []string{"https://b.example.com"}},
+		{"negative index", "$.servers[-1].url", []string{"https://b.example.com"}},
+		{"union", "$.paths['/pets']['get','post'].operationId", []string{"listPets", "createPet"}},
+		{"descendant", "$..operationId", []string{"listPets", "createPet", "purge"}},
+		{"filter equal", "$.paths.*[?(@.operationId == 'purge')].operationId", []string{"purge"}},
+		{"filter without parens", "$.paths.*[?@.operationId != 'purge'].operationId", []string{"listPets", "createPet"}},
+		{"filter existence", "$.paths.*[?@.deprecated].operationId", []string{"createPet"}},
+		{"filter negation", "$.paths.*[?!@.deprecated].operationId", []string{"listPets", "purge"}},
+		{"filter and", "$.paths.*[?@.tags[0] == 'pets' && @.deprecated == true].operationId", []string{"createPet"}},
+		{"filter or", "$.paths.*[?@.operationId == 'purge' || @.operationId == 'listPets'].operationId", []string{"listPets", "purge"}},
+		{"filter number", "$..properties[?@.minimum >= 0].type", []string{"integer"}},
+		{"filter on array items", "$..tags[?@ == 'admin']", []string{"admin", "admin"}},
+		{"no match", "$.paths['/missing']", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(testSpec), &doc))
+
+			p, err := parsePath(tt.path)
+			require.NoError(t, err)
+
+			var got []string
+			for _, m := range p.selectNodes(doc.Content[0]) {
+				got = append(got, m.node.Value)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePathErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing root", "info.title"},
+		{"empty name", "$.info."},
+		{"unclosed bracket", "$.paths['/pets'"},
+		{"unterminated string", "$.paths['/pets]"},
+		{"bad filter", "$.paths[?(@.a == )]"},
+		{"trailing text", "$.info title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePath(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Package overlay applies OpenAPI Overlay documents to OpenAPI specs.
+//
+// An overlay is a list of actions, each targeting nodes of the spec with a JSONPath
+// expression, that either merge an update into the targets or remove them:
+//
+//	overlay: 1.0.0
+//	info:
+//	  title: Public API
+//	  version: 1.0.0
+//	actions:
+//	  - target: $.paths['/admin']
+//	    remove: true
+//	  - target: $.info
+//	    update:
+//	      x-audience: public
+//
+// Specs are handled as yaml.Node trees, so key order and comments are preserved.
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overlay is an OpenAPI Overlay document.
+type Overlay struct {
+	// Overlay is the version of the Overlay Specification (1.x)
+	Overlay string `yaml:"overlay" json:"overlay"`
+	// Info describes the overlay
+	Info Info `yaml:"info" json:"info"`
+	// Extends is the URL of the spec the overlay is meant for (informational)
+	Extends string `yaml:"extends,omitempty" json:"extends,omitempty"`
+	// Actions are applied in order
+	Actions []*Action `yaml:"actions" json:"actions"`
+}
+
+// Info describes an overlay.
+type Info struct {
+	Title   string `yaml:"title" json:"title"`
+	Version string `yaml:"version" json:"version"`
+}
+
+// Action updates or removes the nodes selected by its target.
+type Action struct {
+	// Target is a JSONPath expression selecting the nodes of the spec
+	Target string `yaml:"target" json:"target"`
+	// Description describes the action
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Update is merged into the selected objects, or appended to the selected arrays
+	Update yaml.Node `yaml:"update,omitempty" json:"update,omitempty"`
+	// Remove removes the selected nodes from their parent
+	Remove bool `yaml:"remove,omitempty" json:"remove,omitempty"`
+}
+
+// Parse parses and validates an overlay document in YAML or JSON.
+func Parse(data []byte) (*Overlay, error) {
+	var o Overlay
+	if err := yaml.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay: %w", err)
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// Load reads and parses an overlay file.
+func Load(path string) (*Overlay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	o, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return o, nil
+}
+
+// Validate checks the overlay version and the action targets.
+func (o *Overlay) Validate() error {
+	if !strings.HasPrefix(o.Overlay, "1.") {
+		return fmt.Errorf("unsupported overlay version %q, expected 1.x", o.Overlay)
+	}
+	if len(o.Actions) == 0 {
+		return errors.New("overlay has no actions")
+	}
+
+	var errs []error
+	for i, action := range o.Actions {
+		if action.Target == "" {
+			errs = append(errs, fmt.Errorf("action %d: target is required", i+1))
+			continue
+		}
+		if _, err := parsePath(action.Target); err != nil {
+			errs = append(errs, fmt.Errorf("action %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Apply applies the actions of the overlay, in order, to a spec decoded as a yaml.Node.
+// Actions whose target selects nothing are skipped.
+func (o *Overlay) Apply(root *yaml.Node) error {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	for i, action := range o.Actions {
+		if err := action.apply(root); err != nil {
+			return fmt.Errorf("action %d (%s): %w", i+1, action.Target, err)
+		}
+	}
+	return nil
+}
+
+// apply applies a single action.
+func (a *Action) apply(root *yaml.Node) error {
+	target, err := parsePath(a.Target)
+	if err != nil {
+		return err
+	}
+	matches := target.selectNodes(root)
+
+	if a.Remove {
+		removeMatches(matches)
+		return nil
+	}
+
+	update := &a.Update
+	if update.Kind == yaml.DocumentNode && len(update.Content) > 0 {
+		update = update.Content[0]
+	}
+	if update.Kind == 0 {
+		return nil
+	}
+
+	for _, m := range matches {
+		switch m.node.Kind {
+		case yaml.MappingNode:
+			if update.Kind != yaml.MappingNode {
+				return errors.New("update of an object must be an object")
+			}
+			mergeMapping(m.node, update)
+		case yaml.SequenceNode:
+			m.node.Content = append(m.node.Content, copyNode(update))
+		default:
+			return errors.New("target selects a value that is not an object or array")
+		}
+	}
+	return nil
+}
+
+// removeMatches removes the matched nodes from their parents. Entries are removed
+// from the end of each parent so the indexes of the other matches stay valid.
+func removeMatches(matches []match) {
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return b.index - a.index
+	})
+
+	for _, m := range matches {
+		if m.parent == nil {
+			continue
+		}
+		if m.parent.Kind == yaml.MappingNode {
+			m.parent.Content = slices.Delete(m.parent.Content, m.index-1, m.index+1)
+		} else {
+			m.parent.Content = slices.Delete(m.parent.Content, m.index, m.index+1)
+		}
+	}
+}
+
+// mergeMapping merges src into dst: objects merge recursively, other values replace
+// the existing ones, and new keys are appended.
+func mergeMapping(dst, src *yaml.Node) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+
+		existing := -1
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == key.Value {
+				existing = j + 1
+				break
+			}
+		}
+
+		switch {
+		case existing < 0:
+			dst.Content = append(dst.Content, copyNode(key), copyNode(value))
+		case dst.Content[existing].Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			mergeMapping(dst.Content[existing], value)
+		default:
+			dst.Content[existing] = copyNode(value)
+		}
+	}
+}
+
+// copyNode returns a deep copy of a node, so an update applied to several targets
+// doesn't share nodes between them.
+func copyNode(node *yaml.Node) *yaml.Node {
+	c := *node
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		c.Content[i] = copyNode(child)
+	}
+	return &c
+}
@@ -0,0 +1,191 @@
+package overlay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		check   func(t *testing.T, spec map[string]any)
+	}{
+		{
+			name: "update merges into objects",
+			overlay: `overlay: 1.0.0
+info: {title: test, version: 1.0.0}
+actions:
+  - target: $.info
+    update:
+      description: Public pets API
+      x-audience: public
+  - target: $.components.schemas.Pet.properties.age
+    update:
+      minimum: 1
+      maximum: 30
+`,
+			check: func(t *testing.T, spec map[string]any) {
+				info := spec["info"].(map[string]any)
+				assert.Equal(t, "Pets", info["title"])
+				assert.Equal(t, "Public pets API", info["description"])
+				assert.Equal(t, "public", info["x-audience"])
+
+				age := dig(spec, "components", "schemas", "Pet", "properties", "age")
+				assert.Equal(t, map[string]any{"type": "integer", "minimum": 1, "maximum": 30}, age)
+			},
+		},
+		{
+			name: "update appends to arrays",
+			overlay: `overlay: 1.0.0
+info: {title: test, version: 1.0.0}
+actions:
+  - target: $.servers
+    update:
+      url: https://c.example.com
+`,
+			check: func(t *testing.T, spec map[string]any) {
+				servers := spec["servers"].([]any)
+				require.Len(t, servers, 3)
+				assert.Equal(t, map[string]any{"url": "https://c.example.com"}, servers[2])
+			},
+		},
+		{
+			name: "remove deletes keys and array items",
+			overlay: `overlay: 1.0.0
+info: {title: test, version: 1.0.0}
+actions:
+  - target: $.paths['/admin']
+    remove: true
+  - target: $.paths.*[?@.deprecated]
+    remove: true
+  - target: $.servers[*]
+    remove: true
+`,
+			check: func(t *testing.T, spec map[string]any) {
+				paths := spec["paths"].(map[string]any)
+				assert.NotContains(t, paths, "/admin")
+				assert.Equal(t, []string{"get"}, keys(paths["/pets"].(map[string]any)))
+				assert.Empty(t, spec["servers"])
+			},
+		},
+		{
+			name: "target without matches is a no-op",
+			overlay: `overlay: 1.0.0
+info: {title: test, version: 1.0.0}
+actions:
+  - target: $.paths['/missing']
+    update:
+      summary: ignored
+`,
+			check: func(t *testing.T, spec map[string]any) {
+				assert.NotContains(t, spec["paths"], "/missing")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := Parse([]byte(tt.overlay))
+			require.NoError(t, err)
+
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(testSpec), &doc))
+			require.NoError(t, o.Apply(&doc))
+
+			var spec map[string]any
+			require.NoError(t, doc.Decode(&spec))
+			tt.check(t, spec)
+		})
+	}
+}
+
+func TestApplyPreservesOrder(t *testing.T) {
+	o, err := Parse([]byte(`overlay: 1.0.0
+info: {title: test, version: 1.0.0}
+actions:
+  - target: $.info
+    update:
+      version: 2.0.0
+`))
+	require.NoError(t, err)
+
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(testSpec), &doc))
+	require.NoError(t, o.Apply(&doc))
+
+	out, err := yaml.Marshal(&doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "openapi: 3.1.0\ninfo:\n    title: Pets\n    version: 2.0.0\npaths:")
+}
+
+func TestApplyUpdateScalarTarget(t *testing.T) {
+	o, err := Parse([]byte(`overlay: 1.0.0
+info: {title: test, version: 1.0.0}
+actions:
+  - target: $.info.title
+    update:
+      value: x
+`))
+	require.NoError(t, err)
+
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(testSpec), &doc))
+	err = o.Apply(&doc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "action 1 ($.info.title)")
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		wantErr string
+	}{
+		{"unsupported version", "overlay: 2.0.0\nactions:\n  - target: $.info\n    remove: true\n", "unsupported overlay version"},
+		{"no actions", "overlay: 1.0.0\n", "no actions"},
+		{"missing target", "overlay: 1.0.0\nactions:\n  - remove: true\n", "action 1: target is required"},
+		{"invalid target", "overlay: 1.0.0\nactions:\n  - target: info\n    remove: true\n", "action 1: invalid JSONPath"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.overlay))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overlay.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"overlay": "1.0.0", "info": {"title": "t", "version": "1"},
+"actions": [{"target": "$.info", "update": {"title": "Renamed"}}]}`), 0644))
+
+	o, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, o.Actions, 1)
+	assert.Equal(t, "$.info", o.Actions[0].Target)
+}
+
+// dig returns the value at the given keys of nested maps.
+func dig(v any, keys ...string) any {
+	for _, key := range keys {
+		v = v.(map[string]any)[key]
+	}
+	return v
+}
+
+// keys returns the keys of a map.
+func keys(m map[string]any) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
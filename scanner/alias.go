@@ -116,6 +116,57 @@ func (s *Scanner) resolveTypeAlias(typeName string, visited map[string]bool, all
 	return typeName
 }
 
+// ResolveUnderlyingType resolves a type declared in the scanned packages to the type
+// it stands for: the basic type of a named primitive (e.g., "int64" for type UserID int64),
+// the slice, array, or map type of a named collection (e.g., "[]string" for type Tags
+// []string, "map[string]string" for type Labels map[string]string), or the named type of
+// an alias (e.g., "uuid.UUID" for type ID = uuid.UUID). Named types in collections are
+// qualified with their package name. Returns an empty string if the type is unknown or
+// has another underlying type.
+func (s *Scanner) ResolveUnderlyingType(typeName string) string {
+	typeObj, ok := s.lookupTypeName(typeName)
+	if !ok {
+		return s.underlyingTypes[typeName]
+	}
+
+	t := typeObj.Type()
+	if alias, ok := t.(*types.Alias); ok {
+		t = types.Unalias(alias)
+		if original := s.resolveOriginalType(t); original != "" {
+			return original
+		}
+	}
+
+	switch underlying := t.Underlying().(type) {
+	case *types.Basic:
+		if underlying.Info()&types.IsUntyped == 0 {
+			// Use the canonical name (uint8 for byte, int32 for rune)
+			return types.Typ[underlying.Kind()].Name()
+		}
+	case *types.Slice, *types.Array, *types.Map:
+		return types.TypeString(underlying, func(pkg *types.Package) string { return pkg.Name() })
+	}
+	return ""
+}
+
+// lookupTypeName finds the declaration of a type by its short or package-qualified name.
+// A short-name match for a qualified name is only used when the package names agree.
+func (s *Scanner) lookupTypeName(typeName string) (*types.TypeName, bool) {
+	if typeObj, ok := s.typeInfo[typeName].(*types.TypeName); ok {
+		return typeObj, true
+	}
+
+	qualifier, shortName, qualified := strings.Cut(typeName, ".")
+	if !qualified {
+		return nil, false
+	}
+	typeObj, ok := s.typeInfo[shortName].(*types.TypeName)
+	if !ok || typeObj.Pkg() == nil || typeObj.Pkg().Name() != qualifier {
+		return nil, false
+	}
+	return typeObj, true
+}
+
 // GetEnumForType finds the enum info for a type, resolving aliases if necessary.
 func (s *Scanner) GetEnumForType(typeName string) *EnumInfo {
 	// Try direct lookup first
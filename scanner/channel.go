@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// processChannels processes swagger:channel directives.
+func (s *Scanner) processChannels(filePath string, file *ast.File) error {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Doc == nil {
+			continue
+		}
+
+		if !hasDirective(funcDecl.Doc, ChannelDirective) {
+			continue
+		}
+
+		pos := s.directivePos(funcDecl.Doc, ChannelDirective)
+		channelName, action, tags, operationID, err := parseChannelDirective(extractDirectiveValue(funcDecl.Doc, ChannelDirective))
+		if err != nil {
+			s.warn(WarnInvalidChannel, pos, "%s: %v, expected %s channel publish|subscribe [tags] operationID; channel skipped",
+				directiveText(funcDecl.Doc, ChannelDirective), err, ChannelDirective)
+			continue
+		}
+
+		channel := &ChannelInfo{
+			Channel:     channelName,
+			Action:      action,
+			Tags:        tags,
+			OperationID: operationID,
+			Summary:     extractDirectiveValue(funcDecl.Doc, SummaryFieldDirective),
+			Description: extractRouteDescription(funcDecl.Doc),
+			SourceFile:  filePath,
+			Pos:         pos,
+		}
+		if message := extractDirectiveValue(funcDecl.Doc, ChannelMessageDirective); message != "" {
+			channel.Messages = strings.Split(message, "|")
+		}
+
+		if channel.Description, err = loadDescriptionFile(channel.Description, filePath); err != nil {
+			return fmt.Errorf("%s: operation %s: %w", s.location(pos), operationID, err)
+		}
+
+		// The same position means the file is scanned again
+		if existing, ok := s.Channels[operationID]; ok && existing.Pos != pos {
+			return fmt.Errorf("%s: operation ID %s of %s %s is already used by %s %s declared at %s",
+				s.location(pos), operationID, action, channelName, existing.Action, existing.Channel, s.location(existing.Pos))
+		}
+
+		s.Channels[operationID] = channel
+	}
+	return nil
+}
+
+// parseChannelDirective parses: channel publish|subscribe tag1 tag2 operationID
+// The error describes the first problem of a malformed directive.
+func parseChannelDirective(value string) (channel, action string, tags []string, operationID string, err error) {
+	tokens := tokenizeWithQuotes(strings.TrimSpace(value))
+	if len(tokens) == 0 {
+		return "", "", nil, "", errors.New("missing channel and action")
+	}
+	channel = tokens[0]
+
+	if len(tokens) < 2 {
+		return "", "", nil, "", errors.New("missing action")
+	}
+	action = strings.ToLower(tokens[1])
+	if action != ActionPublish && action != ActionSubscribe {
+		return "", "", nil, "", fmt.Errorf("invalid action %q", tokens[1])
+	}
+
+	if len(tokens) < 3 {
+		return "", "", nil, "", errMissingOperationID
+	}
+	operationID = tokens[len(tokens)-1]
+
+	// Extract tags (everything between action and operationID)
+	if len(tokens) > 3 {
+		tags = tokens[2 : len(tokens)-1]
+	}
+
+	return channel, action, tags, operationID, nil
+}
+
+// addMessage registers the message declared by a swagger:message directive on a model.
+// Format: swagger:message [Name]
+func (s *Scanner) addMessage(filePath string, typeSpec *ast.TypeSpec, doc *ast.CommentGroup, structInfo *StructInfo) error {
+	name := extractDirectiveValue(doc, MessageDirective)
+	if name == "" {
+		name = typeSpec.Name.Name
+	}
+
+	// The Go type keeps resolving to the model when the naming strategy renames it
+	payload := structInfo.GoType
+	if structInfo.PkgPath != "" {
+		payload = structInfo.PkgPath + "." + structInfo.GoType
+	}
+
+	pos := s.directivePos(doc, MessageDirective)
+	if existing, ok := s.Messages[name]; ok && existing.Pos != pos {
+		return fmt.Errorf("%s: message %s is already declared at %s", s.location(pos), name, s.location(existing.Pos))
+	}
+
+	s.Messages[name] = &MessageInfo{
+		Name:       name,
+		Payload:    payload,
+		SourceFile: filePath,
+		Pos:        pos,
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseChannelDirective tests parsing swagger:channel values and rejecting malformed ones
+func TestParseChannelDirective(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		channel     string
+		action      string
+		tags        []string
+		operationID string
+		wantErr     string
+	}{
+		{name: "subscribe", value: "users.created subscribe users onUserCreated", channel: "users.created", action: "subscribe", tags: []string{"users"}, operationID: "onUserCreated"},
+		{name: "publish without tags", value: "users/{id}/commands PUBLISH handleCommand", channel: "users/{id}/commands", action: "publish", operationID: "handleCommand"},
+		{name: "invalid action", value: "users.created send onUserCreated", wantErr: `invalid action "send"`},
+		{name: "missing operation ID", value: "users.created subscribe", wantErr: "missing operation ID"},
+		{name: "channel only", value: "users.created", wantErr: "missing action"},
+		{name: "empty", value: "", wantErr: "missing channel and action"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel, action, tags, operationID, err := parseChannelDirective(tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.channel, channel)
+			assert.Equal(t, tt.action, action)
+			assert.Equal(t, tt.tags, tags)
+			assert.Equal(t, tt.operationID, operationID)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package scanner
+
+// Directive contexts: the doc comments a directive is read from.
+const (
+	ContextMeta    = "meta"    // swagger:meta package comment
+	ContextRoute   = "route"   // swagger:route function comment
+	ContextGroup   = "group"   // swagger:group comment
+	ContextModel   = "model"   // swagger:model type comment
+	ContextField   = "field"   // Field comment of a model or parameters struct
+	ContextChannel = "channel" // swagger:channel function comment
+	ContextType    = "type"    // Comment of a type declaration
+)
+
+// DirectiveDoc documents a directive read by the scanner.
+type DirectiveDoc struct {
+	// Directive as written in doc comments (e.g., swagger:route or min:)
+	Directive string
+	// Syntax of the directive with its arguments
+	Syntax string
+	// What the directive declares
+	Description string
+	// Doc comments the directive is read from
+	Contexts []string
+	// Example of the directive
+	Example string
+}
+
+// Directives returns the documentation of the directives read by the scanner: the
+// swagger: declarations first, then the directives of the meta, routes, models, fields,
+// and channels.
+func Directives() []DirectiveDoc {
+	return directiveDocs
+}
+
+var directiveDocs = []DirectiveDoc{
+	// Declarations
+	{MetaDirective, MetaDirective, "API metadata of the package (title, version, servers, security)",
+		[]string{ContextMeta}, MetaDirective},
+	{RouteDirective, RouteDirective + " METHOD /path [tags] operationID", "Operation of the API",
+		[]string{ContextRoute}, RouteDirective + " GET /users/{id} users getUser"},
+	{ModelDirective, ModelDirective + " [Name]", "Schema of a type, named after the type unless a name is given",
+		[]string{ContextType}, ModelDirective + " User"},
+	{ParameterDirective, ParameterDirective + " operationID...", "Parameters of one or more operations",
+		[]string{ContextType}, ParameterDirective + " listUsers"},
+	{HeadersDirective, HeadersDirective + " operationID...", "Header parameters of one or more operations",
+		[]string{ContextType}, HeadersDirective + " getUser listUsers"},
+	{PathDirective, PathDirective + " /path", "Summary, description, and shared parameters of a path",
+		[]string{ContextType}, PathDirective + " /users/{id}"},
+	{GroupDirective, GroupDirective, "Path prefix, tags, security, and responses shared by the routes of a file or package",
+		[]string{ContextGroup}, GroupDirective},
+	{EnumDirective, EnumDirective + " [Name]", "Enum of the constants of a type",
+		[]string{ContextType}, EnumDirective},
+	{OneOfModelDirective, OneOfModelDirective + " [Name]", "oneOf schema of the swagger:oneOfOption fields of a struct",
+		[]string{ContextType}, OneOfModelDirective + " Payment"},
+	{AnyOfModelDirective, AnyOfModelDirective + " [Name]", "anyOf schema of the swagger:anyOfOption fields of a struct",
+		[]string{ContextType}, AnyOfModelDirective + " Filter"},
+	{OneOfOptionDirective, OneOfOptionDirective, "Embedded field that is an option of a oneOf schema",
+		[]string{ContextField}, OneOfOptionDirective},
+	{AnyOfOptionDirective, AnyOfOptionDirective, "Embedded field that is an option of an anyOf schema",
+		[]string{ContextField}, AnyOfOptionDirective},
+	{IgnoreDirective, IgnoreDirective, "Leaves a field, type, or handler out of the spec",
+		[]string{ContextField, ContextType, ContextRoute}, IgnoreDirective},
+	{FragmentDirective, FragmentDirective + " file", "Merges a hand-written OpenAPI fragment file into the spec",
+		[]string{ContextMeta, ContextRoute}, FragmentDirective + " ./fragments/payments.yaml"},
+	{ChannelDirective, ChannelDirective + " channel publish|subscribe [tags] operationID", "Event operation of the AsyncAPI document",
+		[]string{ContextChannel}, ChannelDirective + " users.created subscribe users onUserCreated"},
+	{MessageDirective, MessageDirective + " [Name]", "Payload of an event message",
+		[]string{ContextType}, MessageDirective + " UserCreated"},
+
+	// Meta
+	{TitleDirective, TitleDirective + " text", "Title of the API", []string{ContextMeta}, TitleDirective + " Users API"},
+	{SummaryDirective, SummaryDirective + " text", "Short summary of the API", []string{ContextMeta}, SummaryDirective + " Users and their roles"},
+	{VersionDirective, VersionDirective + " version", "Version of the API document", []string{ContextMeta}, VersionDirective + " 1.0.0"},
+	{DescriptionDirective, DescriptionDirective + " text", "Description of the API", []string{ContextMeta}, DescriptionDirective + " Manages the users."},
+	{TermsOfServiceDirective, TermsOfServiceDirective + " url", "Terms of service of the API",
+		[]string{ContextMeta}, TermsOfServiceDirective + " https://example.com/terms"},
+	{ContactDirective, ContactDirective + " (- name:|url:|email: value)...", "Contact of the API",
+		[]string{ContextMeta}, ContactDirective + " - email: support@example.com"},
+	{LicenseDirective, LicenseDirective + " name | (- name:|identifier:|url: value)...", "License of the API",
+		[]string{ContextMeta}, LicenseDirective + " MIT"},
+	{LogoDirective, LogoDirective + " (- url:|backgroundColor:|altText:|href: value)...", "Logo of the API (x-logo)",
+		[]string{ContextMeta}, LogoDirective + " - url: https://example.com/logo.png"},
+	{HostDirective, HostDirective + " host", "Host of the server URL", []string{ContextMeta}, HostDirective + " api.example.com"},
+	{BasePathDirective, BasePathDirective + " /path", "Base path of the server URL", []string{ContextMeta}, BasePathDirective + " /v1"},
+	{ServersDirective, ServersDirective + " (- url [description])...", "Servers of the API",
+		[]string{ContextMeta}, ServersDirective + " - https://api.example.com Production"},
+	{TagsDirective, TagsDirective + " (- name: tag description: text)...", "Tags of the API, or of the routes of a group",
+		[]string{ContextMeta, ContextGroup}, TagsDirective + " - name: users description: User management"},
+	{ExternalDocsDirective, ExternalDocsDirective + " url [description]", "External documentation of the API or operation",
+		[]string{ContextMeta, ContextRoute}, ExternalDocsDirective + " https://docs.example.com Guide"},
+	{SecuritySchemesDirective, SecuritySchemesDirective + " (- name: type: ...)...", "Security schemes of the API",
+		[]string{ContextMeta}, SecuritySchemesDirective + " - bearer: type: http scheme: bearer"},
+	{StripPrefixDirective, StripPrefixDirective + " /prefix", "Removes a prefix from the paths of the spec",
+		[]string{ContextMeta}, StripPrefixDirective + " /admin"},
+	{AddPrefixDirective, AddPrefixDirective + " /prefix", "Adds a prefix to the paths of the spec",
+		[]string{ContextMeta}, AddPrefixDirective + " /api"},
+	{IncludesDirective, IncludesDirective + " spec...", "Makes a spec contain the routes of other specs",
+		[]string{ContextMeta}, IncludesDirective + " public"},
+
+	// Routes
+	{SummaryFieldDirective, SummaryFieldDirective + " text", "Short summary of the operation, path, or channel",
+		[]string{ContextRoute, ContextChannel, ContextType}, SummaryFieldDirective + " List users"},
+	{DescriptionFieldDirective, DescriptionFieldDirective + " text | file:path", "Description of the operation, read from a markdown file with file:",
+		[]string{ContextRoute}, DescriptionFieldDirective + " file:./docs/users.md"},
+	{DeprecatedFieldDirective, DeprecatedFieldDirective + "[: [YYYY-MM-DD] [use operationID]]", "Deprecates the operation, with its sunset date and replacement",
+		[]string{ContextRoute}, DeprecatedFieldDirective + ": 2025-06-01 use getUsersV2"},
+	{SecurityDirective, SecurityDirective + " (- scheme [scopes])... | none", "Security requirements of the API or operation; none opts out",
+		[]string{ContextMeta, ContextRoute, ContextGroup}, SecurityDirective + " - bearer"},
+	{ConsumesDirective, ConsumesDirective + " (- media/type)...", "Media types of the request bodies",
+		[]string{ContextMeta, ContextRoute}, ConsumesDirective + " - application/json"},
+	{ProducesDirective, ProducesDirective + " (- media/type [: Type])...", "Media types of the responses",
+		[]string{ContextMeta, ContextRoute}, ProducesDirective + " - text/csv: string"},
+	{ResponsesDirective, ResponsesDirective + " (- status: [[]]Type [description])...", "Responses of the operation",
+		[]string{ContextRoute, ContextGroup}, ResponsesDirective + " - 200: []User"},
+	{ParametersDirective, ParametersDirective, "Ends the description; the parameters are declared by swagger:parameters structs",
+		[]string{ContextRoute}, ParametersDirective},
+	{RequestBodyDirective, RequestBodyDirective + " [[]]Type [required] [description]", "Request body of the operation",
+		[]string{ContextRoute}, RequestBodyDirective + " CreateUser required"},
+	{AllowBodyDirective, AllowBodyDirective + " true", "Keeps the request body of a GET, DELETE, HEAD, or OPTIONS route",
+		[]string{ContextRoute}, AllowBodyDirective + " true"},
+	{IgnoredParametersDirective, IgnoredParametersDirective + " (- name)...", "Parameters left out of the operation",
+		[]string{ContextRoute}, IgnoredParametersDirective + " - X-Request-ID"},
+	{PathPrefixDirective, PathPrefixDirective + " /prefix", "Prefix of the paths of the routes of a group",
+		[]string{ContextGroup}, PathPrefixDirective + " /admin"},
+	{EnvelopeDirective, EnvelopeDirective + " field [Model] | none", "Wraps the success responses in an envelope object",
+		[]string{ContextMeta, ContextRoute}, EnvelopeDirective + " data Envelope"},
+	{ProblemDirective, ProblemDirective + " Model", "Extends the Problem schema of the untyped error responses",
+		[]string{ContextRoute}, ProblemDirective + " ValidationProblem"},
+	{RouteVersionDirective, RouteVersionDirective + " version", "API version of the route in versioned mode",
+		[]string{ContextRoute}, RouteVersionDirective + " v2"},
+	{CodeSampleDirective, CodeSampleDirective + " lang source | file:path", "Code sample of the operation (x-codeSamples)",
+		[]string{ContextRoute}, CodeSampleDirective + " Go file:./samples/list_users.go"},
+	{RateLimitDirective, RateLimitDirective + " limit/window [scope]", "Rate limit of the operation (x-ratelimit) with a 429 response",
+		[]string{ContextRoute}, RateLimitDirective + " 100/minute user"},
+	{PaginationDirective, PaginationDirective + " cursor|offset|page [role=param]...", "Pagination of a list operation (x-pagination)",
+		[]string{ContextRoute}, PaginationDirective + " cursor cursor=after"},
+	{MappingDirective, MappingDirective + "value=Type,...", "Discriminator mapping of a oneOf response",
+		[]string{ContextRoute}, MappingDirective + "card=Card,bank=BankTransfer"},
+	{SpecDirective, SpecDirective + " name... | * | !name", "Specs the route or model belongs to",
+		[]string{ContextMeta, ContextRoute, ContextType}, SpecDirective + " public admin"},
+	{InternalDirective, InternalDirective + " true", "Marks the route or model as internal-only",
+		[]string{ContextRoute, ContextType}, InternalDirective + " true"},
+
+	// Models
+	{AllOfDirective, AllOfDirective + " Model...", "Models the schema extends (allOf)", []string{ContextModel}, AllOfDirective + " Base"},
+	{OneOfDirective, OneOfDirective + " Model...", "Options of a oneOf schema", []string{ContextModel}, OneOfDirective + " Card BankTransfer"},
+	{AnyOfDirective, AnyOfDirective + " Model...", "Options of an anyOf schema", []string{ContextModel}, AnyOfDirective + " Card BankTransfer"},
+	{DiscriminatorDirective, DiscriminatorDirective + " property", "Discriminator property of a oneOf or anyOf schema",
+		[]string{ContextModel, ContextRoute}, DiscriminatorDirective + " type"},
+	{AdditionalPropertiesDirective, AdditionalPropertiesDirective + " true|false", "Closes or opens the object schema of a model",
+		[]string{ContextModel}, AdditionalPropertiesDirective + " false"},
+	{MinPropertiesDirective, MinPropertiesDirective + " n", "Minimum number of properties of an object",
+		[]string{ContextModel, ContextField}, MinPropertiesDirective + " 1"},
+	{MaxPropertiesDirective, MaxPropertiesDirective + " n", "Maximum number of properties of an object",
+		[]string{ContextModel, ContextField}, MaxPropertiesDirective + " 20"},
+	{PropertyNamesDirective, PropertyNamesDirective + " pattern", "Pattern the property names of an object match",
+		[]string{ContextModel, ContextField}, PropertyNamesDirective + " ^[a-z][a-z0-9_]*$"},
+
+	// Fields
+	{InDirective, InDirective + " query|header|path|cookie|body|form", "Location of a parameter",
+		[]string{ContextField}, InDirective + " query"},
+	{RequiredDirective, RequiredDirective + " true|false", "Marks the property or parameter as required",
+		[]string{ContextField}, RequiredDirective + " true"},
+	{NullableDirective, NullableDirective + " true|false", "Marks the property as nullable", []string{ContextField}, NullableDirective + " true"},
+	{ExampleDirective, ExampleDirective + " value", "Example of the property, parameter, or response",
+		[]string{ContextField, ContextRoute, ContextType}, ExampleDirective + " jane@example.com"},
+	{DefaultDirective, DefaultDirective + " value", "Default value of the property or parameter", []string{ContextField}, DefaultDirective + " 20"},
+	{ConstDirective, ConstDirective + " value", "Constant value of the property", []string{ContextField}, ConstDirective + " v1"},
+	{FormatDirective, FormatDirective + " format", "Format of the property or parameter", []string{ContextField}, FormatDirective + " email"},
+	{MinimumDirective, MinimumDirective + " n", "Minimum of a number", []string{ContextField}, MinimumDirective + " 1"},
+	{MaximumDirective, MaximumDirective + " n", "Maximum of a number", []string{ContextField}, MaximumDirective + " 100"},
+	{MinLengthDirective, MinLengthDirective + " n", "Minimum length of a string", []string{ContextField}, MinLengthDirective + " 3"},
+	{MaxLengthDirective, MaxLengthDirective + " n", "Maximum length of a string", []string{ContextField}, MaxLengthDirective + " 64"},
+	{PatternDirective, PatternDirective + " regexp", "Pattern a string matches", []string{ContextField}, PatternDirective + " ^[a-z]+$"},
+	{MinItemsDirective, MinItemsDirective + " n", "Minimum number of items of an array", []string{ContextField}, MinItemsDirective + " 1"},
+	{MaxItemsDirective, MaxItemsDirective + " n", "Maximum number of items of an array", []string{ContextField}, MaxItemsDirective + " 10"},
+	{UniqueItemsDirective, UniqueItemsDirective + " true", "Requires the items of an array to be unique",
+		[]string{ContextField}, UniqueItemsDirective + " true"},
+	{ReadOnlyDirective, ReadOnlyDirective + " true", "Marks the property as read-only", []string{ContextField}, ReadOnlyDirective + " true"},
+	{WriteOnlyDirective, WriteOnlyDirective + " true", "Marks the property as write-only", []string{ContextField}, WriteOnlyDirective + " true"},
+	{StyleDirective, StyleDirective + " style", "Serialization style of a parameter", []string{ContextField}, StyleDirective + " pipeDelimited"},
+	{ExplodeDirective, ExplodeDirective + " true|false", "Explodes an array or object parameter", []string{ContextField}, ExplodeDirective + " false"},
+
+	// Channels
+	{ChannelMessageDirective, ChannelMessageDirective + " Type[|Type...]", "Message of a channel operation; Type1|Type2 declares oneOf",
+		[]string{ContextChannel}, ChannelMessageDirective + " UserCreated"},
+
+	// Extensions
+	{ExtensionPrefix, ExtensionPrefix + "name: value", "Vendor extension of the API, operation, schema, or property",
+		[]string{ContextMeta, ContextRoute, ContextModel, ContextField}, ExtensionPrefix + "order: 1"},
+}
@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDirectives tests that every directive constant is documented with a syntax, contexts, and an example
+func TestDirectives(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, doc := range Directives() {
+		assert.False(t, seen[doc.Directive], "%s documented twice", doc.Directive)
+		seen[doc.Directive] = true
+
+		assert.True(t, strings.HasPrefix(doc.Syntax, doc.Directive), doc.Directive)
+		assert.NotEmpty(t, doc.Description, doc.Directive)
+		assert.NotEmpty(t, doc.Contexts, doc.Directive)
+		assert.True(t, strings.HasPrefix(doc.Example, doc.Directive), doc.Directive)
+	}
+
+	// Every *Directive constant is documented
+	file, err := parser.ParseFile(token.NewFileSet(), "directives.go", nil, 0)
+	require.NoError(t, err)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if !strings.HasSuffix(name.Name, "Directive") {
+					continue
+				}
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				require.True(t, ok, name.Name)
+				directive, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err, name.Name)
+				assert.True(t, seen[directive], "%s (%s) not documented", name.Name, directive)
+			}
+		}
+	}
+}
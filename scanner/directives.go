@@ -8,6 +8,13 @@ const (
 	ModelDirective = "swagger:model"
 	// ParameterDirective marks a struct as OpenAPI parameters
 	ParameterDirective = "swagger:parameters"
+	// HeadersDirective marks a struct as header parameters of one or more operations
+	HeadersDirective = "swagger:headers"
+	// PathDirective marks a struct as the shared parameters, summary, and description of a path
+	PathDirective = "swagger:path"
+	// GroupDirective declares the path prefix, tags, security, and responses shared by the
+	// routes of a file, or of a package when written in the package doc comment
+	GroupDirective = "swagger:group"
 	// RouteDirective marks a function as an API endpoint
 	RouteDirective = "swagger:route"
 	// EnumDirective marks a type as an enum
@@ -22,6 +29,12 @@ const (
 	OneOfOptionDirective = "swagger:oneOfOption"
 	// AnyOfOptionDirective marks an embedded field as an anyOf option
 	AnyOfOptionDirective = "swagger:anyOfOption"
+	// FragmentDirective merges a hand-written OpenAPI fragment file into the spec (meta and route comments)
+	FragmentDirective = "swagger:include"
+	// ChannelDirective marks a function as an event operation of the AsyncAPI document
+	ChannelDirective = "swagger:channel"
+	// MessageDirective marks a type as the payload of an event message
+	MessageDirective = "swagger:message"
 )
 
 // Meta section directives
@@ -32,6 +45,7 @@ const (
 	TermsOfServiceDirective  = "TermsOfService:"
 	ContactDirective         = "Contact:"
 	LicenseDirective         = "License:"
+	LogoDirective            = "Logo:"
 	HostDirective            = "Host:"
 	BasePathDirective        = "BasePath:"
 	ExternalDocsDirective    = "ExternalDocs:"
@@ -44,6 +58,12 @@ const (
 	ParametersDirective      = "Parameters:"
 	SummaryDirective         = "Summary:"
 	ServersDirective         = "Servers:"
+	StripPrefixDirective     = "StripPrefix:"
+	AddPrefixDirective       = "AddPrefix:"
+	PathPrefixDirective      = "PathPrefix:"
+
+	// SecurityNone is the Security: value that removes the global security requirement from a route
+	SecurityNone = "none"
 )
 
 // Schema composition directives (legacy inline style)
@@ -58,12 +78,36 @@ const (
 	// DiscriminatorDirective specifies the property name for discriminator
 	// Format: discriminator: propertyName
 	DiscriminatorDirective = "discriminator:"
+	// MappingDirective overrides discriminator mapping on route responses
+	// Format: mapping:value1=Type1,value2=Type2
+	MappingDirective = "mapping:"
+)
+
+// Model-level directives
+const (
+	// AdditionalPropertiesDirective closes (false) or opens (true) the object schema of a
+	// model, overriding the generator default
+	// Format: additionalProperties: false
+	AdditionalPropertiesDirective = "additionalProperties:"
+	// MinPropertiesDirective sets the minimum number of properties of an object
+	// (models and map fields)
+	// Format: minProperties: 1
+	MinPropertiesDirective = "minProperties:"
+	// MaxPropertiesDirective sets the maximum number of properties of an object
+	// (models and map fields)
+	// Format: maxProperties: 20
+	MaxPropertiesDirective = "maxProperties:"
+	// PropertyNamesDirective sets the pattern the property names of an object match
+	// (models and map fields)
+	// Format: propertyNames: ^[a-z][a-z0-9_]*$
+	PropertyNamesDirective = "propertyNames:"
 )
 
 // Field-level directives
 const (
 	ExampleDirective     = "example:"
 	DefaultDirective     = "default:"
+	ConstDirective       = "const:"
 	RequiredDirective    = "required:"
 	NullableDirective    = "nullable:"
 	FormatDirective      = "format:"
@@ -78,6 +122,8 @@ const (
 	UniqueItemsDirective = "uniqueItems:"
 	ReadOnlyDirective    = "readOnly:"
 	WriteOnlyDirective   = "writeOnly:"
+	StyleDirective       = "style:"
+	ExplodeDirective     = "explode:"
 )
 
 // Route-specific directives
@@ -86,6 +132,54 @@ const (
 	DescriptionFieldDirective  = "description:"
 	DeprecatedFieldDirective   = "deprecated"
 	IgnoredParametersDirective = "IgnoredParameters:"
+	// RequestBodyDirective declares the request body inline
+	// Format: RequestBody: [[]]Type [required] [description]
+	RequestBodyDirective = "RequestBody:"
+	// AllowBodyDirective opts GET, DELETE, HEAD, and OPTIONS routes into a request body
+	// Format: allowBody: true
+	AllowBodyDirective = "allowBody:"
+	// EnvelopeDirective wraps the payload of the success responses in an envelope object,
+	// under the named property, merged with the envelope model when one is given. On the meta
+	// it applies to every route of its specs; on a route, none opts out.
+	// Format: envelope: data [Envelope]
+	EnvelopeDirective = "envelope:"
+	// EnvelopeNone disables the envelope of the meta for a route
+	EnvelopeNone = "none"
+	// ProblemDirective extends the Problem schema of the untyped error responses of a route
+	// with the fields of a model, when problem details are enabled
+	// Format: problem: ValidationProblem
+	ProblemDirective = "problem:"
+	// RouteVersionDirective assigns a route to an API version in versioned mode, instead
+	// of the version segment opening its path (/v2/users)
+	// Format: version: v2
+	RouteVersionDirective = "version:"
+	// CodeSampleDirective adds a code sample to a route (x-codeSamples), inline or read
+	// from a file relative to the source file
+	// Format: codeSample: Go file:./samples/list_users.go
+	CodeSampleDirective = "codeSample:"
+	// RateLimitDirective documents the rate limit of a route (x-ratelimit) and adds a 429
+	// response with a Retry-After header. The window is second, minute, hour, or day.
+	// Format: ratelimit: 100/minute [scope]
+	RateLimitDirective = "ratelimit:"
+	// PaginationDirective documents the pagination of a list route (x-pagination): its style
+	// (cursor, offset, or page) and the query parameters it reads, when not the default ones.
+	// Format: pagination: cursor [cursor=after] [limit=first]
+	PaginationDirective = "pagination:"
+	// DescriptionFilePrefix reads a meta or route description from a markdown file,
+	// relative to the source file
+	// Format: description: file:./docs/users.md
+	DescriptionFilePrefix = "file:"
+)
+
+// Channel-specific directives
+const (
+	// ChannelMessageDirective names the message of a channel operation; Type1|Type2 declares oneOf
+	// Format: Message: UserCreated
+	ChannelMessageDirective = "Message:"
+	// ActionPublish is the channel action of events the application receives
+	ActionPublish = "publish"
+	// ActionSubscribe is the channel action of events the application sends
+	ActionSubscribe = "subscribe"
 )
 
 // Multi-spec directive
@@ -93,14 +187,37 @@ const (
 	// SpecDirective specifies which spec(s) an element belongs to
 	// Format: spec: name1 name2 name3
 	SpecDirective = "spec:"
+	// IncludesDirective makes a spec contain the routes of other specs (meta only)
+	// Format: includes: name1 name2
+	IncludesDirective = "includes:"
 	// DefaultSpec is the name of the default spec for elements without spec: directive
 	DefaultSpec = "default"
+	// SpecWildcard matches every generated spec (spec: *)
+	SpecWildcard = "*"
+	// SpecNegationPrefix excludes a spec (spec: !internal matches every spec except internal)
+	SpecNegationPrefix = "!"
+)
+
+// Tag filtering directive
+const (
+	// InternalDirective marks a route or model as internal-only
+	// Format: internal: true
+	InternalDirective = "internal:"
+	// InternalTag is the filter tag matched by internal routes and models
+	InternalTag = "internal"
 )
 
 // Common prefixes and patterns
 const (
 	SwaggerPrefix = "swagger:"
 	DashPrefix    = "-"
+	// FileResponseType marks a binary file download response
+	// Format: 200: file description:PDF export
+	//         200: file:application/pdf PDF export
+	FileResponseType = "file"
+	// ExtensionPrefix marks a vendor extension directive
+	// Format: x-name: value
+	ExtensionPrefix = "x-"
 )
 
 // HTTP methods
@@ -112,6 +229,8 @@ const (
 	MethodPatch   = "PATCH"
 	MethodHead    = "HEAD"
 	MethodOptions = "OPTIONS"
+	MethodQuery   = "QUERY"
+	MethodTrace   = "TRACE"
 )
 
 // Content types
@@ -120,6 +239,7 @@ const (
 	ContentTypeXML       = "application/xml"
 	ContentTypeForm      = "application/x-www-form-urlencoded"
 	ContentTypeMultipart = "multipart/form-data"
+	ContentTypeBinary    = "application/octet-stream"
 )
 
 // Security scheme types
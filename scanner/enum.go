@@ -2,7 +2,9 @@ package scanner
 
 import (
 	"go/ast"
+	"go/constant"
 	"go/token"
+	"go/types"
 	"strconv"
 
 	"golang.org/x/tools/go/packages"
@@ -36,6 +38,7 @@ func (s *Scanner) processEnums(filePath string, file *ast.File, pkg *packages.Pa
 
 			enumInfo := parseEnumTypeDeclaration(typeSpec, filePath, doc)
 			if enumInfo != nil {
+				enumInfo.Pos = s.directivePos(doc, EnumDirective)
 				s.Enums[enumInfo.TypeName] = enumInfo
 				s.TypeToEnum[typeSpec.Name.Name] = enumInfo.TypeName
 				if pkg != nil && pkg.Types != nil {
@@ -53,51 +56,70 @@ func (s *Scanner) processEnums(filePath string, file *ast.File, pkg *packages.Pa
 			continue
 		}
 
-		s.processEnumConstDeclaration(filePath, genDecl)
+		s.processEnumConstDeclaration(filePath, genDecl, pkg)
 	}
 
 	return nil
 }
 
 // processEnumConstDeclaration processes a const declaration block to extract enum values.
-func (s *Scanner) processEnumConstDeclaration(filePath string, genDecl *ast.GenDecl) {
+// Values are taken from the type checker when available so iota-based and
+// computed constants (e.g. StatusA Status = iota + 1) resolve to their actual values.
+func (s *Scanner) processEnumConstDeclaration(filePath string, genDecl *ast.GenDecl, pkg *packages.Package) {
+	// Track the last explicit type for implicit repetition inside const blocks:
+	//   const (
+	//       A Status = iota
+	//       B          // implicitly "Status = iota"
+	//   )
+	var lastType ast.Expr
+
 	for _, spec := range genDecl.Specs {
 		valueSpec, ok := spec.(*ast.ValueSpec)
-		if !ok || valueSpec.Type == nil || len(valueSpec.Values) == 0 {
-			continue
-		}
-
-		// Get the type name
-		typeIdent, ok := valueSpec.Type.(*ast.Ident)
-		if !ok {
-			continue
-		}
-
-		// Check if this type is a registered enum
-		enumName, ok := s.TypeToEnum[typeIdent.Name]
 		if !ok {
 			continue
 		}
 
-		// Check if this enum was defined in the same file
-		// This prevents mixing enum values from different packages with same enum name
-		if enumSource, exists := s.EnumSources[enumName]; exists && enumSource != filePath {
-			continue
-		}
-
-		enumInfo := s.Enums[enumName]
-		if enumInfo == nil {
-			continue
+		specType := valueSpec.Type
+		if len(valueSpec.Values) > 0 {
+			lastType = valueSpec.Type
+		} else if specType == nil {
+			specType = lastType
 		}
 
 		// Process each constant in this spec
 		for i, name := range valueSpec.Names {
-			if i >= len(valueSpec.Values) {
-				break
+			// Skipped values (_ = iota) still advance iota but are not enum members
+			if name.Name == "_" {
+				continue
+			}
+
+			typeName := constTypeName(pkg, name, specType)
+			if typeName == "" {
+				continue
+			}
+
+			// Check if this type is a registered enum
+			enumName, ok := s.TypeToEnum[typeName]
+			if !ok {
+				continue
+			}
+
+			// Check if this enum was defined in the same file
+			// This prevents mixing enum values from different packages with same enum name
+			if enumSource, exists := s.EnumSources[enumName]; exists && enumSource != filePath {
+				continue
+			}
+
+			enumInfo := s.Enums[enumName]
+			if enumInfo == nil {
+				continue
 			}
 
 			// Extract the value
-			value := extractConstValue(valueSpec.Values[i])
+			value := typedConstValue(pkg, name)
+			if value == nil && i < len(valueSpec.Values) {
+				value = extractConstValue(valueSpec.Values[i])
+			}
 			if value == nil {
 				continue
 			}
@@ -113,6 +135,55 @@ func (s *Scanner) processEnumConstDeclaration(filePath string, genDecl *ast.GenD
 	}
 }
 
+// constTypeName returns the declared type name of a constant.
+// Type aliases are resolved to the named type they refer to.
+func constTypeName(pkg *packages.Package, name *ast.Ident, typeExpr ast.Expr) string {
+	if pkg != nil && pkg.TypesInfo != nil {
+		if obj, ok := pkg.TypesInfo.Defs[name].(*types.Const); ok {
+			if named, ok := types.Unalias(obj.Type()).(*types.Named); ok {
+				return named.Obj().Name()
+			}
+		}
+	}
+
+	if ident, ok := typeExpr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// typedConstValue returns the constant value computed by the type checker.
+func typedConstValue(pkg *packages.Package, name *ast.Ident) any {
+	if pkg == nil || pkg.TypesInfo == nil {
+		return nil
+	}
+
+	obj, ok := pkg.TypesInfo.Defs[name].(*types.Const)
+	if !ok {
+		return nil
+	}
+
+	return constantValue(obj.Val())
+}
+
+// constantValue converts a go/constant value to its Go equivalent.
+func constantValue(val constant.Value) any {
+	switch val.Kind() {
+	case constant.String:
+		return constant.StringVal(val)
+	case constant.Int:
+		if i, exact := constant.Int64Val(val); exact {
+			return i
+		}
+	case constant.Float:
+		f, _ := constant.Float64Val(val)
+		return f
+	case constant.Bool:
+		return constant.BoolVal(val)
+	}
+	return nil
+}
+
 // parseEnumTypeDeclaration parses an enum type declaration (without const values).
 func parseEnumTypeDeclaration(typeSpec *ast.TypeSpec, filePath string, doc *ast.CommentGroup) *EnumInfo {
 	// Get the base type (e.g., string, int)
@@ -0,0 +1,453 @@
+package scanner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
+)
+
+// errExampleCall reports an example variable calling a function, whose result can't be
+// known without running it.
+var errExampleCall = errors.New("function calls are not supported")
+
+// resolveResponseExamples resolves example references of route responses.
+// References are either a JSON/YAML file path (relative to the source file)
+// or the name of a package-level variable initialized with a Go literal.
+// Examples calling functions are dropped with a warning.
+func (s *Scanner) resolveResponseExamples(route *RouteInfo, filePath string, pkg *packages.Package) error {
+	for _, resp := range route.Responses {
+		if resp.ExampleRef == "" {
+			continue
+		}
+
+		var (
+			value any
+			err   error
+		)
+		if isExampleFile(resp.ExampleRef) {
+			value, err = loadExampleFile(resp.ExampleRef, filePath)
+		} else {
+			value, err = loadExampleVar(resp.ExampleRef, pkg)
+		}
+		if errors.Is(err, errExampleCall) {
+			s.warn(WarnInvalidExample, route.Pos, "operation %s, response %s: example %s: %v; example ignored",
+				route.OperationID, resp.StatusCode, resp.ExampleRef, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: operation %s, response %s: %w", filePath, route.OperationID, resp.StatusCode, err)
+		}
+		resp.Example = value
+	}
+	return nil
+}
+
+// isExampleFile reports whether an example reference points to a file.
+func isExampleFile(ref string) bool {
+	switch strings.ToLower(filepath.Ext(ref)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return strings.ContainsAny(ref, `/\`)
+}
+
+// loadExampleFile reads a JSON or YAML example file relative to the source file.
+func loadExampleFile(ref, sourceFile string) (any, error) {
+	path := ref
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(sourceFile), path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read example file: %w", err)
+	}
+
+	var value any
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &value)
+	} else {
+		err = yaml.Unmarshal(data, &value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse example file %s: %w", ref, err)
+	}
+	return value, nil
+}
+
+// loadExampleVar converts the initializer of a package-level variable to a JSON-like value.
+func loadExampleVar(name string, pkg *packages.Package) (any, error) {
+	if pkg == nil || pkg.TypesInfo == nil {
+		return nil, fmt.Errorf("example variable %s: type information not available", name)
+	}
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, ident := range valueSpec.Names {
+					if ident.Name != name || i >= len(valueSpec.Values) {
+						continue
+					}
+					return literalValue(valueSpec.Values[i], pkg.TypesInfo)
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("example variable %s not found in package %s", name, pkg.PkgPath)
+}
+
+// literalValue converts a Go literal expression to the value it encodes as JSON.
+// Struct fields are named after their json tags.
+func literalValue(expr ast.Expr, info *types.Info) (any, error) {
+	// Constant expressions (literals, named constants, conversions)
+	if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+		return constantValue(tv.Value), nil
+	}
+
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return literalValue(e.X, info)
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return literalValue(e.X, info)
+		}
+	case *ast.Ident:
+		if e.Name == "nil" {
+			return nil, nil
+		}
+	case *ast.CallExpr:
+		// Conversions to basic types keep the value, e.g. string(name); other calls
+		// return values only known at run time
+		if tv, ok := info.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			if _, basic := tv.Type.Underlying().(*types.Basic); basic {
+				return literalValue(e.Args[0], info)
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", errExampleCall, types.ExprString(e.Fun))
+	case *ast.CompositeLit:
+		return compositeLiteralValue(e, info)
+	}
+
+	return nil, fmt.Errorf("unsupported example expression %T", expr)
+}
+
+// compositeLiteralValue converts struct, slice, array, and map literals.
+func compositeLiteralValue(lit *ast.CompositeLit, info *types.Info) (any, error) {
+	typ := info.TypeOf(lit)
+	if typ == nil {
+		return nil, fmt.Errorf("unknown type for composite literal")
+	}
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Struct:
+		return structLiteralValue(lit, t, info)
+
+	case *types.Slice, *types.Array:
+		items := make([]any, 0, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			value, err := literalValue(elt, info)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+
+	case *types.Map:
+		result := make(map[string]any, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, err := literalValue(kv.Key, info)
+			if err != nil {
+				return nil, err
+			}
+			value, err := literalValue(kv.Value, info)
+			if err != nil {
+				return nil, err
+			}
+			result[fmt.Sprint(key)] = value
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unsupported composite literal type %s", typ)
+}
+
+// structLiteralValue converts a struct literal to a map keyed by JSON property names.
+// Embedded structs without a json tag are flattened into the parent object.
+func structLiteralValue(lit *ast.CompositeLit, st *types.Struct, info *types.Info) (any, error) {
+	result := make(map[string]any)
+
+	for i, elt := range lit.Elts {
+		fieldIndex := i
+		valueExpr := elt
+
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			fieldIndex = -1
+			for j := range st.NumFields() {
+				if st.Field(j).Name() == key.Name {
+					fieldIndex = j
+					break
+				}
+			}
+			valueExpr = kv.Value
+		}
+		if fieldIndex < 0 || fieldIndex >= st.NumFields() {
+			continue
+		}
+
+		field := st.Field(fieldIndex)
+		name, skip := jsonPropertyName(field.Name(), st.Tag(fieldIndex))
+		if skip {
+			continue
+		}
+
+		value, err := literalValue(valueExpr, info)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name(), err)
+		}
+
+		if nested, ok := value.(map[string]any); ok && field.Embedded() && !hasJSONName(st.Tag(fieldIndex)) {
+			maps.Copy(result, nested)
+			continue
+		}
+
+		result[name] = value
+	}
+
+	return result, nil
+}
+
+// jsonPropertyName returns the JSON name of a struct field and whether it is skipped.
+func jsonPropertyName(fieldName, tag string) (string, bool) {
+	jsonTag := reflect.StructTag(tag).Get("json")
+	if jsonTag == "-" {
+		return "", true
+	}
+	if name, _, _ := strings.Cut(jsonTag, ","); name != "" {
+		return name, false
+	}
+	return fieldName, false
+}
+
+// hasJSONName reports whether a struct tag sets an explicit JSON name.
+func hasJSONName(tag string) bool {
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	return name != ""
+}
+
+// foldExampleBlocks rewrites the multi-line examples of the comments of a file,
+//
+//	// example: |
+//	//   {"id": 1,
+//	//    "tags": ["admin"]}
+//
+// into single-line JSON examples (example: {"id":1,"tags":["admin"]}) read by the
+// field, model, and response parsers like inline examples. A block holds the lines
+// indented deeper than its directive line and is parsed as YAML, a superset of JSON.
+// The blocks of string fields are text, kept as is (see exampleText).
+// Folding is idempotent, files scanned twice are left unchanged.
+func (s *Scanner) foldExampleBlocks(file *ast.File) {
+	textGroups := stringFieldComments(file, s.pkgInfo[file])
+	for _, group := range file.Comments {
+		group.List = s.foldCommentExamples(group.List, textGroups[group])
+	}
+}
+
+// stringFieldComments returns the doc and line comments of the struct fields of a file
+// whose type is a string or a pointer to one, such as type Email string.
+func stringFieldComments(file *ast.File, pkg *packages.Package) map[*ast.CommentGroup]bool {
+	groups := make(map[*ast.CommentGroup]bool)
+	if pkg == nil || pkg.TypesInfo == nil {
+		return groups
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		field, ok := n.(*ast.Field)
+		if !ok {
+			return true
+		}
+		t := pkg.TypesInfo.TypeOf(field.Type)
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if t == nil {
+			return true
+		}
+		if basic, ok := t.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+			groups[field.Doc] = true
+			groups[field.Comment] = true
+		}
+		return true
+	})
+	delete(groups, nil)
+	return groups
+}
+
+// foldCommentExamples folds the example blocks of the lines of a comment group, as
+// text for the comments of string fields.
+func (s *Scanner) foldCommentExamples(comments []*ast.Comment, asText bool) []*ast.Comment {
+	var folded []*ast.Comment
+	for i := 0; i < len(comments); i++ {
+		comment := comments[i]
+		text, ok := strings.CutPrefix(comment.Text, "//")
+		head, isBlock := exampleBlockHead(text)
+		if !ok || !isBlock {
+			folded = append(folded, comment)
+			continue
+		}
+
+		// The block ends before the first line indented like the directive line
+		indent := commentIndent(text)
+		var lines []string
+		end := i + 1
+		for j := i + 1; j < len(comments); j++ {
+			line, ok := strings.CutPrefix(comments[j].Text, "//")
+			if !ok || (strings.TrimSpace(line) != "" && commentIndent(line) <= indent) {
+				break
+			}
+			lines = append(lines, line)
+			if strings.TrimSpace(line) != "" {
+				end = j + 1
+			}
+		}
+		lines = lines[:end-i-1]
+		i = end - 1
+
+		parse := parseExampleBlock
+		if asText {
+			parse = exampleBlockText
+		}
+		value, err := parse(lines)
+		if err != nil {
+			s.warn(WarnInvalidExample, s.fset.Position(comment.Pos()), "invalid example block: %v; example ignored", err)
+			continue
+		}
+		folded = append(folded, &ast.Comment{Slash: comment.Slash, Text: "//" + head + " " + value})
+	}
+	return folded
+}
+
+// exampleBlockHead returns the text of a comment line opening an example block
+// (example: | or - 200: User example: |) without the trailing |.
+func exampleBlockHead(text string) (string, bool) {
+	head, ok := strings.CutSuffix(strings.TrimRight(text, " \t"), "|")
+	if !ok {
+		return "", false
+	}
+	head = strings.TrimRight(head, " \t")
+	before, ok := strings.CutSuffix(head, ExampleDirective)
+	if !ok || (strings.TrimSpace(before) != "" && !strings.HasSuffix(before, " ")) {
+		return "", false
+	}
+	return head, true
+}
+
+// commentIndent returns the indentation of the text of a comment line.
+func commentIndent(text string) int {
+	return len(text) - len(strings.TrimLeft(text, " \t"))
+}
+
+// dedentBlock removes the common indentation of the lines of an example block.
+func dedentBlock(lines []string) ([]string, error) {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" && (indent < 0 || commentIndent(line) < indent) {
+			indent = commentIndent(line)
+		}
+	}
+	if indent < 0 {
+		return nil, fmt.Errorf("the block is empty")
+	}
+
+	dedented := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= indent {
+			dedented[i] = line[indent:]
+		}
+	}
+	return dedented, nil
+}
+
+// parseExampleBlock parses the lines of an example block as YAML and returns the value
+// as single-line JSON.
+func parseExampleBlock(lines []string) (string, error) {
+	dedented, err := dedentBlock(lines)
+	if err != nil {
+		return "", err
+	}
+
+	var value any
+	if err := yaml.Unmarshal([]byte(strings.Join(dedented, "\n")), &value); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// exampleBlockText returns the lines of an example block as a single-line text example:
+// | followed by the quoted text, lines joined with newlines (see exampleText).
+func exampleBlockText(lines []string) (string, error) {
+	dedented, err := dedentBlock(lines)
+	if err != nil {
+		return "", err
+	}
+	return "|" + strconv.Quote(strings.Join(dedented, "\n")), nil
+}
+
+// exampleText returns the text of an example folded from the block of a string field,
+// or the example as is.
+func exampleText(example string) string {
+	if quoted, ok := strings.CutPrefix(example, "|"); ok {
+		if text, err := strconv.Unquote(quoted); err == nil {
+			return text
+		}
+	}
+	return example
+}
+
+// parseExampleValue decodes an inline example: JSON values (objects, arrays, numbers,
+// quoted strings) are decoded, other values are kept as strings.
+func parseExampleValue(example string) any {
+	var value any
+	if err := json.Unmarshal([]byte(example), &value); err == nil {
+		return value
+	}
+	return example
+}
@@ -0,0 +1,262 @@
+package scanner
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// WarnExternalPackage is the code of warnings about dependency packages that could not be loaded.
+const WarnExternalPackage = "external-package"
+
+// recordImports remembers the import paths a file refers to by package name or import alias,
+// so that qualified type names (dto.User) can be traced back to their package.
+func (s *Scanner) recordImports(filePath string, file *ast.File, pkg *packages.Package) {
+	imports := make(map[string]string, len(file.Imports))
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		for _, imported := range pkg.Types.Imports() {
+			if imported.Path() == importPath {
+				name = imported.Name()
+				break
+			}
+		}
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	s.fileImports[filePath] = imports
+}
+
+// externalType is a type referenced from the scanned code and declared in another package.
+type externalType struct {
+	pkgPath string
+	name    string
+}
+
+// resolveExternalTypes loads the dependency packages declaring the types referenced by
+// models and routes that aren't found in the scanned packages. Their swagger directives
+// are processed, and referenced struct types without a swagger:model directive become
+// models. Models found this way may reference further packages, which are loaded in turn.
+func (s *Scanner) resolveExternalTypes(ctx context.Context, scanned map[string]bool) error {
+	loaded := make(map[string]*packages.Package)
+	visited := make(map[externalType]bool)
+
+	for {
+		var wanted []externalType
+		for _, ref := range s.externalReferences(scanned) {
+			if visited[ref] {
+				continue
+			}
+			if _, ok := s.TypeToStruct[ref.pkgPath+"."+ref.name]; ok {
+				continue
+			}
+			wanted = append(wanted, ref)
+		}
+		if len(wanted) == 0 {
+			return nil
+		}
+
+		var paths []string
+		for _, ref := range wanted {
+			if _, ok := loaded[ref.pkgPath]; !ok && !slices.Contains(paths, ref.pkgPath) {
+				paths = append(paths, ref.pkgPath)
+			}
+		}
+		if len(paths) > 0 {
+			slices.Sort(paths)
+			if err := s.loadExternalPackages(ctx, paths, loaded); err != nil {
+				return err
+			}
+		}
+
+		for _, ref := range wanted {
+			visited[ref] = true
+			if _, ok := s.TypeToStruct[ref.pkgPath+"."+ref.name]; ok {
+				continue // Declared with a directive
+			}
+			if pkg := loaded[ref.pkgPath]; pkg != nil {
+				if err := s.addExternalModel(pkg, ref.name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// loadExternalPackages loads dependency packages and processes their enums, aliases,
+// and models. Packages that fail to load are reported as warnings and skipped.
+func (s *Scanner) loadExternalPackages(ctx context.Context, paths []string, loaded map[string]*packages.Package) error {
+	pkgs, err := packages.Load(s.packagesConfig(ctx), paths...)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+
+	for _, p := range paths {
+		loaded[p] = nil
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			s.Warnings = append(s.Warnings, Warning{
+				Code:    WarnExternalPackage,
+				Message: "package " + pkg.PkgPath + ": " + pkg.Errors[0].Msg + ", its types are not resolved",
+			})
+			continue
+		}
+		loaded[pkg.PkgPath] = pkg
+		s.collectTypeInfo(pkg)
+
+		for i, file := range pkg.Syntax {
+			if i >= len(pkg.GoFiles) {
+				continue
+			}
+			filePath := pkg.GoFiles[i]
+			s.pkgInfo[file] = pkg
+			s.recordImports(filePath, file, pkg)
+
+			// Routes and meta of dependencies don't belong to the scanned API
+			s.foldExampleBlocks(file)
+			if err := s.processEnums(filePath, file, pkg); err != nil {
+				return err
+			}
+			s.processTypeAliases(filePath, file, pkg)
+			if err := s.processSchemas(filePath, file, pkg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addExternalModel registers a struct type of a dependency package as a model, as if it
+// had a swagger:model directive. Other types are left to the type resolution of the generator.
+func (s *Scanner) addExternalModel(pkg *packages.Package, name string) error {
+	typeObj, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	if _, ok := typeObj.Type().Underlying().(*types.Struct); !ok {
+		return nil
+	}
+
+	for i, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name.Name != name {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					return nil
+				}
+
+				doc := typeSpec.Doc
+				if doc == nil {
+					doc = genDecl.Doc
+				}
+				structInfo := &StructInfo{
+					Name:           name,
+					GoType:         name,
+					PkgPath:        pkg.PkgPath,
+					PkgName:        pkg.Name,
+					Fields:         []*FieldInfo{},
+					Description:    extractDescription(doc, []string{SwaggerPrefix}),
+					IsModel:        true,
+					SourceFile:     pkg.GoFiles[i],
+					Pos:            s.fset.Position(typeSpec.Pos()),
+					UnderlyingKind: KindStruct,
+				}
+				processStructFields(structInfo, structType)
+				return s.registerStruct(structInfo, false)
+			}
+		}
+	}
+	return nil
+}
+
+// externalReferences returns the types declared outside of the scanned packages that
+// models and routes refer to. Unqualified names used by models of a dependency refer to
+// its own package.
+func (s *Scanner) externalReferences(scanned map[string]bool) []externalType {
+	var refs []externalType
+	add := func(sourceFile, pkgPath, typeName string) {
+		if typeName == "" {
+			return
+		}
+		qualifier, name, qualified := strings.Cut(typeName, ".")
+		if !qualified {
+			if pkgPath == "" || scanned[pkgPath] {
+				return
+			}
+			refs = append(refs, externalType{pkgPath: pkgPath, name: typeName})
+			return
+		}
+
+		importPath, ok := s.fileImports[sourceFile][qualifier]
+		if !ok || scanned[importPath] || isStandardLibrary(importPath) || slices.Contains(s.config.KnownTypes, typeName) {
+			return
+		}
+		refs = append(refs, externalType{pkgPath: importPath, name: name})
+	}
+
+	var addStruct func(structInfo *StructInfo, sourceFile, pkgPath string)
+	addStruct = func(structInfo *StructInfo, sourceFile, pkgPath string) {
+		add(sourceFile, pkgPath, structInfo.ElementType)
+		for _, field := range structInfo.Fields {
+			add(sourceFile, pkgPath, field.Type)
+			if field.InlineStruct != nil {
+				addStruct(field.InlineStruct, sourceFile, pkgPath)
+			}
+		}
+	}
+
+	for _, structInfo := range s.Structs {
+		addStruct(structInfo, structInfo.SourceFile, structInfo.PkgPath)
+	}
+	for _, route := range s.Routes {
+		if route.RequestBody != nil {
+			add(route.SourceFile, "", route.RequestBody.Type)
+		}
+		for _, resp := range route.Responses {
+			add(route.SourceFile, "", resp.Type)
+			for _, option := range resp.OneOf {
+				add(route.SourceFile, "", option)
+			}
+			for _, content := range resp.Contents {
+				add(route.SourceFile, "", content.Type)
+			}
+		}
+	}
+
+	slices.SortFunc(refs, func(a, b externalType) int {
+		return strings.Compare(a.pkgPath+"."+a.name, b.pkgPath+"."+b.name)
+	})
+	return slices.Compact(refs)
+}
+
+// isStandardLibrary reports whether an import path belongs to the standard library,
+// whose first path element has no dot.
+func isStandardLibrary(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,108 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createExternalProject creates a project requiring a local dto module through a replace directive.
+func createExternalProject(t *testing.T) string {
+	tmpDir := createTestProject(t, map[string]string{
+		"dto/go.mod": "module example.com/dto\n\ngo 1.21\n",
+		"dto/user.go": `package dto
+
+// User is a user shared between services.
+type User struct {
+	Name    string  ` + "`json:\"name\"`" + `
+	Address Address ` + "`json:\"address\"`" + `
+	Role    Role    ` + "`json:\"role\"`" + `
+}
+
+// Address is a postal address.
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// swagger:model SharedError
+type Error struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// swagger:enum Role
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleUser  Role = "user"
+)
+`,
+	})
+	goMod := "module testproject\n\ngo 1.21\n\nrequire example.com/dto v0.0.0\n\nreplace example.com/dto => ./dto\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644))
+	return tmpDir
+}
+
+func TestResolveExternalTypes(t *testing.T) {
+	tmpDir := createExternalProject(t)
+	sourceFile := filepath.Join(tmpDir, "api", "users.go")
+
+	s := New(WithDir(tmpDir), WithResolveExternal(true))
+	s.fileImports[sourceFile] = map[string]string{"dto": "example.com/dto", "uuid": "github.com/google/uuid"}
+	s.Structs["Page"] = &StructInfo{
+		Name:       "Page",
+		GoType:     "Page",
+		PkgPath:    "testproject/api",
+		IsModel:    true,
+		SourceFile: sourceFile,
+		Fields: []*FieldInfo{
+			{Name: "Users", Type: "dto.User", IsArray: true},
+			{Name: "ID", Type: "uuid.UUID"},
+		},
+	}
+	s.Routes["getUser"] = &RouteInfo{
+		OperationID: "getUser",
+		SourceFile:  sourceFile,
+		Responses:   []*ResponseInfo{{StatusCode: "404", Type: "dto.Error"}},
+	}
+	s.config.KnownTypes = []string{"uuid.UUID"}
+
+	require.NoError(t, s.resolveExternalTypes(context.Background(), map[string]bool{"testproject/api": true}))
+	assert.Empty(t, s.Warnings)
+
+	// Referenced structs become models, as do the structs they reference
+	require.Contains(t, s.Structs, "User")
+	assert.Equal(t, "User is a user shared between services.", s.Structs["User"].Description)
+	assert.Equal(t, "example.com/dto", s.Structs["User"].PkgPath)
+	assert.Equal(t, "User", s.TypeToStruct["dto.User"])
+	assert.Contains(t, s.Structs, "Address")
+
+	// Directives of dependency packages are honored
+	assert.Contains(t, s.Structs, "SharedError")
+	assert.Equal(t, "SharedError", s.TypeToStruct["dto.Error"])
+	assert.NotNil(t, s.GetEnumForType("dto.Role"))
+}
+
+func TestResolveExternalTypesUnknownPackage(t *testing.T) {
+	tmpDir := createExternalProject(t)
+	sourceFile := filepath.Join(tmpDir, "api", "users.go")
+
+	s := New(WithDir(tmpDir), WithResolveExternal(true))
+	s.fileImports[sourceFile] = map[string]string{"billing": "example.com/billing"}
+	s.Structs["Page"] = &StructInfo{
+		Name:       "Page",
+		PkgPath:    "testproject/api",
+		IsModel:    true,
+		SourceFile: sourceFile,
+		Fields:     []*FieldInfo{{Name: "Invoice", Type: "billing.Invoice"}},
+	}
+
+	require.NoError(t, s.resolveExternalTypes(context.Background(), map[string]bool{"testproject/api": true}))
+	assert.NotContains(t, s.Structs, "Invoice")
+	require.Len(t, s.Warnings, 1)
+	assert.Equal(t, WarnExternalPackage, s.Warnings[0].Code)
+}
@@ -0,0 +1,126 @@
+package scanner
+
+import (
+	"go/ast"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// processGroups processes swagger:group blocks. A block in the package doc comment applies
+// to the routes of the package; anywhere else, such as on a router constructor, to the
+// routes of the file.
+//
+//	// swagger:group
+//	// PathPrefix: /admin
+//	// Tags: admin
+//	// Security:
+//	// - bearer
+//	// Responses:
+//	// - 401: Error description:Unauthorized
+func (s *Scanner) processGroups(filePath string, file *ast.File, pkg *packages.Package) error {
+	for _, cg := range file.Comments {
+		if !hasDirective(cg, GroupDirective) {
+			continue
+		}
+
+		pos := s.directivePos(cg, GroupDirective)
+		group := &RouteGroupInfo{
+			Tags:       strings.Fields(extractDirectiveValue(cg, TagsDirective)),
+			Package:    cg == file.Doc,
+			SourceFile: filePath,
+			Pos:        pos,
+		}
+
+		if prefix := extractDirectiveValue(cg, PathPrefixDirective); prefix != "" {
+			if !strings.HasPrefix(prefix, "/") {
+				s.warn(WarnInvalidPath, s.directivePos(cg, PathPrefixDirective),
+					"%s: path prefix %q does not start with /; prefix ignored", GroupDirective, prefix)
+			} else {
+				group.PathPrefix = strings.TrimSuffix(prefix, "/")
+			}
+		}
+
+		// The group attributes are parsed like those of a route
+		route := &RouteInfo{OperationID: GroupDirective, SourceFile: filePath, Pos: pos}
+		extractResponses(route, cg)
+		s.checkResponseItems(cg)
+		if err := s.checkStatusCodes(route, cg, filePath); err != nil {
+			return err
+		}
+		if err := s.resolveResponseExamples(route, filePath, pkg); err != nil {
+			return err
+		}
+		extractSecurity(route, cg)
+		group.Responses = route.Responses
+		group.Security = route.Security
+		group.NoSecurity = route.NoSecurity
+
+		s.Groups = append(s.Groups, group)
+	}
+	return nil
+}
+
+// applyRouteGroups applies the swagger:group blocks to the routes of their file or package.
+// File groups apply before package groups, so that package prefixes and tags come first
+// and the responses and security of file groups win.
+func (s *Scanner) applyRouteGroups() {
+	groups := slices.Clone(s.Groups)
+	slices.SortStableFunc(groups, func(a, b *RouteGroupInfo) int {
+		switch {
+		case a.Package == b.Package:
+			return 0
+		case b.Package:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	for _, route := range s.Routes {
+		for _, group := range groups {
+			if group.Package && filepath.Dir(group.SourceFile) != filepath.Dir(route.SourceFile) ||
+				!group.Package && group.SourceFile != route.SourceFile {
+				continue
+			}
+			applyRouteGroup(route, group)
+		}
+	}
+}
+
+// applyRouteGroup applies the attributes of a group to a route. The route keeps its own
+// security and responses.
+func applyRouteGroup(route *RouteInfo, group *RouteGroupInfo) {
+	if group.PathPrefix != "" {
+		if route.Path == "/" {
+			route.Path = group.PathPrefix
+		} else {
+			route.Path = group.PathPrefix + route.Path
+		}
+	}
+
+	var tags []string
+	for _, tag := range group.Tags {
+		if !slices.Contains(route.Tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	route.Tags = append(tags, route.Tags...)
+
+	if len(route.Security) == 0 && !route.NoSecurity && (len(group.Security) > 0 || group.NoSecurity) {
+		route.Security = slices.Clone(group.Security)
+		route.NoSecurity = group.NoSecurity
+	}
+
+	for _, resp := range group.Responses {
+		declared := slices.ContainsFunc(route.Responses, func(r *ResponseInfo) bool {
+			return r.StatusCode == resp.StatusCode
+		})
+		if !declared {
+			copied := *resp
+			route.Responses = append(route.Responses, &copied)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package scanner
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Frameworks of the handler signatures recognized by the scanner.
+const (
+	FrameworkNetHTTP = "net/http"
+	FrameworkGin     = "gin"
+	FrameworkEcho    = "echo"
+	FrameworkFiber   = "fiber"
+)
+
+// HandlerInfo is an exported function or method of the scanned code with the signature of
+// an HTTP handler.
+type HandlerInfo struct {
+	Name        string // Function name, or Type.Method for methods
+	Package     string // Import path of the package declaring the handler
+	Framework   string // Framework of the signature (net/http, gin, echo, or fiber)
+	Documented  bool   // The handler has a swagger:route directive
+	OperationID string // Operation ID of the swagger:route, if documented
+	SourceFile  string
+	Pos         token.Position // Position of the function declaration
+}
+
+// processHandlers records the exported handlers declared in a file, documented or not.
+// Handlers are recognized by their signature:
+//
+//	func(w http.ResponseWriter, r *http.Request)
+//	func(c *gin.Context)
+//	func(c echo.Context) error
+//	func(c *fiber.Ctx) error
+//
+// Functions with a swagger:ignore directive are left out.
+func (s *Scanner) processHandlers(filePath string, file *ast.File, pkg *packages.Package) {
+	if pkg.TypesInfo == nil {
+		return
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || !funcDecl.Name.IsExported() {
+			continue
+		}
+		if funcDecl.Doc != nil && hasDirective(funcDecl.Doc, IgnoreDirective) {
+			continue
+		}
+
+		fn, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+		if !ok {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+		framework := handlerFramework(sig)
+		if framework == "" {
+			continue
+		}
+
+		name := funcDecl.Name.Name
+		if recv := sig.Recv(); recv != nil {
+			named, ok := derefType(recv.Type()).(*types.Named)
+			if !ok || !named.Obj().Exported() {
+				continue
+			}
+			name = named.Obj().Name() + "." + name
+		}
+
+		handler := &HandlerInfo{
+			Name:       name,
+			Package:    pkg.PkgPath,
+			Framework:  framework,
+			SourceFile: filePath,
+			Pos:        s.fset.Position(funcDecl.Pos()),
+		}
+		if funcDecl.Doc != nil && hasDirective(funcDecl.Doc, RouteDirective) {
+			handler.Documented = true
+			if _, _, _, operationID, err := parseRouteDirective(extractDirectiveValue(funcDecl.Doc, RouteDirective)); err == nil {
+				handler.OperationID = operationID
+			}
+		}
+		s.Handlers = append(s.Handlers, handler)
+	}
+}
+
+// handlerFramework returns the framework of a handler signature, or "" for other
+// signatures. Types are matched by package and type name, so that the versioned imports
+// (echo/v4, fiber/v2) and the local wrappers of the frameworks are recognized.
+func handlerFramework(sig *types.Signature) string {
+	params, results := sig.Params(), sig.Results()
+	switch {
+	case params.Len() == 2 && results.Len() == 0 &&
+		isNamedType(params.At(0).Type(), "http", "ResponseWriter", false) &&
+		isNamedType(params.At(1).Type(), "http", "Request", true):
+		return FrameworkNetHTTP
+	case params.Len() != 1:
+		return ""
+	case results.Len() == 0 && isNamedType(params.At(0).Type(), "gin", "Context", true):
+		return FrameworkGin
+	case results.Len() != 1 || !isErrorType(results.At(0).Type()):
+		return ""
+	case isNamedType(params.At(0).Type(), "echo", "Context", false):
+		return FrameworkEcho
+	case isNamedType(params.At(0).Type(), "fiber", "Ctx", true), isNamedType(params.At(0).Type(), "fiber", "Ctx", false):
+		return FrameworkFiber
+	}
+	return ""
+}
+
+// isNamedType reports whether t is the named type pkg.name, or a pointer to it.
+func isNamedType(t types.Type, pkg, name string, pointer bool) bool {
+	if pointer {
+		ptr, ok := t.(*types.Pointer)
+		if !ok {
+			return false
+		}
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Name() == pkg && named.Obj().Name() == name
+}
+
+// isErrorType reports whether t is the error interface.
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// derefType returns the element type of a pointer type, or t itself.
+func derefType(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
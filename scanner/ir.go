@@ -0,0 +1,194 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+	"maps"
+)
+
+// IRVersion is the version of the IR written by ExportIR. It changes when a field is removed
+// or changes meaning; new fields are added without a new version.
+const IRVersion = 1
+
+// IR is the language-agnostic representation of the scan results written by ExportIR, for
+// tools that consume them without linking the Go module, such as generators written in
+// other languages. Its objects are the scanner models, keyed by their Go field names.
+// ImportIR loads it back, so a generator can run without scanning again (see
+// generator.NewFromIR).
+type IR struct {
+	Version int                    `json:"version"`
+	Metas   []*MetaInfo            `json:"metas"`   // Metas in scan order
+	Routes  map[string]*RouteInfo  `json:"routes"`  // Routes by operation ID
+	Structs map[string]*StructInfo `json:"structs"` // Models and parameters by schema name
+	Enums   map[string]*EnumInfo   `json:"enums"`   // Enums by name
+
+	Headers  map[string][]*StructInfo `json:"headers,omitempty"`  // swagger:headers structs by operation ID
+	Paths    map[string]*PathInfo     `json:"paths,omitempty"`    // swagger:path info by path
+	Channels map[string]*ChannelInfo  `json:"channels,omitempty"` // Event channels by operation ID
+	Messages map[string]*MessageInfo  `json:"messages,omitempty"` // Event messages by name
+
+	TypeToEnum      map[string]string `json:"typeToEnum,omitempty"`      // Go type name -> enum name
+	TypeToStruct    map[string]string `json:"typeToStruct,omitempty"`    // Go type name -> struct name
+	TypeAliases     map[string]string `json:"typeAliases,omitempty"`     // Alias type name -> original type name
+	TypeDocs        map[string]string `json:"typeDocs,omitempty"`        // Doc comments of the types without directives
+	UnderlyingTypes map[string]string `json:"underlyingTypes,omitempty"` // Named primitives and collections -> Go type (see ResolveUnderlyingType)
+
+	Warnings      []Warning           `json:"warnings,omitempty"`
+	Registrations []*RegistrationInfo `json:"registrations,omitempty"`
+	Handlers      []*HandlerInfo      `json:"handlers,omitempty"`
+}
+
+// ExportIR writes the scan results to w as indented JSON: the metas, routes, structs, and
+// enums, and the type mappings the generator resolves field types with. Map keys are
+// sorted, so scanning the same sources writes the same document. Call it after Scan.
+func (s *Scanner) ExportIR(w io.Writer) error {
+	ir := IR{
+		Version:         IRVersion,
+		Metas:           s.Metas,
+		Routes:          s.Routes,
+		Structs:         s.Structs,
+		Enums:           s.Enums,
+		Headers:         s.Headers,
+		Paths:           s.Paths,
+		Channels:        s.Channels,
+		Messages:        s.Messages,
+		TypeToEnum:      s.TypeToEnum,
+		TypeToStruct:    s.TypeToStruct,
+		TypeAliases:     s.TypeAliases,
+		TypeDocs:        s.typeDocs,
+		UnderlyingTypes: s.underlyingTypeNames(),
+		Warnings:        s.Warnings,
+		Registrations:   s.Registrations,
+		Handlers:        s.Handlers,
+	}
+	if ir.Metas == nil {
+		ir.Metas = []*MetaInfo{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(ir); err != nil {
+		return fmt.Errorf("failed to encode IR: %w", err)
+	}
+	return nil
+}
+
+// ImportIR loads scan results written by ExportIR in place of a Scan. The type information
+// of the packages isn't part of the IR: named primitives resolve through the underlying
+// types recorded at export.
+func (s *Scanner) ImportIR(r io.Reader) error {
+	var ir IR
+	if err := json.NewDecoder(r).Decode(&ir); err != nil {
+		return fmt.Errorf("failed to decode IR: %w", err)
+	}
+	if ir.Version != IRVersion {
+		return fmt.Errorf("unsupported IR version %d, expected %d", ir.Version, IRVersion)
+	}
+
+	s.Metas = ir.Metas
+	if s.Metas == nil {
+		s.Metas = []*MetaInfo{}
+	}
+	s.Meta = nil
+	for _, meta := range s.Metas {
+		if len(meta.Specs) == 0 {
+			s.Meta = meta
+			break
+		}
+	}
+
+	importMap(&s.Routes, ir.Routes)
+	importMap(&s.Structs, ir.Structs)
+	importMap(&s.Enums, ir.Enums)
+	importMap(&s.Headers, ir.Headers)
+	importMap(&s.Paths, ir.Paths)
+	importMap(&s.Channels, ir.Channels)
+	importMap(&s.Messages, ir.Messages)
+	importMap(&s.TypeToEnum, ir.TypeToEnum)
+	importMap(&s.TypeToStruct, ir.TypeToStruct)
+	importMap(&s.TypeAliases, ir.TypeAliases)
+	importMap(&s.typeDocs, ir.TypeDocs)
+	importMap(&s.underlyingTypes, ir.UnderlyingTypes)
+	s.Warnings = ir.Warnings
+	s.Registrations = ir.Registrations
+	s.Handlers = ir.Handlers
+
+	s.RouteSources = make(map[string]string, len(s.Routes))
+	for opID, route := range s.Routes {
+		s.RouteSources[opID] = route.SourceFile
+	}
+	s.StructSources = make(map[string]string, len(s.Structs))
+	for name, structInfo := range s.Structs {
+		s.StructSources[name] = structInfo.SourceFile
+	}
+	s.EnumSources = make(map[string]string, len(s.Enums))
+	for name, enumInfo := range s.Enums {
+		s.EnumSources[name] = enumInfo.SourceFile
+	}
+	return nil
+}
+
+// importMap replaces a map of the scanner with a map of the IR, or an empty map when the
+// IR omits it.
+func importMap[K comparable, V any](dst *map[K]V, src map[K]V) {
+	if src == nil {
+		src = make(map[K]V)
+	}
+	*dst = src
+}
+
+// underlyingTypeNames returns the Go types of the named primitives, named collections, and
+// aliases of the scanned packages, by short and package-qualified name.
+func (s *Scanner) underlyingTypeNames() map[string]string {
+	names := maps.Clone(s.underlyingTypes)
+	if names == nil {
+		names = make(map[string]string)
+	}
+	for name, obj := range s.typeInfo {
+		if _, ok := obj.(*types.TypeName); !ok {
+			continue
+		}
+		if resolved := s.ResolveUnderlyingType(name); resolved != "" && resolved != name {
+			names[name] = resolved
+		}
+	}
+	return names
+}
+
+// underlyingKindNames are the IR names of the underlying kinds.
+var underlyingKindNames = map[UnderlyingKind]string{
+	KindStruct:    "struct",
+	KindArray:     "array",
+	KindMap:       "map",
+	KindPrimitive: "primitive",
+}
+
+// String returns the name of the kind: struct, array, map, or primitive.
+func (k UnderlyingKind) String() string {
+	if name, ok := underlyingKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("UnderlyingKind(%d)", int(k))
+}
+
+// MarshalText encodes the kind by name, so the IR does not depend on the constant values.
+func (k UnderlyingKind) MarshalText() ([]byte, error) {
+	name, ok := underlyingKindNames[k]
+	if !ok {
+		return nil, fmt.Errorf("unknown underlying kind %d", int(k))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes a kind encoded by MarshalText.
+func (k *UnderlyingKind) UnmarshalText(text []byte) error {
+	for kind, name := range underlyingKindNames {
+		if name == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown underlying kind %q", text)
+}
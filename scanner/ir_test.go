@@ -0,0 +1,84 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportIR(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// Package api is the users API.
+//
+// swagger:meta
+//
+// Title: Users API
+// Version: 1.0.0
+package api
+`,
+		"api/models.go": `package api
+
+// swagger:enum
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleUser  Role = "user"
+)
+
+// swagger:model
+type User struct {
+	ID   string ` + "`json:\"id\"`" + `
+	Role Role   ` + "`json:\"role\"`" + `
+}
+
+// swagger:model
+type Users []User
+`,
+		"api/routes.go": `package api
+
+// swagger:route GET /users/{id} users getUser
+//
+// Responses:
+// - 200: User
+func GetUser() {}
+`,
+	})
+
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+	require.NoError(t, s.Scan())
+
+	var buf bytes.Buffer
+	require.NoError(t, s.ExportIR(&buf))
+
+	var ir IR
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &ir))
+	assert.Equal(t, IRVersion, ir.Version)
+	require.Len(t, ir.Metas, 1)
+	assert.Equal(t, "Users API", ir.Metas[0].Title)
+	require.Contains(t, ir.Routes, "getUser")
+	assert.Equal(t, "/users/{id}", ir.Routes["getUser"].Path)
+	assert.Equal(t, "User", ir.Routes["getUser"].Responses[0].Type)
+	require.Contains(t, ir.Structs, "User")
+	assert.Len(t, ir.Structs["User"].Fields, 2)
+	assert.Equal(t, KindArray, ir.Structs["Users"].UnderlyingKind)
+	require.Contains(t, ir.Enums, "Role")
+
+	// Kinds are encoded by name, and the document is stable across scans
+	assert.Contains(t, buf.String(), `"UnderlyingKind": "array"`)
+
+	again := New(WithDir(tmpDir), WithPattern("./..."))
+	require.NoError(t, again.Scan())
+	var second bytes.Buffer
+	require.NoError(t, again.ExportIR(&second))
+	assert.Equal(t, buf.String(), second.String())
+}
+
+func TestExportIREmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, New().ExportIR(&buf))
+	assert.JSONEq(t, `{"version": 1, "metas": [], "routes": {}, "structs": {}, "enums": {}}`, buf.String())
+}
@@ -1,12 +1,13 @@
 package scanner
 
 import (
+	"fmt"
 	"go/ast"
 	"strings"
 )
 
 // processMeta processes swagger:meta directive to extract API metadata.
-func (s *Scanner) processMeta(_ string, file *ast.File) error {
+func (s *Scanner) processMeta(filePath string, file *ast.File) error {
 	for _, cg := range file.Comments {
 		if !hasDirective(cg, MetaDirective) {
 			continue
@@ -17,6 +18,27 @@ func (s *Scanner) processMeta(_ string, file *ast.File) error {
 		if meta != nil {
 			// Extract spec directive
 			meta.Specs = extractSpecs(cg)
+			meta.Includes = extractIncludes(cg)
+			meta.Fragments = extractFragments(cg, filePath)
+			meta.Pos = s.directivePos(cg, MetaDirective)
+			if license := meta.License; license != nil && license.Identifier != "" && license.URL != "" {
+				s.warn(WarnInvalidLicense, s.directivePos(cg, LicenseDirective),
+					"%s identifier %s and url %s are mutually exclusive; url ignored", LicenseDirective, license.Identifier, license.URL)
+				license.URL = ""
+			}
+			description, err := loadDescriptionFile(meta.Description, filePath)
+			if err != nil {
+				return fmt.Errorf("%s: %w", s.location(meta.Pos), err)
+			}
+			meta.Description = description
+			if hasDirective(cg, EnvelopeDirective) {
+				envelope, err := parseEnvelope(extractDirectiveValue(cg, EnvelopeDirective))
+				if err != nil {
+					s.warn(WarnInvalidEnvelope, s.directivePos(cg, EnvelopeDirective), "%s: %v; envelope ignored",
+						directiveText(cg, EnvelopeDirective), err)
+				}
+				meta.Envelope = envelope
+			}
 			s.Metas = append(s.Metas, meta)
 			// Keep backward compatibility: first meta without spec becomes s.Meta
 			if s.Meta == nil && len(meta.Specs) == 0 {
@@ -46,6 +68,9 @@ func parseMetaInfo(doc *ast.CommentGroup) *MetaInfo {
 		case strings.HasPrefix(comment, TitleDirective):
 			meta.Title = strings.TrimSpace(strings.TrimPrefix(comment, TitleDirective))
 
+		case strings.HasPrefix(comment, SummaryDirective):
+			meta.Summary = strings.TrimSpace(strings.TrimPrefix(comment, SummaryDirective))
+
 		case strings.HasPrefix(comment, VersionDirective):
 			meta.Version = strings.TrimSpace(strings.TrimPrefix(comment, VersionDirective))
 
@@ -61,12 +86,24 @@ func parseMetaInfo(doc *ast.CommentGroup) *MetaInfo {
 		case strings.HasPrefix(comment, BasePathDirective):
 			meta.BasePath = strings.TrimSpace(strings.TrimPrefix(comment, BasePathDirective))
 
+		case strings.HasPrefix(comment, ServersDirective):
+			meta.Servers = parseServers(comments, i)
+
+		case strings.HasPrefix(comment, StripPrefixDirective):
+			meta.StripPrefix = strings.TrimSpace(strings.TrimPrefix(comment, StripPrefixDirective))
+
+		case strings.HasPrefix(comment, AddPrefixDirective):
+			meta.AddPrefix = strings.TrimSpace(strings.TrimPrefix(comment, AddPrefixDirective))
+
 		case strings.HasPrefix(comment, ContactDirective):
 			meta.Contact = parseContact(comments, i)
 
 		case strings.HasPrefix(comment, LicenseDirective):
 			meta.License = parseLicense(comments, i)
 
+		case strings.HasPrefix(comment, LogoDirective):
+			meta.Logo = parseLogo(comments, i)
+
 		case strings.HasPrefix(comment, ExternalDocsDirective):
 			meta.ExternalDocs = parseExternalDocs(comments, i)
 
@@ -76,6 +113,9 @@ func parseMetaInfo(doc *ast.CommentGroup) *MetaInfo {
 		case strings.HasPrefix(comment, SecuritySchemesDirective):
 			meta.SecuritySchemes = parseSecuritySchemes(comments, i)
 
+		case strings.HasPrefix(comment, SecurityDirective):
+			meta.Security = parseListSection(comments, i)
+
 		case strings.HasPrefix(comment, ConsumesDirective):
 			meta.Consumes = parseListSection(comments, i)
 
@@ -84,6 +124,8 @@ func parseMetaInfo(doc *ast.CommentGroup) *MetaInfo {
 		}
 	}
 
+	meta.Extensions = extractExtensions(comments)
+
 	return meta
 }
 
@@ -126,11 +168,73 @@ func parseContact(comments []string, startIdx int) *ContactInfo {
 			contact.URL = strings.TrimSpace(after)
 		} else if after, ok := strings.CutPrefix(line, "email:"); ok {
 			contact.Email = strings.TrimSpace(after)
+		} else if name, value, ok := parseExtensionLine(line); ok {
+			if contact.Extensions == nil {
+				contact.Extensions = make(map[string]any)
+			}
+			contact.Extensions[name] = value
 		}
 	}
 	return contact
 }
 
+// parseLogo parses the logo of the API.
+//
+//	Logo:
+//	- url: https://example.com/logo.png
+//	- backgroundColor: #FFFFFF
+//	- altText: Example logo
+//	- href: https://example.com
+func parseLogo(comments []string, startIdx int) *LogoInfo {
+	logo := &LogoInfo{}
+	for i := startIdx + 1; i < len(comments); i++ {
+		line := strings.TrimSpace(comments[i])
+		if !strings.HasPrefix(line, "-") {
+			break
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "-"))
+
+		if after, ok := strings.CutPrefix(line, "url:"); ok {
+			logo.URL = strings.TrimSpace(after)
+		} else if after, ok := strings.CutPrefix(line, "backgroundColor:"); ok {
+			logo.BackgroundColor = strings.TrimSpace(after)
+		} else if after, ok := strings.CutPrefix(line, "altText:"); ok {
+			logo.AltText = strings.TrimSpace(after)
+		} else if after, ok := strings.CutPrefix(line, "href:"); ok {
+			logo.Href = strings.TrimSpace(after)
+		}
+	}
+	if logo.URL == "" {
+		return nil
+	}
+	return logo
+}
+
+// parseServers parses the Servers: section.
+// Each item is a URL optionally followed by a description:
+//
+//	Servers:
+//	- https://admin.example.com Admin API
+func parseServers(comments []string, startIdx int) []*ServerInfo {
+	var servers []*ServerInfo
+	for i := startIdx + 1; i < len(comments); i++ {
+		line := strings.TrimSpace(comments[i])
+		if line == "" {
+			continue
+		}
+		item, ok := strings.CutPrefix(line, "-")
+		if !ok {
+			break
+		}
+		url, description, _ := strings.Cut(strings.TrimSpace(item), " ")
+		servers = append(servers, &ServerInfo{
+			URL:         url,
+			Description: strings.TrimSpace(description),
+		})
+	}
+	return servers
+}
+
 // parseLicense parses license information.
 func parseLicense(comments []string, startIdx int) *LicenseInfo {
 	license := &LicenseInfo{}
@@ -144,6 +248,8 @@ func parseLicense(comments []string, startIdx int) *LicenseInfo {
 
 		if after, ok := strings.CutPrefix(line, "name:"); ok {
 			license.Name = strings.TrimSpace(after)
+		} else if after, ok := strings.CutPrefix(line, "identifier:"); ok {
+			license.Identifier = strings.TrimSpace(after)
 		} else if after, ok := strings.CutPrefix(line, "url:"); ok {
 			license.URL = strings.TrimSpace(after)
 		}
@@ -314,10 +420,11 @@ func parseSecuritySchemes(comments []string, startIdx int) map[string]*SecurityS
 func isTopLevelDirective(line string, _ int, _ []string) bool {
 	// Top-level directives we recognize
 	topLevel := []string{
-		"SecuritySchemes:", "Tags:", "Contact:", "License:",
+		"SecuritySchemes:", "Security:", "Tags:", "Contact:", "License:",
 		"ExternalDocs:", "Consumes:", "Produces:", "Schemes:",
 		"swagger:", "Title:", "Version:", "Host:", "BasePath:",
-		"TermsOfService:", "description:",
+		"TermsOfService:", "description:", "Servers:", "StripPrefix:", "AddPrefix:",
+		"spec:", "includes:", "envelope:",
 	}
 
 	for _, directive := range topLevel {
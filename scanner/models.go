@@ -2,36 +2,100 @@
 // information from Go source code using structured comments (directives).
 package scanner
 
+import "go/token"
+
+// Codes of the warnings reported by the scanner.
+const (
+	WarnMissingOperationID = "missing-operation-id" // swagger:route without an operation ID
+	WarnInvalidRoute       = "invalid-route"        // swagger:route with an unknown method or a relative path
+	WarnInvalidPath        = "invalid-path"         // swagger:path without an absolute path
+	WarnInvalidHeaders     = "invalid-headers"      // swagger:headers without operation IDs
+	WarnInvalidResponse    = "invalid-response"     // response line of a route written without the list dash
+	WarnInvalidChannel     = "invalid-channel"      // swagger:channel without a channel, a valid action, or an operation ID
+	WarnInvalidEnvelope    = "invalid-envelope"     // envelope: without a property, or with more than a property and a model
+	WarnInvalidExample     = "invalid-example"      // example: | block that is empty or not valid YAML, or example variable calling a function
+	WarnInvalidLicense     = "invalid-license"      // License: with both an SPDX identifier and a url
+	WarnInvalidCodeSample  = "invalid-code-sample"  // codeSample: without a language and a source
+	WarnInvalidRateLimit   = "invalid-ratelimit"    // ratelimit: without a limit and a known window
+	WarnInvalidPagination  = "invalid-pagination"   // pagination: with an unknown style or parameter
+	WarnCustomMethod       = "custom-method"        // swagger:route with a method that is not a standard HTTP method
+)
+
+// Warning is a non-fatal problem found while scanning or generating.
+type Warning struct {
+	Code    string         // Kind of problem (e.g., "missing-operation-id")
+	Message string         // Human-readable description, prefixed with the source location when known
+	Pos     token.Position // Position of the directive the warning refers to, if known
+}
+
+// String returns the warning message.
+func (w Warning) String() string {
+	return w.Message
+}
+
 // MetaInfo contains OpenAPI specification metadata extracted from swagger:meta directive.
 type MetaInfo struct {
 	Title           string
+	Summary         string // Short summary of the API (Summary:)
 	Description     string
 	Version         string
 	TermsOfService  string
 	Contact         *ContactInfo
 	License         *LicenseInfo
+	Logo            *LogoInfo // Logo of the API, emitted as the x-logo extension of Redoc (Logo:)
 	Host            string
 	BasePath        string
 	ExternalDocs    *ExternalDocsInfo
 	Tags            []*TagInfo
 	SecuritySchemes map[string]*SecuritySchemeInfo
+	Security        []string // Security schemes required by all operations
 	Consumes        []string
 	Produces        []string
 	Schemes         []string
-	Specs           []string // Multi-spec: which specs this meta belongs to (empty = general/default)
+	Servers         []*ServerInfo  // Servers of the generated spec
+	StripPrefix     string         // Prefix removed from route paths (e.g., /admin)
+	AddPrefix       string         // Prefix prepended to route paths after stripping
+	Specs           []string       // Multi-spec: which specs this meta belongs to (empty = general/default)
+	Includes        []string       // Multi-spec: specs whose routes and models are part of this spec
+	Fragments       []string       // OpenAPI fragment files merged into the spec (swagger:include)
+	Extensions      map[string]any // Vendor extensions (x-*) applied to the Info object
+	Pos             token.Position // Position of the swagger:meta directive
+	Envelope        *EnvelopeInfo  // Envelope of the success responses of the routes (envelope:)
+}
+
+// EnvelopeInfo describes the envelope object wrapping response payloads.
+type EnvelopeInfo struct {
+	Field string // Property holding the payload (e.g., data); empty disables the envelope (envelope: none)
+	Model string // Optional model declaring the other properties of the envelope (e.g., meta)
+}
+
+// ServerInfo represents a server the API is served from.
+type ServerInfo struct {
+	URL         string
+	Description string
 }
 
 // ContactInfo represents contact information for the API.
 type ContactInfo struct {
-	Name  string
-	URL   string
-	Email string
+	Name       string
+	URL        string
+	Email      string
+	Extensions map[string]any // Vendor extensions (x-*) applied to the Contact object
+}
+
+// LogoInfo represents the logo of the API shown by documentation portals (x-logo).
+type LogoInfo struct {
+	URL             string
+	BackgroundColor string
+	AltText         string
+	Href            string // Link opened by a click on the logo
 }
 
 // LicenseInfo represents license information for the API.
 type LicenseInfo struct {
-	Name string
-	URL  string
+	Name       string
+	Identifier string // SPDX license expression (e.g., Apache-2.0), exclusive with URL
+	URL        string
 }
 
 // ExternalDocsInfo represents external documentation.
@@ -83,6 +147,7 @@ type EnumInfo struct {
 	Values      map[string]any
 	Description string
 	SourceFile  string
+	Pos         token.Position // Position of the swagger:enum directive
 }
 
 // EmbeddedTypeInfo contains information about an embedded type with its position.
@@ -104,17 +169,32 @@ const (
 // StructInfo contains information about a struct marked as model or parameters.
 type StructInfo struct {
 	Name              string
+	GoType            string // Go type name (e.g., "Error"); Name may differ (swagger:model Name, naming strategy)
+	PkgPath           string // Import path of the declaring package
+	PkgName           string // Name of the declaring package
 	Fields            []*FieldInfo
 	EmbeddedTypes     []string            // Embedded types that need to be resolved (e.g., "pagination.Pagination")
 	EmbeddedTypeInfos []*EmbeddedTypeInfo // Embedded types with position information
 	Description       string
 	IsParameter       bool
+	IsHeaders         bool     // Header parameters (swagger:headers); fields default to in:header
+	Operations        []string // Operation IDs a swagger:headers struct applies to
 	IsModel           bool
 	SourceFile        string
 	OneOf             []string // Legacy: inline oneOf references from "oneOf:" directive
 	AllOf             []string // Legacy: inline allOf references from "allOf:" directive
 	AnyOf             []string // Legacy: inline anyOf references from "anyOf:" directive
 	Specs             []string // Multi-spec: which specs this model belongs to (empty = all specs)
+	Internal          bool     // Internal-only model (internal: true)
+
+	// AdditionalProperties closes (false) or opens (true) the object schema of the model
+	// (additionalProperties:); nil follows the generator default
+	AdditionalProperties *bool
+	// Validations holds the object constraints of the model: minProperties, maxProperties,
+	// and propertyNames (a pattern)
+	Validations map[string]string
+	// Example is the example of the model (example:), decoded when it is JSON
+	Example any
 
 	UnderlyingKind UnderlyingKind // What kind of Go type this model wraps
 	ElementType    string         // For arrays: element type name; for maps: value type name
@@ -126,6 +206,21 @@ type StructInfo struct {
 	OneOfOptions  []string           // Types marked with swagger:oneOfOption
 	AnyOfOptions  []string           // Types marked with swagger:anyOfOption
 	Discriminator *DiscriminatorInfo // Discriminator configuration for polymorphism
+
+	Extensions map[string]any // Vendor extensions (x-*) applied to the schema
+	Pos        token.Position // Position of the swagger directive (of the type for dependency models)
+}
+
+// withName returns a copy of the struct named name, with copies of its fields.
+func (s *StructInfo) withName(name string) *StructInfo {
+	clone := *s
+	clone.Name = name
+	clone.Fields = make([]*FieldInfo, len(s.Fields))
+	for i, field := range s.Fields {
+		fieldCopy := *field
+		clone.Fields[i] = &fieldCopy
+	}
+	return &clone
 }
 
 // DiscriminatorInfo contains discriminator configuration for oneOf/anyOf schemas.
@@ -141,23 +236,28 @@ type FieldInfo struct {
 	Description      string
 	Default          string
 	Example          string
+	Const            string // Single allowed value (const:), such as the literal type of a variant
 	Required         bool
 	Nullable         bool
 	Validations      map[string]string
 	Enum             string
 	Tags             map[string]string
 	IsArray          bool
-	IsPointer        bool
+	IsPointer        bool // *T or *[]T; []*T is not a pointer field
 	IsMap            bool
 	MapKeyType       string
 	IsRequestBody    bool
 	In               string // Parameter location: query, path, header, cookie, body
+	Style            string // Parameter serialization style: form, simple, deepObject, etc.
+	Explode          *bool  // Parameter explode flag (nil = location default)
 	InlineStruct     *StructInfo
 	IsInlineStruct   bool
 	HasOmitempty     bool
 	ExplicitRequired bool
 	ExplicitOptional bool
-	Index            int // Position in the original struct declaration (for ordering)
+	ExplicitNotNull  bool           // nullable: false, overriding the pointer-nullable policy
+	Index            int            // Position in the original struct declaration (for ordering)
+	Extensions       map[string]any // Vendor extensions (x-*) applied to the property schema
 }
 
 // RouteInfo contains information about an API route/endpoint.
@@ -169,13 +269,102 @@ type RouteInfo struct {
 	Summary           string
 	Description       string
 	Deprecated        bool
+	Sunset            string // Date (YYYY-MM-DD) after which a deprecated route is removed
+	Replacement       string // Operation ID replacing a deprecated route
 	Responses         []*ResponseInfo
 	Security          []string
+	NoSecurity        bool // Opt out of the global security requirement (Security: none)
 	Consumes          []string
 	Produces          []string
+	ProducesSchemas   map[string]string // Per media type response schema overrides (e.g., text/csv: string)
 	IgnoredParameters []string
+	PathParamTypes    map[string]string // Types declared in the path template (/users/{id:int64})
+	RequestBody       *RequestBodyInfo  // Request body declared inline with RequestBody:
+	AllowBody         bool              // Keep request bodies on GET, DELETE, HEAD, and OPTIONS (allowBody: true)
 	SourceFile        string
-	Specs             []string // Multi-spec: which specs this route belongs to (empty = default spec)
+	PkgPath           string            // Package declaring the route, resolving ambiguous type names
+	Pos               token.Position    // Position of the swagger:route directive
+	Specs             []string          // Multi-spec: which specs this route belongs to (empty = default spec)
+	Internal          bool              // Internal-only route (internal: true)
+	Extensions        map[string]any    // Vendor extensions (x-*) applied to the operation
+	ExternalDocs      *ExternalDocsInfo // Operation-level external documentation
+	Fragments         []string          // OpenAPI fragment files merged into the spec (swagger:include)
+	Envelope          *EnvelopeInfo     // Envelope of the success responses; nil uses the envelope of the meta
+	Problem           string            // Model extending the Problem schema of the error responses (problem:)
+	Version           string            // API version of the route in versioned mode (version:)
+	CodeSamples       []CodeSampleInfo  // Code samples of the operation (codeSample:), emitted as x-codeSamples
+	RateLimit         *RateLimitInfo    // Rate limit of the route (ratelimit:), emitted as x-ratelimit with a 429 response
+	Pagination        *PaginationInfo   // Pagination of the route (pagination:), emitted as x-pagination
+}
+
+// RateLimitInfo is the rate limit of a route.
+type RateLimitInfo struct {
+	Limit  int    // Requests allowed per window
+	Window string // second, minute, hour, or day
+	Scope  string // Optional key the limit applies to (e.g., user, ip, token)
+}
+
+// PaginationInfo is the pagination of a list route.
+type PaginationInfo struct {
+	Style  string            // cursor, offset, or page
+	Params map[string]string // Query parameter names by role (cursor, offset, page, limit)
+}
+
+// CodeSampleInfo is a code sample of an operation.
+type CodeSampleInfo struct {
+	Lang   string // Language of the sample (e.g., Go, Shell, TypeScript)
+	Source string // Source code of the sample, read from the file of a file: source
+}
+
+// PathInfo contains path-level information shared by all operations of a path (swagger:path).
+type PathInfo struct {
+	Path        string
+	Summary     string
+	Description string
+	Parameters  *StructInfo // Shared parameters; fields default to in:path when named in the template
+	SourceFile  string
+	Pos         token.Position // Position of the swagger:path directive
+}
+
+// RouteGroupInfo contains the attributes shared by the routes of a file or package (swagger:group).
+type RouteGroupInfo struct {
+	PathPrefix string          // Prefix of the route paths (PathPrefix: /admin)
+	Tags       []string        // Tags added before the route tags
+	Security   []string        // Security of the routes without a Security: section
+	NoSecurity bool            // Security: none for the routes without a Security: section
+	Responses  []*ResponseInfo // Responses of the status codes the routes don't declare
+	Package    bool            // Declared in the package doc comment, applies to the routes of the package
+	SourceFile string
+	Pos        token.Position // Position of the swagger:group directive
+}
+
+// ChannelInfo contains an event operation declared with swagger:channel.
+type ChannelInfo struct {
+	Channel     string // Channel name (e.g., user.created or users/{id}/events)
+	Action      string // publish or subscribe
+	Tags        []string
+	OperationID string
+	Summary     string
+	Description string
+	Messages    []string // Message names or model types; several messages are oneOf
+	SourceFile  string
+	Pos         token.Position // Position of the swagger:channel directive
+}
+
+// MessageInfo contains an event message declared with swagger:message.
+type MessageInfo struct {
+	Name       string
+	Payload    string // Go type of the payload model, qualified by package path when known
+	SourceFile string
+	Pos        token.Position // Position of the swagger:message directive
+}
+
+// RequestBodyInfo contains a request body declared in a route comment.
+type RequestBodyInfo struct {
+	Type        string
+	IsArray     bool
+	Required    bool
+	Description string
 }
 
 // ResponseInfo contains information about an API response.
@@ -186,4 +375,19 @@ type ResponseInfo struct {
 	IsArray     bool
 	IsMap       bool
 	MapKeyType  string
+
+	IsFile        bool               // Binary file download (200: file)
+	FileMediaType string             // Media type of the file (200: file:application/pdf)
+	Example       any                // Media type example (inline JSON, file, or Go variable)
+	ExampleRef    string             // Example reference: file path or variable name
+	OneOf         []string           // Inline oneOf options declared as Type1|Type2
+	Discriminator *DiscriminatorInfo // Response-level discriminator override
+	Contents      []ResponseContent  // Schemas by media type: Type1 (media/type), Type2 (media/type)
+}
+
+// ResponseContent is the schema of a response for a media type.
+type ResponseContent struct {
+	MediaType string
+	Type      string // Schema type, or file for a binary file
+	IsArray   bool
 }
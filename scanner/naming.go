@@ -0,0 +1,214 @@
+package scanner
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Schema naming strategies, deciding how models of different packages declaring the
+// same type name are named (see WithSchemaNaming).
+const (
+	// SchemaNamingShort names models after their Go type; collisions are errors that
+	// explicit swagger:model names resolve.
+	SchemaNamingShort = "short"
+	// SchemaNamingPackage prefixes colliding model names with their package name
+	// (e.g., BillingError and AuthError).
+	SchemaNamingPackage = "package"
+	// SchemaNamingHash suffixes colliding model names with a hash of their package path
+	// (e.g., Error_1c8f0a3e).
+	SchemaNamingHash = "hash"
+)
+
+// SchemaNamings lists the supported schema naming strategies.
+var SchemaNamings = []string{SchemaNamingShort, SchemaNamingPackage, SchemaNamingHash}
+
+// registerStruct adds a model or parameters struct to the scanned data. With the package
+// or hash strategy, models sharing a schema name with a model of another Go type are
+// renamed, unless they were named explicitly (swagger:model Name); collisions between
+// explicit names, or any collision with the short strategy, are errors.
+func (s *Scanner) registerStruct(structInfo *StructInfo, explicit bool) error {
+	name := structInfo.Name
+	if structInfo.IsModel && !explicit && s.collidedNames[name] {
+		name = s.qualifiedSchemaName(structInfo)
+	}
+
+	if existing, ok := s.Structs[name]; ok && collides(existing, structInfo) {
+		if !s.renamesCollisions() || (explicit && s.explicitNames[name]) {
+			return collisionError(structInfo, existing, name, "name one of them with swagger:model <Name> or use the package or hash schema naming")
+		}
+		s.collidedNames[name] = true
+		if !s.explicitNames[name] {
+			if err := s.renameStruct(existing, s.qualifiedSchemaName(existing)); err != nil {
+				return err
+			}
+		}
+		if !explicit {
+			name = s.qualifiedSchemaName(structInfo)
+			if existing, ok := s.Structs[name]; ok && collides(existing, structInfo) {
+				return collisionError(structInfo, existing, name, "name one of them with swagger:model <Name>")
+			}
+		}
+	}
+
+	structInfo.Name = name
+	s.Structs[name] = structInfo
+	s.StructSources[name] = structInfo.SourceFile
+	if explicit {
+		s.explicitNames[name] = true
+	}
+	s.registerTypeName(structInfo)
+	return nil
+}
+
+// registerTypeName maps the Go type of a struct to its schema name, by package path,
+// package name, and short name. Short names declared in several packages are ambiguous
+// and only resolved for the fields of the declaring package (see qualifyAmbiguousTypes).
+func (s *Scanner) registerTypeName(structInfo *StructInfo) {
+	goType := structInfo.GoType
+	if structInfo.PkgPath != "" {
+		s.TypeToStruct[structInfo.PkgPath+"."+goType] = structInfo.Name
+		s.TypeToStruct[structInfo.PkgName+"."+goType] = structInfo.Name
+	}
+
+	if s.ambiguousTypes[goType] {
+		return
+	}
+	if current, ok := s.Structs[s.TypeToStruct[goType]]; ok && current.PkgPath != structInfo.PkgPath {
+		delete(s.TypeToStruct, goType)
+		s.ambiguousTypes[goType] = true
+		return
+	}
+	s.TypeToStruct[goType] = structInfo.Name
+}
+
+// renameStruct moves a registered struct to a new schema name.
+func (s *Scanner) renameStruct(structInfo *StructInfo, name string) error {
+	if existing, ok := s.Structs[name]; ok {
+		return collisionError(structInfo, existing, name, "name one of them with swagger:model <Name>")
+	}
+
+	oldName := structInfo.Name
+	delete(s.Structs, oldName)
+	delete(s.StructSources, oldName)
+	structInfo.Name = name
+	s.Structs[name] = structInfo
+	s.StructSources[name] = structInfo.SourceFile
+	for typeName, model := range s.TypeToStruct {
+		if model == oldName {
+			s.TypeToStruct[typeName] = name
+		}
+	}
+	return nil
+}
+
+// collisionError reports two Go types declaring the same schema name.
+func collisionError(structInfo, existing *StructInfo, name, hint string) error {
+	return fmt.Errorf("%s: model %s of %s.%s collides with %s.%s declared in %s: %s",
+		structInfo.SourceFile, name, structInfo.PkgPath, structInfo.GoType, existing.PkgPath, existing.GoType, existing.SourceFile, hint)
+}
+
+// renamesCollisions reports whether the naming strategy renames colliding models.
+func (s *Scanner) renamesCollisions() bool {
+	return s.config.SchemaNaming == SchemaNamingPackage || s.config.SchemaNaming == SchemaNamingHash
+}
+
+// qualifiedSchemaName returns the name of a colliding model under the naming strategy.
+func (s *Scanner) qualifiedSchemaName(structInfo *StructInfo) string {
+	if s.config.SchemaNaming == SchemaNamingHash {
+		h := fnv.New32a()
+		h.Write([]byte(structInfo.PkgPath))
+		return fmt.Sprintf("%s_%08x", structInfo.GoType, h.Sum32())
+	}
+	return exportedName(structInfo.PkgName) + structInfo.GoType
+}
+
+// collides reports whether two models of different Go types declare the same schema for
+// the same specs. A model with a spec: directive overriding a general model of the same
+// name is not a collision.
+func collides(a, b *StructInfo) bool {
+	if !a.IsModel || !b.IsModel || (a.PkgPath == b.PkgPath && a.GoType == b.GoType) {
+		return false
+	}
+	if len(a.Specs) == 0 || len(b.Specs) == 0 {
+		return len(a.Specs) == len(b.Specs)
+	}
+	return slices.ContainsFunc(a.Specs, func(spec string) bool { return slices.Contains(b.Specs, spec) })
+}
+
+// exportedName upper-cases the first letter of a name.
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// qualifyAmbiguousTypes points the fields of structs, and the response, request body,
+// and path parameter types of routes, that use an ambiguous short type name (a model
+// type declared in several packages) at the model of their own package.
+func (s *Scanner) qualifyAmbiguousTypes() {
+	if len(s.ambiguousTypes) == 0 {
+		return
+	}
+	for _, structInfo := range s.Structs {
+		s.qualifyFieldTypes(structInfo, structInfo.PkgPath)
+	}
+	for _, route := range s.Routes {
+		s.qualifyRouteTypes(route)
+	}
+	for _, pathInfo := range s.Paths {
+		if pathInfo.Parameters != nil {
+			s.qualifyFieldTypes(pathInfo.Parameters, pathInfo.Parameters.PkgPath)
+		}
+	}
+}
+
+// qualifyFieldTypes qualifies the ambiguous field types of a struct and its inline structs.
+func (s *Scanner) qualifyFieldTypes(structInfo *StructInfo, pkgPath string) {
+	structInfo.ElementType = s.qualifyType(structInfo.ElementType, pkgPath)
+	for _, field := range structInfo.Fields {
+		field.Type = s.qualifyType(field.Type, pkgPath)
+		if field.InlineStruct != nil {
+			s.qualifyFieldTypes(field.InlineStruct, pkgPath)
+		}
+	}
+}
+
+// qualifyRouteTypes qualifies the ambiguous types of the responses, request body,
+// problem, and envelope of a route with the package of the route.
+func (s *Scanner) qualifyRouteTypes(route *RouteInfo) {
+	pkgPath := route.PkgPath
+	for _, resp := range route.Responses {
+		resp.Type = s.qualifyType(resp.Type, pkgPath)
+		for i, option := range resp.OneOf {
+			resp.OneOf[i] = s.qualifyType(option, pkgPath)
+		}
+		for i := range resp.Contents {
+			resp.Contents[i].Type = s.qualifyType(resp.Contents[i].Type, pkgPath)
+		}
+	}
+	if route.RequestBody != nil {
+		route.RequestBody.Type = s.qualifyType(route.RequestBody.Type, pkgPath)
+	}
+	for mediaType, typeName := range route.ProducesSchemas {
+		route.ProducesSchemas[mediaType] = s.qualifyType(typeName, pkgPath)
+	}
+	route.Problem = s.qualifyType(route.Problem, pkgPath)
+	if route.Envelope != nil {
+		route.Envelope.Model = s.qualifyType(route.Envelope.Model, pkgPath)
+	}
+}
+
+// qualifyType returns the model of the package at pkgPath for an ambiguous short type
+// name, and other type names as is.
+func (s *Scanner) qualifyType(typeName, pkgPath string) string {
+	if strings.Contains(typeName, ".") || !s.ambiguousTypes[typeName] {
+		return typeName
+	}
+	if model, ok := s.TypeToStruct[pkgPath+"."+typeName]; ok {
+		return model
+	}
+	return typeName
+}
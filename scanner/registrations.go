@@ -0,0 +1,291 @@
+package scanner
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// RegistrationInfo is a handler registered on a router in the scanned code.
+type RegistrationInfo struct {
+	Method     string // Upper-case HTTP method; empty when the handler serves every method
+	Path       string // Path of the registration, with the prefixes of its router
+	SourceFile string
+	Pos        token.Position // Position of the registration call
+}
+
+// registrationMethods are the router methods registering a handler for an HTTP method,
+// as named by chi (Get) and by gin, echo, and fiber (GET).
+var registrationMethods = map[string]string{
+	"Get": MethodGet, "Post": MethodPost, "Put": MethodPut, "Patch": MethodPatch, "Delete": MethodDelete,
+	"Head": MethodHead, "Options": MethodOptions, "Trace": MethodTrace, "Connect": "CONNECT",
+	"GET": MethodGet, "POST": MethodPost, "PUT": MethodPut, "PATCH": MethodPatch, "DELETE": MethodDelete,
+	"HEAD": MethodHead, "OPTIONS": MethodOptions, "TRACE": MethodTrace, "CONNECT": "CONNECT",
+}
+
+// registrationAnyMethods are the router methods registering a handler for every method.
+var registrationAnyMethods = []string{"Handle", "HandleFunc", "Any", "All"}
+
+// registrationMethodArgs are the router methods taking the HTTP method as first argument:
+// chi Method and MethodFunc, gin and httprouter Handle, echo Add.
+var registrationMethodArgs = []string{"Method", "MethodFunc", "Handle", "Handler", "HandlerFunc", "Add"}
+
+// routerPrefixes are the router methods returning a router for a path prefix: gin, echo,
+// and fiber Group, and gorilla PathPrefix (followed by Subrouter).
+var routerPrefixes = []string{"Group", "PathPrefix"}
+
+// processRegistrations records the handlers registered with a literal path on a router in
+// the function bodies of a file: chi, gin, echo, fiber, gorilla/mux, httprouter, and
+// net/http (method patterns such as "GET /users/{id}"). Prefixes are followed within a
+// function, through chi Route blocks and router variables assigned from Group or
+// PathPrefix; routers mounted from other functions keep their own paths.
+func (s *Scanner) processRegistrations(filePath string, file *ast.File, pkg *packages.Package) {
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+			w := &registrationWalker{
+				scanner:  s,
+				filePath: filePath,
+				info:     pkg.TypesInfo,
+				prefixes: make(map[any]string),
+				handled:  make(map[*ast.CallExpr]bool),
+			}
+			w.walk(funcDecl.Body, "")
+		}
+	}
+}
+
+// registrationWalker finds the router registrations of a function body.
+type registrationWalker struct {
+	scanner  *Scanner
+	filePath string
+	info     *types.Info
+	prefixes map[any]string         // Path prefixes of the router variables
+	handled  map[*ast.CallExpr]bool // Calls recorded with the methods of a chained Methods call
+}
+
+// walk records the registrations under node, with prefix for the routers of the enclosing
+// chi Route blocks.
+func (w *registrationWalker) walk(node ast.Node, prefix string) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			w.assign(n, prefix)
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			// chi: r.Route("/users", func(r chi.Router) { ... })
+			if sel.Sel.Name == "Route" && len(n.Args) == 2 {
+				if path, ok := stringLiteral(n.Args[0]); ok {
+					if fn, ok := n.Args[1].(*ast.FuncLit); ok {
+						w.walk(fn.Body, joinRoutePath(w.receiverPrefix(sel.X, prefix), path))
+						return false
+					}
+				}
+			}
+
+			// gorilla/mux: r.HandleFunc("/users", h).Methods("GET", "POST")
+			if sel.Sel.Name == "Methods" {
+				if inner, ok := sel.X.(*ast.CallExpr); ok {
+					if _, path, receiver, ok := registration(inner); ok {
+						w.handled[inner] = true
+						for _, arg := range n.Args {
+							if method, ok := stringLiteral(arg); ok {
+								w.record(n, strings.ToUpper(method), path, receiver, prefix)
+							}
+						}
+						return true
+					}
+				}
+			}
+
+			if method, path, receiver, ok := registration(n); ok && !w.handled[n] {
+				w.record(n, method, path, receiver, prefix)
+			}
+		}
+		return true
+	})
+}
+
+// record adds a registration of the scanned code.
+func (w *registrationWalker) record(call *ast.CallExpr, method, path string, receiver ast.Expr, prefix string) {
+	w.scanner.Registrations = append(w.scanner.Registrations, &RegistrationInfo{
+		Method:     method,
+		Path:       joinRoutePath(w.receiverPrefix(receiver, prefix), path),
+		SourceFile: w.filePath,
+		Pos:        w.scanner.fset.Position(call.Pos()),
+	})
+}
+
+// assign records the prefix of router variables assigned from Group or PathPrefix.
+func (w *registrationWalker) assign(stmt *ast.AssignStmt, prefix string) {
+	if len(stmt.Lhs) != len(stmt.Rhs) {
+		return
+	}
+	for i, rhs := range stmt.Rhs {
+		ident, ok := stmt.Lhs[i].(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if routerPrefix, ok := w.routerPrefix(rhs, prefix); ok {
+			w.prefixes[w.key(ident)] = routerPrefix
+		}
+	}
+}
+
+// routerPrefix returns the path prefix of a router expression built with Group or
+// PathPrefix, such as r.Group("/v1") or r.PathPrefix("/api").Subrouter().
+func (w *registrationWalker) routerPrefix(expr ast.Expr, prefix string) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	if slices.Contains(routerPrefixes, sel.Sel.Name) && len(call.Args) > 0 {
+		if path, ok := stringLiteral(call.Args[0]); ok {
+			return joinRoutePath(w.receiverPrefix(sel.X, prefix), path), true
+		}
+	}
+	if sel.Sel.Name == "Subrouter" {
+		return w.routerPrefix(sel.X, prefix)
+	}
+	return "", false
+}
+
+// receiverPrefix returns the path prefix of the router a handler is registered on: the
+// prefix of a router variable, or of the routers it derives from (r.With(mw).Get).
+func (w *registrationWalker) receiverPrefix(expr ast.Expr, prefix string) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if routerPrefix, ok := w.prefixes[w.key(expr)]; ok {
+			return routerPrefix
+		}
+	case *ast.CallExpr:
+		if routerPrefix, ok := w.routerPrefix(expr, prefix); ok {
+			return routerPrefix
+		}
+		if sel, ok := expr.Fun.(*ast.SelectorExpr); ok {
+			return w.receiverPrefix(sel.X, prefix)
+		}
+	}
+	return prefix
+}
+
+// key returns the identity of a variable: its object when type information is available,
+// else its name.
+func (w *registrationWalker) key(ident *ast.Ident) any {
+	if w.info != nil {
+		if obj := w.info.ObjectOf(ident); obj != nil {
+			return obj
+		}
+	}
+	return ident.Name
+}
+
+// registration returns the method, path, and router of a registration call, with ok false
+// for other calls.
+func registration(call *ast.CallExpr) (method, path string, receiver ast.Expr, ok bool) {
+	sel, isSel := call.Fun.(*ast.SelectorExpr)
+	if !isSel || len(call.Args) < 2 {
+		return "", "", nil, false
+	}
+	name := sel.Sel.Name
+
+	// r.Method("GET", "/users", h)
+	if slices.Contains(registrationMethodArgs, name) && len(call.Args) >= 3 {
+		if method, isLit := stringLiteral(call.Args[0]); isLit && isRegistrationMethod(method) {
+			if path, isLit := stringLiteral(call.Args[1]); isLit && strings.HasPrefix(path, "/") {
+				return strings.ToUpper(method), path, sel.X, true
+			}
+		}
+	}
+
+	// The handler is a function or a value; client calls such as Get("/users", &out) and
+	// map lookups aren't registrations
+	path, isLit := stringLiteral(call.Args[0])
+	if !isLit || !isHandlerArg(call.Args[1]) {
+		return "", "", nil, false
+	}
+
+	if method, known := registrationMethods[name]; known && strings.HasPrefix(path, "/") {
+		return method, path, sel.X, true
+	}
+
+	// r.HandleFunc("/users", h), or the net/http pattern "GET /users/{id}"
+	if slices.Contains(registrationAnyMethods, name) {
+		if method, rest, found := strings.Cut(path, " "); found && isRegistrationMethod(method) {
+			method, path = strings.ToUpper(method), strings.TrimSpace(rest)
+			if strings.HasPrefix(path, "/") {
+				return method, path, sel.X, true
+			}
+		} else if strings.HasPrefix(path, "/") {
+			return "", path, sel.X, true
+		}
+	}
+	return "", "", nil, false
+}
+
+// isHandlerArg reports whether an argument can be a handler: not a literal, an address,
+// or a composite value.
+func isHandlerArg(arg ast.Expr) bool {
+	switch arg := arg.(type) {
+	case *ast.BasicLit, *ast.CompositeLit:
+		return false
+	case *ast.UnaryExpr:
+		return arg.Op != token.AND
+	}
+	return true
+}
+
+// isRegistrationMethod reports whether s is an HTTP method of registrationMethods.
+func isRegistrationMethod(s string) bool {
+	return registrationMethods[s] != "" && strings.ToUpper(s) == s
+}
+
+// stringLiteral returns the value of a string literal expression.
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
+
+// joinRoutePath joins a router prefix and a registered path.
+func joinRoutePath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// routePathParam matches the path parameters of the routers: {id}, {id:[0-9]+},
+// {path...}, :id, and *path.
+var routePathParam = regexp.MustCompile(`\{[^/]*\}|:[^/]+|\*[^/]*`)
+
+// NormalizeRoutePath returns a path with its parameters replaced by {}, so that the paths
+// of swagger:route and of the routers compare equal whatever their parameter syntax. The
+// trailing slash and the net/http {$} anchor are dropped.
+func NormalizeRoutePath(path string) string {
+	path = strings.TrimSuffix(path, "{$}")
+	path = routePathParam.ReplaceAllString(path, "{}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
@@ -0,0 +1,119 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// routerTestSource declares routers shaped like those of chi, gin, gorilla/mux, and
+// net/http, so that the test project builds without dependencies
+const routerTestSource = `package api
+
+type HandlerFunc func()
+
+type Handler interface{}
+
+type ServeMux struct{}
+
+func (m *ServeMux) HandleFunc(pattern string, h HandlerFunc) {}
+
+type Router struct{}
+
+func (r *Router) Get(path string, h HandlerFunc)                      {}
+func (r *Router) POST(path string, h HandlerFunc)                     {}
+func (r *Router) Method(method, path string, h Handler)               {}
+func (r *Router) Route(path string, fn func(r *Router))               {}
+func (r *Router) Group(path string) *Router                           { return r }
+func (r *Router) With(mw func(Handler) Handler) *Router               { return r }
+func (r *Router) HandleFunc(path string, h HandlerFunc) *Registration { return nil }
+
+type Registration struct{}
+
+func (r *Registration) Methods(methods ...string) *Registration { return r }
+
+type Client struct{}
+
+func (c *Client) Get(path string, out any) error { return nil }
+`
+
+func TestScanRegistrations(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/router.go": routerTestSource,
+		"api/routes.go": `package api
+
+func Routes(r *Router, mux *ServeMux, client *Client, h HandlerFunc, mw func(Handler) Handler) {
+	r.Get("/health", h)
+	r.With(mw).Get("/ready", h)
+	r.Method("DELETE", "/users/{id}", h)
+	r.Route("/users", func(r *Router) {
+		r.Get("/{id:[0-9]+}", h)
+		r.Route("/{id}/posts", func(r *Router) {
+			r.Get("/", h)
+		})
+	})
+
+	v1 := r.Group("/v1")
+	admin := v1.Group("/admin")
+	admin.POST("/keys", h)
+
+	r.HandleFunc("/orders/{id}", h).Methods("GET", "PUT")
+	mux.HandleFunc("GET /items/{id}", h)
+	mux.HandleFunc("/static/", h)
+
+	var user struct{}
+	client.Get("/users/1", &user)
+}
+`,
+	})
+
+	s := New(WithDir(tmpDir), WithPattern("./..."), WithRegistrations(true))
+	require.NoError(t, s.Scan())
+
+	var registrations []string
+	for _, reg := range s.Registrations {
+		registrations = append(registrations, reg.Method+" "+reg.Path)
+		assert.Equal(t, "routes.go", filepath.Base(reg.SourceFile))
+	}
+	assert.Equal(t, []string{
+		"GET /health",
+		"GET /ready",
+		"DELETE /users/{id}",
+		"GET /users/{id:[0-9]+}",
+		"GET /users/{id}/posts",
+		"POST /v1/admin/keys",
+		"GET /orders/{id}",
+		"PUT /orders/{id}",
+		"GET /items/{id}",
+		" /static/",
+	}, registrations)
+	assert.Equal(t, 4, s.Registrations[0].Pos.Line)
+
+	// Registrations are only recorded on demand
+	s = New(WithDir(tmpDir), WithPattern("./..."))
+	require.NoError(t, s.Scan())
+	assert.Empty(t, s.Registrations)
+	assert.Empty(t, s.Handlers)
+}
+
+func TestNormalizeRoutePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/users/{id}", expected: "/users/{}"},
+		{path: "/users/{id:[0-9]+}/posts/", expected: "/users/{}/posts"},
+		{path: "/users/:id/files/*filepath", expected: "/users/{}/files/{}"},
+		{path: "/files/{path...}", expected: "/files/{}"},
+		{path: "/{$}", expected: "/"},
+		{path: "/", expected: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeRoutePath(tt.path))
+		})
+	}
+}
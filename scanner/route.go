@@ -1,12 +1,23 @@
 package scanner
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"go/ast"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"golang.org/x/tools/go/packages"
 )
 
 // processRoutes processes swagger:route directives.
-func (s *Scanner) processRoutes(filePath string, file *ast.File) error {
+func (s *Scanner) processRoutes(filePath string, file *ast.File, pkg *packages.Package) error {
 	for _, decl := range file.Decls {
 		funcDecl, ok := decl.(*ast.FuncDecl)
 		if !ok || funcDecl.Doc == nil {
@@ -18,11 +29,24 @@ func (s *Scanner) processRoutes(filePath string, file *ast.File) error {
 		}
 
 		routeValue := extractDirectiveValue(funcDecl.Doc, RouteDirective)
-		method, path, tags, operationID := parseRouteDirective(routeValue)
+		method, path, tags, operationID, err := parseRouteDirective(routeValue)
 
-		if method == "" || path == "" || operationID == "" {
+		pos := s.directivePos(funcDecl.Doc, RouteDirective)
+		if err != nil {
+			code := WarnInvalidRoute
+			if errors.Is(err, errMissingOperationID) {
+				code = WarnMissingOperationID
+			}
+			s.warn(code, pos, "%s: %v, expected %s METHOD /path [tags] operationID; route skipped",
+				directiveText(funcDecl.Doc, RouteDirective), err, RouteDirective)
 			continue
 		}
+		// A misspelled method would silently become an additional operation
+		if !slices.Contains(standardMethods, method) {
+			s.warn(WarnCustomMethod, pos, "%s: %s is not a standard HTTP method, emitted as an additional operation",
+				directiveText(funcDecl.Doc, RouteDirective), method)
+		}
+		path, pathParamTypes := parsePathTemplate(path)
 
 		route := &RouteInfo{
 			Method:            method,
@@ -37,58 +61,322 @@ func (s *Scanner) processRoutes(filePath string, file *ast.File) error {
 			Consumes:          []string{},
 			Produces:          []string{},
 			IgnoredParameters: []string{},
+			PathParamTypes:    pathParamTypes,
 			SourceFile:        filePath,
+			Pos:               pos,
 			Specs:             extractSpecs(funcDecl.Doc),
+			Internal:          extractDirectiveValue(funcDecl.Doc, InternalDirective) == "true",
+			Extensions:        extractExtensions(trimComments(funcDecl.Doc)),
+			ExternalDocs:      extractExternalDocs(funcDecl.Doc),
+			RequestBody:       parseRequestBody(extractDirectiveValue(funcDecl.Doc, RequestBodyDirective)),
+			AllowBody:         extractDirectiveValue(funcDecl.Doc, AllowBodyDirective) == "true",
+			Fragments:         extractFragments(funcDecl.Doc, filePath),
+			Problem:           extractDirectiveValue(funcDecl.Doc, ProblemDirective),
+			Version:           strings.ToLower(extractDirectiveValue(funcDecl.Doc, RouteVersionDirective)),
+		}
+		if pkg != nil {
+			route.PkgPath = pkg.PkgPath
+		}
+
+		if route.Description, err = loadDescriptionFile(route.Description, filePath); err != nil {
+			return fmt.Errorf("%s: operation %s: %w", s.location(pos), operationID, err)
+		}
+		if route.CodeSamples, err = s.extractCodeSamples(funcDecl.Doc, filePath, operationID); err != nil {
+			return fmt.Errorf("%s: operation %s: %w", s.location(pos), operationID, err)
+		}
+
+		if hasDirective(funcDecl.Doc, EnvelopeDirective) {
+			envelope, err := parseEnvelope(extractDirectiveValue(funcDecl.Doc, EnvelopeDirective))
+			if err != nil {
+				s.warn(WarnInvalidEnvelope, s.directivePos(funcDecl.Doc, EnvelopeDirective), "operation %s: %s: %v; envelope ignored",
+					operationID, directiveText(funcDecl.Doc, EnvelopeDirective), err)
+			}
+			route.Envelope = envelope
+		}
+
+		if hasDirective(funcDecl.Doc, RateLimitDirective) {
+			rateLimit, err := parseRateLimit(extractDirectiveValue(funcDecl.Doc, RateLimitDirective))
+			if err != nil {
+				s.warn(WarnInvalidRateLimit, s.directivePos(funcDecl.Doc, RateLimitDirective), "operation %s: %s: %v; rate limit ignored",
+					operationID, directiveText(funcDecl.Doc, RateLimitDirective), err)
+			}
+			route.RateLimit = rateLimit
+		}
+
+		if hasDirective(funcDecl.Doc, PaginationDirective) {
+			pagination, err := parsePagination(extractDirectiveValue(funcDecl.Doc, PaginationDirective))
+			if err != nil {
+				s.warn(WarnInvalidPagination, s.directivePos(funcDecl.Doc, PaginationDirective), "operation %s: %s: %v; pagination ignored",
+					operationID, directiveText(funcDecl.Doc, PaginationDirective), err)
+			}
+			route.Pagination = pagination
+		}
+
+		if route.Deprecated {
+			route.Sunset, route.Replacement = parseDeprecation(extractDirectiveValue(funcDecl.Doc, DeprecatedFieldDirective))
 		}
 
 		extractResponses(route, funcDecl.Doc)
+		s.checkResponseItems(funcDecl.Doc)
+		if err := s.checkStatusCodes(route, funcDecl.Doc, filePath); err != nil {
+			return err
+		}
 		extractSecurity(route, funcDecl.Doc)
 		extractConsumes(route, funcDecl.Doc)
 		extractProduces(route, funcDecl.Doc)
 		extractIgnoredParameters(route, funcDecl.Doc)
 
+		if err := s.resolveResponseExamples(route, filePath, pkg); err != nil {
+			return err
+		}
+
+		// The same position means the file is scanned again
+		if existing, ok := s.Routes[operationID]; ok && existing.Pos != pos {
+			return fmt.Errorf("%s: operation ID %s of %s %s is already used by %s %s declared at %s",
+				s.location(pos), operationID, method, path, existing.Method, existing.Path, s.location(existing.Pos))
+		}
+
 		s.Routes[operationID] = route
 		s.RouteSources[operationID] = filePath
 	}
 	return nil
 }
 
-// parseRouteDirective parses: METHOD /path tag1 tag2 operationID
-func parseRouteDirective(value string) (method, path string, tags []string, operationID string) {
-	value = strings.TrimSpace(value)
-	if value == "" {
-		return
-	}
+// errMissingOperationID is returned by parseRouteDirective for routes without an operation ID.
+var errMissingOperationID = errors.New("missing operation ID")
 
-	tokens := tokenizeWithQuotes(value)
-	if len(tokens) < 3 {
-		return
+// parseRouteDirective parses: METHOD /path tag1 tag2 operationID
+// The error describes the first problem of a malformed directive.
+func parseRouteDirective(value string) (method, path string, tags []string, operationID string, err error) {
+	tokens := tokenizeWithQuotes(strings.TrimSpace(value))
+	if len(tokens) == 0 {
+		return "", "", nil, "", errors.New("missing method and path")
 	}
 
+	// Validate method: standard methods plus custom ones (e.g., PURGE), which are
+	// emitted as additionalOperations
 	method = strings.ToUpper(tokens[0])
-	path = tokens[1]
-	operationID = tokens[len(tokens)-1]
-
-	// Validate method
-	validMethods := map[string]bool{
-		MethodGet: true, MethodPost: true, MethodPut: true,
-		MethodDelete: true, MethodPatch: true, MethodHead: true, MethodOptions: true,
-	}
-	if !validMethods[method] {
-		return "", "", nil, ""
+	if !isHTTPMethod(method) {
+		return "", "", nil, "", fmt.Errorf("invalid HTTP method %q", tokens[0])
 	}
 
 	// Validate path
+	if len(tokens) < 2 {
+		return "", "", nil, "", errors.New("missing path")
+	}
+	path = tokens[1]
 	if !strings.HasPrefix(path, "/") {
-		return "", "", nil, ""
+		return "", "", nil, "", fmt.Errorf("path %q does not start with /", path)
+	}
+
+	if len(tokens) < 3 {
+		return "", "", nil, "", errMissingOperationID
 	}
+	operationID = tokens[len(tokens)-1]
 
 	// Extract tags (everything between path and operationID)
 	if len(tokens) > 3 {
 		tags = tokens[2 : len(tokens)-1]
 	}
 
-	return
+	return method, path, tags, operationID, nil
+}
+
+// parseRequestBody parses the value of the RequestBody directive.
+// Format: [[]]Type [required] [description]
+func parseRequestBody(value string) *RequestBodyInfo {
+	typeName, rest, _ := strings.Cut(strings.TrimSpace(value), " ")
+	if typeName == "" {
+		return nil
+	}
+
+	body := &RequestBodyInfo{Type: typeName}
+	if after, ok := strings.CutPrefix(typeName, "[]"); ok {
+		body.Type = after
+		body.IsArray = true
+	}
+
+	rest = strings.TrimSpace(rest)
+	if after, ok := strings.CutPrefix(rest, "required"); ok && (after == "" || after[0] == ' ') {
+		body.Required = true
+		rest = strings.TrimSpace(after)
+	}
+	body.Description = rest
+
+	return body
+}
+
+// parsePathTemplate strips type suffixes from path template variables and returns them by name.
+// A suffix is a Go type (/users/{id:int64}) or a regular expression (/users/{id:[0-9]+}).
+func parsePathTemplate(path string) (string, map[string]string) {
+	var (
+		b     strings.Builder
+		types map[string]string
+	)
+
+	for {
+		start := strings.IndexByte(path, '{')
+		if start < 0 {
+			b.WriteString(path)
+			break
+		}
+		b.WriteString(path[:start])
+
+		// Find the matching brace; regular expressions may contain nested braces
+		end, depth := -1, 0
+		for i := start; i < len(path) && end < 0; i++ {
+			switch path[i] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			b.WriteString(path[start:])
+			break
+		}
+
+		name, typ, found := strings.Cut(path[start+1:end], ":")
+		b.WriteString("{" + name + "}")
+		if found && typ != "" {
+			if types == nil {
+				types = make(map[string]string)
+			}
+			types[name] = typ
+		}
+		path = path[end+1:]
+	}
+
+	return b.String(), types
+}
+
+// standardMethods are the methods with an operation field in OpenAPI path items.
+var standardMethods = []string{
+	MethodGet, MethodPut, MethodPost, MethodDelete, MethodOptions, MethodHead, MethodPatch, MethodTrace, MethodQuery,
+}
+
+// isHTTPMethod reports whether method is a valid uppercase HTTP method token.
+func isHTTPMethod(method string) bool {
+	if method == "" {
+		return false
+	}
+	for _, r := range method {
+		if (r < 'A' || r > 'Z') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDeprecation parses the optional value of the deprecated directive.
+// Format: deprecated: [YYYY-MM-DD] [use operationID]
+func parseDeprecation(value string) (sunset, replacement string) {
+	value = strings.TrimSpace(strings.TrimPrefix(value, ":"))
+	tokens := strings.Fields(value)
+
+	if len(tokens) > 0 {
+		if _, err := time.Parse(time.DateOnly, tokens[0]); err == nil {
+			sunset = tokens[0]
+			tokens = tokens[1:]
+		}
+	}
+
+	if len(tokens) >= 2 && strings.EqualFold(tokens[0], "use") {
+		replacement = tokens[1]
+	}
+
+	return sunset, replacement
+}
+
+// parseEnvelope parses the value of the envelope directive.
+// Format: envelope: field [Model] or envelope: none
+func parseEnvelope(value string) (*EnvelopeInfo, error) {
+	tokens := strings.Fields(value)
+	switch {
+	case len(tokens) == 0:
+		return nil, errors.New("missing payload property")
+	case len(tokens) > 2:
+		return nil, errors.New("expected a payload property and an optional envelope model")
+	case tokens[0] == EnvelopeNone:
+		return &EnvelopeInfo{}, nil
+	}
+
+	envelope := &EnvelopeInfo{Field: tokens[0]}
+	if len(tokens) == 2 {
+		envelope.Model = tokens[1]
+	}
+	return envelope, nil
+}
+
+// rateLimitWindows maps the accepted rate limit windows to their canonical name.
+var rateLimitWindows = map[string]string{
+	"s": "second", "sec": "second", "second": "second",
+	"m": "minute", "min": "minute", "minute": "minute",
+	"h": "hour", "hour": "hour",
+	"d": "day", "day": "day",
+}
+
+// parseRateLimit parses the value of the ratelimit directive.
+// Format: ratelimit: 100/minute [scope]
+func parseRateLimit(value string) (*RateLimitInfo, error) {
+	tokens := strings.Fields(value)
+	if len(tokens) == 0 || len(tokens) > 2 {
+		return nil, errors.New("expected <limit>/<window> and an optional scope")
+	}
+
+	limit, window, _ := strings.Cut(tokens[0], "/")
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid limit %q", limit)
+	}
+	canonical, ok := rateLimitWindows[strings.ToLower(window)]
+	if !ok {
+		return nil, fmt.Errorf("invalid window %q, expected second, minute, hour, or day", window)
+	}
+
+	rateLimit := &RateLimitInfo{Limit: n, Window: canonical}
+	if len(tokens) == 2 {
+		rateLimit.Scope = tokens[1]
+	}
+	return rateLimit, nil
+}
+
+// paginationParams are the pagination styles with the default names of their query
+// parameters by role.
+var paginationParams = map[string]map[string]string{
+	"cursor": {"cursor": "cursor", "limit": "limit"},
+	"offset": {"offset": "offset", "limit": "limit"},
+	"page":   {"page": "page", "limit": "limit"},
+}
+
+// parsePagination parses the value of the pagination directive.
+// Format: pagination: cursor|offset|page [role=param ...]
+func parsePagination(value string) (*PaginationInfo, error) {
+	tokens := strings.Fields(value)
+	if len(tokens) == 0 {
+		return nil, errors.New("missing style, expected cursor, offset, or page")
+	}
+
+	style := strings.ToLower(tokens[0])
+	defaults, ok := paginationParams[style]
+	if !ok {
+		return nil, fmt.Errorf("unknown style %q, expected cursor, offset, or page", tokens[0])
+	}
+
+	pagination := &PaginationInfo{Style: style, Params: maps.Clone(defaults)}
+	for _, item := range tokens[1:] {
+		role, param, found := strings.Cut(item, "=")
+		if _, known := defaults[role]; !found || !known || param == "" {
+			return nil, fmt.Errorf("invalid parameter %q, expected <role>=<name> with a role of %s", item,
+				strings.Join(slices.Sorted(maps.Keys(defaults)), " or "))
+		}
+		pagination.Params[role] = param
+	}
+	return pagination, nil
 }
 
 // tokenizeWithQuotes splits a string by spaces but respects quoted strings.
@@ -145,6 +433,9 @@ func extractRouteDescription(doc *ast.CommentGroup) string {
 		SwaggerPrefix, SummaryFieldDirective, SecurityDirective,
 		ResponsesDirective, ConsumesDirective, ProducesDirective,
 		ParametersDirective, IgnoredParametersDirective, DeprecatedFieldDirective,
+		ExternalDocsDirective, ExtensionPrefix, RequestBodyDirective, AllowBodyDirective,
+		InternalDirective, ChannelMessageDirective, EnvelopeDirective, ProblemDirective,
+		RouteVersionDirective, CodeSampleDirective, RateLimitDirective, PaginationDirective,
 	}
 
 	for _, comment := range comments {
@@ -202,8 +493,81 @@ func extractResponses(route *RouteInfo, doc *ast.CommentGroup) {
 	}
 }
 
+// checkResponseItems warns about response lines written without the list dash
+// (200: User instead of - 200: User), which end the Responses section.
+func (s *Scanner) checkResponseItems(doc *ast.CommentGroup) {
+	inSection := false
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if strings.HasPrefix(text, ResponsesDirective) {
+			inSection = true
+			continue
+		}
+		if !inSection || text == "" || strings.HasPrefix(text, DashPrefix) {
+			continue
+		}
+
+		code, _, found := strings.Cut(text, ":")
+		if _, ok := normalizeStatusCode(strings.TrimSpace(code)); !found || !ok {
+			return // Next directive
+		}
+		s.warn(WarnInvalidResponse, s.fset.Position(comment.Pos()),
+			"response %q is missing the list dash, expected \"- %s\"; it and the following responses are ignored", text, text)
+		return
+	}
+}
+
+// checkStatusCodes normalizes the status codes of the route responses and rejects
+// codes that are not 100-599, a 1XX-5XX range, or default, reporting the comment line.
+func (s *Scanner) checkStatusCodes(route *RouteInfo, doc *ast.CommentGroup, filePath string) error {
+	for _, resp := range route.Responses {
+		code, ok := normalizeStatusCode(resp.StatusCode)
+		if ok {
+			resp.StatusCode = code
+			continue
+		}
+
+		location := filePath
+		for _, comment := range doc.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			text = strings.TrimSpace(strings.TrimPrefix(text, DashPrefix))
+			if strings.HasPrefix(text, resp.StatusCode+":") {
+				location = fmt.Sprintf("%s:%d", filePath, s.fset.Position(comment.Pos()).Line)
+				break
+			}
+		}
+		return fmt.Errorf("%s: operation %s: invalid response status code %q, expected 100-599, 1XX-5XX, or default",
+			location, route.OperationID, resp.StatusCode)
+	}
+	return nil
+}
+
+// normalizeStatusCode validates a response status code and returns its canonical form:
+// a three-digit code (100-599), an uppercase range (2XX), or default.
+func normalizeStatusCode(code string) (string, bool) {
+	if strings.EqualFold(code, "default") {
+		return "default", true
+	}
+	if len(code) != 3 || code[0] < '1' || code[0] > '5' {
+		return "", false
+	}
+
+	upper := strings.ToUpper(code)
+	if upper[1:] == "XX" {
+		return upper, true
+	}
+	for _, c := range code[1:] {
+		if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+	return code, true
+}
+
 // parseResponseLine parses a single response line.
-// Format: STATUS: Type description:Description text
+// Format: STATUS: Type [discriminator:prop] [mapping:v1=Type1,v2=Type2] [example:REF] description:Description text
+// Type may be Type1|Type2 to declare an inline oneOf, or file[:media/type] for binary downloads,
+// or a list of Type (media/type) entries declaring a schema per media type.
 func parseResponseLine(line string) *ResponseInfo {
 	parts := strings.SplitN(line, ":", 2)
 	if len(parts) < 1 {
@@ -226,6 +590,17 @@ func parseResponseLine(line string) *ResponseInfo {
 		return resp
 	}
 
+	// Schemas by media type: UserJSON (application/json), UserCSV (text/csv)
+	if contents, remainder := extractResponseContents(rest); len(contents) > 0 {
+		resp.Contents = contents
+		desc := extractResponseOptions(resp, remainder)
+		if after, ok := strings.CutPrefix(desc, DescriptionFieldDirective); ok {
+			desc = after
+		}
+		resp.Description = strings.TrimSpace(desc)
+		return resp
+	}
+
 	// Split type from description
 	typeParts := strings.SplitN(rest, " ", 2)
 	typeName := typeParts[0]
@@ -246,11 +621,28 @@ func parseResponseLine(line string) *ResponseInfo {
 		}
 	}
 
+	// Check for file download (file or file:media/type)
+	if typeName == FileResponseType || strings.HasPrefix(typeName, FileResponseType+":") {
+		resp.IsFile = true
+		resp.FileMediaType = strings.TrimPrefix(strings.TrimPrefix(typeName, FileResponseType), ":")
+		typeName = ""
+	}
+
+	// Check for inline oneOf (Type1|Type2)
+	if strings.Contains(typeName, "|") {
+		for option := range strings.SplitSeq(typeName, "|") {
+			if option = strings.TrimSpace(option); option != "" {
+				resp.OneOf = append(resp.OneOf, option)
+			}
+		}
+		typeName = ""
+	}
+
 	resp.Type = typeName
 
-	// Extract description
+	// Extract discriminator options and description
 	if len(typeParts) > 1 {
-		desc := typeParts[1]
+		desc := extractResponseOptions(resp, typeParts[1])
 		if after, ok := strings.CutPrefix(desc, DescriptionFieldDirective); ok {
 			desc = after
 		}
@@ -260,8 +652,178 @@ func parseResponseLine(line string) *ResponseInfo {
 	return resp
 }
 
+// extractResponseContents consumes the leading Type (media/type) entries of a response
+// line, separated by commas, and returns them with the remainder.
+func extractResponseContents(rest string) ([]ResponseContent, string) {
+	var contents []ResponseContent
+	remainder := rest
+	for {
+		typeName, after, ok := strings.Cut(rest, "(")
+		typeName = strings.TrimSpace(typeName)
+		if !ok || typeName == "" || strings.ContainsAny(typeName, " \t") {
+			break
+		}
+		mediaType, after, ok := strings.Cut(after, ")")
+		mediaType = strings.TrimSpace(mediaType)
+		if !ok || !strings.Contains(mediaType, "/") || strings.ContainsAny(mediaType, " \t") {
+			break
+		}
+
+		content := ResponseContent{MediaType: mediaType, Type: typeName}
+		if elem, ok := strings.CutPrefix(typeName, "[]"); ok {
+			content.IsArray = true
+			content.Type = elem
+		}
+		contents = append(contents, content)
+		remainder = strings.TrimSpace(after)
+
+		next, ok := strings.CutPrefix(remainder, ",")
+		if !ok {
+			break
+		}
+		rest = next
+	}
+	return contents, remainder
+}
+
+// extractResponseOptions consumes leading discriminator:NAME, mapping:value=Type,...
+// and example:REF tokens from a response line and returns the remainder.
+// Examples are inline JSON, a JSON/YAML file path, or a package-level variable name.
+func extractResponseOptions(resp *ResponseInfo, rest string) string {
+	for {
+		rest = strings.TrimSpace(rest)
+
+		if after, found := strings.CutPrefix(rest, DiscriminatorDirective); found {
+			token, remainder, _ := strings.Cut(after, " ")
+			ensureResponseDiscriminator(resp).PropertyName = strings.TrimSpace(token)
+			rest = remainder
+			continue
+		}
+
+		if after, found := strings.CutPrefix(rest, ExampleDirective); found {
+			after = strings.TrimSpace(after)
+			if strings.HasPrefix(after, "{") || strings.HasPrefix(after, "[") {
+				// Inline JSON: decode exactly one value and continue after it
+				decoder := json.NewDecoder(strings.NewReader(after))
+				var value any
+				if err := decoder.Decode(&value); err == nil {
+					resp.Example = value
+					rest = after[decoder.InputOffset():]
+					continue
+				}
+			}
+			token, remainder, _ := strings.Cut(after, " ")
+			resp.ExampleRef = token
+			rest = remainder
+			continue
+		}
+
+		if after, found := strings.CutPrefix(rest, MappingDirective); found {
+			token, remainder, _ := strings.Cut(after, " ")
+			discriminator := ensureResponseDiscriminator(resp)
+			for pair := range strings.SplitSeq(token, ",") {
+				value, typeName, ok := strings.Cut(pair, "=")
+				if !ok || strings.TrimSpace(value) == "" || strings.TrimSpace(typeName) == "" {
+					continue
+				}
+				discriminator.Mapping[strings.TrimSpace(value)] = strings.TrimSpace(typeName)
+			}
+			rest = remainder
+			continue
+		}
+
+		return rest
+	}
+}
+
+// ensureResponseDiscriminator returns the response discriminator, creating it if needed.
+func ensureResponseDiscriminator(resp *ResponseInfo) *DiscriminatorInfo {
+	if resp.Discriminator == nil {
+		resp.Discriminator = &DiscriminatorInfo{
+			Mapping: make(map[string]string),
+		}
+	}
+	return resp.Discriminator
+}
+
+// extractExternalDocs parses the ExternalDocs: section of a route.
+// Supports the inline form "ExternalDocs: URL description" and the list form:
+//
+//	ExternalDocs:
+//	- url: https://example.com/docs
+//	- description: Find more info here
+func extractExternalDocs(doc *ast.CommentGroup) *ExternalDocsInfo {
+	comments := trimComments(doc)
+	for i, comment := range comments {
+		after, found := strings.CutPrefix(strings.TrimSpace(comment), ExternalDocsDirective)
+		if !found {
+			continue
+		}
+
+		if inline := strings.TrimSpace(after); inline != "" {
+			url, description, _ := strings.Cut(inline, " ")
+			return &ExternalDocsInfo{URL: url, Description: strings.TrimSpace(description)}
+		}
+
+		docs := parseExternalDocs(comments, i)
+		if docs.URL == "" {
+			return nil
+		}
+		return docs
+	}
+	return nil
+}
+
+// extractCodeSamples parses the codeSample: directives of a route. A source starting with
+// file: is read from a file relative to the source file.
+func (s *Scanner) extractCodeSamples(doc *ast.CommentGroup, filePath, operationID string) ([]CodeSampleInfo, error) {
+	if doc == nil {
+		return nil, nil
+	}
+
+	var samples []CodeSampleInfo
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		value, ok := strings.CutPrefix(text, CodeSampleDirective)
+		if !ok {
+			continue
+		}
+
+		lang, source, _ := strings.Cut(strings.TrimSpace(value), " ")
+		source = strings.TrimSpace(source)
+		if source == "" {
+			s.warn(WarnInvalidCodeSample, s.fset.Position(comment.Pos()), "operation %s: %s: missing source, expected %s <language> <source|file:path>; code sample ignored",
+				operationID, text, CodeSampleDirective)
+			continue
+		}
+
+		if path, ok := strings.CutPrefix(source, DescriptionFilePrefix); ok {
+			path = strings.TrimSpace(path)
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(filepath.Dir(filePath), path)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read code sample: %w", err)
+			}
+			source = strings.TrimRight(string(data), "\n")
+		}
+		samples = append(samples, CodeSampleInfo{Lang: lang, Source: source})
+	}
+	return samples, nil
+}
+
 // extractSecurity parses the Security: section.
 func extractSecurity(route *RouteInfo, doc *ast.CommentGroup) {
+	// "Security: none" opts the route out of the global security requirement
+	for _, comment := range trimComments(doc) {
+		name, value, found := strings.Cut(comment, ":")
+		if found && strings.EqualFold(name, "security") && strings.EqualFold(strings.TrimSpace(value), SecurityNone) {
+			route.NoSecurity = true
+			return
+		}
+	}
+
 	lines := extractSectionLines(doc, SecurityDirective)
 	for _, line := range lines {
 		if after, found := strings.CutPrefix(line, DashPrefix); found {
@@ -287,13 +849,27 @@ func extractConsumes(route *RouteInfo, doc *ast.CommentGroup) {
 }
 
 // extractProduces parses the Produces: section.
+// Items may override the response schema for a media type:
+//
+//	Produces:
+//	- application/json
+//	- text/csv: string
 func extractProduces(route *RouteInfo, doc *ast.CommentGroup) {
 	lines := extractSectionLines(doc, ProducesDirective)
 	for _, line := range lines {
 		if after, found := strings.CutPrefix(line, DashPrefix); found {
-			contentType := strings.TrimSpace(after)
-			if contentType != "" {
-				route.Produces = append(route.Produces, contentType)
+			contentType, typeName, hasType := strings.Cut(strings.TrimSpace(after), ":")
+			contentType = strings.TrimSpace(contentType)
+			if contentType == "" {
+				continue
+			}
+			route.Produces = append(route.Produces, contentType)
+
+			if typeName = strings.TrimSpace(typeName); hasType && typeName != "" {
+				if route.ProducesSchemas == nil {
+					route.ProducesSchemas = make(map[string]string)
+				}
+				route.ProducesSchemas[contentType] = typeName
 			}
 		}
 	}
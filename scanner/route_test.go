@@ -0,0 +1,333 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResponseLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected *ResponseInfo
+	}{
+		{
+			name:     "type with description",
+			line:     "200: User the user",
+			expected: &ResponseInfo{StatusCode: "200", Type: "User", Description: "the user"},
+		},
+		{
+			name:     "file download",
+			line:     "200: file description:PDF export",
+			expected: &ResponseInfo{StatusCode: "200", IsFile: true, Description: "PDF export"},
+		},
+		{
+			name:     "file download with media type",
+			line:     "200: file:application/pdf PDF export",
+			expected: &ResponseInfo{StatusCode: "200", IsFile: true, FileMediaType: "application/pdf", Description: "PDF export"},
+		},
+		{
+			name: "discriminator on model",
+			line: "200: PetOneOf discriminator:petType description:A pet",
+			expected: &ResponseInfo{
+				StatusCode:    "200",
+				Type:          "PetOneOf",
+				Description:   "A pet",
+				Discriminator: &DiscriminatorInfo{PropertyName: "petType", Mapping: map[string]string{}},
+			},
+		},
+		{
+			name: "inline oneOf with mapping",
+			line: "200: []Cat|Dog discriminator:kind mapping:cat=Cat,dog=Dog",
+			expected: &ResponseInfo{
+				StatusCode: "200",
+				IsArray:    true,
+				OneOf:      []string{"Cat", "Dog"},
+				Discriminator: &DiscriminatorInfo{
+					PropertyName: "kind",
+					Mapping:      map[string]string{"cat": "Cat", "dog": "Dog"},
+				},
+			},
+		},
+		{
+			name:     "example reference",
+			line:     "200: User example:./testdata/user.json description:The user",
+			expected: &ResponseInfo{StatusCode: "200", Type: "User", ExampleRef: "./testdata/user.json", Description: "The user"},
+		},
+		{
+			name:     "inline example",
+			line:     `201: User example:{"id": 1, "name": "a b"} Created`,
+			expected: &ResponseInfo{StatusCode: "201", Type: "User", Example: map[string]any{"id": float64(1), "name": "a b"}, Description: "Created"},
+		},
+		{
+			name: "schemas by media type",
+			line: "200: []User (application/json), file (text/csv) description:Exported users",
+			expected: &ResponseInfo{
+				StatusCode: "200",
+				Contents: []ResponseContent{
+					{MediaType: "application/json", Type: "User", IsArray: true},
+					{MediaType: "text/csv", Type: "file"},
+				},
+				Description: "Exported users",
+			},
+		},
+		{
+			name:     "parenthesized description",
+			line:     "200: User the user (cached)",
+			expected: &ResponseInfo{StatusCode: "200", Type: "User", Description: "the user (cached)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseResponseLine(tt.line))
+		})
+	}
+}
+
+func TestExtractExternalDocs(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		expected *ExternalDocsInfo
+	}{
+		{
+			name:     "no external docs",
+			comments: []string{"// swagger:route GET /users users listUsers"},
+			expected: nil,
+		},
+		{
+			name:     "inline form",
+			comments: []string{"// ExternalDocs: https://example.com/users Users guide"},
+			expected: &ExternalDocsInfo{URL: "https://example.com/users", Description: "Users guide"},
+		},
+		{
+			name:     "list form",
+			comments: []string{"// ExternalDocs:", "// - description: Users guide", "// - url: https://example.com/users"},
+			expected: &ExternalDocsInfo{URL: "https://example.com/users", Description: "Users guide"},
+		},
+		{
+			name:     "missing url",
+			comments: []string{"// ExternalDocs:", "// - description: Users guide"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := createCommentGroup(tt.comments...)
+			assert.Equal(t, tt.expected, extractExternalDocs(doc))
+		})
+	}
+}
+
+func TestParseDeprecation(t *testing.T) {
+	tests := []struct {
+		name                string
+		value               string
+		expectedSunset      string
+		expectedReplacement string
+	}{
+		{name: "bare directive", value: ""},
+		{name: "date only", value: ": 2025-06-01", expectedSunset: "2025-06-01"},
+		{name: "date and replacement", value: ": 2025-06-01 use getUsersV2", expectedSunset: "2025-06-01", expectedReplacement: "getUsersV2"},
+		{name: "replacement only", value: ": use getUsersV2", expectedReplacement: "getUsersV2"},
+		{name: "invalid date", value: ": soon", expectedSunset: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sunset, replacement := parseDeprecation(tt.value)
+			assert.Equal(t, tt.expectedSunset, sunset)
+			assert.Equal(t, tt.expectedReplacement, replacement)
+		})
+	}
+}
+
+func TestParsePathTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedPath  string
+		expectedTypes map[string]string
+	}{
+		{name: "plain", path: "/users/{id}", expectedPath: "/users/{id}"},
+		{name: "no variables", path: "/users", expectedPath: "/users"},
+		{
+			name:          "typed",
+			path:          "/users/{id:int64}/posts/{slug}",
+			expectedPath:  "/users/{id}/posts/{slug}",
+			expectedTypes: map[string]string{"id": "int64"},
+		},
+		{
+			name:          "regular expression with braces",
+			path:          "/codes/{code:[A-Z]{3}}",
+			expectedPath:  "/codes/{code}",
+			expectedTypes: map[string]string{"code": "[A-Z]{3}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, types := parsePathTemplate(tt.path)
+			assert.Equal(t, tt.expectedPath, path)
+			assert.Equal(t, tt.expectedTypes, types)
+		})
+	}
+}
+
+func TestParseRequestBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected *RequestBodyInfo
+	}{
+		{name: "empty", value: "", expected: nil},
+		{name: "type only", value: "CreateUserRequest", expected: &RequestBodyInfo{Type: "CreateUserRequest"}},
+		{
+			name:     "required with description",
+			value:    "CreateUserRequest required The user to create",
+			expected: &RequestBodyInfo{Type: "CreateUserRequest", Required: true, Description: "The user to create"},
+		},
+		{
+			name:     "array",
+			value:    "[]User required",
+			expected: &RequestBodyInfo{Type: "User", IsArray: true, Required: true},
+		},
+		{
+			name:     "description starting with required",
+			value:    "User requiredness is checked",
+			expected: &RequestBodyInfo{Type: "User", Description: "requiredness is checked"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseRequestBody(tt.value))
+		})
+	}
+}
+
+func TestParseEnvelope(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected *EnvelopeInfo
+		wantErr  string
+	}{
+		{name: "property", value: "data", expected: &EnvelopeInfo{Field: "data"}},
+		{name: "property and model", value: "data Envelope", expected: &EnvelopeInfo{Field: "data", Model: "Envelope"}},
+		{name: "none", value: "none", expected: &EnvelopeInfo{}},
+		{name: "empty", value: "", wantErr: "missing payload property"},
+		{name: "too many tokens", value: "data Envelope extra", wantErr: "expected a payload property"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := parseEnvelope(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, envelope)
+		})
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected *RateLimitInfo
+		wantErr  string
+	}{
+		{name: "limit and window", value: "100/minute", expected: &RateLimitInfo{Limit: 100, Window: "minute"}},
+		{name: "short window and scope", value: "10/s user", expected: &RateLimitInfo{Limit: 10, Window: "second", Scope: "user"}},
+		{name: "empty", value: "", wantErr: "expected <limit>/<window>"},
+		{name: "invalid limit", value: "many/hour", wantErr: `invalid limit "many"`},
+		{name: "unknown window", value: "100/week", wantErr: `invalid window "week"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rateLimit, err := parseRateLimit(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rateLimit)
+		})
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected *PaginationInfo
+		wantErr  string
+	}{
+		{
+			name:     "default parameters",
+			value:    "cursor",
+			expected: &PaginationInfo{Style: "cursor", Params: map[string]string{"cursor": "cursor", "limit": "limit"}},
+		},
+		{
+			name:     "renamed parameters",
+			value:    "offset offset=skip limit=take",
+			expected: &PaginationInfo{Style: "offset", Params: map[string]string{"offset": "skip", "limit": "take"}},
+		},
+		{name: "empty", value: "", wantErr: "missing style"},
+		{name: "unknown style", value: "keyset", wantErr: `unknown style "keyset"`},
+		{name: "unknown role", value: "page cursor=after", wantErr: `invalid parameter "cursor=after"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pagination, err := parsePagination(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, pagination)
+		})
+	}
+}
+
+func TestParseRouteDirective(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		method      string
+		path        string
+		operationID string
+		wantErr     string
+	}{
+		{name: "standard", value: "get /users users listUsers", method: "GET", path: "/users", operationID: "listUsers"},
+		{name: "trace", value: "TRACE /users users traceUsers", method: "TRACE", path: "/users", operationID: "traceUsers"},
+		{name: "custom", value: "PURGE /cache cache purgeCache", method: "PURGE", path: "/cache", operationID: "purgeCache"},
+		{name: "invalid method", value: "G3T /users users listUsers", wantErr: `invalid HTTP method "G3T"`},
+		{name: "missing path", value: "GET users listUsers", wantErr: `path "users" does not start with /`},
+		{name: "missing operation ID", value: "GET /users", wantErr: "missing operation ID"},
+		{name: "method only", value: "GET", wantErr: "missing path"},
+		{name: "empty", value: "", wantErr: "missing method and path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, path, _, operationID, err := parseRouteDirective(tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.method, method)
+			assert.Equal(t, tt.path, path)
+			assert.Equal(t, tt.operationID, operationID)
+		})
+	}
+}
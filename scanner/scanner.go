@@ -1,9 +1,13 @@
 package scanner
 
 import (
+	"context"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"maps"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -18,6 +22,32 @@ type Config struct {
 	Dir string
 	// IgnorePaths contains path patterns to exclude during scanning
 	IgnorePaths []string
+	// OnlyPaths restricts scanning to the files matching one of these path patterns
+	OnlyPaths []string
+	// BuildTags are the build tags used to select the files of the scanned packages
+	BuildTags []string
+	// IncludeTests loads the _test.go files of the scanned packages, whose package-level
+	// variables can be referenced as examples
+	IncludeTests bool
+	// SchemaNaming is the strategy for models of different packages sharing a name
+	// (SchemaNamingShort, SchemaNamingPackage, SchemaNamingHash)
+	SchemaNaming string
+	// ResolveExternal loads the dependency packages declaring referenced types that
+	// aren't found in the scanned packages
+	ResolveExternal bool
+	// KnownTypes are qualified type names mapped without a model (e.g., custom types),
+	// never resolved from dependency packages
+	KnownTypes []string
+	// Workspace scans the modules of the go.work file in Dir, with Pattern relative to
+	// each module directory
+	Workspace bool
+	// ModulePatterns are the package patterns of workspace modules, by module directory
+	// or module path, in place of Pattern
+	ModulePatterns map[string]string
+	// ScanRegistrations records the handlers registered on routers in Registrations
+	ScanRegistrations bool
+	// ScanHandlers records the exported functions with a handler signature in Handlers
+	ScanHandlers bool
 }
 
 // Option is a function type for configuring the Scanner.
@@ -44,6 +74,67 @@ func WithIgnorePaths(paths ...string) Option {
 	}
 }
 
+// WithOnlyPaths restricts scanning to the files matching one of the path patterns.
+func WithOnlyPaths(paths ...string) Option {
+	return func(c *Config) {
+		c.OnlyPaths = append(c.OnlyPaths, paths...)
+	}
+}
+
+// WithBuildTags sets the build tags used to select the files of the scanned packages
+// (e.g., files with a //go:build integration constraint are only scanned with "integration").
+func WithBuildTags(tags ...string) Option {
+	return func(c *Config) {
+		c.BuildTags = append(c.BuildTags, tags...)
+	}
+}
+
+// WithIncludeTests loads the _test.go files of the scanned packages, so that response
+// examples can reference variables declared in tests. Directives in test files are ignored.
+func WithIncludeTests(enabled bool) Option {
+	return func(c *Config) {
+		c.IncludeTests = enabled
+	}
+}
+
+// WithSchemaNaming sets the strategy for models of different packages sharing a name.
+func WithSchemaNaming(naming string) Option {
+	return func(c *Config) {
+		c.SchemaNaming = naming
+	}
+}
+
+// WithResolveExternal enables loading the dependency packages of referenced types
+// that aren't declared in the scanned packages.
+func WithResolveExternal(enabled bool) Option {
+	return func(c *Config) {
+		c.ResolveExternal = enabled
+	}
+}
+
+// WithKnownTypes sets qualified type names that are never resolved from dependency packages.
+func WithKnownTypes(typeNames ...string) Option {
+	return func(c *Config) {
+		c.KnownTypes = append(c.KnownTypes, typeNames...)
+	}
+}
+
+// WithRegistrations records the handlers registered with a literal path on routers, for
+// comparing them with the swagger:route directives. Registrations are not recorded by default.
+func WithRegistrations(enabled bool) Option {
+	return func(c *Config) {
+		c.ScanRegistrations = enabled
+	}
+}
+
+// WithHandlers records the exported functions with a handler signature, documented or not,
+// for reporting the documentation coverage. Handlers are not recorded by default.
+func WithHandlers(enabled bool) Option {
+	return func(c *Config) {
+		c.ScanHandlers = enabled
+	}
+}
+
 // Scanner scans Go source code for OpenAPI directives.
 type Scanner struct {
 	config *Config
@@ -55,6 +146,18 @@ type Scanner struct {
 	Enums   map[string]*EnumInfo
 	Structs map[string]*StructInfo
 	Routes  map[string]*RouteInfo
+	Headers map[string][]*StructInfo // operation ID -> swagger:headers structs
+	Paths   map[string]*PathInfo     // path -> swagger:path info
+	Groups  []*RouteGroupInfo        // swagger:group blocks, applied to the routes after scanning
+
+	// Registrations are the handlers registered on routers in the scanned code
+	Registrations []*RegistrationInfo
+	// Handlers are the exported functions of the scanned code with a handler signature
+	Handlers []*HandlerInfo
+
+	// Event data (swagger:channel, swagger:message)
+	Channels map[string]*ChannelInfo // operation ID -> swagger:channel info
+	Messages map[string]*MessageInfo // message name -> swagger:message info
 
 	// Type mappings
 	TypeToEnum   map[string]string // Go type name -> enum name
@@ -66,9 +169,30 @@ type Scanner struct {
 	StructSources map[string]string // struct name -> source file
 	RouteSources  map[string]string // operation ID -> source file
 
+	// Warnings collects directives skipped while scanning
+	Warnings []Warning
+
 	// Type info for resolving embedded types
 	typeInfo map[string]types.Object // Fully qualified type name -> types.Object
+
+	// plainStructs holds the structs without directives of the scanned files, by Go type
+	// name and package-qualified name (pkg.Type), to resolve the embedded ones
+	plainStructs map[string]*StructInfo
+	// underlyingTypes holds the basic types of named primitives loaded with ImportIR, in
+	// place of the type information of the packages
+	underlyingTypes map[string]string
+	// typeDocs holds the doc comments of the types without directives, by Go type name
+	// and package-qualified name (pkg.Type)
+	typeDocs map[string]string
 	pkgInfo  map[*ast.File]*packages.Package
+
+	// fileImports maps source files to their imports by package name or alias (with ResolveExternal)
+	fileImports map[string]map[string]string
+
+	// Model naming state
+	explicitNames  map[string]bool // Schema names given with swagger:model Name
+	collidedNames  map[string]bool // Schema names declared by models of several Go types
+	ambiguousTypes map[string]bool // Short Go type names of models declared in several packages
 }
 
 // New creates a new Scanner with the given options.
@@ -84,37 +208,86 @@ func New(options ...Option) *Scanner {
 	}
 
 	return &Scanner{
-		config:        config,
-		fset:          token.NewFileSet(),
-		Meta:          nil, // Will be set to first general meta
-		Metas:         []*MetaInfo{},
-		Enums:         make(map[string]*EnumInfo),
-		Structs:       make(map[string]*StructInfo),
-		Routes:        make(map[string]*RouteInfo),
-		TypeToEnum:    make(map[string]string),
-		TypeToStruct:  make(map[string]string),
-		TypeAliases:   make(map[string]string),
-		EnumSources:   make(map[string]string),
-		StructSources: make(map[string]string),
-		RouteSources:  make(map[string]string),
-		typeInfo:      make(map[string]types.Object),
-		pkgInfo:       make(map[*ast.File]*packages.Package),
+		config:         config,
+		fset:           token.NewFileSet(),
+		Meta:           nil, // Will be set to first general meta
+		Metas:          []*MetaInfo{},
+		Enums:          make(map[string]*EnumInfo),
+		Structs:        make(map[string]*StructInfo),
+		Routes:         make(map[string]*RouteInfo),
+		Headers:        make(map[string][]*StructInfo),
+		Paths:          make(map[string]*PathInfo),
+		Channels:       make(map[string]*ChannelInfo),
+		Messages:       make(map[string]*MessageInfo),
+		TypeToEnum:     make(map[string]string),
+		TypeToStruct:   make(map[string]string),
+		TypeAliases:    make(map[string]string),
+		EnumSources:    make(map[string]string),
+		StructSources:  make(map[string]string),
+		RouteSources:   make(map[string]string),
+		typeInfo:       make(map[string]types.Object),
+		plainStructs:   make(map[string]*StructInfo),
+		typeDocs:       make(map[string]string),
+		pkgInfo:        make(map[*ast.File]*packages.Package),
+		explicitNames:  make(map[string]bool),
+		collidedNames:  make(map[string]bool),
+		ambiguousTypes: make(map[string]bool),
+		fileImports:    make(map[string]map[string]string),
+	}
+}
+
+// WithWorkspace scans the modules of the go.work file in the scanned directory in one
+// run: the pattern is applied in each module directory, and the results are merged.
+func WithWorkspace(enabled bool) Option {
+	return func(c *Config) {
+		c.Workspace = enabled
+	}
+}
+
+// WithModulePatterns sets the package patterns of workspace modules, by module directory
+// (api, ./api) or module path, in place of the scan pattern. Patterns are relative to the
+// module directory; several patterns are separated by spaces.
+func WithModulePatterns(patterns map[string]string) Option {
+	return func(c *Config) {
+		if c.ModulePatterns == nil {
+			c.ModulePatterns = make(map[string]string, len(patterns))
+		}
+		maps.Copy(c.ModulePatterns, patterns)
 	}
 }
 
 // Scan scans all packages matching the configured pattern.
 func (s *Scanner) Scan() error {
-	cfg := &packages.Config{
-		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
-		Dir:   s.config.Dir,
-		Fset:  s.fset,
-		Tests: false,
+	return s.ScanContext(context.Background())
+}
+
+// ScanContext is like Scan but stops loading and processing packages when ctx is done.
+func (s *Scanner) ScanContext(ctx context.Context) error {
+	if err := ValidatePathPatterns(slices.Concat(s.config.IgnorePaths, s.config.OnlyPaths)...); err != nil {
+		return err
+	}
+
+	patterns := []string{s.config.Pattern}
+	if s.config.Workspace {
+		var err error
+		if patterns, err = s.workspacePatterns(); err != nil {
+			return err
+		}
 	}
 
-	pkgs, err := packages.Load(cfg, s.config.Pattern)
+	cfg := s.packagesConfig(ctx)
+	cfg.Tests = s.config.IncludeTests
+	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
+		// packages.Load does not wrap the context error
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		return err
 	}
+	if s.config.IncludeTests {
+		pkgs = testVariants(pkgs)
+	}
 
 	// First pass: collect type information (skip packages with errors)
 	for _, pkg := range pkgs {
@@ -125,7 +298,9 @@ func (s *Scanner) Scan() error {
 	}
 
 	// Second pass: process files
+	scanned := make(map[string]bool, len(pkgs))
 	for _, pkg := range pkgs {
+		scanned[pkg.PkgPath] = true
 		hasErrors := len(pkg.Errors) > 0
 
 		if shouldIgnorePath(pkg.PkgPath, s.config.IgnorePaths) {
@@ -133,14 +308,24 @@ func (s *Scanner) Scan() error {
 		}
 
 		for i, file := range pkg.Syntax {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			if i >= len(pkg.GoFiles) {
 				continue
 			}
 			filePath := pkg.GoFiles[i]
 
+			// Test files only provide example variables
+			if strings.HasSuffix(filePath, "_test.go") {
+				continue
+			}
 			if shouldIgnorePath(filePath, s.config.IgnorePaths) {
 				continue
 			}
+			if len(s.config.OnlyPaths) > 0 && !matchesPathPatterns(filePath, s.config.OnlyPaths) {
+				continue
+			}
 
 			s.pkgInfo[file] = pkg
 
@@ -154,18 +339,118 @@ func (s *Scanner) Scan() error {
 				continue
 			}
 
+			if s.config.ResolveExternal {
+				s.recordImports(filePath, file, pkg)
+			}
 			if err := s.processFile(filePath, file, pkg); err != nil {
 				return err
 			}
 		}
 	}
 
-	// Third pass: resolve embedded types
+	s.applyRouteGroups()
+
+	if s.config.ResolveExternal {
+		if err := s.resolveExternalTypes(ctx, scanned); err != nil {
+			return err
+		}
+	}
+
+	// Third pass: point ambiguous field types at the model of their package and resolve embedded types
+	s.qualifyAmbiguousTypes()
 	s.resolveEmbeddedTypes()
 
 	return nil
 }
 
+// packagesConfig returns the configuration for loading the packages to scan.
+func (s *Scanner) packagesConfig(ctx context.Context) *packages.Config {
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:     s.config.Dir,
+		Fset:    s.fset,
+		Tests:   false,
+	}
+	if len(s.config.BuildTags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(s.config.BuildTags, ",")}
+	}
+	if s.config.Workspace {
+		cfg.Env = s.workspaceEnv()
+	}
+	return cfg
+}
+
+// testVariants selects the packages to scan among packages loaded with their tests: a
+// package compiled with its _test.go files replaces the package itself, while external
+// test packages (package foo_test), generated test mains, and dependencies recompiled
+// for a test are dropped.
+func testVariants(pkgs []*packages.Package) []*packages.Package {
+	withTests := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if pkg.ID == pkg.PkgPath+" ["+pkg.PkgPath+".test]" {
+			withTests[pkg.PkgPath] = true
+		}
+	}
+
+	var selected []*packages.Package
+	for _, pkg := range pkgs {
+		if pkg.ID == pkg.PkgPath+" ["+pkg.PkgPath+".test]" || (pkg.ID == pkg.PkgPath && !withTests[pkg.PkgPath] && !strings.HasSuffix(pkg.PkgPath, ".test")) {
+			selected = append(selected, pkg)
+		}
+	}
+	return selected
+}
+
+// warn records a non-fatal problem found at a directive.
+func (s *Scanner) warn(code string, pos token.Position, format string, args ...any) {
+	s.Warnings = append(s.Warnings, Warning{
+		Code:    code,
+		Message: s.location(pos) + ": " + fmt.Sprintf(format, args...),
+		Pos:     pos,
+	})
+}
+
+// location formats a position as file:line, with the file relative to the scanned directory.
+func (s *Scanner) location(pos token.Position) string {
+	filePath := pos.Filename
+	if dir, err := filepath.Abs(s.config.Dir); err == nil {
+		if rel, err := filepath.Rel(dir, filePath); err == nil {
+			filePath = rel
+		}
+	}
+	return fmt.Sprintf("%s:%d", filePath, pos.Line)
+}
+
+// directivePos returns the position of the comment line holding a directive, or of the
+// comment group when no line starts with it.
+func (s *Scanner) directivePos(doc *ast.CommentGroup, directive string) token.Position {
+	if comment := directiveComment(doc, directive); comment != nil {
+		return s.fset.Position(comment.Pos())
+	}
+	return s.fset.Position(doc.Pos())
+}
+
+// directiveText returns the comment line holding a directive, without the comment marker,
+// to quote malformed directives in warnings.
+func directiveText(doc *ast.CommentGroup, directive string) string {
+	if comment := directiveComment(doc, directive); comment != nil {
+		return strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+	}
+	return directive
+}
+
+// directiveComment returns the comment line starting with a directive.
+func directiveComment(doc *ast.CommentGroup, directive string) *ast.Comment {
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if strings.HasPrefix(text, directive) {
+			return comment
+		}
+	}
+	return nil
+}
+
 // collectTypeInfo collects type information from a package.
 func (s *Scanner) collectTypeInfo(pkg *packages.Package) {
 	if pkg.TypesInfo == nil {
@@ -187,14 +472,7 @@ func (s *Scanner) collectTypeInfo(pkg *packages.Package) {
 
 // ScanFile scans a single Go source file.
 func (s *Scanner) ScanFile(filePath string) error {
-	cfg := &packages.Config{
-		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
-		Dir:   s.config.Dir,
-		Fset:  s.fset,
-		Tests: false,
-	}
-
-	pkgs, err := packages.Load(cfg, "file="+filePath)
+	pkgs, err := packages.Load(s.packagesConfig(context.Background()), "file="+filePath)
 	if err != nil {
 		return err
 	}
@@ -217,6 +495,9 @@ func (s *Scanner) ScanFile(filePath string) error {
 
 // processFile processes a single AST file.
 func (s *Scanner) processFile(filePath string, file *ast.File, pkg *packages.Package) error {
+	// Fold the multi-line examples before the directives are read
+	s.foldExampleBlocks(file)
+
 	// Process meta information
 	if err := s.processMeta(filePath, file); err != nil {
 		return err
@@ -231,15 +512,33 @@ func (s *Scanner) processFile(filePath string, file *ast.File, pkg *packages.Pac
 	s.processTypeAliases(filePath, file, pkg)
 
 	// Process schemas (models and parameters)
-	if err := s.processSchemas(filePath, file); err != nil {
+	if err := s.processSchemas(filePath, file, pkg); err != nil {
 		return err
 	}
 
 	// Process routes
-	if err := s.processRoutes(filePath, file); err != nil {
+	if err := s.processRoutes(filePath, file, pkg); err != nil {
+		return err
+	}
+
+	// Process route groups
+	if err := s.processGroups(filePath, file, pkg); err != nil {
 		return err
 	}
 
+	// Process event channels
+	if err := s.processChannels(filePath, file); err != nil {
+		return err
+	}
+
+	// Process router registrations and handlers, only needed by lint and coverage
+	if s.config.ScanRegistrations {
+		s.processRegistrations(filePath, file, pkg)
+	}
+	if s.config.ScanHandlers {
+		s.processHandlers(filePath, file, pkg)
+	}
+
 	return nil
 }
 
@@ -249,6 +548,14 @@ func (s *Scanner) resolveEmbeddedTypes() {
 	for _, structInfo := range s.Structs {
 		s.resolveEmbeddedTypesRecursive(structInfo, resolved)
 	}
+	for _, headers := range s.Headers {
+		for _, structInfo := range headers {
+			s.resolveEmbeddedTypesRecursive(structInfo, resolved)
+		}
+	}
+	for _, pathInfo := range s.Paths {
+		s.resolveEmbeddedTypesRecursive(pathInfo.Parameters, resolved)
+	}
 }
 
 // resolveEmbeddedTypesRecursive resolves embedded types recursively to handle nested embeds.
@@ -303,6 +610,24 @@ func (s *Scanner) resolveEmbeddedType(structInfo *StructInfo, embeddedInfo *Embe
 		return
 	}
 
+	// Parameter structs are named after their operations, look them up by Go type
+	if embedded, ok := s.Structs[s.TypeToStruct[embeddedTypeName]]; ok {
+		s.resolveEmbeddedTypesRecursive(embedded, resolved)
+		s.addEmbeddedFields(structInfo, embedded.Fields, baseIndex)
+		return
+	}
+
+	// Structs without directives, parsed with the directives of their field comments
+	embedded, ok := s.plainStructs[embeddedTypeName]
+	if !ok {
+		embedded, ok = s.plainStructs[shortName]
+	}
+	if ok {
+		s.resolveEmbeddedTypesRecursive(embedded, resolved)
+		s.addEmbeddedFields(structInfo, embedded.Fields, baseIndex)
+		return
+	}
+
 	// Try to resolve using type information
 	obj := s.typeInfo[embeddedTypeName]
 	if obj == nil {
@@ -331,8 +656,13 @@ func (s *Scanner) resolveEmbeddedType(structInfo *StructInfo, embeddedInfo *Embe
 
 // addEmbeddedFields adds embedded fields with proper index for ordering.
 // Uses fractional indexing (baseIndex + subIndex/1000) to maintain order.
+// As in Go, fields of the struct shadow the embedded fields of the same name, and the
+// first embedded struct wins over the following ones.
 func (s *Scanner) addEmbeddedFields(structInfo *StructInfo, fields []*FieldInfo, baseIndex int) {
 	for i, field := range fields {
+		if slices.ContainsFunc(structInfo.Fields, func(f *FieldInfo) bool { return f.Name == field.Name }) {
+			continue
+		}
 		// Create a copy of the field to avoid modifying the original
 		fieldCopy := *field
 		// Use fractional index: baseIndex.subIndex (e.g., 0.001, 0.002 for embedded at position 0)
@@ -394,7 +724,10 @@ func (s *Scanner) setFieldTypeFromTypesType(fieldInfo *FieldInfo, t types.Type)
 	case *types.Named:
 		fieldInfo.Type = typ.Obj().Name()
 	case *types.Pointer:
-		fieldInfo.IsPointer = true
+		// Pointers to array or map elements don't make the field a pointer
+		if !fieldInfo.IsArray && !fieldInfo.IsMap {
+			fieldInfo.IsPointer = true
+		}
 		s.setFieldTypeFromTypesType(fieldInfo, typ.Elem())
 	case *types.Slice:
 		fieldInfo.IsArray = true
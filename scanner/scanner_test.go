@@ -1,13 +1,17 @@
 package scanner
 
 import (
+	"context"
 	"go/ast"
+	"maps"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
 )
 
 // ==================== Scanner Configuration Tests ====================
@@ -69,6 +73,48 @@ func TestShouldIgnorePath(t *testing.T) {
 			ignorePaths: []string{"testdata"},
 			expected:    true,
 		},
+		{
+			name:        "glob matches nested directory",
+			path:        "/project/internal/users/mocks/store.go",
+			ignorePaths: []string{"internal/*/mocks"},
+			expected:    true,
+		},
+		{
+			name:        "glob doesn't match deeper nesting",
+			path:        "/project/internal/users/v2/mocks/store.go",
+			ignorePaths: []string{"internal/*/mocks"},
+			expected:    false,
+		},
+		{
+			name:        "double star matches any depth",
+			path:        "/project/internal/users/v2/mocks/store.go",
+			ignorePaths: []string{"internal/**/mocks"},
+			expected:    true,
+		},
+		{
+			name:        "glob matches file name",
+			path:        "/project/api/models_gen.go",
+			ignorePaths: []string{"*_gen.go"},
+			expected:    true,
+		},
+		{
+			name:        "glob matches package path",
+			path:        "example.com/project/internal/users/mocks",
+			ignorePaths: []string{"internal/*/mocks"},
+			expected:    true,
+		},
+		{
+			name:        "regex matches",
+			path:        "/project/api/v2/users.go",
+			ignorePaths: []string{`re:/v\d+/`},
+			expected:    true,
+		},
+		{
+			name:        "regex doesn't match",
+			path:        "/project/api/users.go",
+			ignorePaths: []string{`re:/v\d+/`},
+			expected:    false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -79,6 +125,18 @@ func TestShouldIgnorePath(t *testing.T) {
 	}
 }
 
+func TestValidatePathPatterns(t *testing.T) {
+	assert.NoError(t, ValidatePathPatterns("vendor", "internal/*/mocks", "re:_gen\\.go$"))
+
+	err := ValidatePathPatterns("re:(unclosed")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid path pattern "re:(unclosed"`)
+
+	err = ValidatePathPatterns("[a-")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid path pattern "[a-"`)
+}
+
 func TestGetCompiledRegex(t *testing.T) {
 	pattern := `swagger:\w+`
 
@@ -301,6 +359,41 @@ func TestExtractSpecs(t *testing.T) {
 	}
 }
 
+func TestExtractExtensions(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		expected map[string]any
+	}{
+		{
+			name:     "no extensions",
+			comments: []string{"Some description", "example: foo"},
+			expected: nil,
+		},
+		{
+			name:     "string, bool and number values",
+			comments: []string{"x-internal: true", "x-rate-limit: 100", "x-owner: team-a"},
+			expected: map[string]any{"x-internal": true, "x-rate-limit": float64(100), "x-owner": "team-a"},
+		},
+		{
+			name:     "json object value",
+			comments: []string{`x-kong-plugin: {"name": "cors"}`},
+			expected: map[string]any{"x-kong-plugin": map[string]any{"name": "cors"}},
+		},
+		{
+			name:     "invalid names are ignored",
+			comments: []string{"x-: value", "x-two words: value", "x-missing-colon"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, extractExtensions(tt.comments))
+		})
+	}
+}
+
 // ==================== Integration Tests ====================
 
 func createTestProject(t *testing.T, files map[string]string) string {
@@ -370,6 +463,27 @@ func ListUsers() {}
 	assert.Equal(t, "/users", route.Path)
 }
 
+func TestScanContextCanceled(t *testing.T) {
+	files := map[string]string{
+		"handlers.go": `package main
+
+// swagger:route GET /users users listUsers
+func ListUsers() {}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.ScanContext(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, s.Routes)
+}
+
 func TestScanMeta(t *testing.T) {
 	files := map[string]string{
 		"doc.go": `// swagger:meta
@@ -400,6 +514,70 @@ package main
 	assert.Equal(t, "1.0.0", s.Meta.Version)
 }
 
+func TestScanMetaServersAndPrefix(t *testing.T) {
+	files := map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: Admin API
+// Version: 1.0.0
+// StripPrefix: /admin
+// AddPrefix: /v1
+//
+// Servers:
+// - https://admin.example.com Admin API
+// - http://localhost:8080
+//
+// spec: admin
+package main
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+
+	err := s.Scan()
+
+	require.NoError(t, err)
+	require.Len(t, s.Metas, 1)
+	meta := s.Metas[0]
+	assert.Equal(t, "/admin", meta.StripPrefix)
+	assert.Equal(t, "/v1", meta.AddPrefix)
+	assert.Equal(t, []*ServerInfo{
+		{URL: "https://admin.example.com", Description: "Admin API"},
+		{URL: "http://localhost:8080"},
+	}, meta.Servers)
+	assert.Equal(t, []string{"admin"}, meta.Specs)
+}
+
+func TestScanMetaIncludes(t *testing.T) {
+	files := map[string]string{
+		"doc.go": `// swagger:meta
+//
+// Title: Admin API
+// Version: 1.0.0
+//
+// Security:
+// - bearer
+//
+// spec: admin
+// includes: Public mobile
+package main
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+
+	err := s.Scan()
+
+	require.NoError(t, err)
+	require.Len(t, s.Metas, 1)
+	meta := s.Metas[0]
+	assert.Equal(t, []string{"admin"}, meta.Specs)
+	assert.Equal(t, []string{"public", "mobile"}, meta.Includes)
+	assert.Equal(t, []string{"bearer"}, meta.Security)
+}
+
 func TestScanMultiSpec(t *testing.T) {
 	files := map[string]string{
 		"handlers/admin.go": `package handlers
@@ -461,6 +639,49 @@ const (
 	assert.Contains(t, enum.Values, "StatusInactive")
 }
 
+func TestScanIotaEnum(t *testing.T) {
+	files := map[string]string{
+		"models/priority.go": `package models
+
+// swagger:enum Priority
+type Priority int
+
+// Level is an alias of Priority.
+type Level = Priority
+
+const (
+	_ Priority = iota
+	PriorityLow
+	PriorityMedium
+	_
+	PriorityCritical
+)
+
+const (
+	LevelBase Level = iota + 10
+	LevelNext
+)
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+
+	err := s.Scan()
+
+	require.NoError(t, err)
+	require.Contains(t, s.Enums, "Priority")
+	enum := s.Enums["Priority"]
+	assert.Equal(t, "int", enum.BaseType)
+	assert.Equal(t, map[string]any{
+		"PriorityLow":      int64(1),
+		"PriorityMedium":   int64(2),
+		"PriorityCritical": int64(4),
+		"LevelBase":        int64(10),
+		"LevelNext":        int64(11),
+	}, enum.Values)
+}
+
 func TestScanParameters(t *testing.T) {
 	files := map[string]string{
 		"models/params.go": `package models
@@ -488,6 +709,76 @@ type ListUsersParams struct {
 	assert.True(t, params.IsParameter)
 }
 
+func TestScanParametersMultipleOperations(t *testing.T) {
+	files := map[string]string{
+		"models/params.go": `package models
+
+// Pagination has no directive, its fields keep the directives of their comments
+type Pagination struct {
+	// in: query
+	Page int ` + "`json:\"page\"`" + `
+}
+
+// swagger:parameters listUsers searchUsers
+type ListUsersParams struct {
+	// in: query
+	Query string ` + "`json:\"q\"`" + `
+	Pagination
+	// in: header
+	RequestID string ` + "`json:\"X-Request-ID\"`" + `
+}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+
+	require.NoError(t, s.Scan())
+	for _, operation := range []string{"listUsers", "searchUsers"} {
+		require.Contains(t, s.Structs, operation)
+		params := s.Structs[operation]
+		assert.True(t, params.IsParameter)
+		assert.Equal(t, operation, params.Name)
+
+		var names, ins []string
+		for _, field := range params.Fields {
+			names = append(names, field.Name)
+			ins = append(ins, field.In)
+		}
+		assert.Equal(t, []string{"Query", "Page", "RequestID"}, names)
+		assert.Equal(t, []string{"query", "query", "header"}, ins)
+	}
+
+	// Each operation gets its own copy of the fields
+	assert.NotSame(t, s.Structs["listUsers"].Fields[0], s.Structs["searchUsers"].Fields[0])
+}
+
+func TestScanParameterStyle(t *testing.T) {
+	files := map[string]string{
+		"models/params.go": `package models
+
+// swagger:parameters search
+type SearchParams struct {
+	// Tags to match
+	// style: pipeDelimited
+	// explode: false
+	Tags []string ` + "`json:\"tags\"`" + `
+}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+
+	require.NoError(t, s.Scan())
+	require.Contains(t, s.Structs, "search")
+	field := s.Structs["search"].Fields[0]
+	assert.Equal(t, "pipeDelimited", field.Style)
+	require.NotNil(t, field.Explode)
+	assert.False(t, *field.Explode)
+	assert.Equal(t, "Tags to match", field.Description)
+}
+
 func TestScanIgnorePaths(t *testing.T) {
 	files := map[string]string{
 		"api/handlers.go": `package api
@@ -513,3 +804,425 @@ func ListOther() {}
 	assert.Contains(t, s.Routes, "listUsers")
 	// vendor routes should be ignored
 }
+
+func TestScanOnlyPaths(t *testing.T) {
+	files := map[string]string{
+		"api/v1/handlers.go": `package v1
+// swagger:route GET /v1/users users listUsersV1
+func ListUsers() {}
+`,
+		"api/v2/handlers.go": `package v2
+// swagger:route GET /v2/users users listUsersV2
+func ListUsers() {}
+`,
+		"api/v2/handlers_gen.go": `package v2
+// swagger:route GET /v2/generated users listGenerated
+func ListGenerated() {}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	s := New(
+		WithDir(tmpDir),
+		WithPattern("./..."),
+		WithOnlyPaths("api/v2"),
+		WithIgnorePaths("*_gen.go"),
+	)
+
+	require.NoError(t, s.Scan())
+	assert.Contains(t, s.Routes, "listUsersV2")
+	assert.NotContains(t, s.Routes, "listUsersV1")
+	assert.NotContains(t, s.Routes, "listGenerated")
+}
+
+func TestScanBuildTags(t *testing.T) {
+	files := map[string]string{
+		"api/handlers.go": `package api
+
+// swagger:route GET /users users listUsers
+func ListUsers() {}
+`,
+		"api/integration.go": `//go:build integration
+
+package api
+
+// swagger:route POST /test/reset testing resetData
+func ResetData() {}
+`,
+		"api/production.go": `//go:build !integration
+
+package api
+
+// swagger:route GET /status status getStatus
+func GetStatus() {}
+`,
+	}
+
+	tests := []struct {
+		name     string
+		tags     []string
+		included []string
+		excluded []string
+	}{
+		{"no tags", nil, []string{"listUsers", "getStatus"}, []string{"resetData"}},
+		{"integration", []string{"integration"}, []string{"listUsers", "resetData"}, []string{"getStatus"}},
+	}
+
+	tmpDir := createTestProject(t, files)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(WithDir(tmpDir), WithPattern("./..."), WithBuildTags(tt.tags...))
+			require.NoError(t, s.Scan())
+			for _, opID := range tt.included {
+				assert.Contains(t, s.Routes, opID)
+			}
+			for _, opID := range tt.excluded {
+				assert.NotContains(t, s.Routes, opID)
+			}
+		})
+	}
+}
+
+func TestScanPositions(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/doc.go": `// Package api is the API.
+//
+// swagger:meta
+// Title: Users
+package api
+`,
+		"api/users.go": `package api
+
+// Role of a user.
+// swagger:enum Role
+type Role string
+
+const RoleAdmin Role = "admin"
+
+// A user.
+//
+// swagger:model User
+type User struct {
+	Role Role ` + "`json:\"role\"`" + `
+}
+
+// swagger:parameters getUser
+type GetUserParams struct {
+	// in: path
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// Get a user.
+//
+// swagger:route GET /users/{id} users getUser
+// Responses:
+// - 200: User
+func GetUser() {}
+
+// swagger:route GET /users
+func ListUsers() {}
+`,
+	})
+
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+	require.NoError(t, s.Scan())
+
+	usersFile := filepath.Join(tmpDir, "api", "users.go")
+	assert.Equal(t, 3, s.Meta.Pos.Line)
+	assert.Equal(t, 4, s.Enums["Role"].Pos.Line)
+	assert.Equal(t, 11, s.Structs["User"].Pos.Line)
+	assert.Equal(t, 16, s.Structs["getUser"].Pos.Line)
+	assert.Equal(t, usersFile, s.Routes["getUser"].Pos.Filename)
+	assert.Equal(t, 24, s.Routes["getUser"].Pos.Line)
+	assert.Equal(t, 1, s.Routes["getUser"].Pos.Column)
+
+	require.Len(t, s.Warnings, 1)
+	assert.Equal(t, 29, s.Warnings[0].Pos.Line)
+	assert.True(t, strings.HasPrefix(s.Warnings[0].Message, filepath.Join("api", "users.go")+":29: "), s.Warnings[0].Message)
+}
+
+func TestTestVariants(t *testing.T) {
+	pkgs := []*packages.Package{
+		{ID: "example.com/app/api", PkgPath: "example.com/app/api"},
+		{ID: "example.com/app/api [example.com/app/api.test]", PkgPath: "example.com/app/api"},
+		{ID: "example.com/app/api_test [example.com/app/api.test]", PkgPath: "example.com/app/api_test"},
+		{ID: "example.com/app/api.test", PkgPath: "example.com/app/api.test"},
+		{ID: "example.com/app/models", PkgPath: "example.com/app/models"},
+		{ID: "example.com/app/models [example.com/app/api.test]", PkgPath: "example.com/app/models"},
+	}
+
+	var ids []string
+	for _, pkg := range testVariants(pkgs) {
+		ids = append(ids, pkg.ID)
+	}
+	assert.Equal(t, []string{
+		"example.com/app/api [example.com/app/api.test]",
+		"example.com/app/models",
+	}, ids)
+}
+
+func TestScanInvalidPathPattern(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{"api/handlers.go": "package api\n"})
+
+	s := New(WithDir(tmpDir), WithPattern("./..."), WithIgnorePaths("re:[z-a]"))
+	err := s.Scan()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid path pattern "re:[z-a]"`)
+}
+
+func TestScanFragments(t *testing.T) {
+	files := map[string]string{
+		"doc.go": `// swagger:meta
+// Title: Payments API
+// Version: 1.0.0
+// swagger:include fragments/errors.yaml
+package main
+`,
+		"handlers/payments.go": `package handlers
+
+// swagger:route GET /payments payments listPayments
+// swagger:include ../fragments/payments.yaml /etc/openapi/shared.yaml
+// Responses:
+// - 200: description:OK
+func ListPayments() {}
+`,
+	}
+
+	tmpDir := createTestProject(t, files)
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+
+	require.NoError(t, s.Scan())
+	require.NotNil(t, s.Meta)
+	assert.Equal(t, []string{filepath.Join(tmpDir, "fragments", "errors.yaml")}, s.Meta.Fragments)
+
+	route := s.Routes["listPayments"]
+	require.NotNil(t, route)
+	assert.Equal(t, []string{filepath.Join(tmpDir, "fragments", "payments.yaml"), "/etc/openapi/shared.yaml"}, route.Fragments)
+	assert.Empty(t, route.Description)
+}
+
+func TestScanResponseStatusCodes(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"handlers/users.go": `package handlers
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+// - 2xx: description:Other successes
+// - 4XX: description:Client errors
+// - Default: description:Unexpected
+func ListUsers() {}
+`,
+	})
+
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+	require.NoError(t, s.Scan())
+
+	route := s.Routes["listUsers"]
+	require.NotNil(t, route)
+
+	var codes []string
+	for _, resp := range route.Responses {
+		codes = append(codes, resp.StatusCode)
+	}
+	assert.Equal(t, []string{"200", "2XX", "4XX", "default"}, codes)
+}
+
+func TestScanInvalidResponseStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"letter typo", "20O"},
+		{"out of range", "600"},
+		{"two digits", "20"},
+		{"partial range", "2X0"},
+		{"word", "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestProject(t, map[string]string{
+				"handlers/users.go": `package handlers
+
+// swagger:route GET /users users listUsers
+// Responses:
+// - 200: description:OK
+// - ` + tt.code + `: description:Broken
+func ListUsers() {}
+`,
+			})
+
+			s := New(WithDir(tmpDir), WithPattern("./..."))
+			err := s.Scan()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), filepath.Join("handlers", "users.go")+":6: operation listUsers: invalid response status code \""+tt.code+"\"")
+		})
+	}
+}
+
+func TestScanResolveUnderlyingType(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"models/ids.go": `package models
+
+type UserID int64
+type Flags byte
+type Ratio float32
+type Label string
+type Age = int
+type Owner = User
+type Nested UserID
+
+type User struct {
+	ID UserID
+}
+
+type Tags []string
+type Members []*User
+type Digest [32]byte
+type Labels map[string]string
+type Raw []byte
+`,
+	})
+
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+	require.NoError(t, s.Scan())
+
+	tests := []struct {
+		typeName string
+		expected string
+	}{
+		{"UserID", "int64"},
+		{"models.UserID", "int64"},
+		{"Flags", "uint8"},
+		{"Ratio", "float32"},
+		{"Label", "string"},
+		{"Age", "int"},
+		{"Owner", "models.User"},
+		{"Nested", "int64"},
+		{"User", ""},
+		{"Tags", "[]string"},
+		{"models.Tags", "[]string"},
+		{"Members", "[]*models.User"},
+		{"Digest", "[32]byte"},
+		{"Labels", "map[string]string"},
+		{"Raw", "[]byte"},
+		{"other.UserID", ""},
+		{"Unknown", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, s.ResolveUnderlyingType(tt.typeName))
+		})
+	}
+}
+
+// collidingModels declares an Error model in two packages, each used by a local model.
+var collidingModels = map[string]string{
+	"billing/models.go": `package billing
+
+// swagger:model
+type Error struct {
+	Code int ` + "`json:\"code\"`" + `
+}
+
+// swagger:model
+type Invoice struct {
+	Err Error ` + "`json:\"err\"`" + `
+}
+`,
+	"auth/models.go": `package auth
+
+// swagger:model
+type Error struct {
+	Reason string ` + "`json:\"reason\"`" + `
+}
+
+// swagger:model
+type Session struct {
+	Errors []Error ` + "`json:\"errors\"`" + `
+}
+`,
+}
+
+func TestScanModelNameCollision(t *testing.T) {
+	tmpDir := createTestProject(t, collidingModels)
+
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+	err := s.Scan()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "model Error of testproject/")
+	assert.Contains(t, err.Error(), "name one of them with swagger:model <Name>")
+}
+
+func TestScanDuplicateOperationID(t *testing.T) {
+	tmpDir := createTestProject(t, map[string]string{
+		"api/users.go": `package api
+
+// swagger:route GET /users users listUsers
+func ListUsers() {}
+`,
+		"api/legacy.go": `package api
+
+// Legacy listing.
+//
+// swagger:route GET /v1/users users listUsers
+func ListLegacyUsers() {}
+`,
+	})
+
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+	err := s.Scan()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join("api", "users.go")+":3: operation ID listUsers of GET /users is already used by GET /v1/users declared at "+filepath.Join("api", "legacy.go")+":5")
+}
+
+func TestScanSchemaNaming(t *testing.T) {
+	tests := []struct {
+		naming      string
+		billingName string
+		authName    string
+	}{
+		{SchemaNamingPackage, "BillingError", "AuthError"},
+		{SchemaNamingHash, "Error_bc57adf2", "Error_0541709d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.naming, func(t *testing.T) {
+			tmpDir := createTestProject(t, collidingModels)
+
+			s := New(WithDir(tmpDir), WithPattern("./..."), WithSchemaNaming(tt.naming))
+			require.NoError(t, s.Scan())
+
+			assert.NotContains(t, s.Structs, "Error")
+			require.Contains(t, s.Structs, tt.billingName)
+			require.Contains(t, s.Structs, tt.authName)
+			assert.Equal(t, "testproject/billing", s.Structs[tt.billingName].PkgPath)
+			assert.Equal(t, "testproject/auth", s.Structs[tt.authName].PkgPath)
+
+			// Fields of each package point at their own Error model
+			assert.Equal(t, tt.billingName, s.Structs["Invoice"].Fields[0].Type)
+			assert.Equal(t, tt.authName, s.Structs["Session"].Fields[0].Type)
+
+			// Qualified type names resolve to the renamed models
+			assert.Equal(t, tt.billingName, s.TypeToStruct["billing.Error"])
+			assert.Equal(t, tt.authName, s.TypeToStruct["testproject/auth.Error"])
+			assert.NotContains(t, s.TypeToStruct, "Error")
+		})
+	}
+}
+
+func TestScanSchemaNamingExplicitName(t *testing.T) {
+	files := maps.Clone(collidingModels)
+	files["auth/models.go"] = strings.Replace(files["auth/models.go"], "// swagger:model\ntype Error", "// swagger:model AuthFailure\ntype Error", 1)
+	tmpDir := createTestProject(t, files)
+
+	s := New(WithDir(tmpDir), WithPattern("./..."))
+	require.NoError(t, s.Scan())
+
+	assert.Equal(t, "testproject/billing", s.Structs["Error"].PkgPath)
+	assert.Equal(t, "testproject/auth", s.Structs["AuthFailure"].PkgPath)
+	assert.Equal(t, "Error", s.Structs["Invoice"].Fields[0].Type)
+	assert.Equal(t, "AuthFailure", s.Structs["Session"].Fields[0].Type)
+}
@@ -3,11 +3,17 @@ package scanner
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
 	"strings"
+
+	"golang.org/x/tools/go/packages"
 )
 
-// processSchemas processes swagger:model, swagger:parameters, swagger:oneOf, and swagger:anyOf directives.
-func (s *Scanner) processSchemas(filePath string, file *ast.File) error {
+// processSchemas processes swagger:model, swagger:parameters, swagger:headers, swagger:oneOf, swagger:anyOf,
+// and swagger:message directives.
+func (s *Scanner) processSchemas(filePath string, file *ast.File, pkg *packages.Package) error {
 	for _, decl := range file.Decls {
 		genDecl, ok := decl.(*ast.GenDecl)
 		if !ok || genDecl.Tok != token.TYPE {
@@ -20,10 +26,25 @@ func (s *Scanner) processSchemas(filePath string, file *ast.File) error {
 				continue
 			}
 
+			if !hasDirective(genDecl.Doc, SwaggerPrefix) {
+				s.addPlainStruct(filePath, file, typeSpec)
+				s.addTypeDoc(file, genDecl, typeSpec)
+			}
+
 			if genDecl.Doc == nil {
 				continue
 			}
 
+			if hasDirective(genDecl.Doc, HeadersDirective) {
+				s.processHeaders(filePath, typeSpec, genDecl.Doc)
+				continue
+			}
+
+			if hasDirective(genDecl.Doc, PathDirective) {
+				s.processPath(filePath, typeSpec, genDecl.Doc, pkg)
+				continue
+			}
+
 			var name string
 			var isParameter, isModel, isOneOfModel, isAnyOfModel bool
 
@@ -32,6 +53,12 @@ func (s *Scanner) processSchemas(filePath string, file *ast.File) error {
 				isModel = true
 			}
 
+			// Message payloads are models too; the message is named by the directive or the type
+			isMessage := hasDirective(genDecl.Doc, MessageDirective)
+			if isMessage {
+				isModel = true
+			}
+
 			if hasDirective(genDecl.Doc, ParameterDirective) {
 				name = extractDirectiveValue(genDecl.Doc, ParameterDirective)
 				isParameter = true
@@ -61,18 +88,23 @@ func (s *Scanner) processSchemas(filePath string, file *ast.File) error {
 				continue
 			}
 
-			if name == "" {
+			explicit := name != ""
+			if !explicit {
 				name = typeSpec.Name.Name
 			}
 
 			// List of directives to exclude from description
 			descExclude := []string{
 				SwaggerPrefix, OneOfDirective, AllOfDirective, AnyOfDirective,
-				SpecDirective, DiscriminatorDirective,
+				SpecDirective, DiscriminatorDirective, ExtensionPrefix, InternalDirective,
+				AdditionalPropertiesDirective, MinPropertiesDirective, MaxPropertiesDirective, PropertyNamesDirective,
+				ExampleDirective,
 			}
 
 			structInfo := &StructInfo{
 				Name:         name,
+				GoType:       typeSpec.Name.Name,
+				PkgName:      file.Name.Name,
 				Fields:       []*FieldInfo{},
 				Description:  extractDescription(genDecl.Doc, descExclude),
 				IsParameter:  isParameter,
@@ -80,10 +112,21 @@ func (s *Scanner) processSchemas(filePath string, file *ast.File) error {
 				IsOneOfModel: isOneOfModel,
 				IsAnyOfModel: isAnyOfModel,
 				SourceFile:   filePath,
+				Pos:          s.directivePos(genDecl.Doc, SwaggerPrefix),
 				OneOf:        extractCompositionSchemas(genDecl.Doc, OneOfDirective),
 				AllOf:        extractCompositionSchemas(genDecl.Doc, AllOfDirective),
 				AnyOf:        extractCompositionSchemas(genDecl.Doc, AnyOfDirective),
 				Specs:        extractSpecs(genDecl.Doc),
+				Internal:     extractDirectiveValue(genDecl.Doc, InternalDirective) == "true",
+				Extensions:   extractExtensions(trimComments(genDecl.Doc)),
+			}
+
+			if allowed, err := strconv.ParseBool(extractDirectiveValue(genDecl.Doc, AdditionalPropertiesDirective)); err == nil {
+				structInfo.AdditionalProperties = &allowed
+			}
+			structInfo.Validations = extractObjectConstraints(genDecl.Doc, nil)
+			if example := extractDirectiveValue(genDecl.Doc, ExampleDirective); example != "" {
+				structInfo.Example = parseExampleValue(example)
 			}
 
 			// Extract discriminator if present
@@ -117,14 +160,148 @@ func (s *Scanner) processSchemas(filePath string, file *ast.File) error {
 				structInfo.ElementType = extractTypeName(t)
 			}
 
-			s.Structs[name] = structInfo
-			s.TypeToStruct[typeSpec.Name.Name] = name
-			s.StructSources[name] = filePath
+			if pkg != nil {
+				structInfo.PkgPath = pkg.PkgPath
+			}
+			// swagger:parameters listUsers getUser applies the struct to each operation
+			if operations := strings.Fields(name); isParameter && len(operations) > 1 {
+				for _, opID := range operations {
+					if err := s.registerStruct(structInfo.withName(opID), explicit); err != nil {
+						return err
+					}
+				}
+			} else if err := s.registerStruct(structInfo, explicit); err != nil {
+				return err
+			}
+			if isMessage {
+				if err := s.addMessage(filePath, typeSpec, genDecl.Doc, structInfo); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
 }
 
+// addPlainStruct records a struct without swagger directives, which the structs
+// embedding it are composed of.
+func (s *Scanner) addPlainStruct(filePath string, file *ast.File, typeSpec *ast.TypeSpec) {
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		return
+	}
+
+	structInfo := &StructInfo{
+		Name:           file.Name.Name + "." + typeSpec.Name.Name,
+		GoType:         typeSpec.Name.Name,
+		PkgName:        file.Name.Name,
+		Fields:         []*FieldInfo{},
+		SourceFile:     filePath,
+		UnderlyingKind: KindStruct,
+	}
+	processStructFields(structInfo, structType)
+
+	s.plainStructs[structInfo.Name] = structInfo
+	s.plainStructs[typeSpec.Name.Name] = structInfo
+}
+
+// addTypeDoc records the doc comment of a type declared without directives, the
+// description fallback of the fields of that type.
+func (s *Scanner) addTypeDoc(file *ast.File, genDecl *ast.GenDecl, typeSpec *ast.TypeSpec) {
+	// The doc comment of a grouped declaration documents the group
+	doc := typeSpec.Doc
+	if doc == nil && !genDecl.Lparen.IsValid() {
+		doc = genDecl.Doc
+	}
+	description := extractDescription(doc, []string{"go:"})
+	if description == "" {
+		return
+	}
+
+	s.typeDocs[file.Name.Name+"."+typeSpec.Name.Name] = description
+	s.typeDocs[typeSpec.Name.Name] = description
+}
+
+// TypeDescription returns the doc comment of a type declared without directives in the
+// scanned packages, by short or package-qualified name. Returns an empty string if the
+// type is unknown or undocumented.
+func (s *Scanner) TypeDescription(typeName string) string {
+	if description, ok := s.typeDocs[typeName]; ok {
+		return description
+	}
+	if _, shortName, qualified := strings.Cut(typeName, "."); qualified {
+		return s.typeDocs[shortName]
+	}
+	return ""
+}
+
+// processHeaders processes a swagger:headers struct.
+// Format: swagger:headers operationID [operationID...]
+func (s *Scanner) processHeaders(filePath string, typeSpec *ast.TypeSpec, doc *ast.CommentGroup) {
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		return
+	}
+	if strings.TrimSpace(extractDirectiveValue(doc, HeadersDirective)) == "" {
+		s.warn(WarnInvalidHeaders, s.directivePos(doc, HeadersDirective),
+			"%s: missing operation IDs, expected %s operationID [operationID...]; headers skipped", directiveText(doc, HeadersDirective), HeadersDirective)
+		return
+	}
+
+	structInfo := &StructInfo{
+		Name:           typeSpec.Name.Name,
+		Fields:         []*FieldInfo{},
+		Description:    extractDescription(doc, []string{SwaggerPrefix}),
+		IsHeaders:      true,
+		Operations:     strings.Fields(extractDirectiveValue(doc, HeadersDirective)),
+		SourceFile:     filePath,
+		Pos:            s.directivePos(doc, HeadersDirective),
+		UnderlyingKind: KindStruct,
+	}
+	processStructFields(structInfo, structType)
+
+	for _, opID := range structInfo.Operations {
+		// Replace the struct when the same file is scanned again
+		headers := slices.DeleteFunc(s.Headers[opID], func(h *StructInfo) bool {
+			return h.Name == structInfo.Name && h.SourceFile == filePath
+		})
+		s.Headers[opID] = append(headers, structInfo)
+	}
+}
+
+// processPath processes a swagger:path struct.
+// Format: swagger:path /path/{param}
+func (s *Scanner) processPath(filePath string, typeSpec *ast.TypeSpec, doc *ast.CommentGroup, pkg *packages.Package) {
+	path, _ := parsePathTemplate(extractDirectiveValue(doc, PathDirective))
+	if !strings.HasPrefix(path, "/") {
+		s.warn(WarnInvalidPath, s.directivePos(doc, PathDirective),
+			"%s: path %q does not start with /, expected %s /path; path skipped", directiveText(doc, PathDirective), path, PathDirective)
+		return
+	}
+
+	params := &StructInfo{
+		Name:           typeSpec.Name.Name,
+		Fields:         []*FieldInfo{},
+		SourceFile:     filePath,
+		UnderlyingKind: KindStruct,
+	}
+	if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+		processStructFields(params, structType)
+	}
+	if pkg != nil {
+		params.PkgPath = pkg.PkgPath
+	}
+
+	s.Paths[path] = &PathInfo{
+		Path:        path,
+		Summary:     extractDirectiveValue(doc, SummaryFieldDirective),
+		Description: extractDescription(doc, []string{SwaggerPrefix, SummaryFieldDirective}),
+		Parameters:  params,
+		SourceFile:  filePath,
+		Pos:         s.directivePos(doc, PathDirective),
+	}
+}
+
 // extractDiscriminator extracts discriminator configuration from comments.
 // Only extracts the property name; mapping is built from field-level directives.
 func extractDiscriminator(doc *ast.CommentGroup) *DiscriminatorInfo {
@@ -367,7 +544,10 @@ func determineFieldType(fieldInfo *FieldInfo, expr ast.Expr) {
 	case *ast.Ident:
 		fieldInfo.Type = t.Name
 	case *ast.StarExpr:
-		fieldInfo.IsPointer = true
+		// Pointers to array or map elements don't make the field a pointer
+		if !fieldInfo.IsArray && !fieldInfo.IsMap {
+			fieldInfo.IsPointer = true
+		}
 		determineFieldType(fieldInfo, t.X)
 	case *ast.ArrayType:
 		fieldInfo.IsArray = true
@@ -387,6 +567,9 @@ func determineFieldType(fieldInfo *FieldInfo, expr ast.Expr) {
 		fieldInfo.Type = "object"
 		fieldInfo.IsInlineStruct = true
 		fieldInfo.InlineStruct = processInlineStruct(fieldInfo.Name, t)
+	default:
+		// interface{}, func, and chan types keep their declaration for the schema and warnings
+		fieldInfo.Type = types.ExprString(expr)
 	}
 }
 
@@ -495,6 +678,7 @@ var knownFieldDirectives = []string{
 	SwaggerPrefix,
 	ExampleDirective,
 	DefaultDirective,
+	ConstDirective,
 	RequiredDirective,
 	NullableDirective,
 	FormatDirective,
@@ -507,12 +691,18 @@ var knownFieldDirectives = []string{
 	MinItemsDirective,
 	MaxItemsDirective,
 	UniqueItemsDirective,
+	MinPropertiesDirective,
+	MaxPropertiesDirective,
+	PropertyNamesDirective,
 	ReadOnlyDirective,
 	WriteOnlyDirective,
+	StyleDirective,
+	ExplodeDirective,
 	IgnoreDirective,
 	OneOfDirective,
 	AllOfDirective,
 	AnyOfDirective,
+	ExtensionPrefix,
 }
 
 // parseFieldDoc parses documentation comments for a field.
@@ -529,8 +719,9 @@ func parseFieldDoc(fieldInfo *FieldInfo, doc *ast.CommentGroup) {
 	comments := trimComments(doc)
 
 	// Extract single-line directive values
-	fieldInfo.Example = extractDirectiveValue(doc,ExampleDirective)
+	fieldInfo.Example = exampleText(extractDirectiveValue(doc, ExampleDirective))
 	fieldInfo.Default = extractDirectiveValue(doc,DefaultDirective)
+	fieldInfo.Const = extractDirectiveValue(doc, ConstDirective)
 
 	// Handle required directive
 	if requiredValue := extractDirectiveValue(doc,RequiredDirective); requiredValue != "" {
@@ -545,8 +736,11 @@ func parseFieldDoc(fieldInfo *FieldInfo, doc *ast.CommentGroup) {
 	}
 
 	// Extract nullable directive
-	if nullableVal := extractDirectiveValue(doc,NullableDirective); nullableVal == "true" {
+	switch extractDirectiveValue(doc, NullableDirective) {
+	case "true":
 		fieldInfo.Nullable = true
+	case "false":
+		fieldInfo.ExplicitNotNull = true
 	}
 
 	// Extract format directive
@@ -599,6 +793,9 @@ func parseFieldDoc(fieldInfo *FieldInfo, doc *ast.CommentGroup) {
 		fieldInfo.Validations["uniqueItems"] = "true"
 	}
 
+	// Extract object constraints (map fields)
+	fieldInfo.Validations = extractObjectConstraints(doc, fieldInfo.Validations)
+
 	// Extract read/write constraints
 	if hasDirective(doc, ReadOnlyDirective) {
 		fieldInfo.Validations["readOnly"] = "true"
@@ -607,6 +804,15 @@ func parseFieldDoc(fieldInfo *FieldInfo, doc *ast.CommentGroup) {
 		fieldInfo.Validations["writeOnly"] = "true"
 	}
 
+	// Extract parameter serialization
+	fieldInfo.Style = extractDirectiveValue(doc, StyleDirective)
+	if explode, err := strconv.ParseBool(extractDirectiveValue(doc, ExplodeDirective)); err == nil {
+		fieldInfo.Explode = &explode
+	}
+
+	// Extract vendor extensions (x-name: value)
+	fieldInfo.Extensions = extractExtensions(comments)
+
 	// Extract description (non-directive lines)
 	fieldInfo.Description = extractFieldDescription(comments, knownFieldDirectives)
 }
@@ -1,7 +1,12 @@
 package scanner
 
 import (
+	"encoding/json"
+	"fmt"
 	"go/ast"
+	"os"
+	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
@@ -21,16 +26,81 @@ func getCompiledRegex(pattern string) *regexp.Regexp {
 	return re
 }
 
+// RegexPathPrefix marks a path pattern as a regular expression (e.g., "re:_gen\\.go$").
+const RegexPathPrefix = "re:"
+
 // shouldIgnorePath checks if a path should be ignored based on ignore patterns.
 func shouldIgnorePath(path string, ignorePaths []string) bool {
-	for _, pattern := range ignorePaths {
-		if strings.Contains(path, pattern) {
-			return true
+	return matchesPathPatterns(path, ignorePaths)
+}
+
+// matchesPathPatterns reports whether a file or package path matches any of the patterns. A pattern is
+// a regular expression with the "re:" prefix, a glob when it contains *, ?, or [, and a
+// substring otherwise. Globs match consecutive path elements anywhere in the path, with
+// ** matching any number of elements (e.g., "internal/*/mocks" or "*_gen.go").
+func matchesPathPatterns(name string, patterns []string) bool {
+	name = filepath.ToSlash(name)
+	for _, pattern := range patterns {
+		if expr, ok := strings.CutPrefix(pattern, RegexPathPrefix); ok {
+			if getCompiledRegex(expr).MatchString(name) {
+				return true
+			}
+			continue
+		}
+		if !strings.ContainsAny(pattern, "*?[") {
+			if strings.Contains(name, pattern) {
+				return true
+			}
+			continue
+		}
+
+		elems := strings.Split(name, "/")
+		patternElems := strings.Split(strings.Trim(pattern, "/"), "/")
+		for start := range elems {
+			if matchPathElems(patternElems, elems[start:]) {
+				return true
+			}
 		}
 	}
 	return false
 }
 
+// matchPathElems reports whether the leading path elements match the glob elements.
+func matchPathElems(patternElems, elems []string) bool {
+	if len(patternElems) == 0 {
+		return true
+	}
+	if patternElems[0] == "**" {
+		for i := 0; i <= len(elems); i++ {
+			if matchPathElems(patternElems[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elems) == 0 {
+		return false
+	}
+	matched, _ := path.Match(patternElems[0], elems[0])
+	return matched && matchPathElems(patternElems[1:], elems[1:])
+}
+
+// ValidatePathPatterns reports malformed regular expressions and globs among path patterns.
+func ValidatePathPatterns(patterns ...string) error {
+	for _, pattern := range patterns {
+		if expr, ok := strings.CutPrefix(pattern, RegexPathPrefix); ok {
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+			}
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
 // hasDirective checks if a comment group contains a specific directive.
 func hasDirective(doc *ast.CommentGroup, directive string) bool {
 	if doc == nil {
@@ -66,6 +136,29 @@ func extractDirectiveValue(doc *ast.CommentGroup, directive string) string {
 	return ""
 }
 
+// objectConstraintDirectives maps the object constraint directives to their validation keys.
+var objectConstraintDirectives = map[string]string{
+	MinPropertiesDirective: "minProperties",
+	MaxPropertiesDirective: "maxProperties",
+	PropertyNamesDirective: "propertyNames",
+}
+
+// extractObjectConstraints extracts the object constraints (minProperties, maxProperties,
+// propertyNames) of a model or map field into validations, allocating it when needed.
+func extractObjectConstraints(doc *ast.CommentGroup, validations map[string]string) map[string]string {
+	for directive, key := range objectConstraintDirectives {
+		value := extractDirectiveValue(doc, directive)
+		if value == "" {
+			continue
+		}
+		if validations == nil {
+			validations = make(map[string]string)
+		}
+		validations[key] = value
+	}
+	return validations
+}
+
 // trimComments extracts all comment lines, removing comment markers.
 func trimComments(doc *ast.CommentGroup) []string {
 	if doc == nil {
@@ -168,6 +261,57 @@ func extractSectionLines(doc *ast.CommentGroup, directive string) []string {
 // Format: spec: name1 name2 name3
 // Returns nil if no spec directive is found.
 func extractSpecs(doc *ast.CommentGroup) []string {
+	return extractSpecNames(doc, SpecDirective)
+}
+
+// extractIncludes extracts the spec names from the "includes:" directive of a meta.
+// Format: includes: name1 name2
+// Returns nil if no includes directive is found.
+func extractIncludes(doc *ast.CommentGroup) []string {
+	return extractSpecNames(doc, IncludesDirective)
+}
+
+// extractFragments extracts the fragment files of the "swagger:include" directives in comments.
+// Format: swagger:include ./fragments/payments.yaml
+// Relative paths are resolved against the directory of the source file.
+func extractFragments(doc *ast.CommentGroup, filePath string) []string {
+	var fragments []string
+	for _, comment := range trimComments(doc) {
+		value, ok := strings.CutPrefix(comment, FragmentDirective)
+		if !ok || value == "" || value[0] != ' ' && value[0] != '\t' {
+			continue
+		}
+		for _, path := range strings.Fields(value) {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(filepath.Dir(filePath), path)
+			}
+			fragments = append(fragments, path)
+		}
+	}
+	return fragments
+}
+
+// loadDescriptionFile returns the content of the markdown file a description refers to
+// with the file: prefix, relative to the source file. Other descriptions are returned as is.
+func loadDescriptionFile(description, filePath string) (string, error) {
+	path, ok := strings.CutPrefix(description, DescriptionFilePrefix)
+	if !ok {
+		return description, nil
+	}
+	path = strings.TrimSpace(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(filePath), path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read description file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// extractSpecNames extracts the lowercase, space-separated spec names of a directive.
+func extractSpecNames(doc *ast.CommentGroup, directive string) []string {
 	if doc == nil {
 		return nil
 	}
@@ -178,7 +322,7 @@ func extractSpecs(doc *ast.CommentGroup) []string {
 		text = strings.TrimSuffix(text, "*/")
 		text = strings.TrimSpace(text)
 
-		if value, ok := strings.CutPrefix(strings.ToLower(text), SpecDirective); ok {
+		if value, ok := strings.CutPrefix(strings.ToLower(text), directive); ok {
 			value = strings.TrimSpace(value)
 
 			if value == "" {
@@ -197,3 +341,44 @@ func extractSpecs(doc *ast.CommentGroup) []string {
 
 	return nil
 }
+
+// extractExtensions extracts vendor extensions from comment lines.
+// Format: x-name: value
+// Values are decoded as JSON when possible (numbers, booleans, arrays, objects),
+// otherwise they are kept as plain strings.
+// Returns nil if no extension is found.
+func extractExtensions(comments []string) map[string]any {
+	var extensions map[string]any
+	for _, comment := range comments {
+		name, value, ok := parseExtensionLine(comment)
+		if !ok {
+			continue
+		}
+		if extensions == nil {
+			extensions = make(map[string]any)
+		}
+		extensions[name] = value
+	}
+	return extensions
+}
+
+// parseExtensionLine parses a single "x-name: value" line.
+func parseExtensionLine(line string) (string, any, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, ExtensionPrefix) {
+		return "", nil, false
+	}
+
+	name, raw, found := strings.Cut(line, ":")
+	name = strings.TrimSpace(name)
+	if !found || name == ExtensionPrefix || strings.ContainsAny(name, " \t") {
+		return "", nil, false
+	}
+
+	raw = strings.TrimSpace(raw)
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		value = raw
+	}
+	return name, value, true
+}
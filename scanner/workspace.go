@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// WorkspaceFile is the name of the Go workspace file scanned with WithWorkspace.
+const WorkspaceFile = "go.work"
+
+// WorkspaceModule is a module of a Go workspace.
+type WorkspaceModule struct {
+	Dir  string // Directory of the module, relative to the workspace (e.g., "api")
+	Path string // Module path of its go.mod
+}
+
+// ReadWorkspace returns the modules of the go.work file in dir, in the order of its use
+// directives.
+func ReadWorkspace(dir string) ([]WorkspaceModule, error) {
+	workPath := filepath.Join(dir, WorkspaceFile)
+	data, err := os.ReadFile(workPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace: %w", err)
+	}
+	work, err := modfile.ParseWork(workPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workspace: %w", err)
+	}
+
+	var modules []WorkspaceModule
+	for _, use := range work.Use {
+		moduleDir := filepath.ToSlash(filepath.Clean(use.Path))
+		if filepath.IsAbs(use.Path) || moduleDir == ".." || strings.HasPrefix(moduleDir, "../") {
+			return nil, fmt.Errorf("%s: module %s is outside of the workspace directory", workPath, use.Path)
+		}
+
+		modPath := filepath.Join(dir, moduleDir, "go.mod")
+		modData, err := os.ReadFile(modPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workspace module: %w", err)
+		}
+		modulePath := modfile.ModulePath(modData)
+		if modulePath == "" {
+			return nil, fmt.Errorf("%s: missing module directive", modPath)
+		}
+		modules = append(modules, WorkspaceModule{Dir: moduleDir, Path: modulePath})
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("%s: no use directive", workPath)
+	}
+	return modules, nil
+}
+
+// workspacePatterns returns the package patterns scanning the modules of the workspace:
+// the module pattern of each module, or the scan pattern, relative to the module
+// directory.
+func (s *Scanner) workspacePatterns() ([]string, error) {
+	modules, err := ReadWorkspace(s.config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	unused := maps.Clone(s.config.ModulePatterns)
+	var patterns []string
+	for _, module := range modules {
+		pattern := s.config.Pattern
+		for _, key := range []string{module.Dir, "./" + module.Dir, module.Path} {
+			if modulePattern, ok := s.config.ModulePatterns[key]; ok {
+				pattern = modulePattern
+				delete(unused, key)
+			}
+		}
+
+		for _, p := range strings.Fields(pattern) {
+			patterns = append(patterns, workspacePattern(module, p))
+		}
+	}
+
+	if len(unused) > 0 {
+		return nil, fmt.Errorf("module patterns for modules not in %s: %s",
+			WorkspaceFile, strings.Join(slices.Sorted(maps.Keys(unused)), ", "))
+	}
+	return patterns, nil
+}
+
+// workspacePattern returns a package pattern of a module relative to the workspace.
+// Relative patterns are joined to the module directory; import path patterns are kept.
+func workspacePattern(module WorkspaceModule, pattern string) string {
+	if pattern != "." && pattern != ".." && !strings.HasPrefix(pattern, "./") && !strings.HasPrefix(pattern, "../") {
+		return pattern
+	}
+	joined := path.Join(module.Dir, pattern)
+	if joined == "." {
+		return joined
+	}
+	return "./" + joined
+}
+
+// workspaceEnv returns the environment of the go command in workspace mode: GOWORK points
+// to the go.work of the scanned directory, and -mod flags, which the go command rejects
+// in workspace mode, are dropped from GOFLAGS.
+func (s *Scanner) workspaceEnv() []string {
+	workPath := filepath.Join(s.config.Dir, WorkspaceFile)
+	if abs, err := filepath.Abs(workPath); err == nil {
+		workPath = abs
+	}
+
+	var flags []string
+	for _, flag := range strings.Fields(os.Getenv("GOFLAGS")) {
+		if !strings.HasPrefix(flag, "-mod=") {
+			flags = append(flags, flag)
+		}
+	}
+	return append(os.Environ(), "GOWORK="+workPath, "GOFLAGS="+strings.Join(flags, " "))
+}
@@ -0,0 +1,90 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// workspaceTestFiles are a workspace whose handlers and models live in sibling modules
+var workspaceTestFiles = map[string]string{
+	"go.work":    "go 1.21\n\nuse (\n\t./api\n\t./dto\n)\n",
+	"api/go.mod": "module example.com/api\n\ngo 1.21\n\nrequire example.com/dto v0.0.0\n",
+	"api/handlers/users.go": `package handlers
+
+import "example.com/dto"
+
+// swagger:route GET /users/{id} users getUser
+//
+// Responses:
+// - 200: User
+func GetUser() dto.User { return dto.User{} }
+`,
+	"api/internal/admin.go": `package internal
+
+// swagger:model
+type AdminOnly struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+`,
+	"dto/go.mod": "module example.com/dto\n\ngo 1.21\n",
+	"dto/user.go": `package dto
+
+// swagger:model
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+`,
+}
+
+func TestScanWorkspace(t *testing.T) {
+	tmpDir := createTestProject(t, workspaceTestFiles)
+
+	s := New(WithDir(tmpDir), WithPattern("./..."), WithWorkspace(true))
+	require.NoError(t, s.Scan())
+	assert.Contains(t, s.Routes, "getUser")
+	assert.Contains(t, s.Structs, "User")
+	assert.Contains(t, s.Structs, "AdminOnly")
+
+	// Module patterns by directory or module path narrow the scan of a module
+	s = New(WithDir(tmpDir), WithPattern("./..."), WithWorkspace(true),
+		WithModulePatterns(map[string]string{"api": "./handlers/...", "example.com/dto": "."}))
+	require.NoError(t, s.Scan())
+	assert.Contains(t, s.Routes, "getUser")
+	assert.Contains(t, s.Structs, "User")
+	assert.NotContains(t, s.Structs, "AdminOnly")
+}
+
+func TestScanWorkspaceErrors(t *testing.T) {
+	tmpDir := createTestProject(t, workspaceTestFiles)
+
+	s := New(WithDir(tmpDir), WithWorkspace(true), WithModulePatterns(map[string]string{"web": "./..."}))
+	assert.ErrorContains(t, s.Scan(), "module patterns for modules not in go.work: web")
+
+	s = New(WithDir(t.TempDir()), WithWorkspace(true))
+	assert.ErrorContains(t, s.Scan(), "failed to read workspace")
+}
+
+func TestReadWorkspace(t *testing.T) {
+	tmpDir := createTestProject(t, workspaceTestFiles)
+
+	modules, err := ReadWorkspace(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, []WorkspaceModule{
+		{Dir: "api", Path: "example.com/api"},
+		{Dir: "dto", Path: "example.com/dto"},
+	}, modules)
+}
+
+func TestWorkspacePattern(t *testing.T) {
+	api := WorkspaceModule{Dir: "api", Path: "example.com/api"}
+	root := WorkspaceModule{Dir: ".", Path: "example.com/root"}
+
+	assert.Equal(t, "./api/...", workspacePattern(api, "./..."))
+	assert.Equal(t, "./api/handlers/...", workspacePattern(api, "./handlers/..."))
+	assert.Equal(t, "./api", workspacePattern(api, "."))
+	assert.Equal(t, "example.com/api/v2/...", workspacePattern(api, "example.com/api/v2/..."))
+	assert.Equal(t, "./...", workspacePattern(root, "./..."))
+	assert.Equal(t, ".", workspacePattern(root, "."))
+}
@@ -25,7 +25,23 @@ type ProviderConfig struct {
 
 // SpecConfig holds OpenAPI spec file location.
 type SpecConfig struct {
-	Path string `yaml:"path"` // Path to the OpenAPI YAML spec
+	Path    string            `yaml:"path"`    // Path to the OpenAPI YAML or JSON spec, or its http(s) URL
+	Headers map[string]string `yaml:"headers"` // Headers of the spec request, with ${VAR} expanded from the environment (e.g., Authorization)
+	Include FilterConfig      `yaml:"include"` // Operations to generate (empty = all)
+	Exclude FilterConfig      `yaml:"exclude"` // Operations to leave out, among the included ones
+}
+
+// FilterConfig selects operations of the spec. An operation matches when it matches any
+// of its tags, paths, or operations.
+type FilterConfig struct {
+	Tags       []string `yaml:"tags"`       // Operation tags (e.g., "pokemon")
+	Paths      []string `yaml:"paths"`      // Path globs: * matches a segment, ** any number (e.g., "/api/v2/pokemon/**")
+	Operations []string `yaml:"operations"` // Operation IDs, with * wildcards (e.g., "list*")
+}
+
+// empty reports whether the filter selects no operation.
+func (f FilterConfig) empty() bool {
+	return len(f.Tags) == 0 && len(f.Paths) == 0 && len(f.Operations) == 0
 }
 
 // OutputConfig holds module path for the generated SDK.
@@ -109,6 +125,12 @@ func (c *SDKGenConfig) validate() error {
 	if c.Services.ParamsStyle != "" && c.Services.ParamsStyle != "inline" && c.Services.ParamsStyle != "struct" {
 		return fmt.Errorf("services.params_style must be 'inline' or 'struct'")
 	}
+	if err := c.Spec.Include.validate(); err != nil {
+		return fmt.Errorf("spec.include.%w", err)
+	}
+	if err := c.Spec.Exclude.validate(); err != nil {
+		return fmt.Errorf("spec.exclude.%w", err)
+	}
 	for opID, opCfg := range c.Services.Operations {
 		if opCfg.ParamsStyle != "" && opCfg.ParamsStyle != "inline" && opCfg.ParamsStyle != "struct" {
 			return fmt.Errorf("services.operations.%s.params_style must be 'inline' or 'struct'", opID)
@@ -0,0 +1,214 @@
+package sdkgen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// specFetchTimeout bounds the download of a spec by the default client, so an
+// unresponsive server falls back to the cached spec instead of hanging.
+const specFetchTimeout = 30 * time.Second
+
+// DefaultSpecCacheDir returns the default cache directory of the specs fetched over
+// HTTP(S), in the user cache directory.
+func DefaultSpecCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "openapi", "sdkgen")
+}
+
+// isSpecURL reports whether the spec path of the config is an http or https URL.
+func isSpecURL(path string) bool {
+	return strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://")
+}
+
+// fetchSpec downloads the spec at specURL with the headers of the config and of the
+// options, config values expanded from the environment (${TOKEN}). With a cache
+// directory, the spec is cached with its ETag, which is sent back as If-None-Match so an
+// unchanged spec is not downloaded again; the cached spec is used when the server can't
+// be reached or doesn't return the spec.
+func (g *Generator) fetchSpec(specURL string) (data []byte, contentType string, err error) {
+	req, err := http.NewRequest(http.MethodGet, specURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid spec URL %s: %w", specURL, err)
+	}
+	req.Header.Set("Accept", "application/yaml, application/json;q=0.9, */*;q=0.8")
+	for _, name := range slices.Sorted(maps.Keys(g.config.Spec.Headers)) {
+		req.Header.Set(name, os.ExpandEnv(g.config.Spec.Headers[name]))
+	}
+	for name, value := range g.headers {
+		req.Header.Set(name, value)
+	}
+
+	cached, cachedType, etag, cacheErr := readCachedSpec(g.specCacheDir, specURL)
+	if cacheErr != nil && !errors.Is(cacheErr, fs.ErrNotExist) {
+		return nil, "", cacheErr
+	}
+	if cacheErr == nil && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	client := g.httpClient
+	if client == nil {
+		client = &http.Client{Timeout: specFetchTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if cacheErr == nil {
+			g.warnings = append(g.warnings, fmt.Sprintf("failed to fetch spec %s, using the cached spec: %v", specURL, err))
+			return cached, cachedType, nil
+		}
+		return nil, "", fmt.Errorf("failed to fetch spec %s: %w", specURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cacheErr == nil:
+		return cached, cachedType, nil
+	case resp.StatusCode != http.StatusOK && cacheErr == nil:
+		g.warnings = append(g.warnings, fmt.Sprintf("failed to fetch spec %s, using the cached spec: status %s", specURL, resp.Status))
+		return cached, cachedType, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("failed to fetch spec %s: status %s", specURL, resp.Status)
+	}
+
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read spec %s: %w", specURL, err)
+	}
+	contentType = resp.Header.Get("Content-Type")
+	if g.specCacheDir != "" {
+		if err := writeCachedSpec(g.specCacheDir, specURL, data, contentType, resp.Header.Get("ETag")); err != nil {
+			return nil, "", err
+		}
+	}
+	return data, contentType, nil
+}
+
+// Formats of the fetched specs.
+const (
+	specFormatYAML = "YAML"
+	specFormatJSON = "JSON"
+)
+
+// specFormat returns the format of a spec: JSON when its media type is JSON or, without
+// one, when the document is an object literal; YAML otherwise.
+func specFormat(contentType string, data []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch {
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			return specFormatJSON
+		case strings.Contains(mediaType, "yaml"):
+			return specFormatYAML
+		}
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return specFormatJSON
+	}
+	return specFormatYAML
+}
+
+// checkJSONSpec reports the syntax errors of a JSON spec with their line and column,
+// which the YAML decoder of the specs describes poorly.
+func checkJSONSpec(data []byte) error {
+	var doc any
+	err := json.Unmarshal(data, &doc)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line := 1 + bytes.Count(data[:syntaxErr.Offset], []byte("\n"))
+		column := int(syntaxErr.Offset) - bytes.LastIndexByte(data[:syntaxErr.Offset], '\n') - 1
+		return fmt.Errorf("invalid JSON at line %d, column %d: %w", line, column, err)
+	}
+	return err
+}
+
+// specCachePath returns the cache directory of the spec at specURL. It holds the spec,
+// and its ETag and media type in the etag and content-type files.
+func specCachePath(cacheDir, specURL string) string {
+	sum := sha256.Sum256([]byte(specURL))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:8]))
+}
+
+// readCachedSpec reads the cached spec of specURL with its media type and ETag. It
+// returns an fs.ErrNotExist error when the spec is not cached.
+func readCachedSpec(cacheDir, specURL string) (data []byte, contentType, etag string, err error) {
+	if cacheDir == "" {
+		return nil, "", "", fs.ErrNotExist
+	}
+	dir := specCachePath(cacheDir, specURL)
+	if data, err = os.ReadFile(filepath.Join(dir, "spec")); err != nil {
+		return nil, "", "", err
+	}
+	meta := make(map[string]string, 2)
+	for _, name := range []string{"etag", "content-type"} {
+		value, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, "", "", err
+		}
+		meta[name] = strings.TrimSpace(string(value))
+	}
+	return data, meta["content-type"], meta["etag"], nil
+}
+
+// writeCachedSpec caches the spec of specURL with its media type and ETag, readable by
+// the user only since fetching it may have required credentials. Each file is replaced
+// atomically, and the ETag is removed first and written last, so an interrupted write
+// never pairs a spec with the ETag of another version.
+func writeCachedSpec(cacheDir, specURL string, data []byte, contentType, etag string) error {
+	dir := specCachePath(cacheDir, specURL)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create spec cache directory: %w", err)
+	}
+	if err := os.Remove(filepath.Join(dir, "etag")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to cache spec: %w", err)
+	}
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"spec", data},
+		{"content-type", []byte(contentType + "\n")},
+		{"etag", []byte(etag + "\n")},
+	}
+	for _, file := range files {
+		if err := writeFileAtomic(filepath.Join(dir, file.name), file.content); err != nil {
+			return fmt.Errorf("failed to cache spec: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes a file with mode 0600 through a temporary file renamed over it,
+// so readers see either the previous or the new content.
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,172 @@
+package sdkgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kausys/openapi/spec"
+)
+
+// validate checks the glob patterns of the filter.
+func (f FilterConfig) validate() error {
+	for _, pattern := range f.Paths {
+		for _, segment := range strings.Split(pattern, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("paths: invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	for _, pattern := range f.Operations {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("operations: invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the operation at pathName matches the filter.
+func (f FilterConfig) matches(pathName string, op *spec.Operation) bool {
+	for _, tag := range op.Tags {
+		if slices.Contains(f.Tags, tag) {
+			return true
+		}
+	}
+	for _, pattern := range f.Paths {
+		if matchPathGlob(pattern, pathName) {
+			return true
+		}
+	}
+	for _, pattern := range f.Operations {
+		if ok, _ := path.Match(pattern, op.OperationID); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathGlob reports whether a spec path matches a glob pattern. * matches one path
+// segment, or part of one, and ** any number of segments.
+func matchPathGlob(pattern, pathName string) bool {
+	return matchSegments(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(strings.Trim(pathName, "/"), "/"))
+}
+
+// matchSegments matches the segments of a path against the segments of a glob.
+func matchSegments(patterns, segments []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+	if patterns[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(patterns[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(patterns[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(patterns[1:], segments[1:])
+}
+
+// filterSpec removes the operations of the spec left out by the include and exclude
+// filters of the config, then the components no longer referenced by the remaining
+// operations, directly or through other components. The spec is left as is without
+// filters.
+func filterSpec(cfg *SDKGenConfig, openAPI *spec.OpenAPI) error {
+	include, exclude := cfg.Spec.Include, cfg.Spec.Exclude
+	if include.empty() && exclude.empty() {
+		return nil
+	}
+
+	kept := 0
+	for pathName, pathItem := range openAPI.Paths.PathItems {
+		for method, op := range pathItem.Operations() {
+			if (include.empty() || include.matches(pathName, op)) && !exclude.matches(pathName, op) {
+				kept++
+				continue
+			}
+			pathItem.SetOperation(method, nil)
+		}
+		if pathItem.Ref == "" && !hasOperations(pathItem) {
+			delete(openAPI.Paths.PathItems, pathName)
+		}
+	}
+	if kept == 0 {
+		return fmt.Errorf("spec.include and spec.exclude leave no operation")
+	}
+
+	return pruneComponents(openAPI)
+}
+
+// hasOperations reports whether a path item has an operation left.
+func hasOperations(pi *spec.PathItem) bool {
+	for range pi.Operations() {
+		return true
+	}
+	return false
+}
+
+// componentRefPattern matches the references to components in a JSON document.
+var componentRefPattern = regexp.MustCompile(`"\$ref":"#/components/([^/"]+)/([^"]+)"`)
+
+// pruneComponents removes the schemas, parameters, responses, request bodies, and headers
+// of the components that the paths don't reference. The other components, such as the
+// security schemes, are kept.
+func pruneComponents(openAPI *spec.OpenAPI) error {
+	components, err := json.Marshal(openAPI.Components)
+	if err != nil {
+		return fmt.Errorf("failed to encode components: %w", err)
+	}
+	var byKind map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(components, &byKind); err != nil {
+		return fmt.Errorf("failed to decode components: %w", err)
+	}
+
+	paths, err := json.Marshal(openAPI.Paths)
+	if err != nil {
+		return fmt.Errorf("failed to encode paths: %w", err)
+	}
+
+	// Follow the references from the paths through the components they reference
+	referenced := make(map[string]bool)
+	queue := [][]byte{paths}
+	for len(queue) > 0 {
+		doc := queue[0]
+		queue = queue[1:]
+		for _, match := range componentRefPattern.FindAllSubmatch(doc, -1) {
+			kind := string(match[1])
+			name := strings.NewReplacer("~1", "/", "~0", "~").Replace(string(match[2]))
+			if referenced[kind+"/"+name] {
+				continue
+			}
+			referenced[kind+"/"+name] = true
+			if component, ok := byKind[kind][name]; ok {
+				queue = append(queue, component)
+			}
+		}
+	}
+
+	pruneUnreferenced(openAPI.Components.Schemas, "schemas", referenced)
+	pruneUnreferenced(openAPI.Components.Parameters, "parameters", referenced)
+	pruneUnreferenced(openAPI.Components.Responses, "responses", referenced)
+	pruneUnreferenced(openAPI.Components.RequestBodies, "requestBodies", referenced)
+	pruneUnreferenced(openAPI.Components.Headers, "headers", referenced)
+	return nil
+}
+
+// pruneUnreferenced deletes the components of a kind that are not referenced.
+func pruneUnreferenced[V any](components map[string]V, kind string, referenced map[string]bool) {
+	for name := range components {
+		if !referenced[kind+"/"+name] {
+			delete(components, name)
+		}
+	}
+}
@@ -2,22 +2,44 @@ package sdkgen
 
 import (
 	"fmt"
+	"mime"
 	"os"
+	"path/filepath"
 
 	"github.com/kausys/openapi/spec"
-	"gopkg.in/yaml.v3"
 )
 
-// parseSpec reads and parses an OpenAPI YAML spec file.
-func parseSpec(path string) (*spec.OpenAPI, error) {
+// readSpec reads the spec of the config, from a file or fetched over HTTP(S), and returns
+// its format.
+func (g *Generator) readSpec(path string) ([]byte, string, error) {
+	if isSpecURL(path) {
+		data, contentType, err := g.fetchSpec(path)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, specFormat(contentType, data), nil
+	}
+
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read spec file %s: %w", path, err)
+		return nil, "", fmt.Errorf("failed to read spec file %s: %w", path, err)
+	}
+	return data, specFormat(mime.TypeByExtension(filepath.Ext(path)), data), nil
+}
+
+// parseSpec parses an OpenAPI spec in YAML or JSON. It also returns the fields of the
+// spec that are unknown, which are ignored.
+func parseSpec(data []byte, path, format string) (*spec.OpenAPI, []spec.UnknownField, error) {
+	// JSON is YAML, but the YAML decoder explains JSON syntax errors poorly
+	if format == specFormatJSON {
+		if err := checkJSONSpec(data); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse JSON spec %s: %w", path, err)
+		}
 	}
 
-	var openAPI spec.OpenAPI
-	if err := yaml.Unmarshal(data, &openAPI); err != nil {
-		return nil, fmt.Errorf("failed to parse spec file %s: %w", path, err)
+	openAPI, unknown, err := spec.UnmarshalStrict(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s spec %s: %w", format, path, err)
 	}
 
 	if openAPI.Paths == nil {
@@ -33,5 +55,5 @@ func parseSpec(path string) (*spec.OpenAPI, error) {
 		openAPI.Components.Parameters = make(map[string]*spec.Parameter)
 	}
 
-	return &openAPI, nil
+	return openAPI, unknown, nil
 }
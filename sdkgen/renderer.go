@@ -70,6 +70,13 @@ func render(data *SDKData) (map[string][]byte, error) {
 		}
 	}
 
+	// Error types of the error responses: services/errors.go
+	if len(data.Errors) > 0 {
+		if err := renderTemplate(funcMap, "errors.go.tmpl", "services/errors.go", &templateData{SDKData: data}, files); err != nil {
+			return nil, err
+		}
+	}
+
 	// 4. SDK root: {provider}.go
 	if err := renderTemplate(funcMap, "sdk.go.tmpl", data.Provider.Name+".go", &templateData{SDKData: data}, files); err != nil {
 		return nil, err
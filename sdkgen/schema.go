@@ -2,6 +2,7 @@ package sdkgen
 
 import (
 	"fmt"
+	"maps"
 	"sort"
 	"strings"
 
@@ -13,6 +14,8 @@ type schemaConverter struct {
 	customTypes map[string]CustomTypeConfig
 	imports     map[string]string            // import path → alias (or "")
 	schemas     map[string]*spec.Schema      // components/schemas for $ref resolution
+	names       map[string]bool              // names of the schemas and the nested structs
+	nested      []StructData                 // nested structs not yet added to a models file
 }
 
 func newSchemaConverter(customTypes map[string]CustomTypeConfig) *schemaConverter {
@@ -29,6 +32,11 @@ func (sc *schemaConverter) goType(schema *spec.Schema, required bool) string {
 		return "any"
 	}
 
+	// A single allOf $ref is a reference with sibling keywords (e.g., description)
+	if schema.Ref == "" && len(schema.AllOf) == 1 && schema.AllOf[0] != nil && schema.AllOf[0].Ref != "" && len(schema.Properties) == 0 {
+		schema = schema.AllOf[0]
+	}
+
 	// Handle $ref
 	if schema.Ref != "" {
 		typeName := extractRefName(schema.Ref)
@@ -146,7 +154,8 @@ func extractRefName(ref string) string {
 }
 
 // schemaToStruct converts a named OpenAPI schema with properties into a StructData.
-// Supports allOf composition by merging properties from all sub-schemas.
+// Supports allOf composition: sub-schemas referencing a struct are embedded, and the
+// others are flattened into the struct with their own allOf sub-schemas.
 func (sc *schemaConverter) schemaToStruct(name string, schema *spec.Schema) *StructData {
 	if schema == nil {
 		return nil
@@ -156,25 +165,31 @@ func (sc *schemaConverter) schemaToStruct(name string, schema *spec.Schema) *Str
 	mergedProps := make(map[string]*spec.Schema)
 	requiredSet := make(map[string]bool)
 
-	// Collect from allOf first
+	// Collect from allOf first. A referenced struct is embedded unless it shares a
+	// property with an embedded one, which encoding/json would drop as ambiguous; the
+	// flattened sub-schemas leave out the properties of the embedded structs.
+	var fields []FieldData
+	embeddedProps := make(map[string]*spec.Schema)
 	for _, sub := range schema.AllOf {
 		if sub == nil {
 			continue
 		}
-		resolved := sub
-		// Resolve $ref to the actual schema from components/schemas
-		if sub.Ref != "" && sc.schemas != nil {
+		subProps := make(map[string]*spec.Schema)
+		subRequired := make(map[string]bool)
+		sc.mergeAllOf(sub, subProps, subRequired, map[string]bool{name: true})
+
+		if sc.isStructRef(sub) && !hasAnyKey(embeddedProps, subProps) {
+			maps.Copy(embeddedProps, subProps)
 			refName := extractRefName(sub.Ref)
-			if refSchema, ok := sc.schemas[refName]; ok {
-				resolved = refSchema
-			}
-		}
-		for propName, propSchema := range resolved.Properties {
-			mergedProps[propName] = propSchema
+			fields = append(fields, FieldData{Name: refName, Type: refName, Embedded: true})
+			continue
 		}
-		for _, r := range resolved.Required {
-			requiredSet[r] = true
+		for propName, propSchema := range subProps {
+			if embeddedProps[propName] != propSchema {
+				mergedProps[propName] = propSchema
+			}
 		}
+		maps.Copy(requiredSet, subRequired)
 	}
 
 	// Then collect direct properties (override allOf if same name)
@@ -185,18 +200,17 @@ func (sc *schemaConverter) schemaToStruct(name string, schema *spec.Schema) *Str
 		requiredSet[r] = true
 	}
 
-	if len(mergedProps) == 0 {
+	if len(mergedProps) == 0 && len(fields) == 0 && len(schema.AllOf) == 0 {
 		return nil
 	}
 
-	var fields []FieldData
 	// Sort property names for deterministic output
 	propNames := sortedKeys(mergedProps)
 	for _, propName := range propNames {
 		propSchema := mergedProps[propName]
 		isRequired := requiredSet[propName]
 
-		goFieldType := sc.goType(propSchema, isRequired)
+		goFieldType := sc.fieldType(name+toPascalCase(propName), propSchema, isRequired)
 
 		jsonTag := propName
 		if !isRequired {
@@ -219,6 +233,107 @@ func (sc *schemaConverter) schemaToStruct(name string, schema *spec.Schema) *Str
 	}
 }
 
+// fieldType converts the schema of a struct field to a Go type. Inline object schemas,
+// of the field or of the items of its array, become nested structs named after the
+// field (e.g., UserAddress, or UserAddressesItem for array items), collected in nested.
+func (sc *schemaConverter) fieldType(name string, schema *spec.Schema, required bool) string {
+	switch {
+	case isInlineObject(schema):
+		// Reserve the place of the struct, so it comes before its own nested structs
+		structName := sc.nestedName(name)
+		i := len(sc.nested)
+		sc.nested = append(sc.nested, StructData{Name: structName, Comment: schema.Description})
+		if structData := sc.schemaToStruct(structName, schema); structData != nil {
+			sc.nested[i] = *structData
+		}
+		if !required {
+			return "*" + structName
+		}
+		return structName
+	case schema != nil && schema.Ref == "" && schema.Type.Value() == "array" && isInlineObject(schema.Items):
+		return "[]" + sc.fieldType(name+"Item", schema.Items, true)
+	default:
+		return sc.goType(schema, required)
+	}
+}
+
+// isInlineObject reports whether a schema is an object with properties defined in place,
+// rather than a $ref.
+func isInlineObject(schema *spec.Schema) bool {
+	if schema == nil || schema.Ref != "" || len(schema.Enum) > 0 {
+		return false
+	}
+	if len(schema.Properties) > 0 || len(schema.AllOf) > 1 {
+		return true
+	}
+	// A single allOf $ref is a reference (see goType)
+	return len(schema.AllOf) == 1 && schema.AllOf[0] != nil && schema.AllOf[0].Ref == ""
+}
+
+// nestedName returns a name for a nested struct that no schema or other nested struct
+// has, adding a number to the name when taken (e.g., UserAddress2).
+func (sc *schemaConverter) nestedName(name string) string {
+	if sc.names == nil {
+		sc.names = make(map[string]bool, len(sc.schemas))
+		for schemaName := range sc.schemas {
+			sc.names[schemaName] = true
+		}
+	}
+	unique := name
+	for i := 2; sc.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	sc.names[unique] = true
+	return unique
+}
+
+// mergeAllOf collects the properties and required fields of a schema, resolving its
+// $ref, and of its allOf sub-schemas, the later ones overriding the earlier ones. The
+// schemas in seen are skipped, to stop at circular references.
+func (sc *schemaConverter) mergeAllOf(schema *spec.Schema, props map[string]*spec.Schema, required map[string]bool, seen map[string]bool) {
+	if schema.Ref != "" {
+		refName := extractRefName(schema.Ref)
+		refSchema, ok := sc.schemas[refName]
+		if !ok || seen[refName] {
+			return
+		}
+		seen[refName] = true
+		schema = refSchema
+	}
+
+	for _, sub := range schema.AllOf {
+		if sub != nil {
+			sc.mergeAllOf(sub, props, required, seen)
+		}
+	}
+	maps.Copy(props, schema.Properties)
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+}
+
+// isStructRef reports whether a schema is a $ref to a schema generated as a struct.
+func (sc *schemaConverter) isStructRef(schema *spec.Schema) bool {
+	if schema.Ref == "" {
+		return false
+	}
+	refSchema, ok := sc.schemas[extractRefName(schema.Ref)]
+	if !ok || len(refSchema.Enum) > 0 {
+		return false
+	}
+	return len(refSchema.Properties) > 0 || len(refSchema.AllOf) > 0 || refSchema.Type.Value() == "object"
+}
+
+// hasAnyKey reports whether a has one of the keys of b.
+func hasAnyKey[V, W any](a map[string]V, b map[string]W) bool {
+	for key := range b {
+		if _, ok := a[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // schemaToEnum converts an OpenAPI schema with enum values into an EnumData.
 func (sc *schemaConverter) schemaToEnum(name string, schema *spec.Schema) *EnumData {
 	if schema == nil || len(schema.Enum) == 0 {
@@ -3,6 +3,8 @@ package sdkgen
 
 import (
 	"fmt"
+	"maps"
+	"net/http"
 	"path/filepath"
 
 	"github.com/kausys/openapi/spec"
@@ -17,6 +19,14 @@ type Generator struct {
 	// Overrides from CLI flags
 	outputDir string
 	provider  string
+
+	strict   bool
+	warnings []string
+
+	// Fetching of specs published over HTTP(S)
+	headers      map[string]string
+	specCacheDir string
+	httpClient   *http.Client
 }
 
 // Option configures the Generator.
@@ -43,6 +53,41 @@ func WithProvider(name string) Option {
 	}
 }
 
+// WithStrict makes generation fail when the spec has unknown fields, instead of
+// reporting them as warnings.
+func WithStrict(strict bool) Option {
+	return func(g *Generator) {
+		g.strict = strict
+	}
+}
+
+// WithHeaders adds headers to the request of a spec fetched over HTTP(S), such as an
+// Authorization header. They override the headers of the config.
+func WithHeaders(headers map[string]string) Option {
+	return func(g *Generator) {
+		if g.headers == nil {
+			g.headers = make(map[string]string, len(headers))
+		}
+		maps.Copy(g.headers, headers)
+	}
+}
+
+// WithSpecCacheDir sets the directory caching the specs fetched over HTTP(S) with their
+// ETag (see DefaultSpecCacheDir). Empty disables the cache.
+func WithSpecCacheDir(dir string) Option {
+	return func(g *Generator) {
+		g.specCacheDir = dir
+	}
+}
+
+// WithHTTPClient sets the client fetching specs over HTTP(S). The default client uses the
+// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables.
+func WithHTTPClient(client *http.Client) Option {
+	return func(g *Generator) {
+		g.httpClient = client
+	}
+}
+
 // New creates a new Generator with the given options.
 func New(opts ...Option) *Generator {
 	g := &Generator{}
@@ -52,6 +97,12 @@ func New(opts ...Option) *Generator {
 	return g
 }
 
+// Warnings returns the problems of the spec found by the last Generate call that did not
+// stop generation, such as unknown fields, formatted as file:line:column: message.
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
 // Generate runs the full generation pipeline:
 // Parse → Transform → Render → Format → Write
 func (g *Generator) Generate() error {
@@ -74,17 +125,35 @@ func (g *Generator) Generate() error {
 
 	// Resolve spec path relative to config file directory
 	specPath := g.config.Spec.Path
-	if !filepath.IsAbs(specPath) {
+	if !filepath.IsAbs(specPath) && !isSpecURL(specPath) {
 		specPath = filepath.Join(filepath.Dir(g.configPath), specPath)
 	}
 
 	// 2. Parse OpenAPI spec
-	openAPI, err := parseSpec(specPath)
+	g.warnings = nil
+	specData, format, err := g.readSpec(specPath)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	openAPI, unknown, err := parseSpec(specData, specPath, format)
 	if err != nil {
 		return fmt.Errorf("failed to parse spec: %w", err)
 	}
 	g.spec = openAPI
 
+	// Unknown fields are usually misspelled and silently ignored
+	for _, field := range unknown {
+		g.warnings = append(g.warnings, fmt.Sprintf("%s:%s", specPath, field))
+	}
+	if g.strict && len(unknown) > 0 {
+		return fmt.Errorf("spec has %d unknown field(s), first at %s:%s", len(unknown), specPath, unknown[0])
+	}
+
+	// Keep the operations selected by spec.include and spec.exclude, and their schemas
+	if err := filterSpec(g.config, g.spec); err != nil {
+		return fmt.Errorf("failed to filter spec: %w", err)
+	}
+
 	// 3. Transform spec + config → SDKData
 	data, err := transform(g.config, g.spec)
 	if err != nil {
@@ -1,8 +1,11 @@
 package sdkgen
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,6 +23,7 @@ func TestGenerate_Pokemon(t *testing.T) {
 
 	err := gen.Generate()
 	require.NoError(t, err)
+	assert.Empty(t, gen.Warnings())
 
 	// Verify config
 	configContent, err := os.ReadFile(filepath.Join(tmpDir, "config", "config.go"))
@@ -111,6 +115,627 @@ func TestGenerate_Pokemon(t *testing.T) {
 	assert.True(t, found, "expected ElementType enum in some model file")
 }
 
+func TestGenerate_UnknownFields(t *testing.T) {
+	specDir := t.TempDir()
+	config, err := os.ReadFile(filepath.Join("testdata", "pokemon.sdkgen.yaml"))
+	require.NoError(t, err)
+	openAPI, err := os.ReadFile(filepath.Join("testdata", "pokemon.openapi.yaml"))
+	require.NoError(t, err)
+
+	// Misspell the summary of the first operation
+	openAPI = []byte(strings.Replace(string(openAPI), "summary:", "sumary:", 1))
+	configPath := filepath.Join(specDir, "pokemon.sdkgen.yaml")
+	specPath := filepath.Join(specDir, "pokemon.openapi.yaml")
+	require.NoError(t, os.WriteFile(configPath, config, 0o644))
+	require.NoError(t, os.WriteFile(specPath, openAPI, 0o644))
+
+	gen := New(WithConfigPath(configPath), WithOutputDir(t.TempDir()))
+	require.NoError(t, gen.Generate())
+	assert.Equal(t, []string{specPath + ":11:7: unknown field /paths/~1api~1v2~1pokemon~1{id}/get/sumary"}, gen.Warnings())
+
+	gen = New(WithConfigPath(configPath), WithOutputDir(t.TempDir()), WithStrict(true))
+	err = gen.Generate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec has 1 unknown field(s)")
+	assert.Len(t, gen.Warnings(), 1)
+}
+
+func TestGenerate_RemoteSpec(t *testing.T) {
+	openAPI, err := os.ReadFile(filepath.Join("testdata", "pokemon.openapi.yaml"))
+	require.NoError(t, err)
+
+	var requests, downloads int
+	var unavailable bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if unavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Tenant") != "acme" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(openAPI)
+	}))
+
+	config, err := os.ReadFile(filepath.Join("testdata", "pokemon.sdkgen.yaml"))
+	require.NoError(t, err)
+	config = []byte(strings.Replace(string(config), "path: ./pokemon.openapi.yaml",
+		"path: "+server.URL+"/openapi.yaml\n  headers:\n    Authorization: Bearer ${SDKGEN_TEST_TOKEN}", 1))
+	configPath := filepath.Join(t.TempDir(), "pokemon.sdkgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, config, 0o644))
+	t.Setenv("SDKGEN_TEST_TOKEN", "secret")
+
+	cacheDir := t.TempDir()
+	newGenerator := func() *Generator {
+		return New(
+			WithConfigPath(configPath),
+			WithOutputDir(t.TempDir()),
+			WithHeaders(map[string]string{"X-Tenant": "acme"}),
+			WithSpecCacheDir(cacheDir),
+		)
+	}
+
+	require.NoError(t, newGenerator().Generate())
+	assert.Equal(t, 1, downloads)
+
+	// The cache is readable by the user only
+	cached, err := filepath.Glob(filepath.Join(cacheDir, "*", "*"))
+	require.NoError(t, err)
+	require.Len(t, cached, 3)
+	for _, path := range cached {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), path)
+	}
+
+	// The cached spec is revalidated with its ETag
+	require.NoError(t, newGenerator().Generate())
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, downloads)
+
+	// The cached spec is used when the server fails
+	unavailable = true
+	gen := newGenerator()
+	require.NoError(t, gen.Generate())
+	require.Len(t, gen.Warnings(), 1)
+	assert.Contains(t, gen.Warnings()[0], "using the cached spec: status 503")
+	unavailable = false
+
+	// The cached spec is used when the server is down
+	server.Close()
+	gen = newGenerator()
+	require.NoError(t, gen.Generate())
+	require.Len(t, gen.Warnings(), 1)
+	assert.Contains(t, gen.Warnings()[0], "using the cached spec")
+
+	// Without a cache, fetching fails
+	gen = New(WithConfigPath(configPath), WithOutputDir(t.TempDir()))
+	assert.ErrorContains(t, gen.Generate(), "failed to fetch spec")
+}
+
+func TestSpecFormat(t *testing.T) {
+	assert.Equal(t, specFormatJSON, specFormat("application/json; charset=utf-8", []byte("openapi: 3.1.0")))
+	assert.Equal(t, specFormatJSON, specFormat("application/vnd.oai.openapi+json", nil))
+	assert.Equal(t, specFormatYAML, specFormat("application/yaml", []byte("{}")))
+	assert.Equal(t, specFormatJSON, specFormat("text/plain", []byte("\n  {\"openapi\": \"3.1.0\"}")))
+	assert.Equal(t, specFormatYAML, specFormat("", []byte("openapi: 3.1.0")))
+
+	_, _, err := parseSpec([]byte("{\n  \"openapi\": \"3.1.0\",\n}"), "api.json", specFormatJSON)
+	assert.ErrorContains(t, err, "failed to parse JSON spec api.json: invalid JSON at line 3, column 1")
+}
+
+func TestGenerate_Filters(t *testing.T) {
+	specPath, err := filepath.Abs(filepath.Join("testdata", "pokemon.openapi.yaml"))
+	require.NoError(t, err)
+	config, err := os.ReadFile(filepath.Join("testdata", "pokemon.sdkgen.yaml"))
+	require.NoError(t, err)
+
+	writeConfig := func(filters string) string {
+		content := strings.Replace(string(config), "path: ./pokemon.openapi.yaml", "path: "+specPath+"\n"+filters, 1)
+		configPath := filepath.Join(t.TempDir(), "pokemon.sdkgen.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+		return configPath
+	}
+
+	tmpDir := t.TempDir()
+	configPath := writeConfig(`  include:
+    tags: [pokemon]
+    paths: ["/api/v2/ability/*"]
+  exclude:
+    operations: ["list*"]`)
+	require.NoError(t, New(WithConfigPath(configPath), WithOutputDir(tmpDir)).Generate())
+
+	serviceFiles, _ := filepath.Glob(filepath.Join(tmpDir, "services", "*_service.go"))
+	require.Len(t, serviceFiles, 2)
+	pokemonSvc, err := os.ReadFile(filepath.Join(tmpDir, "services", "pokemon_service.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(pokemonSvc), "GetPokemon")
+	assert.NotContains(t, string(pokemonSvc), "ListPokemon")
+	abilitySvc, err := os.ReadFile(filepath.Join(tmpDir, "services", "ability_service.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(abilitySvc), "GetAbility")
+	assert.NotContains(t, string(abilitySvc), "ListAbilities")
+
+	// The schemas of the left out operations are pruned, the ones referenced through
+	// the kept schemas are not
+	var models strings.Builder
+	modelFiles, _ := filepath.Glob(filepath.Join(tmpDir, "models", "*.go"))
+	for _, file := range modelFiles {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		models.Write(content)
+	}
+	assert.Contains(t, models.String(), "type Pokemon struct")
+	assert.Contains(t, models.String(), "type PokemonStat struct")
+	assert.Contains(t, models.String(), "type EffectEntry struct")
+	assert.Contains(t, models.String(), "type NamedResource struct")
+	for _, pruned := range []string{"PokemonList", "PokemonType", "TypePokemonSlot", "AbilityList", "ElementType"} {
+		assert.NotContains(t, models.String(), "type "+pruned+" ")
+	}
+
+	// Filters leaving no operation are an error
+	configPath = writeConfig("  include:\n    tags: [berry]")
+	assert.ErrorContains(t, New(WithConfigPath(configPath), WithOutputDir(t.TempDir())).Generate(), "leave no operation")
+
+	configPath = writeConfig("  exclude:\n    paths: [\"/api/[\"]")
+	assert.ErrorContains(t, New(WithConfigPath(configPath), WithOutputDir(t.TempDir())).Generate(), "spec.exclude.paths: invalid pattern")
+}
+
+func TestGenerate_ComponentRefs(t *testing.T) {
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "teams.openapi.yaml"), []byte(`openapi: "3.0.4"
+info:
+  title: Teams API
+  version: "1.0.0"
+paths:
+  /teams/{id}:
+    put:
+      operationId: updateTeam
+      tags: [teams]
+      parameters:
+        - $ref: "#/components/parameters/TeamID"
+      requestBody:
+        $ref: "#/components/requestBodies/TeamBody"
+      responses:
+        "200":
+          $ref: "#/components/responses/TeamUpdated"
+components:
+  parameters:
+    TeamID:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: integer
+  requestBodies:
+    TeamBody:
+      required: true
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/TeamInput"
+  responses:
+    TeamUpdated:
+      $ref: "#/components/responses/Team"
+    Team:
+      description: The team
+      headers:
+        X-Rate-Limit:
+          $ref: "#/components/headers/RateLimit"
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/Team"
+  headers:
+    RateLimit:
+      schema:
+        $ref: "#/components/schemas/RateLimit"
+  schemas:
+    Team:
+      type: object
+      properties:
+        name:
+          type: string
+    TeamInput:
+      type: object
+      properties:
+        name:
+          type: string
+    RateLimit:
+      type: object
+      properties:
+        remaining:
+          type: integer
+`), 0o644))
+	configPath := filepath.Join(specDir, "teams.sdkgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`provider:
+  name: teams
+spec:
+  path: ./teams.openapi.yaml
+output:
+  module_path: api/pkg/sdk/teams
+`), 0o644))
+
+	tmpDir := t.TempDir()
+	gen := New(WithConfigPath(configPath), WithOutputDir(tmpDir))
+	require.NoError(t, gen.Generate())
+	assert.Empty(t, gen.Warnings())
+
+	teamsSvc, err := os.ReadFile(filepath.Join(tmpDir, "services", "teams_service.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(teamsSvc), "UpdateTeam(ctx context.Context, id int, req models.TeamInput) (models.Team, error)")
+
+	// The schemas referenced through the components belong to the tag of the operation
+	teamsModel, err := os.ReadFile(filepath.Join(tmpDir, "models", "teams.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(teamsModel), "type Team struct")
+	assert.Contains(t, string(teamsModel), "type TeamInput struct")
+	assert.Contains(t, string(teamsModel), "type RateLimit struct")
+}
+
+func TestGenerate_AllOf(t *testing.T) {
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "teams.openapi.yaml"), []byte(`openapi: "3.0.4"
+info:
+  title: Teams API
+  version: "1.0.0"
+paths:
+  /teams:
+    get:
+      operationId: listTeams
+      tags: [teams]
+      responses:
+        "200":
+          description: The teams
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Team"
+  /members/{id}:
+    get:
+      operationId: getMember
+      tags: [teams]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: The member
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Member"
+components:
+  schemas:
+    Resource:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        createdAt:
+          type: string
+          format: date-time
+    Named:
+      type: object
+      properties:
+        name:
+          type: string
+    Audited:
+      allOf:
+        - $ref: "#/components/schemas/Resource"
+        - properties:
+            updatedBy:
+              type: string
+    Team:
+      description: A team of members
+      allOf:
+        - $ref: "#/components/schemas/Audited"
+        - $ref: "#/components/schemas/Named"
+        - type: object
+          required: [size]
+          properties:
+            size:
+              type: integer
+            owner:
+              description: Owner of the team
+              allOf:
+                - $ref: "#/components/schemas/Named"
+    Member:
+      allOf:
+        - $ref: "#/components/schemas/Resource"
+        - $ref: "#/components/schemas/Team"
+`), 0o644))
+	configPath := filepath.Join(specDir, "teams.sdkgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`provider:
+  name: teams
+spec:
+  path: ./teams.openapi.yaml
+output:
+  module_path: api/pkg/sdk/teams
+`), 0o644))
+
+	tmpDir := t.TempDir()
+	require.NoError(t, New(WithConfigPath(configPath), WithOutputDir(tmpDir)).Generate())
+
+	var models strings.Builder
+	modelFiles, _ := filepath.Glob(filepath.Join(tmpDir, "models", "*.go"))
+	for _, file := range modelFiles {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		models.Write(content)
+	}
+	out := models.String()
+
+	// Referenced structs are embedded, inline sub-schemas flattened
+	assert.Contains(t, out, "type Audited struct {\n\tResource\n\tUpdatedBy string `json:\"updatedBy,omitempty\"`")
+	assert.Contains(t, out, "type Team struct {\n\tAudited\n\tNamed\n")
+	assert.Contains(t, out, "Size  int    `json:\"size\"`")
+
+	// A single allOf $ref property is a reference
+	assert.Contains(t, out, "Owner *Named `json:\"owner,omitempty\"`")
+
+	// Team shares the id of Resource, so it is flattened, without the Resource fields
+	assert.Contains(t, out, "type Member struct {\n\tResource\n\tName string")
+	assert.NotContains(t, out, "\tTeam\n")
+	assert.Equal(t, 1, strings.Count(out, "`json:\"id\"`"))
+}
+
+func TestGenerate_NestedObjects(t *testing.T) {
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "users.openapi.yaml"), []byte(`openapi: "3.0.4"
+info:
+  title: Users API
+  version: "1.0.0"
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      tags: [users]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: The user
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+components:
+  schemas:
+    User:
+      type: object
+      required: [address]
+      properties:
+        address:
+          description: Home address
+          type: object
+          properties:
+            city:
+              type: string
+            geo:
+              type: object
+              properties:
+                lat:
+                  type: number
+        phones:
+          type: array
+          items:
+            type: object
+            properties:
+              number:
+                type: string
+        metadata:
+          type: object
+          additionalProperties:
+            type: string
+    UserAddress:
+      type: object
+      properties:
+        label:
+          type: string
+`), 0o644))
+	configPath := filepath.Join(specDir, "users.sdkgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`provider:
+  name: users
+spec:
+  path: ./users.openapi.yaml
+output:
+  module_path: api/pkg/sdk/users
+`), 0o644))
+
+	tmpDir := t.TempDir()
+	require.NoError(t, New(WithConfigPath(configPath), WithOutputDir(tmpDir)).Generate())
+
+	usersModel, err := os.ReadFile(filepath.Join(tmpDir, "models", "users.go"))
+	require.NoError(t, err)
+	out := string(usersModel)
+
+	assert.Contains(t, out, "Address  UserAddress2      `json:\"address\"`")
+	assert.Contains(t, out, "Phones   []UserPhonesItem  `json:\"phones,omitempty\"`")
+	assert.Contains(t, out, "Metadata map[string]string `json:\"metadata,omitempty\"`")
+
+	// Nested structs follow their parent, and their names don't collide with the schemas
+	assert.Contains(t, out, "// UserAddress2 Home address\ntype UserAddress2 struct {")
+	assert.Contains(t, out, "Geo  *UserAddress2Geo `json:\"geo,omitempty\"`")
+	assert.Contains(t, out, "type UserAddress2Geo struct {\n\tLat float64 `json:\"lat,omitempty\"`")
+	assert.Contains(t, out, "type UserPhonesItem struct {\n\tNumber string `json:\"number,omitempty\"`")
+	assert.Less(t, strings.Index(out, "type User struct"), strings.Index(out, "type UserAddress2 struct"))
+	assert.Less(t, strings.Index(out, "type UserAddress2 struct"), strings.Index(out, "type UserAddress2Geo struct"))
+}
+
+func TestGenerate_ErrorResponses(t *testing.T) {
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "teams.openapi.yaml"), []byte(`openapi: "3.0.4"
+info:
+  title: Teams API
+  version: "1.0.0"
+paths:
+  /teams/{id}:
+    get:
+      operationId: getTeam
+      tags: [teams]
+      parameters:
+        - $ref: "#/components/parameters/TeamID"
+      responses:
+        "200":
+          description: The team
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Team"
+        "404":
+          $ref: "#/components/responses/NotFound"
+        4XX:
+          description: Invalid request
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/ValidationError"
+        "500":
+          description: Inline error bodies are not typed
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  message:
+                    type: string
+        default:
+          $ref: "#/components/responses/NotFound"
+    delete:
+      operationId: deleteTeam
+      tags: [teams]
+      parameters:
+        - $ref: "#/components/parameters/TeamID"
+      responses:
+        "204":
+          description: Deleted
+        "404":
+          $ref: "#/components/responses/NotFound"
+components:
+  parameters:
+    TeamID:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: string
+  responses:
+    NotFound:
+      description: Not found
+      content:
+        application/problem+json:
+          schema:
+            $ref: "#/components/schemas/Problem"
+  schemas:
+    Team:
+      type: object
+      properties:
+        name:
+          type: string
+    Problem:
+      type: object
+      properties:
+        title:
+          type: string
+    ValidationError:
+      type: object
+      properties:
+        error:
+          type: string
+`), 0o644))
+	configPath := filepath.Join(specDir, "teams.sdkgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`provider:
+  name: teams
+spec:
+  path: ./teams.openapi.yaml
+output:
+  module_path: api/pkg/sdk/teams
+`), 0o644))
+
+	tmpDir := t.TempDir()
+	require.NoError(t, New(WithConfigPath(configPath), WithOutputDir(tmpDir)).Generate())
+
+	errorsFile, err := os.ReadFile(filepath.Join(tmpDir, "services", "errors.go"))
+	require.NoError(t, err)
+	errs := string(errorsFile)
+	assert.Contains(t, errs, "type ProblemError struct {\n\tStatusCode int\n\tBody       models.Problem\n}")
+	assert.Contains(t, errs, "type ValidationError struct {\n\tStatusCode int\n\tBody       models.ValidationError\n}")
+	assert.Contains(t, errs, "func (e *ProblemError) Error() string {")
+	assert.Contains(t, errs, "func newValidationError(statusCode int, raw string) *ValidationError {")
+
+	teamsSvc, err := os.ReadFile(filepath.Join(tmpDir, "services", "teams_service.go"))
+	require.NoError(t, err)
+	svc := string(teamsSvc)
+	assert.Contains(t, svc, `		switch status := resp.StatusCode(); {
+		case status == 404:
+			if apiErr := newProblemError(status, data.Raw); apiErr != nil {
+				return models.Team{}, apiErr
+			}
+		case status >= 400 && status < 500:
+			if apiErr := newValidationError(status, data.Raw); apiErr != nil {
+				return models.Team{}, apiErr
+			}
+		default:
+			if apiErr := newProblemError(status, data.Raw); apiErr != nil {
+				return models.Team{}, apiErr
+			}
+		}`)
+	assert.NotContains(t, svc, "status == 500")
+	assert.Contains(t, svc, `		case status == 404:
+			if apiErr := newProblemError(status, data.Raw); apiErr != nil {
+				return apiErr
+			}`)
+
+	// Without error response schemas, no error types are generated
+	tmpDir = t.TempDir()
+	require.NoError(t, New(WithConfigPath(filepath.Join("testdata", "pokemon.sdkgen.yaml")), WithOutputDir(tmpDir)).Generate())
+	_, err = os.Stat(filepath.Join(tmpDir, "services", "errors.go"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestMatchPathGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"/api/v2/pokemon", "/api/v2/pokemon", true},
+		{"/api/v2/pokemon", "/api/v2/pokemon/{id}", false},
+		{"/api/v2/*/{id}", "/api/v2/type/{id}", true},
+		{"/api/*", "/api/v2/type", false},
+		{"/api/**", "/api/v2/type/{id}", true},
+		{"/api/**", "/api", true},
+		{"/**/{id}", "/api/v2/ability/{id}", true},
+		{"/api/v2/poke*", "/api/v2/pokemon", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.match, matchPathGlob(tt.pattern, tt.path))
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsStr(s, substr))
 }
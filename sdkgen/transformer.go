@@ -2,7 +2,10 @@ package sdkgen
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/kausys/openapi/spec"
@@ -13,6 +16,7 @@ type SDKData struct {
 	Provider   ProviderData
 	Config     ConfigData
 	Services   []ServiceData
+	Errors     []ErrorData // Error types of the error response schemas
 	Models     []ModelFileData
 	ModulePath string // Go module path for the SDK (e.g., "api/pkg/sdk/pokemon")
 }
@@ -61,6 +65,20 @@ type MethodData struct {
 	Comment          string
 	UseParamsStruct  bool
 	ParamsStructName string // e.g., "GetWalletParams"
+	ErrorResponses   []ErrorResponseData
+}
+
+// ErrorResponseData represents the 4xx/5xx responses of an operation with a body schema.
+type ErrorResponseData struct {
+	Condition string // Condition on status, e.g., "status == 404"; empty for the default response
+	Schema    string // Model name of the body (e.g., "Problem")
+	ErrorType string // Error type decoding the body (e.g., "ProblemError")
+}
+
+// ErrorData represents an error type wrapping an error response model.
+type ErrorData struct {
+	Name   string // e.g., "ProblemError"
+	Schema string // e.g., "Problem"
 }
 
 // ParamData represents a path or query parameter.
@@ -102,6 +120,7 @@ type FieldData struct {
 	JSONTag  string
 	Comment  string
 	Required bool
+	Embedded bool // Embedded base type of an allOf schema, named Type
 }
 
 // EnumData represents a Go enum (type + const block).
@@ -151,10 +170,49 @@ func transform(cfg *SDKGenConfig, openAPI *spec.OpenAPI) (*SDKData, error) {
 		return nil, fmt.Errorf("failed to transform services: %w", err)
 	}
 	data.Services = services
+	data.Errors = transformErrors(data.Services)
 
 	return data, nil
 }
 
+// transformErrors names the error types of the error response schemas of the services,
+// and sets the error types of their methods. The error type of a schema is named after
+// it with an Error suffix (e.g., ProblemError, or ValidationError for ValidationError).
+func transformErrors(services []ServiceData) []ErrorData {
+	schemaSet := make(map[string]bool)
+	for _, svc := range services {
+		for _, method := range svc.Methods {
+			for _, resp := range method.ErrorResponses {
+				schemaSet[resp.Schema] = true
+			}
+		}
+	}
+
+	var errs []ErrorData
+	typeNames := make(map[string]string)
+	usedNames := make(map[string]bool)
+	for _, schema := range sortedKeys(schemaSet) {
+		base := strings.TrimSuffix(schema, "Error") + "Error"
+		name := base
+		for i := 2; usedNames[name]; i++ {
+			name = fmt.Sprintf("%s%d", strings.TrimSuffix(base, "Error"), i) + "Error"
+		}
+		usedNames[name] = true
+		typeNames[schema] = name
+		errs = append(errs, ErrorData{Name: name, Schema: schema})
+	}
+
+	for i := range services {
+		for j := range services[i].Methods {
+			for k := range services[i].Methods[j].ErrorResponses {
+				resp := &services[i].Methods[j].ErrorResponses[k]
+				resp.ErrorType = typeNames[resp.Schema]
+			}
+		}
+	}
+	return errs
+}
+
 // transformConfig builds ConfigData from the config.
 func transformConfig(cfg *SDKGenConfig) ConfigData {
 	var fields []ConfigFieldData
@@ -243,13 +301,17 @@ func transformModels(sc *schemaConverter, cfg *SDKGenConfig, openAPI *spec.OpenA
 			})
 		} else if schema.Type.Value() == "array" && schema.Items != nil {
 			// Top-level array schema — generate a type alias
-			itemType := sc.goType(schema.Items, true)
+			itemType := sc.fieldType(name+"Item", schema.Items, true)
 			mf.TypeAliases = append(mf.TypeAliases, TypeAliasData{
 				Name:    name,
 				Type:    "[]" + itemType,
 				Comment: schema.Description,
 			})
 		}
+
+		// Nested structs of the inline objects go in the file of their schema
+		mf.Structs = append(mf.Structs, sc.nested...)
+		sc.nested = nil
 	}
 
 	// Collect imports from schema converter
@@ -285,7 +347,7 @@ func buildSchemaTagMap(openAPI *spec.OpenAPI) map[string][]string {
 			}
 			tag := op.Tags[0]
 
-			refs := collectOperationSchemaRefs(op)
+			refs := collectOperationSchemaRefs(op, openAPI)
 			for _, ref := range refs {
 				name := extractRefName(ref)
 				existing := schemaTagMap[name]
@@ -306,12 +368,13 @@ func buildSchemaTagMap(openAPI *spec.OpenAPI) map[string][]string {
 	return schemaTagMap
 }
 
-// collectOperationSchemaRefs collects all $ref strings from an operation.
-func collectOperationSchemaRefs(op *spec.Operation) []string {
+// collectOperationSchemaRefs collects all $ref strings from an operation, including the
+// ones of its referenced request body, responses, and response headers.
+func collectOperationSchemaRefs(op *spec.Operation, openAPI *spec.OpenAPI) []string {
 	var refs []string
 
-	if op.RequestBody != nil {
-		for _, mt := range op.RequestBody.Content {
+	if requestBody := resolveRequestBody(op.RequestBody, openAPI); requestBody != nil {
+		for _, mt := range requestBody.Content {
 			if mt.Schema != nil && mt.Schema.Ref != "" {
 				refs = append(refs, mt.Schema.Ref)
 			}
@@ -328,6 +391,15 @@ func collectOperationSchemaRefs(op *spec.Operation) []string {
 		}
 
 		for _, resp := range allResponses {
+			resp = resolveResponse(resp, openAPI)
+			if resp == nil {
+				continue
+			}
+			for _, header := range resp.Headers {
+				if header = resolveHeader(header, openAPI); header != nil && header.Schema != nil && header.Schema.Ref != "" {
+					refs = append(refs, header.Schema.Ref)
+				}
+			}
 			for _, mt := range resp.Content {
 				if mt.Schema == nil {
 					continue
@@ -374,16 +446,37 @@ func operationsFromPathItem(pi *spec.PathItem) []*spec.Operation {
 
 // resolveParameter resolves a $ref parameter to its definition in components/parameters.
 func resolveParameter(param *spec.Parameter, openAPI *spec.OpenAPI) *spec.Parameter {
-	if param.Ref == "" {
-		return param
-	}
-	refName := extractRefName(param.Ref)
-	if openAPI.Components != nil && openAPI.Components.Parameters != nil {
-		if resolved, ok := openAPI.Components.Parameters[refName]; ok {
-			return resolved
+	return resolveComponent(param, func(p *spec.Parameter) string { return p.Ref }, openAPI.Components.Parameters)
+}
+
+// resolveRequestBody resolves a $ref request body to its definition in components/requestBodies.
+func resolveRequestBody(body *spec.RequestBody, openAPI *spec.OpenAPI) *spec.RequestBody {
+	return resolveComponent(body, func(b *spec.RequestBody) string { return b.Ref }, openAPI.Components.RequestBodies)
+}
+
+// resolveResponse resolves a $ref response to its definition in components/responses.
+func resolveResponse(resp *spec.Response, openAPI *spec.OpenAPI) *spec.Response {
+	return resolveComponent(resp, func(r *spec.Response) string { return r.Ref }, openAPI.Components.Responses)
+}
+
+// resolveHeader resolves a $ref header to its definition in components/headers.
+func resolveHeader(header *spec.Header, openAPI *spec.OpenAPI) *spec.Header {
+	return resolveComponent(header, func(h *spec.Header) string { return h.Ref }, openAPI.Components.Headers)
+}
+
+// resolveComponent follows the $ref of a component, through the components it references
+// in turn, to its definition. It returns nil when a reference is missing or circular.
+func resolveComponent[T any](obj *T, ref func(*T) string, components map[string]*T) *T {
+	seen := make(map[string]bool)
+	for obj != nil && ref(obj) != "" {
+		name := extractRefName(ref(obj))
+		if seen[name] {
+			return nil
 		}
+		seen[name] = true
+		obj = components[name]
 	}
-	return nil
+	return obj
 }
 
 // shouldUseParamsStruct determines if a method should use a params struct based on config.
@@ -510,9 +603,9 @@ func transformOperation(sc *schemaConverter, cfg *SDKGenConfig, openAPI *spec.Op
 		}
 	}
 
-	if op.RequestBody != nil {
+	if requestBody := resolveRequestBody(op.RequestBody, openAPI); requestBody != nil {
 		method.HasRequestBody = true
-		for _, mt := range op.RequestBody.Content {
+		for _, mt := range requestBody.Content {
 			if mt.Schema != nil {
 				if mt.Schema.Ref != "" {
 					method.RequestBodyType = "models." + extractRefName(mt.Schema.Ref)
@@ -524,7 +617,8 @@ func transformOperation(sc *schemaConverter, cfg *SDKGenConfig, openAPI *spec.Op
 		}
 	}
 
-	method.ResponseType = extractResponseType(sc, op)
+	method.ResponseType = extractResponseType(sc, op, openAPI)
+	method.ErrorResponses = extractErrorResponses(sc, op, openAPI)
 
 	method.UseParamsStruct = shouldUseParamsStruct(cfg, op.OperationID)
 	if method.UseParamsStruct {
@@ -535,15 +629,15 @@ func transformOperation(sc *schemaConverter, cfg *SDKGenConfig, openAPI *spec.Op
 }
 
 // extractResponseType determines the Go return type from the operation's success response.
-func extractResponseType(sc *schemaConverter, op *spec.Operation) string {
+func extractResponseType(sc *schemaConverter, op *spec.Operation, openAPI *spec.OpenAPI) string {
 	if op.Responses == nil {
 		return ""
 	}
 
 	successCodes := []string{"200", "201", "202"}
 	for _, code := range successCodes {
-		resp, ok := op.Responses.StatusCodes[code]
-		if !ok || resp == nil {
+		resp := resolveResponse(op.Responses.StatusCodes[code], openAPI)
+		if resp == nil {
 			continue
 		}
 		for _, mt := range resp.Content {
@@ -584,6 +678,64 @@ func extractResponseType(sc *schemaConverter, op *spec.Operation) string {
 	return ""
 }
 
+// extractErrorResponses returns the 4xx and 5xx responses of an operation, and its
+// default response, whose body is a model struct: the status codes first, then the 4XX
+// and 5XX ranges, then the default response.
+func extractErrorResponses(sc *schemaConverter, op *spec.Operation, openAPI *spec.OpenAPI) []ErrorResponseData {
+	if op.Responses == nil {
+		return nil
+	}
+
+	var codes, ranges, defaults []ErrorResponseData
+	responses := op.Responses.StatusCodes
+	if op.Responses.Default != nil {
+		responses = maps.Clone(responses)
+		if responses == nil {
+			responses = make(map[string]*spec.Response)
+		}
+		responses["default"] = op.Responses.Default
+	}
+	for _, code := range sortedKeys(responses) {
+		schema := errorResponseSchema(sc, resolveResponse(responses[code], openAPI))
+		if schema == "" {
+			continue
+		}
+		switch upper := strings.ToUpper(code); {
+		case code == "default":
+			defaults = append(defaults, ErrorResponseData{Schema: schema})
+		case upper == "4XX" || upper == "5XX":
+			low := int(upper[0]-'0') * 100
+			ranges = append(ranges, ErrorResponseData{
+				Condition: fmt.Sprintf("status >= %d && status < %d", low, low+100),
+				Schema:    schema,
+			})
+		default:
+			if status, err := strconv.Atoi(code); err == nil && status >= 400 && status < 600 {
+				codes = append(codes, ErrorResponseData{Condition: fmt.Sprintf("status == %d", status), Schema: schema})
+			}
+		}
+	}
+	return slices.Concat(codes, ranges, defaults)
+}
+
+// errorResponseSchema returns the name of the model struct of a response body, preferring
+// JSON media types, or "" when the body is not a $ref to a struct.
+func errorResponseSchema(sc *schemaConverter, resp *spec.Response) string {
+	if resp == nil {
+		return ""
+	}
+	mediaTypes := sortedKeys(resp.Content)
+	sort.SliceStable(mediaTypes, func(i, j int) bool {
+		return strings.Contains(mediaTypes[i], "json") && !strings.Contains(mediaTypes[j], "json")
+	})
+	for _, mediaType := range mediaTypes {
+		if mt := resp.Content[mediaType]; mt != nil && mt.Schema != nil && sc.isStructRef(mt.Schema) {
+			return extractRefName(mt.Schema.Ref)
+		}
+	}
+	return ""
+}
+
 // operationComment builds the comment for a method from the operation's summary and description.
 // If description is present, it combines summary + description. Otherwise, just summary.
 func operationComment(op *spec.Operation) string {
@@ -13,21 +13,29 @@ import (
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#callback-object
 type Callback struct {
+	// A reference to a callback defined in components/callbacks.
+	// If present, the other fields are not serialized.
+	Ref string `json:"-" yaml:"-"`
 	// A Path Item Object used to define a callback request and expected responses.
 	PathItems map[string]*PathItem `json:"-" yaml:"-"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
-// It marshals the PathItems map directly as the Callback object.
+// It marshals the PathItems map and the Specification Extensions as the Callback object.
 func (c *Callback) MarshalJSON() ([]byte, error) {
-	if c == nil || c.PathItems == nil {
+	if c != nil && c.Ref != "" {
+		return json.Marshal(&Reference{Ref: c.Ref})
+	}
+	if c == nil || (c.PathItems == nil && len(c.Extensions) == 0) {
 		return []byte("{}"), nil
 	}
-	return json.Marshal(c.PathItems)
+	return json.Marshal(mapWithExtensions(c.PathItems, c.Extensions))
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
-// It unmarshals the JSON directly into the PathItems map.
+// It unmarshals the JSON into the PathItems map and the Specification Extensions.
 func (c *Callback) UnmarshalJSON(data []byte) error {
 	if c == nil {
 		return nil
@@ -41,21 +49,31 @@ func (c *Callback) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	// Unmarshal the data into the PathItems map
-	return json.Unmarshal(data, &c.PathItems)
+	// A reference replaces the callback
+	var ref Reference
+	if err := json.Unmarshal(data, &ref); err == nil && ref.Ref != "" {
+		c.Ref = ref.Ref
+		return nil
+	}
+
+	// Unmarshal the data into the PathItems map, except the extensions
+	return unmarshalJSONMapWithExtensions(data, c.PathItems, &c.Extensions)
 }
 
 // MarshalYAML implements the yaml.Marshaler interface.
-// It marshals the PathItems map directly as the Callback object.
+// It marshals the PathItems map and the Specification Extensions as the Callback object.
 func (c *Callback) MarshalYAML() (any, error) {
-	if c == nil || c.PathItems == nil {
+	if c != nil && c.Ref != "" {
+		return &Reference{Ref: c.Ref}, nil
+	}
+	if c == nil || (c.PathItems == nil && len(c.Extensions) == 0) {
 		return map[string]any{}, nil
 	}
-	return c.PathItems, nil
+	return mapWithExtensions(c.PathItems, c.Extensions), nil
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
-// It unmarshals the YAML directly into the PathItems map.
+// It unmarshals the YAML into the PathItems map and the Specification Extensions.
 func (c *Callback) UnmarshalYAML(value *yaml.Node) error {
 	if c == nil {
 		return nil
@@ -69,6 +87,13 @@ func (c *Callback) UnmarshalYAML(value *yaml.Node) error {
 		return nil
 	}
 
-	// Unmarshal the node into the PathItems map
-	return value.Decode(&c.PathItems)
+	// A reference replaces the callback
+	var ref Reference
+	if value.Kind == yaml.MappingNode && value.Decode(&ref) == nil && ref.Ref != "" {
+		c.Ref = ref.Ref
+		return nil
+	}
+
+	// Unmarshal the node into the PathItems map, except the extensions
+	return unmarshalYAMLMapWithExtensions(value, c.PathItems, &c.Extensions)
 }
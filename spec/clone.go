@@ -0,0 +1,477 @@
+package spec
+
+import "maps"
+
+// Clone methods return deep copies, so a copy can be modified without affecting the
+// original (for example by post-processors or when deriving localized specs). Nil
+// objects, maps, and slices stay nil, and empty ones stay empty, since some of them
+// serialize differently (security: []). Values of type any (examples, defaults,
+// extensions) are copied when they are maps or slices decoded from JSON or YAML;
+// other values are shared.
+
+// Clone returns a deep copy of the OpenAPI document.
+func (o *OpenAPI) Clone() *OpenAPI {
+	if o == nil {
+		return nil
+	}
+	c := *o
+	c.Info = o.Info.Clone()
+	c.Servers = cloneSlice(o.Servers, (*Server).Clone)
+	c.Paths = o.Paths.Clone()
+	c.Webhooks = cloneMap(o.Webhooks, (*PathItem).Clone)
+	c.Components = o.Components.Clone()
+	c.Security = cloneSlice(o.Security, (*SecurityRequirement).Clone)
+	c.Tags = cloneSlice(o.Tags, (*Tag).Clone)
+	c.ExternalDocs = o.ExternalDocs.Clone()
+	c.Extensions = o.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the extensions.
+func (e Extensions) Clone() Extensions {
+	return cloneMap(e, cloneValue)
+}
+
+// Clone returns a deep copy of the Info object.
+func (i *Info) Clone() *Info {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	c.Contact = i.Contact.Clone()
+	c.License = i.License.Clone()
+	c.Extensions = i.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Contact object.
+func (ct *Contact) Clone() *Contact {
+	if ct == nil {
+		return nil
+	}
+	c := *ct
+	c.Extensions = ct.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the License object.
+func (l *License) Clone() *License {
+	if l == nil {
+		return nil
+	}
+	c := *l
+	c.Extensions = l.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Server object.
+func (s *Server) Clone() *Server {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Variables = cloneMap(s.Variables, (*ServerVariable).Clone)
+	c.Extensions = s.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the ServerVariable object.
+func (v *ServerVariable) Clone() *ServerVariable {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	c.Enum = cloneSlice(v.Enum, identity)
+	c.Extensions = v.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Paths object.
+func (p *Paths) Clone() *Paths {
+	if p == nil {
+		return nil
+	}
+	return &Paths{PathItems: cloneMap(p.PathItems, (*PathItem).Clone), Extensions: p.Extensions.Clone(), Order: cloneSlice(p.Order, identity)}
+}
+
+// Clone returns a deep copy of the PathItem object.
+func (p *PathItem) Clone() *PathItem {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Get = p.Get.Clone()
+	c.Put = p.Put.Clone()
+	c.Post = p.Post.Clone()
+	c.Delete = p.Delete.Clone()
+	c.Options = p.Options.Clone()
+	c.Head = p.Head.Clone()
+	c.Patch = p.Patch.Clone()
+	c.Trace = p.Trace.Clone()
+	c.Query = p.Query.Clone()
+	c.AdditionalOperations = cloneMap(p.AdditionalOperations, (*Operation).Clone)
+	c.Servers = cloneSlice(p.Servers, (*Server).Clone)
+	c.Parameters = cloneSlice(p.Parameters, (*Parameter).Clone)
+	c.Extensions = p.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Operation object.
+func (o *Operation) Clone() *Operation {
+	if o == nil {
+		return nil
+	}
+	c := *o
+	c.Tags = cloneSlice(o.Tags, identity)
+	c.ExternalDocs = o.ExternalDocs.Clone()
+	c.Parameters = cloneSlice(o.Parameters, (*Parameter).Clone)
+	c.RequestBody = o.RequestBody.Clone()
+	c.Responses = o.Responses.Clone()
+	c.Callbacks = cloneMap(o.Callbacks, (*Callback).Clone)
+	c.Security = cloneSlice(o.Security, (*SecurityRequirement).Clone)
+	c.Servers = cloneSlice(o.Servers, (*Server).Clone)
+	c.Extensions = o.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Parameter object.
+func (p *Parameter) Clone() *Parameter {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Explode = clonePointer(p.Explode)
+	c.Schema = p.Schema.Clone()
+	c.Example = cloneValue(p.Example)
+	c.Examples = cloneMap(p.Examples, (*Example).Clone)
+	c.Content = cloneMap(p.Content, (*MediaType).Clone)
+	c.Extensions = p.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Header object.
+func (h *Header) Clone() *Header {
+	if h == nil {
+		return nil
+	}
+	c := *h
+	c.Explode = clonePointer(h.Explode)
+	c.Schema = h.Schema.Clone()
+	c.Example = cloneValue(h.Example)
+	c.Examples = cloneMap(h.Examples, (*Example).Clone)
+	c.Content = cloneMap(h.Content, (*MediaType).Clone)
+	c.Extensions = h.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the RequestBody object.
+func (r *RequestBody) Clone() *RequestBody {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	c.Content = cloneMap(r.Content, (*MediaType).Clone)
+	c.Extensions = r.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Responses object.
+func (r *Responses) Clone() *Responses {
+	if r == nil {
+		return nil
+	}
+	return &Responses{
+		Default:     r.Default.Clone(),
+		StatusCodes: cloneMap(r.StatusCodes, (*Response).Clone),
+		Extensions:  r.Extensions.Clone(),
+	}
+}
+
+// Clone returns a deep copy of the Response object.
+func (r *Response) Clone() *Response {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	c.Headers = cloneMap(r.Headers, (*Header).Clone)
+	c.Content = cloneMap(r.Content, (*MediaType).Clone)
+	c.Links = cloneMap(r.Links, (*Link).Clone)
+	c.Extensions = r.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the MediaType object.
+func (m *MediaType) Clone() *MediaType {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	c.Schema = m.Schema.Clone()
+	c.Example = cloneValue(m.Example)
+	c.Examples = cloneMap(m.Examples, (*Example).Clone)
+	c.Encoding = cloneMap(m.Encoding, (*Encoding).Clone)
+	c.Extensions = m.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Encoding object.
+func (e *Encoding) Clone() *Encoding {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.Headers = cloneMap(e.Headers, (*Header).Clone)
+	c.Extensions = e.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Example object.
+func (e *Example) Clone() *Example {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.Value = cloneValue(e.Value)
+	c.Extensions = e.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Link object.
+func (l *Link) Clone() *Link {
+	if l == nil {
+		return nil
+	}
+	c := *l
+	c.Parameters = cloneMap(l.Parameters, cloneValue)
+	c.RequestBody = cloneValue(l.RequestBody)
+	c.Server = l.Server.Clone()
+	c.Extensions = l.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Callback object.
+func (cb *Callback) Clone() *Callback {
+	if cb == nil {
+		return nil
+	}
+	return &Callback{PathItems: cloneMap(cb.PathItems, (*PathItem).Clone), Extensions: cb.Extensions.Clone()}
+}
+
+// Clone returns a deep copy of the Components object.
+func (c *Components) Clone() *Components {
+	if c == nil {
+		return nil
+	}
+	return &Components{
+		Schemas:         cloneMap(c.Schemas, (*Schema).Clone),
+		Responses:       cloneMap(c.Responses, (*Response).Clone),
+		Parameters:      cloneMap(c.Parameters, (*Parameter).Clone),
+		Examples:        cloneMap(c.Examples, (*Example).Clone),
+		RequestBodies:   cloneMap(c.RequestBodies, (*RequestBody).Clone),
+		Headers:         cloneMap(c.Headers, (*Header).Clone),
+		SecuritySchemes: cloneMap(c.SecuritySchemes, (*SecurityScheme).Clone),
+		Links:           cloneMap(c.Links, (*Link).Clone),
+		Callbacks:       cloneMap(c.Callbacks, (*Callback).Clone),
+		PathItems:       cloneMap(c.PathItems, (*PathItem).Clone),
+		Extensions:      c.Extensions.Clone(),
+	}
+}
+
+// Clone returns a deep copy of the SecurityScheme object.
+func (s *SecurityScheme) Clone() *SecurityScheme {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Flows = s.Flows.Clone()
+	c.Extensions = s.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the OAuthFlows object.
+func (f *OAuthFlows) Clone() *OAuthFlows {
+	if f == nil {
+		return nil
+	}
+	return &OAuthFlows{
+		Implicit:          f.Implicit.Clone(),
+		Password:          f.Password.Clone(),
+		ClientCredentials: f.ClientCredentials.Clone(),
+		AuthorizationCode: f.AuthorizationCode.Clone(),
+		Extensions:        f.Extensions.Clone(),
+	}
+}
+
+// Clone returns a deep copy of the OAuthFlow object.
+func (f *OAuthFlow) Clone() *OAuthFlow {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	c.Scopes = maps.Clone(f.Scopes)
+	c.Extensions = f.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the SecurityRequirement object.
+func (s *SecurityRequirement) Clone() *SecurityRequirement {
+	if s == nil {
+		return nil
+	}
+	return &SecurityRequirement{Requirements: cloneMap(s.Requirements, func(scopes []string) []string {
+		return cloneSlice(scopes, identity)
+	})}
+}
+
+// Clone returns a deep copy of the Tag object.
+func (t *Tag) Clone() *Tag {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	c.ExternalDocs = t.ExternalDocs.Clone()
+	c.Extensions = t.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the ExternalDocs object.
+func (e *ExternalDocs) Clone() *ExternalDocs {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.Extensions = e.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the Schema object.
+func (s *Schema) Clone() *Schema {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Vocabulary = cloneMap(s.Vocabulary, identity)
+	c.Defs = cloneMap(s.Defs, (*Schema).Clone)
+	c.MultipleOf = clonePointer(s.MultipleOf)
+	c.Maximum = clonePointer(s.Maximum)
+	c.ExclusiveMaximum = clonePointer(s.ExclusiveMaximum)
+	c.Minimum = clonePointer(s.Minimum)
+	c.ExclusiveMinimum = clonePointer(s.ExclusiveMinimum)
+	c.MaxLength = clonePointer(s.MaxLength)
+	c.MaxItems = clonePointer(s.MaxItems)
+	c.MinItems = clonePointer(s.MinItems)
+	c.MaxContains = clonePointer(s.MaxContains)
+	c.MinContains = clonePointer(s.MinContains)
+	c.MaxProperties = clonePointer(s.MaxProperties)
+	c.MinProperties = clonePointer(s.MinProperties)
+	c.Bool = clonePointer(s.Bool)
+	c.Required = cloneSlice(s.Required, identity)
+	c.DependentRequired = cloneMap(s.DependentRequired, func(names []string) []string { return cloneSlice(names, identity) })
+	c.Enum = cloneSlice(s.Enum, cloneValue)
+	c.Const = cloneValue(s.Const)
+	c.Type = s.Type.Clone()
+	c.AllOf = cloneSlice(s.AllOf, (*Schema).Clone)
+	c.OneOf = cloneSlice(s.OneOf, (*Schema).Clone)
+	c.AnyOf = cloneSlice(s.AnyOf, (*Schema).Clone)
+	c.Not = s.Not.Clone()
+	c.If = s.If.Clone()
+	c.Then = s.Then.Clone()
+	c.Else = s.Else.Clone()
+	c.DependentSchemas = cloneMap(s.DependentSchemas, (*Schema).Clone)
+	c.PrefixItems = cloneSlice(s.PrefixItems, (*Schema).Clone)
+	c.Items = s.Items.Clone()
+	c.Contains = s.Contains.Clone()
+	c.Properties = cloneMap(s.Properties, (*Schema).Clone)
+	c.PatternProperties = cloneMap(s.PatternProperties, (*Schema).Clone)
+	c.AdditionalProperties = s.AdditionalProperties.Clone()
+	c.PropertyNames = s.PropertyNames.Clone()
+	c.UnevaluatedProperties = s.UnevaluatedProperties.Clone()
+	c.UnevaluatedItems = s.UnevaluatedItems.Clone()
+	c.ContentSchema = s.ContentSchema.Clone()
+	c.Default = cloneValue(s.Default)
+	c.Examples = cloneSlice(s.Examples, cloneValue)
+	c.Example = cloneValue(s.Example)
+	c.Discriminator = s.Discriminator.Clone()
+	c.XML = s.XML.Clone()
+	c.ExternalDocs = s.ExternalDocs.Clone()
+	c.Extensions = s.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a copy of the schema type.
+func (st SchemaType) Clone() SchemaType {
+	return SchemaType{values: cloneSlice(st.values, identity)}
+}
+
+// Clone returns a deep copy of the Discriminator object.
+func (d *Discriminator) Clone() *Discriminator {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	c.Mapping = maps.Clone(d.Mapping)
+	c.Extensions = d.Extensions.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the XML object.
+func (x *XML) Clone() *XML {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Extensions = x.Extensions.Clone()
+	return &c
+}
+
+// clonePointer returns a shallow copy of the value p points to, or nil.
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+// cloneSlice copies a slice, cloning each element; nil stays nil.
+func cloneSlice[S ~[]E, E any](s S, clone func(E) E) S {
+	if s == nil {
+		return nil
+	}
+	c := make(S, len(s))
+	for i, v := range s {
+		c[i] = clone(v)
+	}
+	return c
+}
+
+// cloneMap copies a map, cloning each value; nil stays nil.
+func cloneMap[M ~map[K]V, K comparable, V any](m M, clone func(V) V) M {
+	if m == nil {
+		return nil
+	}
+	c := make(M, len(m))
+	for k, v := range m {
+		c[k] = clone(v)
+	}
+	return c
+}
+
+// cloneValue deep copies the maps and slices of a decoded JSON or YAML value.
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return cloneMap(v, cloneValue)
+	case map[any]any:
+		return cloneMap(v, cloneValue)
+	case []any:
+		return cloneSlice(v, cloneValue)
+	default:
+		return v
+	}
+}
+
+// identity returns v, for slices and maps of values without references.
+func identity[T any](v T) T {
+	return v
+}
@@ -0,0 +1,128 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const cloneTestSpec = `openapi: 3.1.2
+info:
+  title: Users
+  version: 1.0.0
+  x-audience: public
+servers:
+  - url: https://api.example.com
+    variables:
+      region:
+        default: eu
+        enum: [eu, us]
+security:
+  - bearer: []
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      tags: [users]
+      security: []
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: User
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+              example:
+                id: usr_1
+                roles: [admin]
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: [string, "null"]
+          maxLength: 32
+        roles:
+          type: array
+          items:
+            type: string
+            enum: [admin, member]
+      x-internal: true
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+`
+
+// TestOpenAPIClone tests that a cloned document can be modified without affecting the original
+func TestOpenAPIClone(t *testing.T) {
+	var original OpenAPI
+	require.NoError(t, yaml.Unmarshal([]byte(cloneTestSpec), &original))
+
+	clone := original.Clone()
+	require.True(t, clone.Equal(&original))
+
+	op := clone.Paths.PathItems["/users/{id}"].Get
+	op.Tags[0] = "accounts"
+	op.Parameters[0].Schema.Type = NewSchemaTypes("integer")
+	example := op.Responses.StatusCodes["200"].Content["application/json"].Example.(map[string]any)
+	example["roles"].([]any)[0] = "member"
+	user := clone.Components.Schemas["User"]
+	user.Required = append(user.Required[:0], "roles")
+	*user.Properties["id"].MaxLength = 64
+	user.Properties["roles"].Items.Enum[0] = "owner"
+	user.Extensions["x-internal"] = false
+	clone.Info.Extensions["x-audience"] = "internal"
+	clone.Servers[0].Variables["region"].Enum[0] = "ap"
+	clone.Security[0].Requirements["bearer"] = append(clone.Security[0].Requirements["bearer"], "admin")
+
+	assert.False(t, clone.Equal(&original))
+
+	origOp := original.Paths.PathItems["/users/{id}"].Get
+	assert.Equal(t, []string{"users"}, origOp.Tags)
+	assert.Equal(t, "string", origOp.Parameters[0].Schema.Type.Value())
+	origExample := origOp.Responses.StatusCodes["200"].Content["application/json"].Example.(map[string]any)
+	assert.Equal(t, []any{"admin"}, origExample["roles"])
+	origUser := original.Components.Schemas["User"]
+	assert.Equal(t, []string{"id"}, origUser.Required)
+	assert.Equal(t, uint64(32), *origUser.Properties["id"].MaxLength)
+	assert.Equal(t, []string{"string", "null"}, origUser.Properties["id"].Type.Values())
+	assert.Equal(t, []any{"admin", "member"}, origUser.Properties["roles"].Items.Enum)
+	assert.Equal(t, true, origUser.Extensions["x-internal"])
+	assert.Equal(t, "public", original.Info.Extensions["x-audience"])
+	assert.Equal(t, []string{"eu", "us"}, original.Servers[0].Variables["region"].Enum)
+	assert.Empty(t, original.Security[0].Requirements["bearer"])
+
+	// An empty security list removes the top-level requirement and must stay empty, not nil
+	require.NotNil(t, clone.Paths.PathItems["/users/{id}"].Get.Security)
+	assert.Empty(t, clone.Paths.PathItems["/users/{id}"].Get.Security)
+	assert.Nil(t, (*OpenAPI)(nil).Clone())
+}
+
+// TestOpenAPIEqual tests comparing documents by their serialized content
+func TestOpenAPIEqual(t *testing.T) {
+	a := &OpenAPI{OpenAPI: "3.1.2", Info: &Info{Title: "API", Version: "1.0.0", Extensions: Extensions{"x-rate": 10}}}
+	b := &OpenAPI{OpenAPI: "3.1.2", Info: &Info{Title: "API", Version: "1.0.0", Extensions: Extensions{"x-rate": float64(10)}}}
+	assert.True(t, a.Equal(b), "numbers of different Go types are equal")
+
+	b.Info.Title = "Other"
+	assert.False(t, a.Equal(b))
+	assert.False(t, a.Equal(nil))
+	assert.True(t, (*OpenAPI)(nil).Equal(nil))
+
+	withSecurity := &Operation{Security: SecurityRequirements{}}
+	withoutSecurity := &Operation{}
+	assert.False(t, (&Schema{Description: "a"}).Equal(&Schema{Description: "b"}))
+	assert.False(t, (&OpenAPI{Paths: &Paths{PathItems: map[string]*PathItem{"/": {Get: withSecurity}}}}).
+		Equal(&OpenAPI{Paths: &Paths{PathItems: map[string]*PathItem{"/": {Get: withoutSecurity}}}}))
+}
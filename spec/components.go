@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // Holds a set of reusable objects for different aspects of the OAS.
 // All objects defined within the Components Object will have no effect on the API unless they are
 // explicitly referenced from outside the Components Object.
@@ -26,4 +28,40 @@ type Components struct {
 	Callbacks map[string]*Callback `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
 	// An object to hold reusable Path Item Objects.
 	PathItems map[string]*PathItem `json:"pathItems,omitempty" yaml:"pathItems,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Components fields followed by its Specification Extensions.
+func (c *Components) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return []byte("null"), nil
+	}
+	type plain Components
+	return marshalJSONWithExtensions((*plain)(c), c.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Components fields followed by its Specification Extensions.
+func (c *Components) MarshalYAML() (any, error) {
+	if c == nil {
+		return nil, nil
+	}
+	type plain Components
+	return marshalYAMLWithExtensions((*plain)(c), c.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Components fields and collects its Specification Extensions.
+func (c *Components) UnmarshalJSON(data []byte) error {
+	type plain Components
+	return unmarshalJSONWithExtensions(data, (*plain)(c), &c.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Components fields and collects its Specification Extensions.
+func (c *Components) UnmarshalYAML(value *yaml.Node) error {
+	type plain Components
+	return unmarshalYAMLWithExtensions(value, (*plain)(c), &c.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // Contact information for the exposed API.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#contact-object
@@ -10,4 +12,40 @@ type Contact struct {
 	URL string `json:"url,omitempty" yaml:"url,omitempty"`
 	// The email address of the contact person/organization. This MUST be in the form of an email address.
 	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Contact fields followed by its Specification Extensions.
+func (c *Contact) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return []byte("null"), nil
+	}
+	type plain Contact
+	return marshalJSONWithExtensions((*plain)(c), c.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Contact fields followed by its Specification Extensions.
+func (c *Contact) MarshalYAML() (any, error) {
+	if c == nil {
+		return nil, nil
+	}
+	type plain Contact
+	return marshalYAMLWithExtensions((*plain)(c), c.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Contact fields and collects its Specification Extensions.
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	type plain Contact
+	return unmarshalJSONWithExtensions(data, (*plain)(c), &c.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Contact fields and collects its Specification Extensions.
+func (c *Contact) UnmarshalYAML(value *yaml.Node) error {
+	type plain Contact
+	return unmarshalYAMLWithExtensions(value, (*plain)(c), &c.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // Discriminator Object
 //
 // When request bodies or response payloads may be one of a number of different schemas, a Discriminator Object
@@ -17,4 +19,40 @@ type Discriminator struct {
 	PropertyName string `json:"propertyName" yaml:"propertyName"`
 	// An object to hold mappings between payload values and schema names or URI references.
 	Mapping map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Discriminator fields followed by its Specification Extensions.
+func (d *Discriminator) MarshalJSON() ([]byte, error) {
+	if d == nil {
+		return []byte("null"), nil
+	}
+	type plain Discriminator
+	return marshalJSONWithExtensions((*plain)(d), d.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Discriminator fields followed by its Specification Extensions.
+func (d *Discriminator) MarshalYAML() (any, error) {
+	if d == nil {
+		return nil, nil
+	}
+	type plain Discriminator
+	return marshalYAMLWithExtensions((*plain)(d), d.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Discriminator fields and collects its Specification Extensions.
+func (d *Discriminator) UnmarshalJSON(data []byte) error {
+	type plain Discriminator
+	return unmarshalJSONWithExtensions(data, (*plain)(d), &d.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Discriminator fields and collects its Specification Extensions.
+func (d *Discriminator) UnmarshalYAML(value *yaml.Node) error {
+	type plain Discriminator
+	return unmarshalYAMLWithExtensions(value, (*plain)(d), &d.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // A single encoding definition applied to a single schema property.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#encoding-object
@@ -28,4 +30,40 @@ type Encoding struct {
 	// pass through unchanged. The default value is false. This field SHALL be ignored if the request
 	// body media type is not application/x-www-form-urlencoded.
 	AllowReserved bool `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Encoding fields followed by its Specification Extensions.
+func (e *Encoding) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	type plain Encoding
+	return marshalJSONWithExtensions((*plain)(e), e.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Encoding fields followed by its Specification Extensions.
+func (e *Encoding) MarshalYAML() (any, error) {
+	if e == nil {
+		return nil, nil
+	}
+	type plain Encoding
+	return marshalYAMLWithExtensions((*plain)(e), e.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Encoding fields and collects its Specification Extensions.
+func (e *Encoding) UnmarshalJSON(data []byte) error {
+	type plain Encoding
+	return unmarshalJSONWithExtensions(data, (*plain)(e), &e.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Encoding fields and collects its Specification Extensions.
+func (e *Encoding) UnmarshalYAML(value *yaml.Node) error {
+	type plain Encoding
+	return unmarshalYAMLWithExtensions(value, (*plain)(e), &e.Extensions)
 }
@@ -0,0 +1,38 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Equal reports whether two OpenAPI documents are the same once serialized: map order,
+// and the Go types of numbers in examples and extensions, don't matter, while a nil and
+// an empty security list do (security: [] is serialized).
+func (o *OpenAPI) Equal(other *OpenAPI) bool {
+	return jsonEqual(o, other)
+}
+
+// Equal reports whether two schemas are the same once serialized (see OpenAPI.Equal).
+func (s *Schema) Equal(other *Schema) bool {
+	return jsonEqual(s, other)
+}
+
+// jsonEqual compares the JSON encodings of two objects. Objects that fail to encode are
+// only equal to themselves.
+func jsonEqual[T any](a, b *T) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	dataA, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	dataB, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(dataA, dataB)
+}
@@ -1,11 +1,21 @@
 package spec
 
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // An object grouping an internal or external example value with basic summary and description
 // metadata. This object is typically used in fields named examples (plural), and is a referenceable
 // alternative to older example (singular) fields that do not support referencing or metadata.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#example-object
 type Example struct {
+	// A reference to an example defined in components/examples.
+	// If present, the other fields are not serialized.
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+
 	// Short description for the example.
 	Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
 	// Long description for the example. CommonMark syntax MAY be used for rich text representation.
@@ -18,4 +28,46 @@ type Example struct {
 	// that cannot easily be included in JSON or YAML documents. The value field and externalValue
 	// field are mutually exclusive.
 	ExternalValue string `json:"externalValue,omitempty" yaml:"externalValue,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Example fields followed by its Specification Extensions.
+func (e *Example) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	if e.Ref != "" {
+		return json.Marshal(&Reference{Ref: e.Ref})
+	}
+	type plain Example
+	return marshalJSONWithExtensions((*plain)(e), e.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Example fields followed by its Specification Extensions.
+func (e *Example) MarshalYAML() (any, error) {
+	if e == nil {
+		return nil, nil
+	}
+	if e.Ref != "" {
+		return &Reference{Ref: e.Ref}, nil
+	}
+	type plain Example
+	return marshalYAMLWithExtensions((*plain)(e), e.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Example fields and collects its Specification Extensions.
+func (e *Example) UnmarshalJSON(data []byte) error {
+	type plain Example
+	return unmarshalJSONWithExtensions(data, (*plain)(e), &e.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Example fields and collects its Specification Extensions.
+func (e *Example) UnmarshalYAML(value *yaml.Node) error {
+	type plain Example
+	return unmarshalYAMLWithExtensions(value, (*plain)(e), &e.Extensions)
 }
@@ -0,0 +1,222 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Extensions holds Specification Extensions. Extension field names MUST begin with "x-".
+// The value can be null, a primitive, an array or an object.
+//
+// See: https://spec.openapis.org/oas/v3.1.1.html#specification-extensions
+type Extensions map[string]any
+
+// IsExtension reports whether a field name is a Specification Extension.
+func IsExtension(name string) bool {
+	return strings.HasPrefix(name, "x-")
+}
+
+// marshalJSONWithExtensions marshals v and appends the extension fields to the resulting object.
+// v MUST NOT implement json.Marshaler through the same method, to avoid infinite recursion.
+func marshalJSONWithExtensions(v any, extensions Extensions) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSuffix(data, []byte("}")))
+	first := bytes.Equal(data, []byte("{}"))
+
+	for _, name := range slices.Sorted(maps.Keys(extensions)) {
+		if !IsExtension(name) {
+			continue
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(extensions[name])
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalYAMLWithExtensions encodes v as a YAML node and appends the extension fields to it.
+// v MUST NOT implement yaml.Marshaler through the same method, to avoid infinite recursion.
+func marshalYAMLWithExtensions(v any, extensions Extensions) (any, error) {
+	if len(extensions) == 0 {
+		return v, nil
+	}
+
+	node := &yaml.Node{}
+	if err := node.Encode(v); err != nil {
+		return nil, err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(extensions)) {
+		if !IsExtension(name) {
+			continue
+		}
+		value := &yaml.Node{}
+		if err := value.Encode(extensions[name]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name},
+			value,
+		)
+	}
+
+	return node, nil
+}
+
+// unmarshalJSONWithExtensions unmarshals data into v and collects its extension fields.
+// v MUST NOT implement json.Unmarshaler through the same method, to avoid infinite recursion.
+func unmarshalJSONWithExtensions(data []byte, v any, extensions *Extensions) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name, raw := range fields {
+		if !IsExtension(name) {
+			continue
+		}
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		if *extensions == nil {
+			*extensions = make(Extensions)
+		}
+		(*extensions)[name] = value
+	}
+	return nil
+}
+
+// unmarshalYAMLWithExtensions decodes node into v and collects its extension fields.
+// v MUST NOT implement yaml.Unmarshaler through the same method, to avoid infinite recursion.
+func unmarshalYAMLWithExtensions(node *yaml.Node, v any, extensions *Extensions) error {
+	if err := node.Decode(v); err != nil {
+		return err
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+		if !IsExtension(name) {
+			continue
+		}
+		var value any
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+		if *extensions == nil {
+			*extensions = make(Extensions)
+		}
+		(*extensions)[name] = value
+	}
+	return nil
+}
+
+// mapWithExtensions returns the entries of a map-like object (Paths, Responses, Callback)
+// merged with its extension fields, or the entries alone when there are no extensions.
+func mapWithExtensions[V any](entries map[string]V, extensions Extensions) any {
+	if len(extensions) == 0 {
+		return entries
+	}
+
+	merged := make(map[string]any, len(entries)+len(extensions))
+	for name, value := range entries {
+		merged[name] = value
+	}
+	for name, value := range extensions {
+		if IsExtension(name) {
+			merged[name] = value
+		}
+	}
+	return merged
+}
+
+// unmarshalJSONMapWithExtensions unmarshals the fields of a map-like object into entries,
+// and its extension fields into extensions.
+func unmarshalJSONMapWithExtensions[V any](data []byte, entries map[string]V, extensions *Extensions) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for name, raw := range fields {
+		if IsExtension(name) {
+			var value any
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+			if *extensions == nil {
+				*extensions = make(Extensions)
+			}
+			(*extensions)[name] = value
+			continue
+		}
+
+		var entry V
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		entries[name] = entry
+	}
+	return nil
+}
+
+// unmarshalYAMLMapWithExtensions decodes the fields of a map-like object into entries,
+// and its extension fields into extensions.
+func unmarshalYAMLMapWithExtensions[V any](node *yaml.Node, entries map[string]V, extensions *Extensions) error {
+	if node.Kind != yaml.MappingNode {
+		// Let the decoder report the type mismatch
+		return node.Decode(&entries)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+		if IsExtension(name) {
+			var value any
+			if err := node.Content[i+1].Decode(&value); err != nil {
+				return err
+			}
+			if *extensions == nil {
+				*extensions = make(Extensions)
+			}
+			(*extensions)[name] = value
+			continue
+		}
+
+		var entry V
+		if err := node.Content[i+1].Decode(&entry); err != nil {
+			return err
+		}
+		entries[name] = entry
+	}
+	return nil
+}
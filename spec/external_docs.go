@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // Allows referencing an external resource for extended documentation.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#external-documentation-object
@@ -9,4 +11,40 @@ type ExternalDocs struct {
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	// REQUIRED. The URL for the target documentation. This MUST be in the form of a URL.
 	URL string `json:"url" yaml:"url"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the ExternalDocs fields followed by its Specification Extensions.
+func (e *ExternalDocs) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	type plain ExternalDocs
+	return marshalJSONWithExtensions((*plain)(e), e.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the ExternalDocs fields followed by its Specification Extensions.
+func (e *ExternalDocs) MarshalYAML() (any, error) {
+	if e == nil {
+		return nil, nil
+	}
+	type plain ExternalDocs
+	return marshalYAMLWithExtensions((*plain)(e), e.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the ExternalDocs fields and collects its Specification Extensions.
+func (e *ExternalDocs) UnmarshalJSON(data []byte) error {
+	type plain ExternalDocs
+	return unmarshalJSONWithExtensions(data, (*plain)(e), &e.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the ExternalDocs fields and collects its Specification Extensions.
+func (e *ExternalDocs) UnmarshalYAML(value *yaml.Node) error {
+	type plain ExternalDocs
+	return unmarshalYAMLWithExtensions(value, (*plain)(e), &e.Extensions)
 }
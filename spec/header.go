@@ -1,5 +1,11 @@
 package spec
 
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Describes a single header for HTTP responses and for individual parts in multipart
 // representations.
 //
@@ -11,6 +17,10 @@ package spec
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#header-object
 type Header struct {
+	// A reference to a header defined in components/headers.
+	// If present, the other fields are not serialized.
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+
 	// A brief description of the header. This could contain examples of use. CommonMark syntax MAY
 	// be used for rich text representation.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
@@ -35,4 +45,46 @@ type Header struct {
 	// A map containing the representations for the header. The key is the media type and the value
 	// describes it. The map MUST only contain one entry.
 	Content map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Header fields followed by its Specification Extensions.
+func (h *Header) MarshalJSON() ([]byte, error) {
+	if h == nil {
+		return []byte("null"), nil
+	}
+	if h.Ref != "" {
+		return json.Marshal(&Reference{Ref: h.Ref})
+	}
+	type plain Header
+	return marshalJSONWithExtensions((*plain)(h), h.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Header fields followed by its Specification Extensions.
+func (h *Header) MarshalYAML() (any, error) {
+	if h == nil {
+		return nil, nil
+	}
+	if h.Ref != "" {
+		return &Reference{Ref: h.Ref}, nil
+	}
+	type plain Header
+	return marshalYAMLWithExtensions((*plain)(h), h.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Header fields and collects its Specification Extensions.
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type plain Header
+	return unmarshalJSONWithExtensions(data, (*plain)(h), &h.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Header fields and collects its Specification Extensions.
+func (h *Header) UnmarshalYAML(value *yaml.Node) error {
+	type plain Header
+	return unmarshalYAMLWithExtensions(value, (*plain)(h), &h.Extensions)
 }
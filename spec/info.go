@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // The object provides metadata about the API.
 // The metadata MAY be used by the clients if needed, and MAY be presented in editing or
 // documentation generation tools for convenience.
@@ -21,4 +23,40 @@ type Info struct {
 	// REQUIRED. The version of the OpenAPI Document (which is distinct from the OpenAPI Specification
 	// version or the version of the API being described or the version of the OpenAPI Description).
 	Version string `json:"version" yaml:"version"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Info fields followed by its Specification Extensions.
+func (i *Info) MarshalJSON() ([]byte, error) {
+	if i == nil {
+		return []byte("null"), nil
+	}
+	type plain Info
+	return marshalJSONWithExtensions((*plain)(i), i.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Info fields followed by its Specification Extensions.
+func (i *Info) MarshalYAML() (any, error) {
+	if i == nil {
+		return nil, nil
+	}
+	type plain Info
+	return marshalYAMLWithExtensions((*plain)(i), i.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Info fields and collects its Specification Extensions.
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type plain Info
+	return unmarshalJSONWithExtensions(data, (*plain)(i), &i.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Info fields and collects its Specification Extensions.
+func (i *Info) UnmarshalYAML(value *yaml.Node) error {
+	type plain Info
+	return unmarshalYAMLWithExtensions(value, (*plain)(i), &i.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // License information for the exposed API.
 //
 // See: https://spec.openapis.org/oas/v3.1.1.html#license-object
@@ -11,4 +13,40 @@ type License struct {
 	// A URL for the license used for the API. This MUST be in the form of a URL.
 	// Mutually exclusive with Identifier.
 	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the License fields followed by its Specification Extensions.
+func (l *License) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("null"), nil
+	}
+	type plain License
+	return marshalJSONWithExtensions((*plain)(l), l.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the License fields followed by its Specification Extensions.
+func (l *License) MarshalYAML() (any, error) {
+	if l == nil {
+		return nil, nil
+	}
+	type plain License
+	return marshalYAMLWithExtensions((*plain)(l), l.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the License fields and collects its Specification Extensions.
+func (l *License) UnmarshalJSON(data []byte) error {
+	type plain License
+	return unmarshalJSONWithExtensions(data, (*plain)(l), &l.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the License fields and collects its Specification Extensions.
+func (l *License) UnmarshalYAML(value *yaml.Node) error {
+	type plain License
+	return unmarshalYAMLWithExtensions(value, (*plain)(l), &l.Extensions)
 }
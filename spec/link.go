@@ -1,11 +1,21 @@
 package spec
 
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // The Link Object represents a possible design-time link for a response. The presence of a link
 // does not guarantee the caller's ability to successfully invoke it, rather it provides a known
 // relationship and traversal mechanism between responses and other operations.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#link-object
 type Link struct {
+	// A reference to a link defined in components/links.
+	// If present, the other fields are not serialized.
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+
 	// A URI reference to an OAS operation. This field is mutually exclusive of the operationId field,
 	// and MUST point to an Operation Object.
 	OperationRef string `json:"operationRef,omitempty" yaml:"operationRef,omitempty"`
@@ -23,4 +33,46 @@ type Link struct {
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	// A server object to be used by the target operation.
 	Server *Server `json:"server,omitempty" yaml:"server,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Link fields followed by its Specification Extensions.
+func (l *Link) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("null"), nil
+	}
+	if l.Ref != "" {
+		return json.Marshal(&Reference{Ref: l.Ref})
+	}
+	type plain Link
+	return marshalJSONWithExtensions((*plain)(l), l.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Link fields followed by its Specification Extensions.
+func (l *Link) MarshalYAML() (any, error) {
+	if l == nil {
+		return nil, nil
+	}
+	if l.Ref != "" {
+		return &Reference{Ref: l.Ref}, nil
+	}
+	type plain Link
+	return marshalYAMLWithExtensions((*plain)(l), l.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Link fields and collects its Specification Extensions.
+func (l *Link) UnmarshalJSON(data []byte) error {
+	type plain Link
+	return unmarshalJSONWithExtensions(data, (*plain)(l), &l.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Link fields and collects its Specification Extensions.
+func (l *Link) UnmarshalYAML(value *yaml.Node) error {
+	type plain Link
+	return unmarshalYAMLWithExtensions(value, (*plain)(l), &l.Extensions)
 }
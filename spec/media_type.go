@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // Each Media Type Object provides schema and examples for the media type identified by its key.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#media-type-object
@@ -16,4 +18,40 @@ type MediaType struct {
 	// If no Encoding Object is provided for a property, the behavior is determined by the default
 	// values documented for the Encoding Object.
 	Encoding map[string]*Encoding `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the MediaType fields followed by its Specification Extensions.
+func (m *MediaType) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	type plain MediaType
+	return marshalJSONWithExtensions((*plain)(m), m.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the MediaType fields followed by its Specification Extensions.
+func (m *MediaType) MarshalYAML() (any, error) {
+	if m == nil {
+		return nil, nil
+	}
+	type plain MediaType
+	return marshalYAMLWithExtensions((*plain)(m), m.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the MediaType fields and collects its Specification Extensions.
+func (m *MediaType) UnmarshalJSON(data []byte) error {
+	type plain MediaType
+	return unmarshalJSONWithExtensions(data, (*plain)(m), &m.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the MediaType fields and collects its Specification Extensions.
+func (m *MediaType) UnmarshalYAML(value *yaml.Node) error {
+	type plain MediaType
+	return unmarshalYAMLWithExtensions(value, (*plain)(m), &m.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // OAuth Flow Object
 //
 // # Configuration details for a supported OAuth Flow
@@ -18,4 +20,40 @@ type OAuthFlow struct {
 	// REQUIRED. The available scopes for the OAuth2 security scheme. A map between the scope name and
 	// a short description for it. The map MAY be empty.
 	Scopes map[string]string `json:"scopes" yaml:"scopes"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the OAuthFlow fields followed by its Specification Extensions.
+func (f *OAuthFlow) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+	type plain OAuthFlow
+	return marshalJSONWithExtensions((*plain)(f), f.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the OAuthFlow fields followed by its Specification Extensions.
+func (f *OAuthFlow) MarshalYAML() (any, error) {
+	if f == nil {
+		return nil, nil
+	}
+	type plain OAuthFlow
+	return marshalYAMLWithExtensions((*plain)(f), f.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the OAuthFlow fields and collects its Specification Extensions.
+func (f *OAuthFlow) UnmarshalJSON(data []byte) error {
+	type plain OAuthFlow
+	return unmarshalJSONWithExtensions(data, (*plain)(f), &f.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the OAuthFlow fields and collects its Specification Extensions.
+func (f *OAuthFlow) UnmarshalYAML(value *yaml.Node) error {
+	type plain OAuthFlow
+	return unmarshalYAMLWithExtensions(value, (*plain)(f), &f.Extensions)
 }
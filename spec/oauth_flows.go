@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // OAuth Flows Object
 //
 // Allows configuration of the supported OAuth Flows.
@@ -14,4 +16,40 @@ type OAuthFlows struct {
 	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
 	// Configuration for the OAuth Authorization Code flow. Previously called accessCode in OpenAPI 2.0.
 	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the OAuthFlows fields followed by its Specification Extensions.
+func (f *OAuthFlows) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+	type plain OAuthFlows
+	return marshalJSONWithExtensions((*plain)(f), f.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the OAuthFlows fields followed by its Specification Extensions.
+func (f *OAuthFlows) MarshalYAML() (any, error) {
+	if f == nil {
+		return nil, nil
+	}
+	type plain OAuthFlows
+	return marshalYAMLWithExtensions((*plain)(f), f.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the OAuthFlows fields and collects its Specification Extensions.
+func (f *OAuthFlows) UnmarshalJSON(data []byte) error {
+	type plain OAuthFlows
+	return unmarshalJSONWithExtensions(data, (*plain)(f), &f.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the OAuthFlows fields and collects its Specification Extensions.
+func (f *OAuthFlows) UnmarshalYAML(value *yaml.Node) error {
+	type plain OAuthFlows
+	return unmarshalYAMLWithExtensions(value, (*plain)(f), &f.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // This is the root object of the OpenAPI Description.
 //
 // See: https://spec.openapis.org/oas/v3.1.1.html#openapi-object
@@ -38,4 +40,40 @@ type OpenAPI struct {
 	Tags []*Tag `json:"tags,omitempty" yaml:"tags,omitempty"`
 	// Additional external documentation.
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the OpenAPI fields followed by its Specification Extensions.
+func (o *OpenAPI) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+	type plain OpenAPI
+	return marshalJSONWithExtensions((*plain)(o), o.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the OpenAPI fields followed by its Specification Extensions.
+func (o *OpenAPI) MarshalYAML() (any, error) {
+	if o == nil {
+		return nil, nil
+	}
+	type plain OpenAPI
+	return marshalYAMLWithExtensions((*plain)(o), o.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the OpenAPI fields and collects its Specification Extensions.
+func (o *OpenAPI) UnmarshalJSON(data []byte) error {
+	type plain OpenAPI
+	return unmarshalJSONWithExtensions(data, (*plain)(o), &o.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the OpenAPI fields and collects its Specification Extensions.
+func (o *OpenAPI) UnmarshalYAML(value *yaml.Node) error {
+	type plain OpenAPI
+	return unmarshalYAMLWithExtensions(value, (*plain)(o), &o.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // Describes a single API operation on a path.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#operation-object
@@ -44,8 +46,44 @@ type Operation struct {
 	// Requirement Objects need to be satisfied to authorize a request. To make security optional,
 	// an empty security requirement ({}) can be included in the array. This definition overrides any
 	// declared top-level security. To remove a top-level security declaration, an empty array can be used.
-	Security []*SecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
+	Security SecurityRequirements `json:"security,omitzero" yaml:"security,omitempty"`
 	// An alternative servers array to service this operation. If a servers array is specified at the
 	// Path Item Object or OpenAPI Object level, it will be overridden by this value.
 	Servers []*Server `json:"servers,omitempty" yaml:"servers,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Operation fields followed by its Specification Extensions.
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+	type plain Operation
+	return marshalJSONWithExtensions((*plain)(o), o.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Operation fields followed by its Specification Extensions.
+func (o *Operation) MarshalYAML() (any, error) {
+	if o == nil {
+		return nil, nil
+	}
+	type plain Operation
+	return marshalYAMLWithExtensions((*plain)(o), o.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Operation fields and collects its Specification Extensions.
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type plain Operation
+	return unmarshalJSONWithExtensions(data, (*plain)(o), &o.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Operation fields and collects its Specification Extensions.
+func (o *Operation) UnmarshalYAML(value *yaml.Node) error {
+	type plain Operation
+	return unmarshalYAMLWithExtensions(value, (*plain)(o), &o.Extensions)
 }
@@ -1,11 +1,18 @@
 package spec
 
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Describes a single operation parameter.
 // A unique parameter is defined by a combination of a name and location.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#parameter-object
 type Parameter struct {
 	// A reference to a parameter defined in components/parameters.
+	// If present, the other fields are not serialized.
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 
 	// REQUIRED. The name of the parameter. Parameter names are case sensitive.
@@ -55,4 +62,46 @@ type Parameter struct {
 	// A map containing the representations for the parameter. The key is the media type and the value
 	// describes it. The map MUST only contain one entry.
 	Content map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Parameter fields followed by its Specification Extensions.
+func (p *Parameter) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+	if p.Ref != "" {
+		return json.Marshal(&Reference{Ref: p.Ref})
+	}
+	type plain Parameter
+	return marshalJSONWithExtensions((*plain)(p), p.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Parameter fields followed by its Specification Extensions.
+func (p *Parameter) MarshalYAML() (any, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if p.Ref != "" {
+		return &Reference{Ref: p.Ref}, nil
+	}
+	type plain Parameter
+	return marshalYAMLWithExtensions((*plain)(p), p.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Parameter fields and collects its Specification Extensions.
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type plain Parameter
+	return unmarshalJSONWithExtensions(data, (*plain)(p), &p.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Parameter fields and collects its Specification Extensions.
+func (p *Parameter) UnmarshalYAML(value *yaml.Node) error {
+	type plain Parameter
+	return unmarshalYAMLWithExtensions(value, (*plain)(p), &p.Extensions)
 }
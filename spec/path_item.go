@@ -1,5 +1,14 @@
 package spec
 
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Describes the operations available on a single path.
 // A Path Item MAY be empty, due to ACL constraints.
 // The path itself is still exposed to the documentation viewer but they will not know which
@@ -33,6 +42,11 @@ type PathItem struct {
 	Patch *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
 	// A definition of a TRACE operation on this path.
 	Trace *Operation `json:"trace,omitempty" yaml:"trace,omitempty"`
+	// A definition of a QUERY operation on this path (introduced in OpenAPI 3.2).
+	Query *Operation `json:"query,omitempty" yaml:"query,omitempty"`
+	// A map of additional operations on this path, keyed by HTTP method (e.g., "PURGE").
+	// The keys MUST NOT duplicate the methods with fixed fields (introduced in OpenAPI 3.2).
+	AdditionalOperations map[string]*Operation `json:"additionalOperations,omitempty" yaml:"additionalOperations,omitempty"`
 	// An alternative servers array to service all operations in this path. If a servers array is
 	// specified at the OpenAPI Object level, it will be overridden by this value.
 	Servers []*Server `json:"servers,omitempty" yaml:"servers,omitempty"`
@@ -42,4 +56,103 @@ type PathItem struct {
 	// of a name and location. The list can use the Reference Object to link to parameters that are
 	// defined in the OpenAPI Object's components.parameters.
 	Parameters []*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// operationFields returns the operation fields of the path item by HTTP method, in the
+// order of the fields.
+func (p *PathItem) operationFields() []struct {
+	method string
+	field  **Operation
+} {
+	return []struct {
+		method string
+		field  **Operation
+	}{
+		{"GET", &p.Get}, {"PUT", &p.Put}, {"POST", &p.Post}, {"DELETE", &p.Delete},
+		{"OPTIONS", &p.Options}, {"HEAD", &p.Head}, {"PATCH", &p.Patch}, {"TRACE", &p.Trace},
+		{"QUERY", &p.Query},
+	}
+}
+
+// Operations iterates over the operations of the path item with their HTTP method: the
+// operation fields in their order, then the additional operations sorted by method.
+func (p *PathItem) Operations() iter.Seq2[string, *Operation] {
+	return func(yield func(string, *Operation) bool) {
+		for _, f := range p.operationFields() {
+			if *f.field != nil && !yield(f.method, *f.field) {
+				return
+			}
+		}
+		for _, method := range slices.Sorted(maps.Keys(p.AdditionalOperations)) {
+			if op := p.AdditionalOperations[method]; op != nil && !yield(method, op) {
+				return
+			}
+		}
+	}
+}
+
+// Operation returns the operation of an HTTP method, from its field or the additional
+// operations, or nil.
+func (p *PathItem) Operation(method string) *Operation {
+	for _, f := range p.operationFields() {
+		if strings.EqualFold(f.method, method) {
+			return *f.field
+		}
+	}
+	return p.AdditionalOperations[method]
+}
+
+// SetOperation sets the operation of an HTTP method, in its field or in the additional
+// operations for the other methods. A nil operation removes it.
+func (p *PathItem) SetOperation(method string, op *Operation) {
+	for _, f := range p.operationFields() {
+		if strings.EqualFold(f.method, method) {
+			*f.field = op
+			return
+		}
+	}
+	if op == nil {
+		delete(p.AdditionalOperations, method)
+		return
+	}
+	if p.AdditionalOperations == nil {
+		p.AdditionalOperations = make(map[string]*Operation)
+	}
+	p.AdditionalOperations[method] = op
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the PathItem fields followed by its Specification Extensions.
+func (p *PathItem) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+	type plain PathItem
+	return marshalJSONWithExtensions((*plain)(p), p.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the PathItem fields followed by its Specification Extensions.
+func (p *PathItem) MarshalYAML() (any, error) {
+	if p == nil {
+		return nil, nil
+	}
+	type plain PathItem
+	return marshalYAMLWithExtensions((*plain)(p), p.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the PathItem fields and collects its Specification Extensions.
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	type plain PathItem
+	return unmarshalJSONWithExtensions(data, (*plain)(p), &p.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the PathItem fields and collects its Specification Extensions.
+func (p *PathItem) UnmarshalYAML(value *yaml.Node) error {
+	type plain PathItem
+	return unmarshalYAMLWithExtensions(value, (*plain)(p), &p.Extensions)
 }
@@ -1,7 +1,10 @@
 package spec
 
 import (
+	"bytes"
 	"encoding/json"
+	"maps"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
@@ -19,19 +22,48 @@ type Paths struct {
 	// paths with the same hierarchy but different templated names MUST NOT exist as they are identical.
 	// In case of ambiguous matching, it's up to the tooling to decide which one to use.
 	PathItems map[string]*PathItem `json:"-" yaml:"-"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+	// Order is the order of the paths in the serialized object. Paths missing from it
+	// follow in alphabetical order, then the extensions; nil orders every path
+	// alphabetically. It is not part of the document.
+	Order []string `json:"-" yaml:"-"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
-// It marshals the PathItems map directly as the Paths object.
+// It marshals the PathItems map and the Specification Extensions as the Paths object.
 func (p *Paths) MarshalJSON() ([]byte, error) {
-	if p == nil || p.PathItems == nil {
+	if p == nil || (p.PathItems == nil && len(p.Extensions) == 0) {
 		return []byte("{}"), nil
 	}
-	return json.Marshal(p.PathItems)
+	if p.Order == nil {
+		return json.Marshal(mapWithExtensions(p.PathItems, p.Extensions))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range p.orderedNames() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(p.field(name))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
-// It unmarshals the JSON directly into the PathItems map.
+// It unmarshals the JSON into the PathItems map and the Specification Extensions.
 func (p *Paths) UnmarshalJSON(data []byte) error {
 	if p == nil {
 		return nil
@@ -45,21 +77,68 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	// Unmarshal the data into the PathItems map
-	return json.Unmarshal(data, &p.PathItems)
+	// Unmarshal the data into the PathItems map, except the extensions
+	return unmarshalJSONMapWithExtensions(data, p.PathItems, &p.Extensions)
 }
 
 // MarshalYAML implements the yaml.Marshaler interface.
-// It marshals the PathItems map directly as the Paths object.
+// It marshals the PathItems map and the Specification Extensions as the Paths object.
 func (p *Paths) MarshalYAML() (any, error) {
-	if p == nil || p.PathItems == nil {
+	if p == nil || (p.PathItems == nil && len(p.Extensions) == 0) {
 		return map[string]any{}, nil
 	}
-	return p.PathItems, nil
+	if p.Order == nil {
+		return mapWithExtensions(p.PathItems, p.Extensions), nil
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, name := range p.orderedNames() {
+		var value yaml.Node
+		if err := value.Encode(p.field(name)); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, &value)
+	}
+	return node, nil
+}
+
+// orderedNames returns the names of the fields of the Paths object in serialization
+// order: the paths of Order, the other paths in alphabetical order, then the extensions.
+func (p *Paths) orderedNames() []string {
+	names := make([]string, 0, len(p.PathItems)+len(p.Extensions))
+	for _, name := range p.Order {
+		if _, ok := p.PathItems[name]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	var rest []string
+	for name := range p.PathItems {
+		if !slices.Contains(names, name) {
+			rest = append(rest, name)
+		}
+	}
+	slices.Sort(rest)
+	names = append(names, rest...)
+
+	for _, name := range slices.Sorted(maps.Keys(p.Extensions)) {
+		if _, ok := p.PathItems[name]; !ok && IsExtension(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// field returns the path item or extension of a field of the Paths object.
+func (p *Paths) field(name string) any {
+	if item, ok := p.PathItems[name]; ok {
+		return item
+	}
+	return p.Extensions[name]
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
-// It unmarshals the YAML directly into the PathItems map.
+// It unmarshals the YAML into the PathItems map and the Specification Extensions.
 func (p *Paths) UnmarshalYAML(value *yaml.Node) error {
 	if p == nil {
 		return nil
@@ -73,6 +152,6 @@ func (p *Paths) UnmarshalYAML(value *yaml.Node) error {
 		return nil
 	}
 
-	// Unmarshal the node into the PathItems map
-	return value.Decode(&p.PathItems)
+	// Unmarshal the node into the PathItems map, except the extensions
+	return unmarshalYAMLMapWithExtensions(value, p.PathItems, &p.Extensions)
 }
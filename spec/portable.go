@@ -0,0 +1,152 @@
+package spec
+
+import (
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// documentOrder is the order of the fields of the OpenAPI Object in portable YAML, the order
+// of the specification and of most hand-written documents. Other fields follow them.
+var documentOrder = []string{
+	"openapi", "info", "jsonSchemaDialect", "servers", "security", "tags", "externalDocs",
+	"paths", "webhooks", "components",
+}
+
+// MarshalPortableYAML encodes v as YAML that parsers implementing a subset of YAML read the
+// same way (see PortableYAML).
+func MarshalPortableYAML(v any) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(PortableYAML(&node))
+}
+
+// PortableYAML returns a copy of a YAML node without the constructs some parsers choke
+// on: aliases are replaced by copies of the anchored nodes, merge keys (<<) are expanded,
+// anchors are removed, and literal "<<" keys are quoted. The fields of the OpenAPI Object
+// are ordered as in the specification: openapi, info, servers, tags, paths, components.
+func PortableYAML(node *yaml.Node) *yaml.Node {
+	portable := expandAliases(node)
+
+	root := portable
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if hasField(root, "openapi") {
+		sortDocumentFields(root)
+	}
+	return portable
+}
+
+// expandAliases returns a deep copy of node with its aliases and merge keys expanded.
+func expandAliases(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return expandAliases(node.Alias)
+	}
+
+	c := *node
+	c.Anchor = ""
+	c.Content = nil
+
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			c.Content = append(c.Content, expandAliases(child))
+		}
+		return &c
+	}
+
+	// Explicit fields take precedence over merged ones, and earlier merged mappings
+	// over later ones
+	explicit := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isMergeKey(node.Content[i]) {
+			explicit[node.Content[i].Value] = true
+		}
+	}
+
+	added := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !isMergeKey(key) {
+			key = expandAliases(key)
+			if key.Kind == yaml.ScalarNode && key.Value == "<<" {
+				key.Style = yaml.DoubleQuotedStyle
+			}
+			c.Content = append(c.Content, key, expandAliases(value))
+			added[key.Value] = true
+			continue
+		}
+
+		for _, merged := range mergedMappings(value) {
+			for j := 0; j+1 < len(merged.Content); j += 2 {
+				name := merged.Content[j].Value
+				if explicit[name] || added[name] {
+					continue
+				}
+				c.Content = append(c.Content, merged.Content[j], merged.Content[j+1])
+				added[name] = true
+			}
+		}
+	}
+	return &c
+}
+
+// isMergeKey reports whether a mapping key is the merge key (an unquoted <<).
+func isMergeKey(key *yaml.Node) bool {
+	return key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge"
+}
+
+// mergedMappings returns the expanded mappings of the value of a merge key: a mapping
+// or a sequence of mappings.
+func mergedMappings(value *yaml.Node) []*yaml.Node {
+	value = expandAliases(value)
+	if value.Kind == yaml.SequenceNode {
+		return slices.DeleteFunc(value.Content, func(n *yaml.Node) bool { return n.Kind != yaml.MappingNode })
+	}
+	if value.Kind == yaml.MappingNode {
+		return []*yaml.Node{value}
+	}
+	return nil
+}
+
+// hasField reports whether node is a mapping with the named field.
+func hasField(node *yaml.Node, name string) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sortDocumentFields orders the fields of the OpenAPI Object as documentOrder, keeping
+// the order of the other fields.
+func sortDocumentFields(root *yaml.Node) {
+	type field struct {
+		key, value *yaml.Node
+	}
+	fields := make([]field, 0, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		fields = append(fields, field{root.Content[i], root.Content[i+1]})
+	}
+
+	rank := func(f field) int {
+		if i := slices.Index(documentOrder, f.key.Value); i >= 0 {
+			return i
+		}
+		return len(documentOrder)
+	}
+	slices.SortStableFunc(fields, func(a, b field) int {
+		return rank(a) - rank(b)
+	})
+
+	root.Content = root.Content[:0]
+	for _, f := range fields {
+		root.Content = append(root.Content, f.key, f.value)
+	}
+}
@@ -0,0 +1,105 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPortableYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "aliases are copied",
+			input: `paths:
+  /users:
+    get: &list
+      summary: List
+  /accounts:
+    get: *list
+`,
+			expected: `paths:
+    /users:
+        get:
+            summary: List
+    /accounts:
+        get:
+            summary: List
+`,
+		},
+		{
+			name: "merge keys are expanded",
+			input: `base: &base
+  type: string
+  format: uuid
+id:
+  <<: *base
+  format: ulid
+  "<<": literal
+`,
+			expected: `base:
+    type: string
+    format: uuid
+id:
+    type: string
+    format: ulid
+    "<<": literal
+`,
+		},
+		{
+			name: "document fields in specification order",
+			input: `components: {}
+paths: {}
+x-owner: api
+tags: []
+info: {title: API}
+openapi: 3.1.2
+servers: []
+`,
+			expected: `openapi: 3.1.2
+info: {title: API}
+servers: []
+tags: []
+paths: {}
+components: {}
+x-owner: api
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tt.input), &node))
+
+			data, err := yaml.Marshal(PortableYAML(&node))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestMarshalPortableYAML(t *testing.T) {
+	openAPI := &OpenAPI{
+		OpenAPI: "3.1.2",
+		Info:    &Info{Title: "API", Version: "1.0.0"},
+		Paths:   &Paths{PathItems: map[string]*PathItem{}},
+		Tags:    []*Tag{{Name: "users"}},
+	}
+
+	data, err := MarshalPortableYAML(openAPI)
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.2
+info:
+    title: API
+    version: 1.0.0
+tags:
+    - name: users
+paths: {}
+`, string(data))
+}
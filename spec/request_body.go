@@ -1,9 +1,19 @@
 package spec
 
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Describes a single request body.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#request-body-object
 type RequestBody struct {
+	// A reference to a request body defined in components/requestBodies.
+	// If present, the other fields are not serialized.
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+
 	// A brief description of the request body. This could contain examples of use. CommonMark syntax
 	// MAY be used for rich text representation.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
@@ -13,4 +23,46 @@ type RequestBody struct {
 	Content map[string]*MediaType `json:"content" yaml:"content"`
 	// Determines if the request body is required in the request. Defaults to false.
 	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the RequestBody fields followed by its Specification Extensions.
+func (r *RequestBody) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+	if r.Ref != "" {
+		return json.Marshal(&Reference{Ref: r.Ref})
+	}
+	type plain RequestBody
+	return marshalJSONWithExtensions((*plain)(r), r.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the RequestBody fields followed by its Specification Extensions.
+func (r *RequestBody) MarshalYAML() (any, error) {
+	if r == nil {
+		return nil, nil
+	}
+	if r.Ref != "" {
+		return &Reference{Ref: r.Ref}, nil
+	}
+	type plain RequestBody
+	return marshalYAMLWithExtensions((*plain)(r), r.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the RequestBody fields and collects its Specification Extensions.
+func (r *RequestBody) UnmarshalJSON(data []byte) error {
+	type plain RequestBody
+	return unmarshalJSONWithExtensions(data, (*plain)(r), &r.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the RequestBody fields and collects its Specification Extensions.
+func (r *RequestBody) UnmarshalYAML(value *yaml.Node) error {
+	type plain RequestBody
+	return unmarshalYAMLWithExtensions(value, (*plain)(r), &r.Extensions)
 }
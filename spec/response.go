@@ -1,10 +1,20 @@
 package spec
 
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Describes a single response from an API operation, including design-time, static links to
 // operations based on the response.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#response-object
 type Response struct {
+	// A reference to a response defined in components/responses.
+	// If present, the other fields are not serialized.
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+
 	// REQUIRED. A description of the response. CommonMark syntax MAY be used for rich text
 	// representation.
 	Description string `json:"description" yaml:"description"`
@@ -18,4 +28,46 @@ type Response struct {
 	// A map of operations links that can be followed from the response. The key of the map is a short
 	// name for the link, following the naming constraints of the names for Component Objects.
 	Links map[string]*Link `json:"links,omitempty" yaml:"links,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Response fields followed by its Specification Extensions.
+func (r *Response) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+	if r.Ref != "" {
+		return json.Marshal(&Reference{Ref: r.Ref})
+	}
+	type plain Response
+	return marshalJSONWithExtensions((*plain)(r), r.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Response fields followed by its Specification Extensions.
+func (r *Response) MarshalYAML() (any, error) {
+	if r == nil {
+		return nil, nil
+	}
+	if r.Ref != "" {
+		return &Reference{Ref: r.Ref}, nil
+	}
+	type plain Response
+	return marshalYAMLWithExtensions((*plain)(r), r.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Response fields and collects its Specification Extensions.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type plain Response
+	return unmarshalJSONWithExtensions(data, (*plain)(r), &r.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Response fields and collects its Specification Extensions.
+func (r *Response) UnmarshalYAML(value *yaml.Node) error {
+	type plain Response
+	return unmarshalYAMLWithExtensions(value, (*plain)(r), &r.Extensions)
 }
@@ -31,10 +31,12 @@ type Responses struct {
 	// range of response codes, this field MAY contain the uppercase wildcard character X. For
 	// example, 2XX represents all response codes between 200 and 299.
 	StatusCodes map[string]*Response `json:"-" yaml:"-"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
-// It marshals the StatusCodes map and Default response into a single JSON object.
+// It marshals the StatusCodes map, Default response, and Specification Extensions into a single JSON object.
 func (r *Responses) MarshalJSON() ([]byte, error) {
 	if r == nil {
 		return []byte("{}"), nil
@@ -53,11 +55,11 @@ func (r *Responses) MarshalJSON() ([]byte, error) {
 		result["default"] = r.Default
 	}
 
-	return json.Marshal(result)
+	return json.Marshal(mapWithExtensions(result, r.Extensions))
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
-// It unmarshals a JSON object into StatusCodes map and Default response.
+// It unmarshals a JSON object into StatusCodes map, Default response, and Specification Extensions.
 func (r *Responses) UnmarshalJSON(data []byte) error {
 	if r == nil {
 		return nil
@@ -72,8 +74,8 @@ func (r *Responses) UnmarshalJSON(data []byte) error {
 	}
 
 	// Unmarshal into a temporary map
-	var temp map[string]*Response
-	if err := json.Unmarshal(data, &temp); err != nil {
+	temp := make(map[string]*Response)
+	if err := unmarshalJSONMapWithExtensions(data, temp, &r.Extensions); err != nil {
 		return err
 	}
 
@@ -90,7 +92,7 @@ func (r *Responses) UnmarshalJSON(data []byte) error {
 }
 
 // MarshalYAML implements the yaml.Marshaler interface.
-// It marshals the StatusCodes map and Default response into a single YAML object.
+// It marshals the StatusCodes map, Default response, and Specification Extensions into a single YAML object.
 func (r *Responses) MarshalYAML() (any, error) {
 	if r == nil {
 		return map[string]any{}, nil
@@ -109,11 +111,11 @@ func (r *Responses) MarshalYAML() (any, error) {
 		result["default"] = r.Default
 	}
 
-	return result, nil
+	return mapWithExtensions(result, r.Extensions), nil
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
-// It unmarshals a YAML node into StatusCodes map and Default response.
+// It unmarshals a YAML node into StatusCodes map, Default response, and Specification Extensions.
 func (r *Responses) UnmarshalYAML(value *yaml.Node) error {
 	if r == nil {
 		return nil
@@ -128,8 +130,8 @@ func (r *Responses) UnmarshalYAML(value *yaml.Node) error {
 	}
 
 	// Unmarshal into a temporary map
-	var temp map[string]*Response
-	if err := value.Decode(&temp); err != nil {
+	temp := make(map[string]*Response)
+	if err := unmarshalYAMLMapWithExtensions(value, temp, &r.Extensions); err != nil {
 		return err
 	}
 
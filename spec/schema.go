@@ -1,5 +1,12 @@
 package spec
 
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // The Schema Object allows the definition of input and output data types. These types can be
 // objects, but also primitives and arrays. This object is a superset of the JSON Schema
 // Specification Draft 2020-12.
@@ -14,10 +21,18 @@ type Schema struct {
 
 	// The JSON Schema dialect for this schema.
 	Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+	// The base URI of this schema, identifying it for references.
+	ID string `json:"$id,omitempty" yaml:"$id,omitempty"`
+	// The vocabularies of a meta-schema, by URI, and whether they are required.
+	Vocabulary map[string]bool `json:"$vocabulary,omitempty" yaml:"$vocabulary,omitempty"`
 	// Inline schema definitions (replaces "definitions").
 	Defs map[string]*Schema `json:"$defs,omitempty" yaml:"$defs,omitempty"`
 	// Plain name anchor for this schema.
 	Anchor string `json:"$anchor,omitempty" yaml:"$anchor,omitempty"`
+	// A reference resolved in the dynamic scope, to a schema with a matching $dynamicAnchor.
+	DynamicRef string `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
+	// Anchor for this schema that $dynamicRef resolves in the dynamic scope.
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty" yaml:"$dynamicAnchor,omitempty"`
 	// A comment for schema maintainers.
 	Comment string `json:"$comment,omitempty" yaml:"$comment,omitempty"`
 
@@ -51,12 +66,18 @@ type Schema struct {
 	MinItems *uint64 `json:"minItems,omitempty" yaml:"minItems,omitempty"`
 	// Indicates whether items in an array must be unique.
 	UniqueItems bool `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	// The maximum number of array items matching contains.
+	MaxContains *uint64 `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
+	// The minimum number of array items matching contains (default 1).
+	MinContains *uint64 `json:"minContains,omitempty" yaml:"minContains,omitempty"`
 	// The maximum number of properties in an object.
 	MaxProperties *uint64 `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
 	// The minimum number of properties in an object.
 	MinProperties *uint64 `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
 	// A list of required properties when type is "object".
 	Required []string `json:"required,omitempty" yaml:"required,omitempty"`
+	// Properties that are required when a specific property is present.
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty" yaml:"dependentRequired,omitempty"`
 	// The enumeration of possible values.
 	Enum []any `json:"enum,omitempty" yaml:"enum,omitempty"`
 	// A constant value that the instance must be equal to.
@@ -91,9 +112,13 @@ type Schema struct {
 	Contains *Schema `json:"contains,omitempty" yaml:"contains,omitempty"`
 	// Property definitions MUST be a Schema Object (inline or referenced).
 	Properties map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	// Schemas of the properties whose names match a regular expression.
+	PatternProperties map[string]*Schema `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
 	// Value can be boolean or object. Inline or referenced schema MUST be of a Schema Object.
 	// Consistent with JSON Schema, additionalProperties defaults to true.
 	AdditionalProperties *Schema `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	// Schema that every property name of the object must validate against.
+	PropertyNames *Schema `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`
 	// Applies to properties not evaluated by other keywords (e.g., allOf/properties).
 	UnevaluatedProperties *Schema `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
 	// Applies to array items not evaluated by other keywords (e.g., prefixItems/items).
@@ -106,6 +131,8 @@ type Schema struct {
 	ContentMediaType string `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
 	// The encoding of the string content (e.g., "base64").
 	ContentEncoding string `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+	// The schema of the decoded string content.
+	ContentSchema *Schema `json:"contentSchema,omitempty" yaml:"contentSchema,omitempty"`
 
 	// JSON Schema keywords — Metadata/Annotation
 
@@ -119,6 +146,8 @@ type Schema struct {
 	Default any `json:"default,omitempty" yaml:"default,omitempty"`
 	// An array of examples. Replaces the singular "example" keyword from OpenAPI 3.0.
 	Examples []any `json:"examples,omitempty" yaml:"examples,omitempty"`
+	// Deprecated: A free-form example of an instance of this schema. Use examples instead.
+	Example any `json:"example,omitempty" yaml:"example,omitempty"`
 
 	// OpenAPI-specific fixed fields
 
@@ -137,4 +166,69 @@ type Schema struct {
 	// Specifies that a schema is deprecated and SHOULD be transitioned out of usage. Default value is
 	// false.
 	Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+
+	// A boolean schema: true accepts any value and false rejects every value. When set, the
+	// schema is serialized as the boolean and the other fields are ignored.
+	Bool *bool `json:"-" yaml:"-"`
+}
+
+// BoolSchema returns the boolean schema true or false, as in additionalProperties: false.
+func BoolSchema(value bool) *Schema {
+	return &Schema{Bool: &value}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Schema fields followed by its Specification Extensions.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	if s.Bool != nil {
+		return json.Marshal(*s.Bool)
+	}
+	type plain Schema
+	return marshalJSONWithExtensions((*plain)(s), s.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Schema fields followed by its Specification Extensions.
+func (s *Schema) MarshalYAML() (any, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if s.Bool != nil {
+		return *s.Bool, nil
+	}
+	type plain Schema
+	return marshalYAMLWithExtensions((*plain)(s), s.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Schema fields and collects its Specification Extensions.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true", "false":
+		value := string(bytes.TrimSpace(data)) == "true"
+		*s = Schema{Bool: &value}
+		return nil
+	}
+	type plain Schema
+	return unmarshalJSONWithExtensions(data, (*plain)(s), &s.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Schema fields and collects its Specification Extensions.
+func (s *Schema) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode && value.Tag == "!!bool" {
+		var b bool
+		if err := value.Decode(&b); err != nil {
+			return err
+		}
+		*s = Schema{Bool: &b}
+		return nil
+	}
+	type plain Schema
+	return unmarshalYAMLWithExtensions(value, (*plain)(s), &s.Extensions)
 }
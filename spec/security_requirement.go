@@ -31,6 +31,16 @@ type SecurityRequirement struct {
 	Requirements map[string][]string `json:"-" yaml:"-"`
 }
 
+// SecurityRequirements is a list of alternative Security Requirement Objects.
+// A nil list is omitted; an empty list is serialized as [] so an operation can
+// remove the top-level security declaration.
+type SecurityRequirements []*SecurityRequirement
+
+// IsZero returns true if the list is nil (for omitzero and yaml omitempty support).
+func (s SecurityRequirements) IsZero() bool {
+	return s == nil
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 // It marshals the Requirements map directly as the SecurityRequirement object.
 func (s *SecurityRequirement) MarshalJSON() ([]byte, error) {
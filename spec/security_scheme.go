@@ -1,5 +1,11 @@
 package spec
 
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Security Scheme Object
 //
 // Defines a security scheme that can be used by the operations.
@@ -13,6 +19,10 @@ package spec
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#security-scheme-object
 type SecurityScheme struct {
+	// A reference to a security scheme defined in components/securitySchemes.
+	// If present, the other fields are not serialized.
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+
 	// REQUIRED. The type of the security scheme. Valid values are "apiKey", "http", "oauth2", "openIdConnect".
 	Type string `json:"type" yaml:"type"`
 	// A description for security scheme. CommonMark syntax MAY be used for rich text representation.
@@ -32,4 +42,46 @@ type SecurityScheme struct {
 	Flows *OAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
 	// REQUIRED (openIdConnect). Well-known URL to discover the OpenID Connect Discovery provider metadata.
 	OpenIdConnectUrl string `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the SecurityScheme fields followed by its Specification Extensions.
+func (s *SecurityScheme) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	if s.Ref != "" {
+		return json.Marshal(&Reference{Ref: s.Ref})
+	}
+	type plain SecurityScheme
+	return marshalJSONWithExtensions((*plain)(s), s.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the SecurityScheme fields followed by its Specification Extensions.
+func (s *SecurityScheme) MarshalYAML() (any, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if s.Ref != "" {
+		return &Reference{Ref: s.Ref}, nil
+	}
+	type plain SecurityScheme
+	return marshalYAMLWithExtensions((*plain)(s), s.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the SecurityScheme fields and collects its Specification Extensions.
+func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type plain SecurityScheme
+	return unmarshalJSONWithExtensions(data, (*plain)(s), &s.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the SecurityScheme fields and collects its Specification Extensions.
+func (s *SecurityScheme) UnmarshalYAML(value *yaml.Node) error {
+	type plain SecurityScheme
+	return unmarshalYAMLWithExtensions(value, (*plain)(s), &s.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // An object representing a Server.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#server-object
@@ -15,4 +17,40 @@ type Server struct {
 	// A map between a variable name and its value. The value is used for substitution in the
 	// server's URL template.
 	Variables map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Server fields followed by its Specification Extensions.
+func (s *Server) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	type plain Server
+	return marshalJSONWithExtensions((*plain)(s), s.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Server fields followed by its Specification Extensions.
+func (s *Server) MarshalYAML() (any, error) {
+	if s == nil {
+		return nil, nil
+	}
+	type plain Server
+	return marshalYAMLWithExtensions((*plain)(s), s.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Server fields and collects its Specification Extensions.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	return unmarshalJSONWithExtensions(data, (*plain)(s), &s.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Server fields and collects its Specification Extensions.
+func (s *Server) UnmarshalYAML(value *yaml.Node) error {
+	type plain Server
+	return unmarshalYAMLWithExtensions(value, (*plain)(s), &s.Extensions)
 }
@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // An object representing a Server Variable for server URL template substitution.
 //
 // See: https://spec.openapis.org/oas/v3.0.4.html#server-variable-object
@@ -15,4 +17,40 @@ type ServerVariable struct {
 	// An optional description for the server variable. CommonMark syntax MAY be used for rich text
 	// representation.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the ServerVariable fields followed by its Specification Extensions.
+func (s *ServerVariable) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	type plain ServerVariable
+	return marshalJSONWithExtensions((*plain)(s), s.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the ServerVariable fields followed by its Specification Extensions.
+func (s *ServerVariable) MarshalYAML() (any, error) {
+	if s == nil {
+		return nil, nil
+	}
+	type plain ServerVariable
+	return marshalYAMLWithExtensions((*plain)(s), s.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the ServerVariable fields and collects its Specification Extensions.
+func (s *ServerVariable) UnmarshalJSON(data []byte) error {
+	type plain ServerVariable
+	return unmarshalJSONWithExtensions(data, (*plain)(s), &s.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the ServerVariable fields and collects its Specification Extensions.
+func (s *ServerVariable) UnmarshalYAML(value *yaml.Node) error {
+	type plain ServerVariable
+	return unmarshalYAMLWithExtensions(value, (*plain)(s), &s.Extensions)
 }
@@ -93,6 +93,38 @@ func TestPathsUnmarshalYAML(t *testing.T) {
 	assert.Contains(t, paths.PathItems, "/users")
 }
 
+func TestPathsOrder(t *testing.T) {
+	paths := &Paths{
+		PathItems: map[string]*PathItem{
+			"/a":     {Summary: "A"},
+			"/b":     {Summary: "B"},
+			"/users": {Summary: "Users"},
+			"/z":     {Summary: "Z"},
+		},
+		Extensions: Extensions{"x-owner": "team"},
+		Order:      []string{"/z", "/users", "/missing"},
+	}
+
+	data, err := json.Marshal(paths)
+	require.NoError(t, err)
+	assert.Equal(t, `{"/z":{"summary":"Z"},"/users":{"summary":"Users"},"/a":{"summary":"A"},"/b":{"summary":"B"},"x-owner":"team"}`, string(data))
+
+	data, err = yaml.Marshal(paths)
+	require.NoError(t, err)
+	assert.Equal(t, `/z:
+    summary: Z
+/users:
+    summary: Users
+/a:
+    summary: A
+/b:
+    summary: B
+x-owner: team
+`, string(data))
+
+	assert.Equal(t, paths.Order, paths.Clone().Order)
+}
+
 // ==================== Responses Tests ====================
 
 func TestResponsesMarshalJSON(t *testing.T) {
@@ -380,3 +412,348 @@ func TestCallbackUnmarshalJSONEmpty(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, callback.PathItems)
 }
+
+// ==================== Extensions Tests ====================
+
+func TestExtensionsMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "info with extensions",
+			value:    &Info{Title: "API", Version: "1.0.0", Extensions: Extensions{"x-logo": map[string]any{"url": "logo.png"}}},
+			expected: `{"title":"API","version":"1.0.0","x-logo":{"url":"logo.png"}}`,
+		},
+		{
+			name:     "operation with extensions",
+			value:    &Operation{OperationID: "getUser", Extensions: Extensions{"x-internal": true, "x-rate-limit": 100}},
+			expected: `{"operationId":"getUser","responses":null,"x-internal":true,"x-rate-limit":100}`,
+		},
+		{
+			name:     "empty schema with extensions",
+			value:    &Schema{Extensions: Extensions{"x-go-type": "uuid.UUID"}},
+			expected: `{"x-go-type":"uuid.UUID"}`,
+		},
+		{
+			name:     "parameter with extensions",
+			value:    &Parameter{Name: "id", In: "path", Extensions: Extensions{"x-example-id": "42"}},
+			expected: `{"name":"id","in":"path","x-example-id":"42"}`,
+		},
+		{
+			name:     "paths with extensions",
+			value:    &Paths{PathItems: map[string]*PathItem{"/users": {Summary: "Users"}}, Extensions: Extensions{"x-group": "users"}},
+			expected: `{"/users":{"summary":"Users"},"x-group":"users"}`,
+		},
+		{
+			name:     "responses with extensions",
+			value:    &Responses{Default: &Response{Description: "Error"}, Extensions: Extensions{"x-retry": true}},
+			expected: `{"default":{"description":"Error"},"x-retry":true}`,
+		},
+		{
+			name:     "non-extension keys are dropped",
+			value:    &Schema{Format: "uuid", Extensions: Extensions{"internal": true}},
+			expected: `{"format":"uuid"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestExtensionsMarshalYAML(t *testing.T) {
+	schema := &Schema{
+		Type:       NewSchemaType("string"),
+		Properties: map[string]*Schema{"id": {Extensions: Extensions{"x-order": 1}}},
+		Extensions: Extensions{"x-internal": true},
+	}
+
+	data, err := yaml.Marshal(schema)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.Equal(t, true, decoded["x-internal"])
+	assert.Equal(t, map[string]any{"id": map[string]any{"x-order": 1}}, decoded["properties"])
+}
+
+func TestExtensionsNilReceiver(t *testing.T) {
+	var schema *Schema
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestExtensionsUnmarshalJSON(t *testing.T) {
+	data := `{
+		"openapi": "3.1.2",
+		"info": {"title": "API", "version": "1.0.0", "x-logo": {"url": "logo.png"}},
+		"servers": [{"url": "https://api.example.com", "x-region": "eu"}],
+		"paths": {"/users": {"get": {"operationId": "listUsers", "x-rate-limit": 100}}},
+		"components": {"schemas": {"User": {"type": "object", "x-go-type": "User"}}},
+		"x-tenant": "acme"
+	}`
+
+	var openAPI OpenAPI
+	require.NoError(t, json.Unmarshal([]byte(data), &openAPI))
+
+	assert.Equal(t, Extensions{"x-tenant": "acme"}, openAPI.Extensions)
+	assert.Equal(t, "API", openAPI.Info.Title)
+	assert.Equal(t, Extensions{"x-logo": map[string]any{"url": "logo.png"}}, openAPI.Info.Extensions)
+	assert.Equal(t, Extensions{"x-region": "eu"}, openAPI.Servers[0].Extensions)
+	assert.Equal(t, "listUsers", openAPI.Paths.PathItems["/users"].Get.OperationID)
+	assert.Equal(t, Extensions{"x-rate-limit": float64(100)}, openAPI.Paths.PathItems["/users"].Get.Extensions)
+	assert.Equal(t, "object", openAPI.Components.Schemas["User"].Type.Value())
+	assert.Equal(t, Extensions{"x-go-type": "User"}, openAPI.Components.Schemas["User"].Extensions)
+}
+
+func TestExtensionsUnmarshalYAML(t *testing.T) {
+	original := &OpenAPI{
+		OpenAPI: "3.1.2",
+		Info:    &Info{Title: "API", Version: "1.0.0", Extensions: Extensions{"x-audience": "public"}},
+		Paths: &Paths{PathItems: map[string]*PathItem{
+			"/users": {Get: &Operation{OperationID: "listUsers", Extensions: Extensions{"x-internal": true}}},
+		}},
+		Components: &Components{Schemas: map[string]*Schema{
+			"User": {Type: NewSchemaType("object"), Extensions: Extensions{"x-order": 1}},
+		}},
+		Extensions: Extensions{"x-tenant": "acme"},
+	}
+
+	data, err := yaml.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded OpenAPI
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.Equal(t, original.Extensions, decoded.Extensions)
+	assert.Equal(t, original.Info.Extensions, decoded.Info.Extensions)
+	assert.Equal(t, Extensions{"x-internal": true}, decoded.Paths.PathItems["/users"].Get.Extensions)
+	assert.Equal(t, Extensions{"x-order": 1}, decoded.Components.Schemas["User"].Extensions)
+	assert.Nil(t, decoded.Components.Schemas["User"].Properties)
+}
+
+func TestExtensionsUnmarshalMapObjects(t *testing.T) {
+	data := `
+/users:
+  x-controller: users
+  get:
+    operationId: listUsers
+    responses:
+      "200":
+        description: OK
+      x-cache: 60
+x-group: accounts
+`
+
+	var paths Paths
+	require.NoError(t, yaml.Unmarshal([]byte(data), &paths))
+	assert.Equal(t, Extensions{"x-group": "accounts"}, paths.Extensions)
+	require.Len(t, paths.PathItems, 1)
+
+	pathItem := paths.PathItems["/users"]
+	assert.Equal(t, Extensions{"x-controller": "users"}, pathItem.Extensions)
+	assert.Equal(t, Extensions{"x-cache": 60}, pathItem.Get.Responses.Extensions)
+	assert.Len(t, pathItem.Get.Responses.StatusCodes, 1)
+
+	// JSON round trip
+	encoded, err := json.Marshal(&paths)
+	require.NoError(t, err)
+	var decoded Paths
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, Extensions{"x-group": "accounts"}, decoded.Extensions)
+	assert.Equal(t, Extensions{"x-controller": "users"}, decoded.PathItems["/users"].Extensions)
+	assert.Equal(t, Extensions{"x-cache": float64(60)}, decoded.PathItems["/users"].Get.Responses.Extensions)
+}
+
+func TestExtensionsRoundTrip(t *testing.T) {
+	// Every object of the document has an extension
+	document := `openapi: 3.1.2
+info:
+  title: API
+  version: 1.0.0
+  contact: {name: Team, x-slack: "#api"}
+  license: {name: MIT, x-spdx: MIT}
+  x-info: 1
+servers:
+  - url: https://{region}.example.com
+    variables:
+      region: {default: eu, x-variable: 1}
+    x-server: 1
+paths:
+  /users:
+    parameters:
+      - {name: tenant, in: header, x-parameter: 1}
+    post:
+      operationId: createUser
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+            encoding:
+              avatar: {contentType: image/png, x-encoding: 1}
+            examples:
+              basic: {value: {name: Ann}, x-example: 1}
+            x-media-type: 1
+        x-request-body: 1
+      responses:
+        "201":
+          description: Created
+          headers:
+            Location: {schema: {type: string}, x-header: 1}
+          links:
+            self: {operationId: getUser, x-link: 1}
+          x-response: 1
+        x-responses: 1
+      callbacks:
+        created:
+          '{$request.body#/callback}':
+            post:
+              responses:
+                "200": {description: OK}
+          x-callback: 1
+      x-operation: 1
+    x-path-item: 1
+  x-paths: 1
+components:
+  schemas:
+    User:
+      type: object
+      discriminator: {propertyName: kind, x-discriminator: 1}
+      xml: {name: user, x-xml: 1}
+      x-schema: 1
+  securitySchemes:
+    oauth:
+      type: oauth2
+      flows:
+        password:
+          tokenUrl: /token
+          scopes: {read: Read}
+          x-flow: 1
+        x-flows: 1
+      x-security-scheme: 1
+  x-components: 1
+tags:
+  - name: users
+    externalDocs: {url: https://example.com, x-docs: 1}
+    x-tag: 1
+x-document: 1
+`
+
+	var original map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(document), &original))
+
+	var openAPI OpenAPI
+	require.NoError(t, yaml.Unmarshal([]byte(document), &openAPI))
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := yaml.Marshal(&openAPI)
+		require.NoError(t, err)
+
+		var got map[string]any
+		require.NoError(t, yaml.Unmarshal(data, &got))
+		assert.Equal(t, original, got)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(&openAPI)
+		require.NoError(t, err)
+
+		var decoded OpenAPI
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		data, err = json.Marshal(&decoded)
+		require.NoError(t, err)
+
+		expected, err := json.Marshal(original)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(data))
+	})
+}
+
+// ==================== Boolean Schema Tests ====================
+
+func TestBoolSchema(t *testing.T) {
+	schema := &Schema{
+		Type:                 NewSchemaType("object"),
+		AdditionalProperties: BoolSchema(false),
+		Properties:           map[string]*Schema{"any": BoolSchema(true)},
+	}
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{"any":true},"additionalProperties":false}`, string(data))
+
+	var decoded Schema
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, schema, &decoded)
+
+	data, err = yaml.Marshal(schema)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "additionalProperties: false\n")
+
+	decoded = Schema{}
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.Equal(t, schema, &decoded)
+
+	// null is not a boolean schema
+	var nullable struct {
+		Items *Schema `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"items":null}`), &nullable))
+	assert.Nil(t, nullable.Items)
+}
+
+// ==================== Reference Tests ====================
+
+func TestReferenceMarshal(t *testing.T) {
+	// The fields set next to $ref are not serialized
+	operation := &Operation{
+		Parameters: []*Parameter{{Ref: "#/components/parameters/Tenant", Name: "tenant", In: "header"}},
+		RequestBody: &RequestBody{
+			Ref:      "#/components/requestBodies/User",
+			Required: true,
+		},
+		Responses: &Responses{StatusCodes: map[string]*Response{
+			"200": {
+				Ref:         "#/components/responses/User",
+				Description: "User",
+				Headers: map[string]*Header{
+					"X-Rate-Limit": {Ref: "#/components/headers/RateLimit", Required: true},
+				},
+			},
+		}},
+	}
+
+	data, err := json.Marshal(operation)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"parameters": [{"$ref": "#/components/parameters/Tenant"}],
+		"requestBody": {"$ref": "#/components/requestBodies/User"},
+		"responses": {"200": {"$ref": "#/components/responses/User"}}
+	}`, string(data))
+
+	header, err := yaml.Marshal(operation.Responses.StatusCodes["200"].Headers["X-Rate-Limit"])
+	require.NoError(t, err)
+	assert.Equal(t, "$ref: '#/components/headers/RateLimit'\n", string(header))
+
+	// References decode back to the same objects
+	var decoded Operation
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "#/components/parameters/Tenant", decoded.Parameters[0].Ref)
+	assert.Equal(t, "#/components/requestBodies/User", decoded.RequestBody.Ref)
+	assert.Equal(t, "#/components/responses/User", decoded.Responses.StatusCodes["200"].Ref)
+
+	yamlData, err := yaml.Marshal(&decoded)
+	require.NoError(t, err)
+	var fromYAML Operation
+	require.NoError(t, yaml.Unmarshal(yamlData, &fromYAML))
+	roundTrip, err := json.Marshal(&fromYAML)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(roundTrip))
+}
@@ -0,0 +1,166 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownField is a field of a decoded document that is neither defined by the specification
+// nor a Specification Extension. The decoder ignores it, which usually hides a misspelling.
+type UnknownField struct {
+	// JSON pointer of the field (for example /paths/~1users/get/sumary).
+	Pointer string
+	// Position of the field name in the document.
+	Line   int
+	Column int
+}
+
+// String returns the field formatted as line:column: unknown field pointer.
+func (f UnknownField) String() string {
+	return fmt.Sprintf("%d:%d: unknown field %s", f.Line, f.Column, f.Pointer)
+}
+
+// UnmarshalStrict decodes a YAML or JSON OpenAPI document like yaml.Unmarshal, and also
+// returns the fields that are unknown, in document order. Extensions (x-* fields) are
+// preserved on every object that supports them and are never reported.
+func UnmarshalStrict(data []byte) (*OpenAPI, []UnknownField, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, nil, err
+	}
+
+	var openAPI OpenAPI
+	if err := node.Decode(&openAPI); err != nil {
+		return nil, nil, err
+	}
+
+	var unknown []UnknownField
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		collectUnknownFields(node.Content[0], reflect.TypeFor[OpenAPI](), "", &unknown)
+	}
+	return &openAPI, unknown, nil
+}
+
+// Types decoded as a whole: their fields are not Go struct fields.
+var (
+	pathsType               = reflect.TypeFor[Paths]()
+	callbackType            = reflect.TypeFor[Callback]()
+	responsesType           = reflect.TypeFor[Responses]()
+	responseType            = reflect.TypeFor[Response]()
+	pathItemType            = reflect.TypeFor[PathItem]()
+	securityRequirementType = reflect.TypeFor[SecurityRequirement]()
+	schemaTypeType          = reflect.TypeFor[SchemaType]()
+	extensionsType          = reflect.TypeFor[Extensions]()
+)
+
+// collectUnknownFields walks node along the Go type t it is decoded into, and appends the
+// fields of mapping nodes that t does not define.
+func collectUnknownFields(node *yaml.Node, t reflect.Type, pointer string, unknown *[]UnknownField) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case pathsType, callbackType:
+		collectMapFields(node, pathItemType, pointer, unknown)
+		return
+	case responsesType:
+		collectMapFields(node, responseType, pointer, unknown)
+		return
+	case securityRequirementType, schemaTypeType, extensionsType:
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		// Null objects have no fields
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if IsExtension(key.Value) {
+				continue
+			}
+			childPointer := pointer + "/" + escapePointer(key.Value)
+			field, ok := fields[key.Value]
+			if !ok {
+				*unknown = append(*unknown, UnknownField{Pointer: childPointer, Line: key.Line, Column: key.Column})
+				continue
+			}
+			collectUnknownFields(node.Content[i+1], field, childPointer, unknown)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			collectUnknownFields(node.Content[i+1], t.Elem(), pointer+"/"+escapePointer(node.Content[i].Value), unknown)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range node.Content {
+			collectUnknownFields(item, t.Elem(), pointer+"/"+strconv.Itoa(i), unknown)
+		}
+	}
+}
+
+// collectMapFields walks the entries of a map-like object (Paths, Callback, Responses),
+// whose extension fields are not entries.
+func collectMapFields(node *yaml.Node, elem reflect.Type, pointer string, unknown *[]UnknownField) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if IsExtension(key) {
+			continue
+		}
+		collectUnknownFields(node.Content[i+1], elem, pointer+"/"+escapePointer(key), unknown)
+	}
+}
+
+// yamlFieldsCache holds the field types of struct types by YAML field name.
+var yamlFieldsCache sync.Map
+
+// yamlFields returns the field types of a struct type by YAML field name.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	if fields, ok := yamlFieldsCache.Load(t); ok {
+		return fields.(map[string]reflect.Type)
+	}
+
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+
+	yamlFieldsCache.Store(t, fields)
+	return fields
+}
+
+// escapePointer escapes a field name as a JSON pointer reference token (RFC 6901).
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
@@ -0,0 +1,206 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "known fields and extensions",
+			input: `openapi: 3.1.2
+info:
+  title: API
+  version: 1.0.0
+  x-logo: logo.png
+paths:
+  x-group: users
+  /users:
+    get:
+      responses:
+        default:
+          description: Error
+        x-cache: 60
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+          x-order: 1
+`,
+		},
+		{
+			name: "misspelled fields",
+			input: `openapi: 3.1.2
+info:
+  title: API
+  verison: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      sumary: Get a user
+      parameters:
+        - name: id
+          in: path
+          requird: true
+`,
+			expected: []string{
+				"4:3: unknown field /info/verison",
+				"8:7: unknown field /paths/~1users~1{id}/get/sumary",
+				"12:11: unknown field /paths/~1users~1{id}/get/parameters/0/requird",
+			},
+		},
+		{
+			name: "map values",
+			input: `{"components": {"schemas": {"a~b": {"propertys": {}}},
+  "securitySchemes": {"key": {"type": "apiKey", "flow": {}}}}}`,
+			expected: []string{
+				"1:37: unknown field /components/schemas/a~0b/propertys",
+				"2:49: unknown field /components/securitySchemes/key/flow",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openAPI, unknown, err := UnmarshalStrict([]byte(tt.input))
+			require.NoError(t, err)
+			require.NotNil(t, openAPI)
+
+			var got []string
+			for _, field := range unknown {
+				got = append(got, field.String())
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestUnmarshalStrictDecodes(t *testing.T) {
+	openAPI, unknown, err := UnmarshalStrict([]byte(`openapi: 3.1.2
+info:
+  title: API
+  version: 1.0.0
+  summry: typo
+paths:
+  /users:
+    x-controller: users
+    get:
+      operationId: listUsers
+`))
+	require.NoError(t, err)
+	require.Len(t, unknown, 1)
+	assert.Equal(t, "/info/summry", unknown[0].Pointer)
+
+	// Decoding is the same as yaml.Unmarshal
+	assert.Equal(t, "API", openAPI.Info.Title)
+	assert.Equal(t, "listUsers", openAPI.Paths.PathItems["/users"].Get.OperationID)
+	assert.Equal(t, Extensions{"x-controller": "users"}, openAPI.Paths.PathItems["/users"].Extensions)
+
+	_, _, err = UnmarshalStrict([]byte("info: [API]"))
+	assert.Error(t, err)
+}
+
+func TestUnmarshalStrictRoundTrip(t *testing.T) {
+	input := `openapi: 3.1.2
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      callbacks:
+        created:
+          $ref: '#/components/callbacks/Created'
+      responses:
+        "201":
+          description: Created
+          links:
+            self:
+              $ref: '#/components/links/Self'
+          content:
+            application/json:
+              examples:
+                admin:
+                  $ref: '#/components/examples/Admin'
+components:
+  schemas:
+    Node:
+      $schema: https://json-schema.org/draft/2020-12/schema
+      $id: https://example.com/schemas/node
+      $vocabulary:
+        https://json-schema.org/draft/2020-12/vocab/core: true
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $dynamicRef: '#node'
+          contains:
+            type: object
+          minContains: 1
+          maxContains: 8
+        payload:
+          type: string
+          contentMediaType: application/json
+          contentSchema:
+            type: object
+      patternProperties:
+        ^x-:
+          type: string
+      dependentRequired:
+        billing: [address]
+      example:
+        children: []
+  links:
+    Self:
+      operationId: createUser
+  examples:
+    Admin:
+      value:
+        role: admin
+  callbacks:
+    Created:
+      '{$request.body#/callbackUrl}':
+        post:
+          responses:
+            "204":
+              description: Received
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+    shared:
+      $ref: '#/components/securitySchemes/bearer'
+`
+
+	openAPI, unknown, err := UnmarshalStrict([]byte(input))
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+
+	node := openAPI.Components.Schemas["Node"]
+	assert.Equal(t, "https://example.com/schemas/node", node.ID)
+	assert.Equal(t, "#node", node.Properties["children"].Items.DynamicRef)
+	assert.Equal(t, map[string][]string{"billing": {"address"}}, node.DependentRequired)
+	assert.Contains(t, node.PatternProperties, "^x-")
+	assert.Equal(t, "#/components/securitySchemes/bearer", openAPI.Components.SecuritySchemes["shared"].Ref)
+
+	output, err := yaml.Marshal(openAPI)
+	require.NoError(t, err)
+	var want, got any
+	require.NoError(t, yaml.Unmarshal([]byte(input), &want))
+	require.NoError(t, yaml.Unmarshal(output, &got))
+	assert.Equal(t, want, got)
+}
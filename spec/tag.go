@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // Adds metadata to a single tag that is used by the Operation Object. It is not mandatory to have
 // a Tag Object per tag defined in the Operation Object instances.
 //
@@ -11,4 +13,40 @@ type Tag struct {
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	// Additional external documentation for this tag.
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the Tag fields followed by its Specification Extensions.
+func (t *Tag) MarshalJSON() ([]byte, error) {
+	if t == nil {
+		return []byte("null"), nil
+	}
+	type plain Tag
+	return marshalJSONWithExtensions((*plain)(t), t.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the Tag fields followed by its Specification Extensions.
+func (t *Tag) MarshalYAML() (any, error) {
+	if t == nil {
+		return nil, nil
+	}
+	type plain Tag
+	return marshalYAMLWithExtensions((*plain)(t), t.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the Tag fields and collects its Specification Extensions.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type plain Tag
+	return unmarshalJSONWithExtensions(data, (*plain)(t), &t.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the Tag fields and collects its Specification Extensions.
+func (t *Tag) UnmarshalYAML(value *yaml.Node) error {
+	type plain Tag
+	return unmarshalYAMLWithExtensions(value, (*plain)(t), &t.Extensions)
 }
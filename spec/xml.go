@@ -1,5 +1,7 @@
 package spec
 
+import "gopkg.in/yaml.v3"
+
 // XML Object
 //
 // A metadata object that allows for more fine-tuned XML model definitions.
@@ -25,4 +27,40 @@ type XML struct {
 	// <books><book/><book/></books>) or unwrapped (<book/><book/>). Default value is false. The definition
 	// takes effect only when defined alongside type being "array" (outside the items).
 	Wrapped bool `json:"wrapped,omitempty" yaml:"wrapped,omitempty"`
+	// Specification Extensions (x-* fields) serialized inline with the object.
+	Extensions Extensions `json:"-" yaml:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It marshals the XML fields followed by its Specification Extensions.
+func (x *XML) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return []byte("null"), nil
+	}
+	type plain XML
+	return marshalJSONWithExtensions((*plain)(x), x.Extensions)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+// It marshals the XML fields followed by its Specification Extensions.
+func (x *XML) MarshalYAML() (any, error) {
+	if x == nil {
+		return nil, nil
+	}
+	type plain XML
+	return marshalYAMLWithExtensions((*plain)(x), x.Extensions)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It unmarshals the XML fields and collects its Specification Extensions.
+func (x *XML) UnmarshalJSON(data []byte) error {
+	type plain XML
+	return unmarshalJSONWithExtensions(data, (*plain)(x), &x.Extensions)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It decodes the XML fields and collects its Specification Extensions.
+func (x *XML) UnmarshalYAML(value *yaml.Node) error {
+	type plain XML
+	return unmarshalYAMLWithExtensions(value, (*plain)(x), &x.Extensions)
 }
@@ -0,0 +1,82 @@
+package swagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kausys/openapi/cache"
+)
+
+// gzipMinSize is the size from which served specs are compressed for clients accepting gzip.
+const gzipMinSize = 1024
+
+// content is a served document with its caching metadata. Large documents are compressed
+// once, when loaded, instead of on each request.
+type content struct {
+	data     []byte
+	gzipped  []byte // nil for documents smaller than gzipMinSize
+	checksum string
+	modified time.Time
+}
+
+// newContent returns the content of a document. The modification time of the previous
+// content of the document is kept when the document is unchanged.
+func newContent(data []byte, previous *content, now time.Time) *content {
+	c := &content{
+		data:     data,
+		checksum: cache.CalculateContentChecksum(data),
+		modified: now,
+	}
+	if previous != nil && previous.checksum == c.checksum {
+		c.modified = previous.modified
+	}
+
+	if len(data) >= gzipMinSize {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err == nil && zw.Close() == nil {
+			c.gzipped = buf.Bytes()
+		}
+	}
+	return c
+}
+
+// serve writes the content with ETag and Last-Modified headers, answering conditional
+// requests with 304 Not Modified, gzip-encoded when the client accepts it.
+func (c *content) serve(w http.ResponseWriter, r *http.Request, contentType string) {
+	data, etag := c.data, strconv.Quote(c.checksum)
+	if c.gzipped != nil {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if acceptsGzip(r) {
+			data, etag = c.gzipped, strconv.Quote(c.checksum+"+gzip")
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", etag)
+	http.ServeContent(w, r, "", c.modified, bytes.NewReader(data))
+}
+
+// acceptsGzip reports whether the Accept-Encoding header of a request accepts gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, coding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.TrimSpace(name)
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		if weight, err := strconv.ParseFloat(q, 64); err != nil || weight > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package swagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kausys/openapi/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeSpecCaching(t *testing.T) {
+	small := []byte("openapi: 3.0.0")
+	large := []byte("openapi: 3.0.0\npaths:\n" + strings.Repeat("  /users: {}\n", 200))
+
+	handler, err := New(createTestZip(t), Config{
+		Specs:       map[string][]byte{"small": small, "large": large},
+		DefaultSpec: "small",
+	})
+	require.NoError(t, err)
+
+	get := func(target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		req.Header = header
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("etag", func(t *testing.T) {
+		w := get("/openapi/specs?spec=small", http.Header{})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"`+cache.CalculateContentChecksum(small)+`"`, w.Header().Get("ETag"))
+		assert.NotEmpty(t, w.Header().Get("Last-Modified"))
+		assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+		assert.Equal(t, string(small), w.Body.String())
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		etag := get("/openapi/specs?spec=small", http.Header{}).Header().Get("ETag")
+
+		w := get("/openapi/specs?spec=small", http.Header{"If-None-Match": {etag}})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		w = get("/openapi/specs?spec=large", http.Header{"If-None-Match": {etag}})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		modified := get("/openapi/resources", http.Header{}).Header().Get("Last-Modified")
+		w = get("/openapi/resources", http.Header{"If-Modified-Since": {modified}})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		w := get("/openapi/specs?spec=large", http.Header{"Accept-Encoding": {"gzip, deflate"}})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		assert.Equal(t, `"`+cache.CalculateContentChecksum(large)+`+gzip"`, w.Header().Get("ETag"))
+		assert.Less(t, w.Body.Len(), len(large))
+
+		zr, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+		require.NoError(t, err)
+		data, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		assert.Equal(t, large, data)
+
+		// Clients not accepting gzip get the document as is
+		w = get("/openapi/specs?spec=large", http.Header{})
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, w.Body.Bytes())
+
+		// Small documents are not compressed
+		w = get("/openapi/specs?spec=small", http.Header{"Accept-Encoding": {"gzip"}})
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, small, w.Body.Bytes())
+	})
+}
+
+func TestNewContentKeepsModificationTime(t *testing.T) {
+	first := newContent([]byte("openapi: 3.0.0"), nil, testTime(1))
+
+	unchanged := newContent([]byte("openapi: 3.0.0"), first, testTime(2))
+	assert.Equal(t, testTime(1), unchanged.modified)
+
+	changed := newContent([]byte("openapi: 3.1.0"), first, testTime(2))
+	assert.Equal(t, testTime(2), changed.modified)
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip;q=0.8", true},
+		{"br, *", true},
+		{"gzip;q=0", false},
+		{"gzip; q=0.0, identity", false},
+		{"deflate", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/openapi/specs", nil)
+			req.Header.Set("Accept-Encoding", tt.header)
+			assert.Equal(t, tt.want, acceptsGzip(req))
+		})
+	}
+}
+
+// testTime returns a fixed time, seconds after the Unix epoch.
+func testTime(seconds int64) time.Time {
+	return time.Unix(seconds, 0)
+}
@@ -4,13 +4,17 @@ import (
 	"archive/zip"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/kausys/openapi/cache"
 )
 
 const (
@@ -37,18 +41,51 @@ type DownloadOptions struct {
 	CustomInitializer string
 	// Version is the specific version to download (empty for latest)
 	Version string
+	// Checksum is the expected SHA-256 checksum of the release archive (sha256:<hex> or
+	// <hex>). The download fails when the archive does not match.
+	Checksum string
+	// CacheDir is the directory where release archives are cached by version (see
+	// DefaultCacheDir). Empty disables the cache.
+	CacheDir string
+	// Offline fails instead of accessing the network when the release is not cached
+	Offline bool
+	// HTTPClient is the client of the GitHub requests. The default client uses the
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables.
+	HTTPClient *http.Client
+	// URLTemplate is the download URL of the release archives, with the version as %s
+	// (default DownloadURLTemplate), for mirrors
+	URLTemplate string
+}
+
+// DefaultCacheDir returns the default cache directory of the Swagger UI release archives,
+// in the user cache directory.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "openapi", "swagger-ui")
 }
 
 // GetLatestVersion fetches the latest Swagger UI version from GitHub.
 func GetLatestVersion() (string, error) {
+	return latestVersion(http.DefaultClient)
+}
+
+// latestVersion fetches the latest Swagger UI version from GitHub. The GITHUB_TOKEN
+// environment variable authenticates the request, to raise the rate limit.
+func latestVersion(client *http.Client) (string, error) {
 	req, err := http.NewRequest("GET", GitHubReleasesAPI, nil)
 	if err != nil {
 		return "", err
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", "openapi-cli")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch releases: %w", err)
 	}
@@ -66,12 +103,21 @@ func GetLatestVersion() (string, error) {
 	return release.TagName, nil
 }
 
-// Download downloads and prepares Swagger UI for embedding.
+// Download downloads and prepares Swagger UI for embedding. The release archive is read
+// from the cache directory when cached, and verified against the expected checksum.
 func Download(opts DownloadOptions) (string, error) {
+	client := opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
 	version := opts.Version
 	if version == "" {
+		if opts.Offline {
+			return "", errors.New("offline mode requires a Swagger UI version")
+		}
 		var err error
-		version, err = GetLatestVersion()
+		version, err = latestVersion(client)
 		if err != nil {
 			return "", fmt.Errorf("failed to get latest version: %w", err)
 		}
@@ -81,24 +127,14 @@ func Download(opts DownloadOptions) (string, error) {
 	if !strings.HasPrefix(version, "v") {
 		version = "v" + version
 	}
-
-	downloadURL := fmt.Sprintf(DownloadURLTemplate, version)
-	fmt.Printf("Downloading Swagger UI %s from %s\n", version, downloadURL)
-
-	// Download the zip file
-	resp, err := http.Get(downloadURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to download: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("download failed with status %d", resp.StatusCode)
+	// The version names a directory of the cache
+	if strings.ContainsAny(version, `/\`) || strings.Contains(version, "..") {
+		return "", fmt.Errorf("invalid Swagger UI version %q", version)
 	}
 
-	zipData, err := io.ReadAll(resp.Body)
+	zipData, err := fetchRelease(client, version, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to read download: %w", err)
+		return "", err
 	}
 
 	// Process the zip file
@@ -125,6 +161,138 @@ func Download(opts DownloadOptions) (string, error) {
 	return version, nil
 }
 
+// fetchRelease returns the release archive of a version, from the cache directory or
+// downloaded (and then cached), verified against the expected checksum.
+func fetchRelease(client *http.Client, version string, opts DownloadOptions) ([]byte, error) {
+	expected, err := normalizeChecksum(opts.Checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.CacheDir != "" {
+		zipData, err := readCachedRelease(opts.CacheDir, version)
+		switch {
+		case err == nil:
+			if checksum := cache.CalculateContentChecksum(zipData); expected != "" && checksum != expected {
+				return nil, fmt.Errorf("cached Swagger UI %s has checksum %s, expected %s", version, checksum, expected)
+			}
+			fmt.Printf("Using cached Swagger UI %s from %s\n", version, opts.CacheDir)
+			if expected == "" {
+				warnUnverified(version, cache.CalculateContentChecksum(zipData))
+			}
+			return zipData, nil
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+	}
+
+	if opts.Offline {
+		if opts.CacheDir == "" {
+			return nil, fmt.Errorf("offline: Swagger UI %s cannot be downloaded and no cache directory is set", version)
+		}
+		return nil, fmt.Errorf("offline: Swagger UI %s is not cached in %s, download it without offline mode first", version, opts.CacheDir)
+	}
+
+	urlTemplate := opts.URLTemplate
+	if urlTemplate == "" {
+		urlTemplate = DownloadURLTemplate
+	}
+	downloadURL := fmt.Sprintf(urlTemplate, version)
+	fmt.Printf("Downloading Swagger UI %s from %s\n", version, downloadURL)
+
+	// Download the zip file
+	resp, err := client.Get(downloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	zipData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read download: %w", err)
+	}
+
+	checksum := cache.CalculateContentChecksum(zipData)
+	if expected != "" && checksum != expected {
+		return nil, fmt.Errorf("checksum mismatch for Swagger UI %s: got %s, expected %s", version, checksum, expected)
+	}
+	fmt.Printf("Checksum: %s\n", checksum)
+	if expected == "" {
+		warnUnverified(version, checksum)
+	}
+
+	if opts.CacheDir != "" {
+		if err := writeCachedRelease(opts.CacheDir, version, zipData, checksum); err != nil {
+			return nil, err
+		}
+	}
+	return zipData, nil
+}
+
+// warnUnverified warns on stderr that a release archive was not verified against an
+// expected checksum.
+func warnUnverified(version, checksum string) {
+	fmt.Fprintf(os.Stderr, "WARNING: Swagger UI %s was not verified, no expected checksum was given. "+
+		"Check the checksum %s and pin it with Checksum (--sha256)\n", version, checksum)
+}
+
+// normalizeChecksum returns an expected SHA-256 checksum as sha256:<hex>.
+func normalizeChecksum(checksum string) (string, error) {
+	if checksum == "" {
+		return "", nil
+	}
+	digest := strings.ToLower(strings.TrimPrefix(checksum, "sha256:"))
+	if len(digest) != 64 || strings.Trim(digest, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("invalid SHA-256 checksum %q", checksum)
+	}
+	return "sha256:" + digest, nil
+}
+
+// cachedReleasePath returns the path of the cached release archive of a version. Its
+// checksum is recorded next to it, in a .sha256 file.
+func cachedReleasePath(cacheDir, version string) string {
+	return filepath.Join(cacheDir, version, "swagger-ui.zip")
+}
+
+// readCachedRelease reads the cached release archive of a version, and checks it against
+// its recorded checksum. It returns an fs.ErrNotExist error when the version is not cached.
+func readCachedRelease(cacheDir, version string) ([]byte, error) {
+	path := cachedReleasePath(cacheDir, version)
+	zipData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := os.ReadFile(path + ".sha256")
+	if err != nil {
+		return nil, err
+	}
+
+	if checksum := cache.CalculateContentChecksum(zipData); checksum != strings.TrimSpace(string(recorded)) {
+		return nil, fmt.Errorf("cached Swagger UI %s is corrupted (checksum %s, recorded %s), remove %s",
+			version, checksum, strings.TrimSpace(string(recorded)), filepath.Dir(path))
+	}
+	return zipData, nil
+}
+
+// writeCachedRelease caches the release archive of a version with its checksum.
+func writeCachedRelease(cacheDir, version string, zipData []byte, checksum string) error {
+	path := cachedReleasePath(cacheDir, version)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(path, zipData, 0644); err != nil {
+		return fmt.Errorf("failed to cache Swagger UI: %w", err)
+	}
+	if err := os.WriteFile(path+".sha256", []byte(checksum+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to cache Swagger UI: %w", err)
+	}
+	return nil
+}
+
 // processSwaggerUI extracts dist folder, applies customizations, and repackages.
 func processSwaggerUI(zipData []byte, version string, opts DownloadOptions) ([]byte, error) {
 	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/kausys/openapi/cache"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -154,6 +157,116 @@ func TestDownloadWithMockServer(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDownloadCacheAndChecksum(t *testing.T) {
+	var zipBuf bytes.Buffer
+	w := zip.NewWriter(&zipBuf)
+	f, err := w.Create("swagger-ui-5.0.0/dist/index.html")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("<html><head></head><body>Mock</body></html>"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	checksum := cache.CalculateContentChecksum(zipBuf.Bytes())
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v5.0.0.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(zipBuf.Bytes())
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	opts := func(outputDir string) DownloadOptions {
+		return DownloadOptions{
+			OutputDir:   outputDir,
+			Version:     "5.0.0",
+			CacheDir:    cacheDir,
+			URLTemplate: server.URL + "/%s.zip",
+		}
+	}
+
+	t.Run("download and cache", func(t *testing.T) {
+		o := opts(t.TempDir())
+		o.Checksum = strings.TrimPrefix(checksum, "sha256:")
+		version, err := Download(o)
+		require.NoError(t, err)
+		assert.Equal(t, "v5.0.0", version)
+		assert.Equal(t, 1, requests)
+		assert.FileExists(t, filepath.Join(o.OutputDir, "swagger-ui.zip"))
+
+		recorded, err := os.ReadFile(filepath.Join(cacheDir, "v5.0.0", "swagger-ui.zip.sha256"))
+		require.NoError(t, err)
+		assert.Equal(t, checksum+"\n", string(recorded))
+	})
+
+	t.Run("offline from cache", func(t *testing.T) {
+		o := opts(t.TempDir())
+		o.Offline = true
+		o.Checksum = checksum
+		_, err := Download(o)
+		require.NoError(t, err)
+		assert.Equal(t, 1, requests, "cached archives are not downloaded again")
+		assert.FileExists(t, filepath.Join(o.OutputDir, "swagger-ui.zip"))
+	})
+
+	t.Run("offline without cache", func(t *testing.T) {
+		o := opts(t.TempDir())
+		o.Version = "5.1.0"
+		o.Offline = true
+		_, err := Download(o)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "offline: Swagger UI v5.1.0 is not cached in "+cacheDir)
+		assert.Equal(t, 1, requests)
+
+		o.Version = ""
+		_, err = Download(o)
+		assert.EqualError(t, err, "offline mode requires a Swagger UI version")
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		for _, version := range []string{"../../etc", `5.0.0\x`, "v5/0"} {
+			o := opts(t.TempDir())
+			o.Version = version
+			_, err := Download(o)
+			require.Error(t, err, version)
+			assert.Contains(t, err.Error(), "invalid Swagger UI version", version)
+		}
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		o := opts(t.TempDir())
+		o.CacheDir = ""
+		o.Checksum = strings.Repeat("0", 64)
+		_, err := Download(o)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "checksum mismatch for Swagger UI v5.0.0: got "+checksum)
+		assert.NoFileExists(t, filepath.Join(o.OutputDir, "swagger-ui.zip"))
+
+		// A cached archive is checked against the expected checksum too
+		o.CacheDir = cacheDir
+		_, err = Download(o)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cached Swagger UI v5.0.0 has checksum "+checksum)
+
+		o.Checksum = "md5:1234"
+		_, err = Download(o)
+		assert.EqualError(t, err, `invalid SHA-256 checksum "md5:1234"`)
+	})
+
+	t.Run("corrupted cache", func(t *testing.T) {
+		path := filepath.Join(cacheDir, "v5.0.0", "swagger-ui.zip")
+		require.NoError(t, os.WriteFile(path, []byte("truncated"), 0644))
+
+		_, err := Download(opts(t.TempDir()))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cached Swagger UI v5.0.0 is corrupted")
+	})
+}
+
 func TestDownloadOptions(t *testing.T) {
 	opts := DownloadOptions{
 		OutputDir:         "/tmp/test",
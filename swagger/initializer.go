@@ -0,0 +1,154 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+)
+
+// InitializerConfig configures the Swagger UI initializer (swagger-initializer.js) rendered
+// by the handler in place of the one of the zip.
+type InitializerConfig struct {
+	// DocExpansion is the default expansion of tags and operations: list, full, or none
+	// (default)
+	DocExpansion string
+	// DefaultModelsExpandDepth is the expansion depth of the models section; -1 hides it
+	// (default 1)
+	DefaultModelsExpandDepth *int
+	// DisableDeepLinking disables the links to tags and operations (#/users/listUsers)
+	DisableDeepLinking bool
+	// TryItOutEnabled opens the "Try it out" section of the operations by default
+	TryItOutEnabled bool
+	// PersistAuthorization keeps the authorization data across browser reloads
+	PersistAuthorization bool
+	// WithCredentials sends the browser cookies with "Try it out" requests, for cookie
+	// parameters and cookie authentication. Cross-origin APIs must allow credentials.
+	WithCredentials bool
+	// PluginURLs are scripts loaded before Swagger UI starts. Each registers its plugins
+	// by pushing them to window.swaggerUIPlugins.
+	PluginURLs []string
+	// OAuth2 pre-fills the OAuth2 authorization dialog
+	OAuth2 *OAuth2Config
+}
+
+// OAuth2Config is the OAuth2 configuration of Swagger UI (ui.initOAuth).
+type OAuth2Config struct {
+	// ClientID is the default client ID
+	ClientID string `json:"clientId,omitempty"`
+	// ClientSecret is the default client secret. It is readable by every visitor, never
+	// use the secret of a confidential client.
+	ClientSecret string `json:"clientSecret,omitempty"`
+	// Realm is appended to the authorization URL
+	Realm string `json:"realm,omitempty"`
+	// AppName is the application name shown in the dialog
+	AppName string `json:"appName,omitempty"`
+	// Scopes are the scopes selected by default
+	Scopes []string `json:"scopes,omitempty"`
+	// AdditionalQueryStringParams are added to the authorization and token URLs
+	AdditionalQueryStringParams map[string]string `json:"additionalQueryStringParams,omitempty"`
+	// UsePKCE uses PKCE with the authorization code flow
+	UsePKCE bool `json:"usePkceWithAuthorizationCodeGrant,omitempty"`
+}
+
+// initializerTemplate renders the Swagger UI initializer. Values are JSON-encoded, which
+// escapes HTML characters in scripts.
+var initializerTemplate = template.Must(template.New("swagger-initializer.js").Funcs(template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}).Parse(`window.onload = async function () {
+  const options = {{json .Options}};
+
+  for (const src of {{json .PluginURLs}}) {
+    try {
+      await new Promise((resolve, reject) => {
+        const script = document.createElement('script');
+        script.src = src;
+        script.onload = resolve;
+        script.onerror = () => reject(new Error('failed to load ' + src));
+        document.head.appendChild(script);
+      });
+    } catch (err) {
+      console.error('Error loading Swagger UI plugin: ', err);
+    }
+  }
+
+  let resources = [];
+  try {
+    const response = await fetch({{json .ResourcesPath}}, {
+      credentials: 'same-origin',
+      headers: { Accept: 'application/json' },
+    });
+    resources = (await response.json()) || [];
+  } catch (err) {
+    console.error('Error loading Swagger UI: ', err);
+  }
+
+  window.ui = SwaggerUIBundle(Object.assign({
+    dom_id: '#swagger-ui',
+    url: {{json .SpecPath}},
+    urls: resources.length > 0 ? resources : undefined,
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl,
+    ].concat(window.swaggerUIPlugins || []),
+    layout: "StandaloneLayout",
+    operationsSorter: "alpha",
+    tagsSorter: function (a, b) {
+      if (a === "Authentication") return -1;
+      if (b === "Authentication") return 1;
+      return a.localeCompare(b);
+    },
+  }, options));
+{{- if .OAuth2}}
+
+  window.ui.initOAuth({{json .OAuth2}});
+{{- end}}
+};
+`))
+
+// renderInitializer renders the Swagger UI initializer of the handler configuration.
+func renderInitializer(config Config) ([]byte, error) {
+	ic := config.Initializer
+
+	options := map[string]any{
+		"docExpansion":         "none",
+		"deepLinking":          !ic.DisableDeepLinking,
+		"tryItOutEnabled":      ic.TryItOutEnabled,
+		"persistAuthorization": ic.PersistAuthorization,
+	}
+	if ic.DocExpansion != "" {
+		options["docExpansion"] = ic.DocExpansion
+	}
+	if ic.WithCredentials {
+		options["withCredentials"] = true
+	}
+	if ic.DefaultModelsExpandDepth != nil {
+		options["defaultModelsExpandDepth"] = *ic.DefaultModelsExpandDepth
+	}
+	if config.DefaultSpec != "" {
+		options["urls.primaryName"] = config.DefaultSpec
+	}
+
+	pluginURLs := ic.PluginURLs
+	if pluginURLs == nil {
+		pluginURLs = []string{}
+	}
+
+	var buf bytes.Buffer
+	err := initializerTemplate.Execute(&buf, map[string]any{
+		"Options":       options,
+		"PluginURLs":    pluginURLs,
+		"SpecPath":      config.SpecPath,
+		"ResourcesPath": config.ResourcesPath,
+		"OAuth2":        ic.OAuth2,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,73 @@
+package swagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_RenderedInitializer(t *testing.T) {
+	hidden := -1
+
+	get := func(handler *Handler) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/docs/swagger-initializer.js", nil))
+		return w
+	}
+
+	t.Run("zip initializer", func(t *testing.T) {
+		handler, err := New(createTestZip(t), Config{BasePath: "/docs"})
+		require.NoError(t, err)
+		assert.Equal(t, "window.onload = function() {};", get(handler).Body.String())
+	})
+
+	t.Run("rendered", func(t *testing.T) {
+		handler, err := New(createTestZip(t), Config{
+			BasePath:      "/docs",
+			SpecPath:      "/api/specs",
+			ResourcesPath: "/api/resources",
+			DefaultSpec:   "public",
+			Initializer: &InitializerConfig{
+				DocExpansion:             "list",
+				DefaultModelsExpandDepth: &hidden,
+				TryItOutEnabled:          true,
+				PersistAuthorization:     true,
+				WithCredentials:          true,
+				PluginURLs:               []string{"/static/plugin.js"},
+				OAuth2: &OAuth2Config{
+					ClientID: "docs",
+					Scopes:   []string{"read", "write"},
+					UsePKCE:  true,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		w := get(handler)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+
+		js := w.Body.String()
+		assert.Contains(t, js, `const options = {"deepLinking":true,"defaultModelsExpandDepth":-1,"docExpansion":"list","persistAuthorization":true,"tryItOutEnabled":true,"urls.primaryName":"public","withCredentials":true};`)
+		assert.Contains(t, js, `for (const src of ["/static/plugin.js"])`)
+		assert.Contains(t, js, `await fetch("/api/resources", {`)
+		assert.Contains(t, js, `url: "/api/specs",`)
+		assert.Contains(t, js, `window.ui.initOAuth({"clientId":"docs","scopes":["read","write"],"usePkceWithAuthorizationCodeGrant":true});`)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		handler, err := New(createTestZip(t), Config{BasePath: "/docs", Initializer: &InitializerConfig{}})
+		require.NoError(t, err)
+
+		js := get(handler).Body.String()
+		assert.Contains(t, js, `const options = {"deepLinking":true,"docExpansion":"none","persistAuthorization":false,"tryItOutEnabled":false};`)
+		assert.Contains(t, js, `for (const src of [])`)
+		assert.NotContains(t, js, "initOAuth")
+		assert.NotContains(t, js, "withCredentials")
+		// The Authentication tag comes first, like with the default initializer
+		assert.Contains(t, js, `if (a === "Authentication") return -1;`)
+	})
+}
@@ -0,0 +1,137 @@
+package swagger
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+)
+
+// Documentation UIs served by a handler (Config.UI).
+const (
+	// UISwagger serves Swagger UI from the swagger-ui.zip of the handler
+	UISwagger = "swagger"
+	// UIRedoc serves a Redoc page, the three-panel reference docs, for the default spec
+	UIRedoc = "redoc"
+	// UIScalar serves a Scalar page, listing every spec
+	UIScalar = "scalar"
+)
+
+// Releases of the Redoc and Scalar scripts loaded from their CDNs. A release is updated
+// with its Subresource Integrity hash, the base64 SHA-384 digest of the script:
+//
+//	curl -sL <script URL> | openssl dgst -sha384 -binary | openssl base64 -A
+const (
+	// RedocVersion is the Redoc release of the Redoc page
+	RedocVersion = "2.1.5"
+	// RedocIntegrity is the Subresource Integrity hash of the Redoc script, checked by
+	// the browser when set
+	RedocIntegrity = ""
+	// ScalarVersion is the @scalar/api-reference release of the Scalar page
+	ScalarVersion = "1.28.0"
+	// ScalarIntegrity is the Subresource Integrity hash of the Scalar script, checked by
+	// the browser when set
+	ScalarIntegrity = ""
+)
+
+// Scripts of the Redoc and Scalar pages, at the pinned releases.
+const (
+	redocScript  = "https://cdn.redoc.ly/redoc/v" + RedocVersion + "/bundles/redoc.standalone.js"
+	scalarScript = "https://cdn.jsdelivr.net/npm/@scalar/api-reference@" + ScalarVersion + "/dist/browser/standalone.js"
+)
+
+// redocPage is the HTML shell of Redoc, loaded from its CDN.
+var redocPage = template.Must(template.New(UIRedoc).Parse(`<!DOCTYPE html>
+<html>
+  <head>
+    <title>{{.Title}}</title>
+    <meta charset="utf-8"/>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <style>body { margin: 0; padding: 0; }</style>
+  </head>
+  <body>
+    <redoc spec-url="{{.SpecURL}}"></redoc>
+    <script src="{{.Script}}"{{with .Integrity}} integrity="{{.}}" crossorigin="anonymous"{{end}}></script>
+  </body>
+</html>
+`))
+
+// scalarPage is the HTML shell of Scalar, loaded from its CDN. The first source is the
+// spec shown first.
+var scalarPage = template.Must(template.New(UIScalar).Parse(`<!DOCTYPE html>
+<html>
+  <head>
+    <title>{{.Title}}</title>
+    <meta charset="utf-8"/>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+  </head>
+  <body>
+    <div id="app"></div>
+    <script src="{{.Script}}"{{with .Integrity}} integrity="{{.}}" crossorigin="anonymous"{{end}}></script>
+    <script>
+      Scalar.createApiReference('#app', { sources: {{.Sources}} })
+    </script>
+  </body>
+</html>
+`))
+
+// pageSource is a spec listed by the Scalar page.
+type pageSource struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// servePage serves the HTML shell of the Redoc or Scalar UI, pointing at the spec
+// endpoints. Its only file is index.html.
+func (h *Handler) servePage(w http.ResponseWriter, r *http.Request, filePath string) {
+	if filePath != "index.html" {
+		http.NotFound(w, r)
+		return
+	}
+
+	specs, _, err := h.current(r.Context(), true)
+	if err != nil {
+		h.internalError(w, r, fmt.Errorf("failed to load specs: %w", err))
+		return
+	}
+
+	// The default spec is shown first
+	names := h.allowedSpecs(r, specs)
+	if i := slices.Index(names, h.config.DefaultSpec); i > 0 {
+		names = slices.Insert(slices.Delete(names, i, i+1), 0, h.config.DefaultSpec)
+	}
+	if len(names) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	sources := make([]pageSource, 0, len(names))
+	for _, resource := range h.resourceList(names) {
+		sources = append(sources, pageSource{Title: resource.Name, URL: resource.URL})
+	}
+
+	page, script, integrity := redocPage, redocScript, RedocIntegrity
+	if h.config.UI == UIScalar {
+		page, script, integrity = scalarPage, scalarScript, ScalarIntegrity
+	}
+
+	var buf bytes.Buffer
+	err = page.Execute(&buf, map[string]any{
+		"Title":     h.config.Title,
+		"SpecURL":   sources[0].URL,
+		"Sources":   sources,
+		"Script":    script,
+		"Integrity": integrity,
+	})
+	if err != nil {
+		h.internalError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if h.config.Authorize != nil {
+		w.Header().Set("Cache-Control", "private")
+	}
+	w.Write(buf.Bytes())
+}
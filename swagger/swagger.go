@@ -4,12 +4,21 @@ package swagger
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/fs"
+	"log"
+	"maps"
 	"net/http"
 	"path"
+	"slices"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/kausys/openapi/generator"
 )
 
 // Resource represents a named OpenAPI spec URL for the Swagger UI dropdown.
@@ -30,18 +39,87 @@ type Config struct {
 	Specs map[string][]byte
 	// DefaultSpec is the name of the default spec to serve when no query param is provided
 	DefaultSpec string
+	// UI is the documentation UI served under BasePath: UISwagger (default), UIRedoc, or
+	// UIScalar. Redoc and Scalar are loaded from their CDN and need no zip.
+	UI string
+	// Title is the title of the Redoc and Scalar pages (default "API Reference")
+	Title string
+	// Initializer, when set, renders the Swagger UI initializer (swagger-initializer.js)
+	// with these options instead of serving the one of the zip
+	Initializer *InitializerConfig
+	// Authorize, when set, is called before serving a spec with the request and the name of
+	// the spec. Specs it refuses are answered with 403 Forbidden and left out of the
+	// resources list, so an admin spec can require a session while the public one stays open.
+	Authorize func(r *http.Request, specName string) bool
+	// RefreshInterval is the minimum time between two refreshes of the specs of a provider
+	// (see NewWithProvider). Zero refreshes them whenever the resources list is requested,
+	// that is each time Swagger UI is loaded; a negative interval only with Refresh.
+	RefreshInterval time.Duration
+	// ErrorLog logs the errors answered with 500 Internal Server Error, whose details
+	// (file paths, source positions) are not sent to the client. Nil uses the standard
+	// logger of the log package.
+	ErrorLog *log.Logger
+}
+
+// SpecProvider provides the specs served by a handler, by spec name (YAML or JSON bytes).
+// It is called again each time the handler refreshes its specs.
+type SpecProvider interface {
+	Specs(ctx context.Context) (map[string][]byte, error)
+}
+
+// SpecProviderFunc adapts a function to a SpecProvider.
+type SpecProviderFunc func(ctx context.Context) (map[string][]byte, error)
+
+// Specs calls f(ctx).
+func (f SpecProviderFunc) Specs(ctx context.Context) (map[string][]byte, error) {
+	return f(ctx)
+}
+
+// GeneratorProvider returns a SpecProvider generating the YAML specs of g, one per spec:
+// directive (see generator.Generator.EncodeSpecs). No output file is written.
+func GeneratorProvider(g *generator.Generator) SpecProvider {
+	return SpecProviderFunc(func(ctx context.Context) (map[string][]byte, error) {
+		return g.EncodeSpecs(ctx, generator.FormatYAML)
+	})
 }
 
 // Handler serves Swagger UI and OpenAPI specifications.
 type Handler struct {
-	config        Config
-	swaggerUI     fs.FS
-	resourcesJSON []byte
+	config    Config
+	swaggerUI fs.FS
+	provider  SpecProvider
+	// initializer is the rendered swagger-initializer.js, nil to serve the one of the zip
+	initializer []byte
+
+	// refreshMu serializes refreshes, mu guards the served specs
+	refreshMu   sync.Mutex
+	mu          sync.RWMutex
+	specs       map[string]*content
+	resources   *content
+	refreshedAt time.Time
 }
 
 // New creates a new Swagger UI handler with the given configuration.
 // swaggerUIZip should be the bytes of the swagger-ui.zip file.
 func New(swaggerUIZip []byte, config Config) (*Handler, error) {
+	return newHandler(swaggerUIZip, config, nil)
+}
+
+// NewWithProvider creates a Swagger UI handler serving the specs of a provider instead of
+// config.Specs. The specs are loaded on the first request and refreshed as configured by
+// config.RefreshInterval, so a development server shows the current annotations without a
+// rebuild.
+func NewWithProvider(swaggerUIZip []byte, provider SpecProvider, config Config) (*Handler, error) {
+	return newHandler(swaggerUIZip, config, provider)
+}
+
+// NewWithGenerator creates a Swagger UI handler serving the specs generated by g at
+// runtime (see GeneratorProvider and NewWithProvider).
+func NewWithGenerator(swaggerUIZip []byte, g *generator.Generator, config Config) (*Handler, error) {
+	return NewWithProvider(swaggerUIZip, GeneratorProvider(g), config)
+}
+
+func newHandler(swaggerUIZip []byte, config Config, provider SpecProvider) (*Handler, error) {
 	if config.BasePath == "" {
 		config.BasePath = "/swagger"
 	}
@@ -52,32 +130,142 @@ func New(swaggerUIZip []byte, config Config) (*Handler, error) {
 		config.ResourcesPath = "/openapi/resources"
 	}
 
-	// Parse the zip file
-	reader := bytes.NewReader(swaggerUIZip)
-	zipReader, err := zip.NewReader(reader, reader.Size())
+	if config.UI == "" {
+		config.UI = UISwagger
+	}
+	if config.Title == "" {
+		config.Title = "API Reference"
+	}
+
+	h := &Handler{
+		config:   config,
+		provider: provider,
+	}
+
+	switch config.UI {
+	case UISwagger:
+		// Parse the zip file
+		reader := bytes.NewReader(swaggerUIZip)
+		zipReader, err := zip.NewReader(reader, reader.Size())
+		if err != nil {
+			return nil, err
+		}
+		h.swaggerUI = zipReader
+
+		if config.Initializer != nil {
+			if h.initializer, err = renderInitializer(config); err != nil {
+				return nil, err
+			}
+		}
+	case UIRedoc, UIScalar:
+	default:
+		return nil, fmt.Errorf("unknown UI %q, expected %s, %s, or %s", config.UI, UISwagger, UIRedoc, UIScalar)
+	}
+
+	if provider == nil {
+		if err := h.setSpecs(config.Specs); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Refresh loads the specs of the provider again. It does nothing for a handler created
+// with New, whose specs are static.
+func (h *Handler) Refresh(ctx context.Context) error {
+	if h.provider == nil {
+		return nil
+	}
+
+	h.refreshMu.Lock()
+	defer h.refreshMu.Unlock()
+	return h.refresh(ctx)
+}
+
+// refresh loads the specs of the provider. The caller holds refreshMu.
+func (h *Handler) refresh(ctx context.Context) error {
+	specs, err := h.provider.Specs(ctx)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	return h.setSpecs(specs)
+}
+
+// setSpecs replaces the served specs and their resources list.
+func (h *Handler) setSpecs(specs map[string][]byte) error {
+	resourcesJSON, err := json.Marshal(h.resourceList(slices.Sorted(maps.Keys(specs))))
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	contents := make(map[string]*content, len(specs))
+	for name, data := range specs {
+		contents[name] = newContent(data, h.specs[name], now)
 	}
+	h.specs = contents
+	h.resources = newContent(resourcesJSON, h.resources, now)
+	h.refreshedAt = now
+	return nil
+}
 
-	// Build resources list
+// resourceList returns the resources list of the named specs.
+func (h *Handler) resourceList(names []string) []Resource {
 	var resources []Resource
-	for name := range config.Specs {
+	for _, name := range names {
 		resources = append(resources, Resource{
 			Name: name,
-			URL:  config.SpecPath + "?spec=" + name,
+			URL:  h.config.SpecPath + "?spec=" + name,
 		})
 	}
+	return resources
+}
 
-	resourcesJSON, err := json.Marshal(resources)
-	if err != nil {
-		return nil, err
+// allowedSpecs returns the sorted names of the specs the request is authorized to read.
+func (h *Handler) allowedSpecs(r *http.Request, specs map[string]*content) []string {
+	names := slices.Sorted(maps.Keys(specs))
+	if h.config.Authorize == nil {
+		return names
+	}
+	return slices.DeleteFunc(names, func(name string) bool {
+		return !h.config.Authorize(r, name)
+	})
+}
+
+// current returns the served specs and resources list, refreshing the specs of the
+// provider first when they were never loaded or are stale. Loading the resources list
+// refreshes the specs unless a refresh interval is configured.
+func (h *Handler) current(ctx context.Context, resources bool) (map[string]*content, *content, error) {
+	if h.provider != nil {
+		h.refreshMu.Lock()
+		h.mu.RLock()
+		age := time.Since(h.refreshedAt)
+		loaded := !h.refreshedAt.IsZero()
+		h.mu.RUnlock()
+
+		var stale bool
+		switch {
+		case h.config.RefreshInterval > 0:
+			stale = age >= h.config.RefreshInterval
+		case h.config.RefreshInterval == 0:
+			stale = resources
+		}
+
+		var err error
+		if !loaded || stale {
+			err = h.refresh(ctx)
+		}
+		h.refreshMu.Unlock()
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	return &Handler{
-		config:        config,
-		swaggerUI:     zipReader,
-		resourcesJSON: resourcesJSON,
-	}, nil
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.specs, h.resources, nil
 }
 
 // ServeHTTP implements http.Handler.
@@ -118,7 +306,7 @@ func (h *Handler) ServeUI(w http.ResponseWriter, r *http.Request) {
 		filePath = "index.html"
 	}
 
-	h.serveFile(w, filePath)
+	h.serveFile(w, r, filePath)
 }
 
 // Routes registers the handler routes on the given mux.
@@ -146,10 +334,21 @@ func (h *Handler) serveSwaggerUI(w http.ResponseWriter, r *http.Request) {
 		filePath = "index.html"
 	}
 
-	h.serveFile(w, filePath)
+	h.serveFile(w, r, filePath)
 }
 
-func (h *Handler) serveFile(w http.ResponseWriter, filePath string) {
+func (h *Handler) serveFile(w http.ResponseWriter, r *http.Request, filePath string) {
+	if h.config.UI != UISwagger {
+		h.servePage(w, r, filePath)
+		return
+	}
+
+	if filePath == "swagger-initializer.js" && h.initializer != nil {
+		w.Header().Set("Content-Type", "application/javascript")
+		w.Write(h.initializer)
+		return
+	}
+
 	file, err := h.swaggerUI.Open(filePath)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -177,18 +376,35 @@ func (h *Handler) serveFile(w http.ResponseWriter, filePath string) {
 	io.Copy(w, file)
 }
 
+// internalError logs an error and answers the request with a generic 500 Internal Server
+// Error, keeping the details of the error from the client.
+func (h *Handler) internalError(w http.ResponseWriter, r *http.Request, err error) {
+	logger := h.config.ErrorLog
+	if logger == nil {
+		logger = log.Default()
+	}
+	logger.Printf("swagger: %s %s: %v", r.Method, r.URL.Path, err)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
 func (h *Handler) serveSpec(w http.ResponseWriter, r *http.Request) {
+	specs, _, err := h.current(r.Context(), false)
+	if err != nil {
+		h.internalError(w, r, fmt.Errorf("failed to load specs: %w", err))
+		return
+	}
+
 	specName := r.URL.Query().Get("spec")
 	if specName == "" {
 		specName = h.config.DefaultSpec
 	}
 
-	spec, ok := h.config.Specs[specName]
+	spec, ok := specs[specName]
 	if !ok {
 		// If no specific spec requested and we have a default, use it
-		if len(h.config.Specs) > 0 && specName == "" {
-			for _, s := range h.config.Specs {
-				spec = s
+		if len(specs) > 0 && specName == "" {
+			for name, s := range specs {
+				specName, spec = name, s
 				break
 			}
 		} else {
@@ -197,11 +413,35 @@ func (h *Handler) serveSpec(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/yaml")
-	w.Write(spec)
+	if h.config.Authorize != nil {
+		if !h.config.Authorize(r, specName) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		// The response depends on the credentials of the request
+		w.Header().Set("Cache-Control", "private")
+	}
+
+	spec.serve(w, r, "application/yaml")
 }
 
 func (h *Handler) serveResources(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(h.resourcesJSON)
+	specs, resources, err := h.current(r.Context(), true)
+	if err != nil {
+		h.internalError(w, r, fmt.Errorf("failed to load specs: %w", err))
+		return
+	}
+
+	if h.config.Authorize == nil {
+		resources.serve(w, r, "application/json")
+		return
+	}
+
+	resourcesJSON, err := json.Marshal(h.resourceList(h.allowedSpecs(r, specs)))
+	if err != nil {
+		h.internalError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "private")
+	newContent(resourcesJSON, nil, resources.modified).serve(w, r, "application/json")
 }
@@ -3,9 +3,18 @@ package swagger
 import (
 	"archive/zip"
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/kausys/openapi/generator"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -170,6 +179,232 @@ func TestHandler_ServeSpec(t *testing.T) {
 	}
 }
 
+func TestNewWithProvider(t *testing.T) {
+	version := 0
+	var failure error
+	provider := SpecProviderFunc(func(ctx context.Context) (map[string][]byte, error) {
+		if failure != nil {
+			return nil, failure
+		}
+		version++
+		return map[string][]byte{"api": fmt.Appendf(nil, "openapi: 3.0.0 v%d", version)}, nil
+	})
+
+	get := func(handler *Handler, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+		return w
+	}
+
+	t.Run("refresh on load", func(t *testing.T) {
+		version, failure = 0, nil
+		handler, err := NewWithProvider(createTestZip(t), provider, Config{DefaultSpec: "api"})
+		require.NoError(t, err)
+		assert.Zero(t, version, "specs are loaded on the first request")
+
+		// The first spec request loads the specs, later ones reuse them
+		assert.Equal(t, "openapi: 3.0.0 v1", get(handler, "/openapi/specs?spec=api").Body.String())
+		assert.Equal(t, "openapi: 3.0.0 v1", get(handler, "/openapi/specs?spec=api").Body.String())
+
+		// Loading Swagger UI (the resources list) refreshes them
+		assert.JSONEq(t, `[{"name":"api","url":"/openapi/specs?spec=api"}]`, get(handler, "/openapi/resources").Body.String())
+		assert.Equal(t, "openapi: 3.0.0 v2", get(handler, "/openapi/specs").Body.String())
+
+		require.NoError(t, handler.Refresh(context.Background()))
+		assert.Equal(t, "openapi: 3.0.0 v3", get(handler, "/openapi/specs").Body.String())
+	})
+
+	t.Run("refresh interval", func(t *testing.T) {
+		version, failure = 0, nil
+		handler, err := NewWithProvider(createTestZip(t), provider, Config{DefaultSpec: "api", RefreshInterval: time.Hour})
+		require.NoError(t, err)
+
+		get(handler, "/openapi/resources")
+		get(handler, "/openapi/resources")
+		assert.Equal(t, "openapi: 3.0.0 v1", get(handler, "/openapi/specs").Body.String())
+
+		handler.refreshedAt = time.Now().Add(-2 * time.Hour)
+		assert.Equal(t, "openapi: 3.0.0 v2", get(handler, "/openapi/specs").Body.String())
+	})
+
+	t.Run("explicit refresh only", func(t *testing.T) {
+		version, failure = 0, nil
+		handler, err := NewWithProvider(createTestZip(t), provider, Config{DefaultSpec: "api", RefreshInterval: -1})
+		require.NoError(t, err)
+
+		get(handler, "/openapi/resources")
+		handler.refreshedAt = time.Now().Add(-24 * time.Hour)
+		get(handler, "/openapi/resources")
+		assert.Equal(t, "openapi: 3.0.0 v1", get(handler, "/openapi/specs").Body.String())
+
+		require.NoError(t, handler.Refresh(context.Background()))
+		assert.Equal(t, "openapi: 3.0.0 v2", get(handler, "/openapi/specs").Body.String())
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		version, failure = 0, errors.New("api/users.go:12: syntax error")
+		var errorLog bytes.Buffer
+		handler, err := NewWithProvider(createTestZip(t), provider, Config{ErrorLog: log.New(&errorLog, "", 0)})
+		require.NoError(t, err)
+
+		// The error is logged, not sent to the client
+		w := get(handler, "/openapi/specs")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "Internal Server Error\n", w.Body.String())
+		assert.Contains(t, errorLog.String(), "swagger: GET /openapi/specs: failed to load specs: api/users.go:12: syntax error")
+		assert.Equal(t, http.StatusInternalServerError, get(handler, "/openapi/resources").Code)
+	})
+}
+
+func TestNewWithGenerator(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module testproject\n\ngo 1.21\n"), 0644))
+	source := filepath.Join(dir, "api.go")
+	route := func(path string) string {
+		return "package api\n\n// swagger:route GET " + path + " users listUsers\n// Responses:\n// - 200: string\nfunc ListUsers() {}\n"
+	}
+	require.NoError(t, os.WriteFile(source, []byte(route("/users")), 0644))
+
+	g := generator.New(generator.WithDir(dir), generator.WithPattern("./..."), generator.WithCache(false), generator.WithOutput("", ""))
+	handler, err := NewWithGenerator(createTestZip(t), g, Config{})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/openapi/resources", nil))
+	assert.JSONEq(t, `[{"name":"default","url":"/openapi/specs?spec=default"}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/openapi/specs", nil))
+	assert.Contains(t, w.Body.String(), "/users:")
+
+	// Changed annotations are served after a refresh, without a rebuild
+	require.NoError(t, os.WriteFile(source, []byte(route("/members")), 0644))
+	require.NoError(t, handler.Refresh(context.Background()))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/openapi/specs", nil))
+	assert.Contains(t, w.Body.String(), "/members:")
+	assert.NotContains(t, w.Body.String(), "/users:")
+}
+
+func TestHandler_Authorize(t *testing.T) {
+	handler, err := New(createTestZip(t), Config{
+		Specs: map[string][]byte{
+			"public": []byte("openapi: 3.0.0 public"),
+			"admin":  []byte("openapi: 3.0.0 admin"),
+		},
+		DefaultSpec: "admin",
+		Authorize: func(r *http.Request, specName string) bool {
+			return specName != "admin" || r.Header.Get("X-Role") == "admin"
+		},
+	})
+	require.NoError(t, err)
+
+	get := func(target, role string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		if role != "" {
+			req.Header.Set("X-Role", role)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name         string
+		target       string
+		role         string
+		expectedCode int
+	}{
+		{"public spec", "/openapi/specs?spec=public", "", http.StatusOK},
+		{"admin spec without role", "/openapi/specs?spec=admin", "", http.StatusForbidden},
+		{"admin spec with role", "/openapi/specs?spec=admin", "admin", http.StatusOK},
+		{"default spec without role", "/openapi/specs", "", http.StatusForbidden},
+		{"unknown spec", "/openapi/specs?spec=unknown", "admin", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := get(tt.target, tt.role)
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, "private", w.Header().Get("Cache-Control"))
+			}
+		})
+	}
+
+	t.Run("resources", func(t *testing.T) {
+		assert.JSONEq(t, `[{"name":"public","url":"/openapi/specs?spec=public"}]`,
+			get("/openapi/resources", "").Body.String())
+		assert.JSONEq(t, `[{"name":"admin","url":"/openapi/specs?spec=admin"},{"name":"public","url":"/openapi/specs?spec=public"}]`,
+			get("/openapi/resources", "admin").Body.String())
+	})
+}
+
+func TestHandler_Pages(t *testing.T) {
+	specs := map[string][]byte{
+		"admin":  []byte("openapi: 3.0.0 admin"),
+		"public": []byte("openapi: 3.0.0 public"),
+	}
+
+	get := func(handler *Handler, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+		return w
+	}
+
+	t.Run("redoc", func(t *testing.T) {
+		handler, err := New(nil, Config{UI: UIRedoc, Specs: specs, DefaultSpec: "public", Title: "Users & Co"})
+		require.NoError(t, err)
+
+		w := get(handler, "/swagger/")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `<redoc spec-url="/openapi/specs?spec=public"></redoc>`)
+		assert.Contains(t, w.Body.String(), "<title>Users &amp; Co</title>")
+		assert.Contains(t, w.Body.String(), `<script src="https://cdn.redoc.ly/redoc/v`+RedocVersion+`/bundles/redoc.standalone.js"`)
+
+		// The browser checks the script against its integrity hash
+		var page bytes.Buffer
+		require.NoError(t, redocPage.Execute(&page, map[string]any{"Script": redocScript, "Integrity": "sha384-digest"}))
+		assert.Contains(t, page.String(), `integrity="sha384-digest" crossorigin="anonymous"`)
+
+		// The spec endpoints are shared by every UI
+		assert.Equal(t, "openapi: 3.0.0 admin", get(handler, "/openapi/specs?spec=admin").Body.String())
+		assert.Equal(t, http.StatusNotFound, get(handler, "/swagger/swagger-ui.css").Code)
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		handler, err := New(nil, Config{UI: UIScalar, Specs: specs, DefaultSpec: "public"})
+		require.NoError(t, err)
+
+		w := get(handler, "/swagger/index.html")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<title>API Reference</title>")
+		assert.Contains(t, w.Body.String(), `sources: [{"title":"public","url":"/openapi/specs?spec=public"},{"title":"admin","url":"/openapi/specs?spec=admin"}]`)
+		assert.Contains(t, w.Body.String(), `<script src="https://cdn.jsdelivr.net/npm/@scalar/api-reference@`+ScalarVersion+`/dist/browser/standalone.js"`)
+	})
+
+	t.Run("authorized specs", func(t *testing.T) {
+		handler, err := New(nil, Config{
+			UI:        UIScalar,
+			Specs:     specs,
+			Authorize: func(r *http.Request, specName string) bool { return specName == "public" },
+		})
+		require.NoError(t, err)
+
+		w := get(handler, "/swagger/")
+		assert.Contains(t, w.Body.String(), `"title":"public"`)
+		assert.NotContains(t, w.Body.String(), `"title":"admin"`)
+	})
+
+	t.Run("unknown UI", func(t *testing.T) {
+		_, err := New(nil, Config{UI: "rapidoc", Specs: specs})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown UI "rapidoc"`)
+	})
+}
+
 func TestHandler_ServeSwaggerUI(t *testing.T) {
 	zipData := createTestZip(t)
 	handler, err := New(zipData, Config{